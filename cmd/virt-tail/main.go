@@ -38,6 +38,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 )
 
 type TermFileError struct{}
@@ -55,6 +57,8 @@ type VirtTail struct {
 	ctx     context.Context
 	logFile string
 	g       *errgroup.Group
+	// persisted receives a copy of the serial console log, if it has to be persisted
+	persisted *consolelog.Writer
 }
 
 func (v *VirtTail) checkFile(socketFile string) bool {
@@ -91,6 +95,7 @@ func (v *VirtTail) tailLogs() error {
 					log.Log.V(3).Infof("tail error: %v", line.Err)
 				} else {
 					fmt.Println(line.Text)
+					v.persist(line)
 				}
 			}
 		case <-v.ctx.Done():
@@ -99,6 +104,15 @@ func (v *VirtTail) tailLogs() error {
 	}
 }
 
+func (v *VirtTail) persist(line *tail.Line) {
+	if v.persisted == nil {
+		return
+	}
+	if err := v.persisted.WriteLine(line.Time, line.Text); err != nil {
+		log.Log.V(3).Infof("failed to persist the serial console log: %v", err)
+	}
+}
+
 func (v *VirtTail) watchFS() error {
 	socketFile := strings.TrimSuffix(v.logFile, "-log")
 	termFile := v.logFile + "-sigTerm"
@@ -195,6 +209,9 @@ func main() {
 	pflag.CommandLine.AddGoFlag(goflag.CommandLine.Lookup("v"))
 	pflag.CommandLine.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
 	logFile := pflag.String("logfile", "", "path of the logfile to be streamed")
+	persistDir := pflag.String("persist-dir", "", "directory the streamed log is additionally persisted to")
+	maxFileSize := pflag.Int64("max-file-size", consolelog.DefaultMaxFileSize, "size of the persisted log file before it is rotated")
+	maxFiles := pflag.Int("max-files", consolelog.DefaultMaxFiles, "number of rotated persisted log files to keep")
 	pflag.Parse()
 
 	log.InitializeLogging("virt-tail")
@@ -217,6 +234,16 @@ func main() {
 		g:       g,
 	}
 
+	if *persistDir != "" {
+		persisted, err := consolelog.NewWriter(*persistDir, *maxFileSize, *maxFiles)
+		if err != nil {
+			log.Log.V(3).Infof("failed to open the persisted log in %s: %v", *persistDir, err)
+			os.Exit(1)
+		}
+		defer persisted.Close()
+		v.persisted = persisted
+	}
+
 	g.Go(v.tailLogs)
 	g.Go(v.watchFS)
 
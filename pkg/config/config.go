@@ -67,6 +67,12 @@ var (
 	DownwardAPISourceDir = filepath.Join(mountBaseDir, "downwardapi")
 	// ServiceAccountSourceDir represents the location where the ServiceAccount token is attached to the pod
 	ServiceAccountSourceDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+	// TPMEncryptionSecretDir represents the location where the TPM state encryption key Secret is attached to the pod
+	TPMEncryptionSecretDir = filepath.Join(mountBaseDir, "tpm-encryption")
+	// TPMEKCertificateAuthorityDir represents the location where the TPM EK certificate authority Secret is attached to the pod
+	TPMEKCertificateAuthorityDir = filepath.Join(mountBaseDir, "tpm-ek-ca")
+	// SecureBootKeysDir represents the location where the SecureBoot keys Secret or ConfigMap is attached to the pod
+	SecureBootKeysDir = filepath.Join(mountBaseDir, "secure-boot-keys")
 
 	// ConfigMapDisksDir represents a path to ConfigMap iso images
 	ConfigMapDisksDir = filepath.Join(mountBaseDir, "config-map-disks")
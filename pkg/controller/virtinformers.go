@@ -39,6 +39,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -317,6 +318,12 @@ type KubeInformerFactory interface {
 
 	ResourceQuota() cache.SharedIndexInformer
 
+	// Services which request their EndpointSlices to mirror a VMI secondary network
+	SecondaryNetworkService() cache.SharedIndexInformer
+
+	// EndpointSlices mirroring a VMI secondary network
+	SecondaryNetworkEndpointSlice() cache.SharedIndexInformer
+
 	K8SInformerFactory() informers.SharedInformerFactory
 }
 
@@ -1394,6 +1401,30 @@ func (f *kubeInformerFactory) ResourceQuota() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) SecondaryNetworkService() cache.SharedIndexInformer {
+	return f.getInformer("secondaryNetworkServiceInformer", func() cache.SharedIndexInformer {
+		labelSelector, err := labels.Parse(kubev1.EndpointsNetworkLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "services", k8sv1.NamespaceAll, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Service{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) SecondaryNetworkEndpointSlice() cache.SharedIndexInformer {
+	return f.getInformer("secondaryNetworkEndpointSliceInformer", func() cache.SharedIndexInformer {
+		labelSelector, err := labels.Parse(kubev1.EndpointsNetworkLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.DiscoveryV1().RESTClient(), "endpointslices", k8sv1.NamespaceAll, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &discoveryv1.EndpointSlice{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 // VolumeSnapshotInformer returns an informer for VolumeSnapshots
 func VolumeSnapshotInformer(clientSet kubecli.KubevirtClient, resyncPeriod time.Duration) cache.SharedIndexInformer {
 	restClient := clientSet.KubernetesSnapshotClient().SnapshotV1().RESTClient()
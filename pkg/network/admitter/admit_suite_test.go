@@ -22,6 +22,8 @@ package admitter_test
 import (
 	"testing"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/client-go/testutils"
 )
 
@@ -35,6 +37,8 @@ type stubClusterConfigChecker struct {
 	macvtapFeatureGateEnabled    bool
 	passtFeatureGateEnabled      bool
 	bindingPluginFGEnabled       bool
+	dhcpServerIPAMFGEnabled      bool
+	networkBindings              map[string]v1.InterfaceBindingPlugin
 }
 
 func (s stubClusterConfigChecker) IsSlirpInterfaceEnabled() bool {
@@ -56,3 +60,11 @@ func (s stubClusterConfigChecker) PasstEnabled() bool {
 func (s stubClusterConfigChecker) NetworkBindingPlugingsEnabled() bool {
 	return s.bindingPluginFGEnabled
 }
+
+func (s stubClusterConfigChecker) DHCPServerIPAMEnabled() bool {
+	return s.dhcpServerIPAMFGEnabled
+}
+
+func (s stubClusterConfigChecker) GetNetworkBindings() map[string]v1.InterfaceBindingPlugin {
+	return s.networkBindings
+}
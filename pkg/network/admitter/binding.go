@@ -41,6 +41,7 @@ func validateInterfaceBinding(
 		causes = append(causes, validateMasqueradeBinding(fieldPath, idx, iface, networksByName[iface.Name])...)
 		causes = append(causes, validateBridgeBinding(fieldPath, idx, iface, networksByName[iface.Name], config)...)
 		causes = append(causes, validateBindingPlugin(fieldPath, idx, iface, config)...)
+		causes = append(causes, validateVhostUserBindingPlugin(fieldPath, idx, iface, spec, config)...)
 		causes = append(causes, validateMacvtapBinding(fieldPath, idx, iface, networksByName[iface.Name], config)...)
 		causes = append(causes, validatePasstBinding(fieldPath, idx, iface, networksByName[iface.Name], config)...)
 	}
@@ -109,3 +110,19 @@ func validateBindingPlugin(fieldPath *field.Path, idx int, iface v1.Interface, c
 	}
 	return nil
 }
+
+func validateVhostUserBindingPlugin(
+	fieldPath *field.Path, idx int, iface v1.Interface, spec *v1.VirtualMachineInstanceSpec, config clusterConfigChecker,
+) []metav1.StatusCause {
+	if !vmispec.HasBindingPluginVhostUser(iface, config.GetNetworkBindings()) {
+		return nil
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("vhost-user interface %s requires hugepages backed memory", iface.Name),
+			Field:   fieldPath.Child("domain", "memory", "hugepages").String(),
+		}}
+	}
+	return nil
+}
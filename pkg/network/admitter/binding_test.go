@@ -153,4 +153,42 @@ var _ = Describe("Validating core binding", func() {
 			Field:   "fake.domain.devices.interfaces[0].name",
 		}))
 	})
+
+	Context("vhost-user binding plugin", func() {
+		const pluginName = "vhostuser"
+		configChecker := stubClusterConfigChecker{
+			bindingPluginFGEnabled: true,
+			networkBindings:        map[string]v1.InterfaceBindingPlugin{pluginName: {DomainAttachmentType: v1.VhostUser}},
+		}
+
+		newSpec := func() *v1.VirtualMachineInstanceSpec {
+			spec := &v1.VirtualMachineInstanceSpec{}
+			spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:    "dpdk",
+				Binding: &v1.PluginBinding{Name: pluginName},
+			}}
+			spec.Networks = []v1.Network{{
+				Name:          "dpdk",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "dpdk-net"}},
+			}}
+			return spec
+		}
+
+		It("should reject an interface without hugepages backed memory", func() {
+			validator := admitter.NewValidator(k8sfield.NewPath("fake"), newSpec(), configChecker)
+			Expect(validator.Validate()).To(ConsistOf(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "vhost-user interface dpdk requires hugepages backed memory",
+				Field:   "fake.domain.memory.hugepages",
+			}))
+		})
+
+		It("should accept an interface with hugepages backed memory", func() {
+			spec := newSpec()
+			spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "1Gi"}}
+
+			validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, configChecker)
+			Expect(validator.Validate()).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,102 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package admitter
+
+import (
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+func validateDHCPServerIPAM(fieldPath *field.Path, spec *v1.VirtualMachineInstanceSpec, config clusterConfigChecker) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	ifacesByName := vmispec.IndexInterfaceSpecByName(spec.Domain.Devices.Interfaces)
+	for idx, net := range spec.Networks {
+		if net.Multus == nil || net.Multus.DHCPServer == nil {
+			continue
+		}
+		dhcpServerField := fieldPath.Child("networks").Index(idx).Child("multus", "dhcpServer")
+
+		if !config.DHCPServerIPAMEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "DHCPServerIPAM feature gate is not enabled",
+				Field:   dhcpServerField.String(),
+			})
+			continue
+		}
+
+		if net.Multus.Default {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "DHCP server is not supported on the Multus default network",
+				Field:   dhcpServerField.String(),
+			})
+		}
+
+		if iface, exists := ifacesByName[net.Name]; exists && iface.Bridge == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("DHCP server is only supported with bridge binding, interface %s", net.Name),
+				Field:   dhcpServerField.String(),
+			})
+		}
+
+		causes = append(causes, validateDHCPServerSubnet(dhcpServerField, net.Multus.DHCPServer)...)
+	}
+	return causes
+}
+
+func validateDHCPServerSubnet(fieldPath *field.Path, dhcpServer *v1.DHCPServerIPAM) []metav1.StatusCause {
+	_, subnet, err := net.ParseCIDR(dhcpServer.Subnet)
+	if err != nil || subnet.IP.To4() == nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("DHCP server subnet %q is not a valid IPv4 CIDR", dhcpServer.Subnet),
+			Field:   fieldPath.Child("subnet").String(),
+		}}
+	}
+
+	if ones, _ := subnet.Mask.Size(); ones > 30 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("DHCP server subnet %q is too small, the prefix length must be 30 or less", dhcpServer.Subnet),
+			Field:   fieldPath.Child("subnet").String(),
+		}}
+	}
+
+	if dhcpServer.Gateway != "" {
+		gateway := net.ParseIP(dhcpServer.Gateway)
+		if gateway == nil || !subnet.Contains(gateway) {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("DHCP server gateway %q is not part of subnet %q", dhcpServer.Gateway, dhcpServer.Subnet),
+				Field:   fieldPath.Child("gateway").String(),
+			}}
+		}
+	}
+	return nil
+}
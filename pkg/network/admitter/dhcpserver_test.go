@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package admitter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/admitter"
+)
+
+var _ = Describe("Validating DHCP server IPAM", func() {
+	const netName = "blue"
+
+	newSpec := func(binding v1.InterfaceBindingMethod, dhcpServer *v1.DHCPServerIPAM) *v1.VirtualMachineInstanceSpec {
+		spec := &v1.VirtualMachineInstanceSpec{}
+		spec.Domain.Devices.Interfaces = []v1.Interface{{Name: netName, InterfaceBindingMethod: binding}}
+		spec.Networks = []v1.Network{{
+			Name:          netName,
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "nad", DHCPServer: dhcpServer}},
+		}}
+		return spec
+	}
+	bridgeBinding := v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}
+
+	It("should reject a DHCP server when the feature gate is disabled", func() {
+		spec := newSpec(bridgeBinding, &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"})
+
+		validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, stubClusterConfigChecker{})
+		Expect(validator.Validate()).To(ConsistOf(metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "DHCPServerIPAM feature gate is not enabled",
+			Field:   "fake.networks[0].multus.dhcpServer",
+		}))
+	})
+
+	It("should accept a DHCP server on a bridge bound secondary interface", func() {
+		spec := newSpec(bridgeBinding, &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"})
+
+		validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, stubClusterConfigChecker{dhcpServerIPAMFGEnabled: true})
+		Expect(validator.Validate()).To(BeEmpty())
+	})
+
+	It("should reject a DHCP server on a non bridge bound interface", func() {
+		spec := newSpec(v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}, &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"})
+
+		validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, stubClusterConfigChecker{dhcpServerIPAMFGEnabled: true})
+		Expect(validator.Validate()).To(ConsistOf(metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "DHCP server is only supported with bridge binding, interface blue",
+			Field:   "fake.networks[0].multus.dhcpServer",
+		}))
+	})
+
+	DescribeTable("should reject an invalid range", func(dhcpServer *v1.DHCPServerIPAM, expectedCause metav1.StatusCause) {
+		spec := newSpec(bridgeBinding, dhcpServer)
+
+		validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, stubClusterConfigChecker{dhcpServerIPAMFGEnabled: true})
+		Expect(validator.Validate()).To(ConsistOf(expectedCause))
+	},
+		Entry("with a malformed subnet", &v1.DHCPServerIPAM{Subnet: "10.10.0.0"}, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: `DHCP server subnet "10.10.0.0" is not a valid IPv4 CIDR`,
+			Field:   "fake.networks[0].multus.dhcpServer.subnet",
+		}),
+		Entry("with an IPv6 subnet", &v1.DHCPServerIPAM{Subnet: "fd10::/64"}, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: `DHCP server subnet "fd10::/64" is not a valid IPv4 CIDR`,
+			Field:   "fake.networks[0].multus.dhcpServer.subnet",
+		}),
+		Entry("with a too small subnet", &v1.DHCPServerIPAM{Subnet: "10.10.0.0/31"}, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: `DHCP server subnet "10.10.0.0/31" is too small, the prefix length must be 30 or less`,
+			Field:   "fake.networks[0].multus.dhcpServer.subnet",
+		}),
+		Entry("with a gateway outside of the subnet", &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.20.0.1"}, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: `DHCP server gateway "10.20.0.1" is not part of subnet "10.10.0.0/24"`,
+			Field:   "fake.networks[0].multus.dhcpServer.gateway",
+		}),
+	)
+})
@@ -34,6 +34,8 @@ type clusterConfigChecker interface {
 	MacvtapEnabled() bool
 	PasstEnabled() bool
 	NetworkBindingPlugingsEnabled() bool
+	DHCPServerIPAMEnabled() bool
+	GetNetworkBindings() map[string]v1.InterfaceBindingPlugin
 }
 
 type Validator struct {
@@ -67,6 +69,7 @@ func (v Validator) Validate() []metav1.StatusCause {
 	causes = append(causes, validateInterfaceNameUnique(v.field, v.vmiSpec)...)
 	causes = append(causes, validateInterfacesAssignedToNetworks(v.field, v.vmiSpec)...)
 	causes = append(causes, validateInterfacesFields(v.field, v.vmiSpec)...)
+	causes = append(causes, validateDHCPServerIPAM(v.field, v.vmiSpec, v.configChecker)...)
 
 	return causes
 }
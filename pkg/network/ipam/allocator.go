@@ -0,0 +1,270 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package ipam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	k8scorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	allocationObjectPrefix = "kubevirt-ipam-"
+	// AllocationLabel marks the ConfigMaps which hold the DHCP server address allocations.
+	AllocationLabel = "kubevirt.io/dhcp-server-ipam"
+	// AllocationNetworkAnnotation records the network attachment definition, as <namespace>/<name>,
+	// whose allocations a ConfigMap holds.
+	AllocationNetworkAnnotation = "kubevirt.io/dhcp-server-ipam-network"
+)
+
+type coreClient interface {
+	CoreV1() typedcorev1.CoreV1Interface
+}
+
+// Allocator hands out the DHCP server addresses of a VMI.
+// The allocations of each network attachment definition are persisted in a ConfigMap
+// in the KubeVirt install namespace, so that users of the workload namespaces can not
+// tamper with them. The ConfigMap data maps an allocated address to the VMI network which holds it.
+type Allocator struct {
+	client    coreClient
+	namespace string
+}
+
+func NewAllocator(client coreClient, kubevirtNamespace string) *Allocator {
+	return &Allocator{client: client, namespace: kubevirtNamespace}
+}
+
+// Allocate reserves an address for each network of the VMI which requests a DHCP server
+// and records the result on the VMI leases annotation.
+// Addresses already held by the VMI are reused. The annotation is always rewritten,
+// so that a lease set by the user is never served.
+func (a *Allocator) Allocate(vmi *v1.VirtualMachineInstance) error {
+	networks := dhcpServerNetworks(vmi.Spec.Networks)
+	if len(networks) == 0 {
+		delete(vmi.Annotations, LeasesAnnotation)
+		return nil
+	}
+
+	leases := Leases{}
+	for _, network := range networks {
+		lease, err := a.allocate(vmi, network)
+		if err != nil {
+			return fmt.Errorf("failed to allocate a DHCP server address for network %s: %v", network.Name, err)
+		}
+		leases[network.Name] = lease
+	}
+	return SetLeases(vmi, leases)
+}
+
+// Release frees the addresses held by the VMI.
+func (a *Allocator) Release(vmi *v1.VirtualMachineInstance) error {
+	for _, network := range dhcpServerNetworks(vmi.Spec.Networks) {
+		if err := a.release(vmi, network); err != nil {
+			return fmt.Errorf("failed to release the DHCP server address of network %s: %v", network.Name, err)
+		}
+	}
+	return nil
+}
+
+func (a *Allocator) allocate(vmi *v1.VirtualMachineInstance, network v1.Network) (string, error) {
+	_, subnet, err := net.ParseCIDR(network.Multus.DHCPServer.Subnet)
+	if err != nil {
+		return "", err
+	}
+
+	networkRef := networkReference(vmi.Namespace, network.Multus.NetworkName)
+	holder := allocationHolder(vmi, network)
+
+	var allocated net.IP
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		allocationObject, err := a.getOrCreateAllocationObject(networkRef)
+		if err != nil {
+			return err
+		}
+
+		if ip := lookupHolderAddress(allocationObject.Data, holder, subnet); ip != nil {
+			allocated = ip
+			return nil
+		}
+
+		ip, err := nextFreeAddress(subnet, net.ParseIP(network.Multus.DHCPServer.Gateway), allocationObject.Data)
+		if err != nil {
+			return err
+		}
+
+		if allocationObject.Data == nil {
+			allocationObject.Data = map[string]string{}
+		}
+		allocationObject.Data[ip.String()] = holder
+		if _, err = a.client.CoreV1().ConfigMaps(a.namespace).Update(context.Background(), allocationObject, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		allocated = ip
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	prefixLength, _ := subnet.Mask.Size()
+	return fmt.Sprintf("%s/%d", allocated, prefixLength), nil
+}
+
+func (a *Allocator) release(vmi *v1.VirtualMachineInstance, network v1.Network) error {
+	networkRef := networkReference(vmi.Namespace, network.Multus.NetworkName)
+	holder := allocationHolder(vmi, network)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		allocationObject, err := a.client.CoreV1().ConfigMaps(a.namespace).Get(context.Background(), allocationObjectName(networkRef), metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateOwnership(allocationObject, networkRef); err != nil {
+			return err
+		}
+
+		released := false
+		for ip, ipHolder := range allocationObject.Data {
+			if ipHolder == holder {
+				delete(allocationObject.Data, ip)
+				released = true
+			}
+		}
+		if !released {
+			return nil
+		}
+		_, err = a.client.CoreV1().ConfigMaps(a.namespace).Update(context.Background(), allocationObject, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (a *Allocator) getOrCreateAllocationObject(networkRef string) (*k8scorev1.ConfigMap, error) {
+	name := allocationObjectName(networkRef)
+	allocationObject, err := a.client.CoreV1().ConfigMaps(a.namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		allocationObject = &k8scorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   a.namespace,
+				Labels:      map[string]string{AllocationLabel: ""},
+				Annotations: map[string]string{AllocationNetworkAnnotation: networkRef},
+			},
+		}
+		allocationObject, err = a.client.CoreV1().ConfigMaps(a.namespace).Create(context.Background(), allocationObject, metav1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			allocationObject, err = a.client.CoreV1().ConfigMaps(a.namespace).Get(context.Background(), name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOwnership(allocationObject, networkRef); err != nil {
+		return nil, err
+	}
+	return allocationObject, nil
+}
+
+// validateOwnership makes sure the ConfigMap was created by the allocator for the network,
+// so that a foreign ConfigMap of the same name is never used nor modified.
+func validateOwnership(allocationObject *k8scorev1.ConfigMap, networkRef string) error {
+	if _, isAllocationObject := allocationObject.Labels[AllocationLabel]; !isAllocationObject ||
+		allocationObject.Annotations[AllocationNetworkAnnotation] != networkRef {
+		return fmt.Errorf("ConfigMap %s/%s does not hold the DHCP server allocations of network %s",
+			allocationObject.Namespace, allocationObject.Name, networkRef)
+	}
+	return nil
+}
+
+func dhcpServerNetworks(networks []v1.Network) []v1.Network {
+	var dhcpNetworks []v1.Network
+	for _, network := range networks {
+		if network.Multus != nil && network.Multus.DHCPServer != nil {
+			dhcpNetworks = append(dhcpNetworks, network)
+		}
+	}
+	return dhcpNetworks
+}
+
+// networkReference returns the <namespace>/<name> of a network attachment definition,
+// referenced as <name> or <namespace>/<name>.
+func networkReference(vmiNamespace, networkName string) string {
+	if strings.Contains(networkName, "/") {
+		return networkName
+	}
+	return vmiNamespace + "/" + networkName
+}
+
+// allocationObjectName derives the ConfigMap name from a hash of the network reference,
+// since the networks of all namespaces share the KubeVirt install namespace.
+func allocationObjectName(networkRef string) string {
+	hash := sha256.Sum256([]byte(networkRef))
+	return allocationObjectPrefix + hex.EncodeToString(hash[:])[:16]
+}
+
+func allocationHolder(vmi *v1.VirtualMachineInstance, network v1.Network) string {
+	return fmt.Sprintf("%s/%s/%s", vmi.Namespace, vmi.Name, network.Name)
+}
+
+func lookupHolderAddress(allocations map[string]string, holder string, subnet *net.IPNet) net.IP {
+	for address, addressHolder := range allocations {
+		if addressHolder != holder {
+			continue
+		}
+		if ip := net.ParseIP(address); ip != nil && subnet.Contains(ip) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// nextFreeAddress returns the lowest address of the subnet which is not allocated,
+// skipping the network, broadcast and gateway addresses.
+func nextFreeAddress(subnet *net.IPNet, gateway net.IP, allocations map[string]string) (net.IP, error) {
+	first := binary.BigEndian.Uint32(subnet.IP.To4())
+	ones, bits := subnet.Mask.Size()
+	last := first + uint32(1)<<uint(bits-ones) - 1
+
+	for candidate := first + 1; candidate < last; candidate++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, candidate)
+		if gateway != nil && ip.Equal(gateway) {
+			continue
+		}
+		if _, allocated := allocations[ip.String()]; !allocated {
+			return ip, nil
+		}
+	}
+	return nil, fmt.Errorf("no free address left in subnet %s", subnet)
+}
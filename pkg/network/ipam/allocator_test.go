@@ -0,0 +1,168 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package ipam_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/ipam"
+)
+
+var _ = Describe("DHCP server address allocator", func() {
+	const (
+		namespace         = "default"
+		kubevirtNamespace = "kubevirt"
+		netName           = "blue"
+		nadName           = "isolated"
+	)
+
+	var (
+		client    *fake.Clientset
+		allocator *ipam.Allocator
+	)
+
+	newVMI := func(name string, dhcpServer *v1.DHCPServerIPAM) *v1.VirtualMachineInstance {
+		network := libvmi.MultusNetwork(netName, nadName)
+		network.Multus.DHCPServer = dhcpServer
+		vmi := libvmi.New(
+			libvmi.WithNamespace(namespace),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding(netName)),
+			libvmi.WithNetwork(network),
+		)
+		vmi.Name = name
+		return vmi
+	}
+
+	BeforeEach(func() {
+		client = fake.NewSimpleClientset()
+		allocator = ipam.NewAllocator(client, kubevirtNamespace)
+	})
+
+	allocationObjects := func() []k8sv1.ConfigMap {
+		configMaps, err := client.CoreV1().ConfigMaps(kubevirtNamespace).List(context.Background(), metav1.ListOptions{LabelSelector: ipam.AllocationLabel})
+		Expect(err).NotTo(HaveOccurred())
+		return configMaps.Items
+	}
+
+	It("should not annotate a VMI without DHCP server networks", func() {
+		vmi := newVMI("vmi1", nil)
+
+		Expect(allocator.Allocate(vmi)).To(Succeed())
+		Expect(vmi.Annotations).NotTo(HaveKey(ipam.LeasesAnnotation))
+	})
+
+	DescribeTable("should replace a leases annotation set by the user", func(dhcpServer *v1.DHCPServerIPAM, expectedLeases ipam.Leases) {
+		vmi := newVMI("vmi1", dhcpServer)
+		vmi.Annotations = map[string]string{ipam.LeasesAnnotation: `{"blue":"192.168.0.1/16","red":"10.0.0.1/8"}`}
+
+		Expect(allocator.Allocate(vmi)).To(Succeed())
+		Expect(ipam.LeasesFromVMI(vmi)).To(Equal(expectedLeases))
+	},
+		Entry("without DHCP server networks", nil, nil),
+		Entry("with a DHCP server network", &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}, ipam.Leases{netName: "10.10.0.2/24"}),
+	)
+
+	It("should allocate distinct addresses, skipping the gateway", func() {
+		dhcpServer := &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}
+		vmi1 := newVMI("vmi1", dhcpServer)
+		vmi2 := newVMI("vmi2", dhcpServer)
+
+		Expect(allocator.Allocate(vmi1)).To(Succeed())
+		Expect(allocator.Allocate(vmi2)).To(Succeed())
+
+		Expect(ipam.LeasesFromVMI(vmi1)).To(Equal(ipam.Leases{netName: "10.10.0.2/24"}))
+		Expect(ipam.LeasesFromVMI(vmi2)).To(Equal(ipam.Leases{netName: "10.10.0.3/24"}))
+
+		Expect(allocationObjects()).To(HaveLen(1))
+		allocationObject := allocationObjects()[0]
+		Expect(allocationObject.Annotations).To(HaveKeyWithValue(ipam.AllocationNetworkAnnotation, namespace+"/"+nadName))
+		Expect(allocationObject.Data).To(Equal(map[string]string{
+			"10.10.0.2": "default/vmi1/blue",
+			"10.10.0.3": "default/vmi2/blue",
+		}))
+	})
+
+	It("should keep the allocations of networks of the same name in different namespaces apart", func() {
+		dhcpServer := &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"}
+		vmi1 := newVMI("vmi1", dhcpServer)
+		vmi2 := newVMI("vmi2", dhcpServer)
+		vmi2.Namespace = "other"
+
+		Expect(allocator.Allocate(vmi1)).To(Succeed())
+		Expect(allocator.Allocate(vmi2)).To(Succeed())
+
+		Expect(ipam.LeasesFromVMI(vmi1)).To(Equal(ipam.Leases{netName: "10.10.0.1/24"}))
+		Expect(ipam.LeasesFromVMI(vmi2)).To(Equal(ipam.Leases{netName: "10.10.0.1/24"}))
+		Expect(allocationObjects()).To(HaveLen(2))
+	})
+
+	It("should refuse to use an allocation object which is not owned by the allocator", func() {
+		dhcpServer := &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"}
+		Expect(allocator.Allocate(newVMI("vmi1", dhcpServer))).To(Succeed())
+		allocationObject := allocationObjects()[0]
+		allocationObject.Annotations[ipam.AllocationNetworkAnnotation] = "other/" + nadName
+		_, err := client.CoreV1().ConfigMaps(kubevirtNamespace).Update(context.Background(), &allocationObject, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(allocator.Allocate(newVMI("vmi2", dhcpServer))).To(MatchError(ContainSubstring("does not hold the DHCP server allocations of network default/isolated")))
+		Expect(allocator.Release(newVMI("vmi1", dhcpServer))).To(MatchError(ContainSubstring("does not hold the DHCP server allocations of network default/isolated")))
+	})
+
+	It("should reuse the address already held by the VMI", func() {
+		vmi := newVMI("vmi1", &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"})
+
+		Expect(allocator.Allocate(vmi)).To(Succeed())
+		Expect(allocator.Allocate(vmi)).To(Succeed())
+
+		Expect(ipam.LeasesFromVMI(vmi)).To(Equal(ipam.Leases{netName: "10.10.0.1/24"}))
+	})
+
+	It("should hand out a released address again", func() {
+		dhcpServer := &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"}
+		vmi1 := newVMI("vmi1", dhcpServer)
+		Expect(allocator.Allocate(vmi1)).To(Succeed())
+		Expect(allocator.Release(vmi1)).To(Succeed())
+
+		vmi2 := newVMI("vmi2", dhcpServer)
+		Expect(allocator.Allocate(vmi2)).To(Succeed())
+		Expect(ipam.LeasesFromVMI(vmi2)).To(Equal(ipam.Leases{netName: "10.10.0.1/24"}))
+	})
+
+	It("should fail when the subnet is exhausted", func() {
+		dhcpServer := &v1.DHCPServerIPAM{Subnet: "10.10.0.0/30", Gateway: "10.10.0.1"}
+		Expect(allocator.Allocate(newVMI("vmi1", dhcpServer))).To(Succeed())
+
+		Expect(allocator.Allocate(newVMI("vmi2", dhcpServer))).To(MatchError(ContainSubstring("no free address left in subnet 10.10.0.0/30")))
+	})
+
+	It("should succeed releasing a VMI without allocations", func() {
+		Expect(allocator.Release(newVMI("vmi1", &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24"}))).To(Succeed())
+	})
+})
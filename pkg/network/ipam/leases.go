@@ -0,0 +1,59 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package ipam
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// LeasesAnnotation holds the DHCP server addresses allocated to the VMI, indexed by the VMI network name.
+const LeasesAnnotation = "kubevirt.io/dhcp-server-leases"
+
+// Leases maps a VMI network name to the address, in CIDR notation, the DHCP server hands out on it.
+type Leases map[string]string
+
+// LeasesFromVMI returns the DHCP server addresses allocated to the VMI.
+func LeasesFromVMI(vmi *v1.VirtualMachineInstance) (Leases, error) {
+	rawLeases, exists := vmi.Annotations[LeasesAnnotation]
+	if !exists {
+		return nil, nil
+	}
+
+	leases := Leases{}
+	if err := json.Unmarshal([]byte(rawLeases), &leases); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %v", LeasesAnnotation, err)
+	}
+	return leases, nil
+}
+
+func SetLeases(vmi *v1.VirtualMachineInstance, leases Leases) error {
+	rawLeases, err := json.Marshal(leases)
+	if err != nil {
+		return err
+	}
+	if vmi.Annotations == nil {
+		vmi.Annotations = map[string]string{}
+	}
+	vmi.Annotations[LeasesAnnotation] = string(rawLeases)
+	return nil
+}
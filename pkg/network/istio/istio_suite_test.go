@@ -0,0 +1,11 @@
+package istio_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestIstio(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
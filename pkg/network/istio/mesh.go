@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package istio
+
+import (
+	"strings"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// DataplaneModeLabel specifies the Istio data plane mode of the workload
+// https://istio.io/latest/docs/reference/config/labels/#IoIstioDataplaneMode
+const DataplaneModeLabel = "istio.io/dataplane-mode"
+
+type MeshMode string
+
+const (
+	NoMesh MeshMode = ""
+	// SidecarMesh is a mesh which injects a proxy into the virt-launcher pod.
+	SidecarMesh MeshMode = "sidecar"
+	// AmbientMesh is a mesh whose proxy runs on the node and redirects the pod traffic to it.
+	AmbientMesh MeshMode = "ambient"
+)
+
+const ambientDataplaneMode = "ambient"
+
+// Mesh describes the service mesh data plane a VMI takes part in.
+type Mesh struct {
+	Mode               MeshMode
+	ReservedPorts      []int
+	NonProxiedPorts    []int
+	ProxySourceAddress string
+	TranslateSourceIP  bool
+}
+
+// NewMesh resolves the service mesh data plane of the VMI, applying the cluster wide
+// service mesh configuration on top of the Istio defaults.
+func NewMesh(vmi *v1.VirtualMachineInstance, config *v1.ServiceMeshConfiguration) Mesh {
+	mesh := Mesh{
+		Mode:               meshMode(vmi, config),
+		ReservedPorts:      ReservedPorts(),
+		NonProxiedPorts:    NonProxiedPorts(),
+		ProxySourceAddress: GetLoopbackAddress(),
+	}
+	if config == nil {
+		return mesh
+	}
+
+	if len(config.ReservedPorts) > 0 {
+		mesh.ReservedPorts = toInts(config.ReservedPorts)
+	}
+	if len(config.NonProxiedPorts) > 0 {
+		mesh.NonProxiedPorts = toInts(config.NonProxiedPorts)
+	}
+	if config.ProxySourceAddress != "" {
+		mesh.ProxySourceAddress = config.ProxySourceAddress
+	}
+	mesh.TranslateSourceIP = config.TranslateSourceIP
+	return mesh
+}
+
+// SidecarInjectionEnabled returns true when a mesh proxy is expected to be injected into the virt-launcher pod.
+func SidecarInjectionEnabled(vmi *v1.VirtualMachineInstance, config *v1.ServiceMeshConfiguration) bool {
+	return meshMode(vmi, config) == SidecarMesh
+}
+
+func meshMode(vmi *v1.VirtualMachineInstance, config *v1.ServiceMeshConfiguration) MeshMode {
+	if ProxyInjectionEnabled(vmi) {
+		return SidecarMesh
+	}
+	if config != nil {
+		for key, value := range config.InjectionAnnotations {
+			if val, ok := vmi.GetAnnotations()[key]; ok && strings.EqualFold(val, value) {
+				return SidecarMesh
+			}
+		}
+	}
+	if vmi.GetLabels()[DataplaneModeLabel] == ambientDataplaneMode {
+		return AmbientMesh
+	}
+	return NoMesh
+}
+
+// InheritDataplaneMode labels the VMI with the ambient data plane mode of its namespace,
+// unless the VMI chooses a data plane mode itself, the same way Istio enrolls the pods of a namespace.
+func InheritDataplaneMode(vmi *v1.VirtualMachineInstance, namespaceLabels map[string]string) {
+	if namespaceLabels[DataplaneModeLabel] != ambientDataplaneMode {
+		return
+	}
+	if _, exists := vmi.Labels[DataplaneModeLabel]; exists {
+		return
+	}
+	if vmi.Labels == nil {
+		vmi.Labels = map[string]string{}
+	}
+	vmi.Labels[DataplaneModeLabel] = ambientDataplaneMode
+}
+
+func toInts(ports []int32) []int {
+	var ints []int
+	for _, port := range ports {
+		ints = append(ints, int(port))
+	}
+	return ints
+}
@@ -0,0 +1,135 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package istio_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/istio"
+)
+
+var _ = Describe("Service mesh", func() {
+	const linkerdInjectAnnotation = "linkerd.io/inject"
+
+	linkerdConfig := &v1.ServiceMeshConfiguration{
+		InjectionAnnotations: map[string]string{linkerdInjectAnnotation: "enabled"},
+	}
+
+	DescribeTable("should detect the mesh mode", func(vmi *v1.VirtualMachineInstance, config *v1.ServiceMeshConfiguration, expectedMode istio.MeshMode) {
+		Expect(istio.NewMesh(vmi, config).Mode).To(Equal(expectedMode))
+		Expect(istio.SidecarInjectionEnabled(vmi, config)).To(Equal(expectedMode == istio.SidecarMesh))
+	},
+		Entry("without a mesh", libvmi.New(), nil, istio.NoMesh),
+		Entry("with the Istio sidecar annotation",
+			libvmi.New(libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true")), nil, istio.SidecarMesh),
+		Entry("with the Istio sidecar annotation in a different case",
+			libvmi.New(libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "True")), nil, istio.SidecarMesh),
+		Entry("with the Istio sidecar annotation disabled",
+			libvmi.New(libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "false")), nil, istio.NoMesh),
+		Entry("with a configured injection annotation",
+			libvmi.New(libvmi.WithAnnotation(linkerdInjectAnnotation, "enabled")), linkerdConfig, istio.SidecarMesh),
+		Entry("with a configured injection annotation in a different case",
+			libvmi.New(libvmi.WithAnnotation(linkerdInjectAnnotation, "Enabled")), linkerdConfig, istio.SidecarMesh),
+		Entry("with a configured injection annotation set to another value",
+			libvmi.New(libvmi.WithAnnotation(linkerdInjectAnnotation, "disabled")), linkerdConfig, istio.NoMesh),
+		Entry("with an injection annotation which is not configured",
+			libvmi.New(libvmi.WithAnnotation(linkerdInjectAnnotation, "enabled")), nil, istio.NoMesh),
+		Entry("with the ambient data plane mode label",
+			libvmi.New(libvmi.WithLabel(istio.DataplaneModeLabel, "ambient")), nil, istio.AmbientMesh),
+		Entry("with another data plane mode label",
+			libvmi.New(libvmi.WithLabel(istio.DataplaneModeLabel, "none")), nil, istio.NoMesh),
+		Entry("with the ambient data plane mode set as an annotation",
+			libvmi.New(libvmi.WithAnnotation(istio.DataplaneModeLabel, "ambient")), nil, istio.NoMesh),
+		Entry("with both the sidecar annotation and the ambient label",
+			libvmi.New(
+				libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true"),
+				libvmi.WithLabel(istio.DataplaneModeLabel, "ambient"),
+			), nil, istio.SidecarMesh),
+	)
+
+	DescribeTable("should resolve the excluded ports and the proxy source", func(config *v1.ServiceMeshConfiguration, expectedMesh istio.Mesh) {
+		vmi := libvmi.New(libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true"))
+		expectedMesh.Mode = istio.SidecarMesh
+		Expect(istio.NewMesh(vmi, config)).To(Equal(expectedMesh))
+	},
+		Entry("with the Istio defaults when there is no configuration", nil, istio.Mesh{
+			ReservedPorts:      istio.ReservedPorts(),
+			NonProxiedPorts:    []int{istio.SshPort},
+			ProxySourceAddress: "127.0.0.6",
+		}),
+		Entry("with the Istio defaults when the configuration is empty", &v1.ServiceMeshConfiguration{}, istio.Mesh{
+			ReservedPorts:      istio.ReservedPorts(),
+			NonProxiedPorts:    []int{istio.SshPort},
+			ProxySourceAddress: "127.0.0.6",
+		}),
+		Entry("with the configured reserved inbound ports", &v1.ServiceMeshConfiguration{
+			ReservedPorts: []int32{4140, 4143, 4191},
+		}, istio.Mesh{
+			ReservedPorts:      []int{4140, 4143, 4191},
+			NonProxiedPorts:    []int{istio.SshPort},
+			ProxySourceAddress: "127.0.0.6",
+		}),
+		Entry("with the configured non proxied ports", &v1.ServiceMeshConfiguration{
+			NonProxiedPorts: []int32{22, 3389},
+		}, istio.Mesh{
+			ReservedPorts:      istio.ReservedPorts(),
+			NonProxiedPorts:    []int{22, 3389},
+			ProxySourceAddress: "127.0.0.6",
+		}),
+		Entry("with the configured proxy source address and source IP translation", &v1.ServiceMeshConfiguration{
+			ProxySourceAddress: "127.0.0.1",
+			TranslateSourceIP:  true,
+		}, istio.Mesh{
+			ReservedPorts:      istio.ReservedPorts(),
+			NonProxiedPorts:    []int{istio.SshPort},
+			ProxySourceAddress: "127.0.0.1",
+			TranslateSourceIP:  true,
+		}),
+	)
+
+	It("should reserve the Envoy ports", func() {
+		Expect(istio.ReservedPorts()).To(ConsistOf(
+			15000, 15001, 15004, 15006, 15008, 15009, 15020, 15021, 15053, 15090,
+		))
+	})
+
+	DescribeTable("should inherit the data plane mode of the namespace", func(vmi *v1.VirtualMachineInstance, namespaceLabels map[string]string, expectedLabels map[string]string) {
+		istio.InheritDataplaneMode(vmi, namespaceLabels)
+		Expect(vmi.Labels).To(Equal(expectedLabels))
+	},
+		Entry("when the namespace is in the ambient mesh",
+			libvmi.New(), map[string]string{istio.DataplaneModeLabel: "ambient"},
+			map[string]string{istio.DataplaneModeLabel: "ambient"}),
+		Entry("unless the namespace is not in a mesh",
+			libvmi.New(), map[string]string{}, nil),
+		Entry("unless the namespace uses another data plane mode",
+			libvmi.New(), map[string]string{istio.DataplaneModeLabel: "none"}, nil),
+		Entry("unless the VMI opts out of the ambient mesh",
+			libvmi.New(libvmi.WithLabel(istio.DataplaneModeLabel, "none")), map[string]string{istio.DataplaneModeLabel: "ambient"},
+			map[string]string{istio.DataplaneModeLabel: "none"}),
+		Entry("keeping the other VMI labels",
+			libvmi.New(libvmi.WithLabel("app", "web")), map[string]string{istio.DataplaneModeLabel: "ambient"},
+			map[string]string{"app": "web", istio.DataplaneModeLabel: "ambient"}),
+	)
+})
@@ -35,6 +35,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/network/cache"
 	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
+	"kubevirt.io/kubevirt/pkg/network/ipam"
 	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/network/netns"
 	"kubevirt.io/kubevirt/pkg/network/setup/netpod"
@@ -49,6 +50,7 @@ type cacheCreator interface {
 type clusterConfigurer interface {
 	GetNetworkBindings() map[string]v1.InterfaceBindingPlugin
 	DynamicPodInterfaceNamingEnabled() bool
+	GetServiceMeshConfiguration() *v1.ServiceMeshConfiguration
 }
 
 type NetConf struct {
@@ -116,6 +118,11 @@ func (c *NetConf) Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, l
 		vmiIfacesStatuses = nil
 	}
 
+	dhcpServerLeases, err := ipam.LeasesFromVMI(vmi)
+	if err != nil {
+		return err
+	}
+
 	netpod := netpod.NewNetPod(
 		networks,
 		vmispec.FilterInterfacesByNetworks(vmi.Spec.Domain.Devices.Interfaces, networks),
@@ -124,11 +131,12 @@ func (c *NetConf) Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, l
 		ownerID,
 		queuesCapacity,
 		state,
-		netpod.WithMasqueradeAdapter(newMasqueradeAdapter(vmi)),
+		netpod.WithMasqueradeAdapter(newMasqueradeAdapter(vmi, c.clusterConfigurer.GetServiceMeshConfiguration())),
 		netpod.WithCacheCreator(c.cacheCreator),
 		netpod.WithBindingPlugins(c.clusterConfigurer.GetNetworkBindings()),
 		netpod.WithLogger(log.Log.Object(vmi)),
 		netpod.WithVMIIfaceStatuses(vmiIfacesStatuses),
+		netpod.WithDHCPServerLeases(dhcpServerLeases),
 	)
 
 	if err := netpod.Setup(); err != nil {
@@ -174,12 +182,13 @@ func (c *NetConf) Teardown(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
-func newMasqueradeAdapter(vmi *v1.VirtualMachineInstance) masquerade.MasqPod {
+func newMasqueradeAdapter(vmi *v1.VirtualMachineInstance, meshConfig *v1.ServiceMeshConfiguration) masquerade.MasqPod {
+	mesh := istio.NewMesh(vmi, meshConfig)
 	if vmi.Status.MigrationTransport == v1.MigrationTransportUnix {
-		return masquerade.New(masquerade.WithIstio(istio.ProxyInjectionEnabled(vmi)))
+		return masquerade.New(masquerade.WithServiceMesh(mesh))
 	} else {
 		return masquerade.New(
-			masquerade.WithIstio(istio.ProxyInjectionEnabled(vmi)),
+			masquerade.WithServiceMesh(mesh),
 			masquerade.WithLegacyMigrationPorts(),
 		)
 	}
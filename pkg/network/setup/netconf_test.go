@@ -226,3 +226,7 @@ func (c cConfigStub) GetNetworkBindings() map[string]v1.InterfaceBindingPlugin {
 func (c cConfigStub) DynamicPodInterfaceNamingEnabled() bool {
 	return c.dynamicPodInterfaceNamingEnabled
 }
+
+func (c cConfigStub) GetServiceMeshConfiguration() *v1.ServiceMeshConfiguration {
+	return nil
+}
@@ -71,6 +71,10 @@ func (n NetPod) storeBridgeBindingDHCPInterfaceData(currentStatus *nmstate.Statu
 		if len(dhcpRoutes) > 0 {
 			dhcpConfig.Routes = &dhcpRoutes
 		}
+	} else if lease, exists := n.dhcpServerLeases[vmiSpecIface.Name]; exists {
+		if err := n.fillDHCPServerLeaseConfig(&dhcpConfig, lease, podIfaceStatus, vmiSpecIface); err != nil {
+			return err
+		}
 	}
 
 	log.Log.V(4).Infof("The generated dhcpConfig: %s\nRoutes: %+v", dhcpConfig.String(), dhcpConfig.Routes)
@@ -81,6 +85,29 @@ func (n NetPod) storeBridgeBindingDHCPInterfaceData(currentStatus *nmstate.Statu
 	return nil
 }
 
+// fillDHCPServerLeaseConfig sets the address allocated by KubeVirt for a network which has no IPAM of its own.
+func (n NetPod) fillDHCPServerLeaseConfig(dhcpConfig *cache.DHCPConfig, lease string, podIfaceStatus nmstate.Interface, vmiSpecIface v1.Interface) error {
+	addr, err := vishnetlink.ParseAddr(lease)
+	if err != nil {
+		return fmt.Errorf("failed to parse the DHCP server lease of network %s: %v", vmiSpecIface.Name, err)
+	}
+	dhcpConfig.IPAMDisabled = false
+	dhcpConfig.IP = *addr
+
+	mac, err := resolveMacAddress(podIfaceStatus.MacAddress, vmiSpecIface.MacAddress)
+	if err != nil {
+		return err
+	}
+	dhcpConfig.MAC = mac
+
+	for _, network := range n.vmiSpecNets {
+		if network.Name == vmiSpecIface.Name && network.Multus != nil && network.Multus.DHCPServer != nil {
+			dhcpConfig.Gateway = net.ParseIP(network.Multus.DHCPServer.Gateway)
+		}
+	}
+	return nil
+}
+
 func (n NetPod) storeBridgeDomainInterfaceData(podIfaceStatus nmstate.Interface, vmiSpecIface v1.Interface) error {
 	mac, err := resolveMacAddress(podIfaceStatus.MacAddress, vmiSpecIface.MacAddress)
 	if err != nil {
@@ -42,7 +42,7 @@ type nftable interface {
 
 type MasqPod struct {
 	nftable        nftable
-	istioEnabled   bool
+	mesh           istio.Mesh
 	migrationPorts []int
 }
 
@@ -67,9 +67,10 @@ func New(opts ...option) MasqPod {
 	return m
 }
 
-func WithIstio(enabled bool) option {
+// WithServiceMesh sets up the NAT rules to cooperate with the given service mesh data plane.
+func WithServiceMesh(mesh istio.Mesh) option {
 	return func(m *MasqPod) {
-		m.istioEnabled = enabled
+		m.mesh = mesh
 	}
 }
 
@@ -144,8 +145,12 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 		}
 	}
 
+	meshEnabled := m.mesh.Mode != istio.NoMesh
+	// Only a sidecar proxy runs in the pod, using the reserved ports and delivering the inbound traffic
+	// from its own source address.
+	sidecarMesh := m.mesh.Mode == istio.SidecarMesh
 	addressesToDnat := []string{ipLoopback(family)}
-	if m.istioEnabled && family == nft.IPv4 {
+	if meshEnabled && family == nft.IPv4 {
 		addressesToDnat = append(addressesToDnat, podIfaceSpec.IPv4.Address[0].IP)
 	}
 	addressesToDnatSpec := fmt.Sprintf("{ %s }", strings.Join(addressesToDnat, ", "))
@@ -157,9 +162,9 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 		protocol := strings.ToLower(port.Protocol)
 		addressesToSnat := []string{ipLoopback(family)}
 
-		if m.istioEnabled {
+		if meshEnabled {
 			var portsToForward []int
-			for _, nonProxiedPort := range istio.NonProxiedPorts() {
+			for _, nonProxiedPort := range m.mesh.NonProxiedPorts {
 				if int(port.Port) == nonProxiedPort {
 					portsToForward = append(portsToForward, nonProxiedPort)
 				}
@@ -168,8 +173,8 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 				return err
 			}
 
-			if family == nft.IPv4 {
-				addressesToSnat = append(addressesToSnat, istio.GetLoopbackAddress())
+			if sidecarMesh && family == nft.IPv4 {
+				addressesToSnat = append(addressesToSnat, m.mesh.ProxySourceAddress)
 			}
 		} else {
 			if err := m.forwardPorts(family, guestIP, protocol, int(port.Port)); err != nil {
@@ -177,9 +182,9 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 			}
 		}
 
-		addressesToSnatSpec := fmt.Sprintf("{ %s }", strings.Join(addressesToSnat, ", "))
 		gw := guestIPGateway(family, *bridgeIfaceSpec).String()
-		if err := m.nftable.AddRule(family, natTable, kubevirtPostInboundChain, protocol, "dport", strconv.Itoa(int(port.Port)), string(family), "saddr", addressesToSnatSpec, "counter", "snat", "to", gw); err != nil {
+		snatRule := append([]string{protocol, "dport", strconv.Itoa(int(port.Port))}, m.snatSourceMatch(family, addressesToSnat)...)
+		if err := m.nftable.AddRule(family, natTable, kubevirtPostInboundChain, append(snatRule, "counter", "snat", "to", gw)...); err != nil {
 			return err
 		}
 
@@ -190,25 +195,27 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 
 	if len(vmiIface.Ports) == 0 {
 		addressesToSnat := []string{ipLoopback(family)}
-		if m.istioEnabled {
-			// Skip forwarding for the reserved istio ports
-			if err := m.skipForwardPorts(family, istio.ReservedPorts()...); err != nil {
-				return err
+		if meshEnabled {
+			if sidecarMesh {
+				// Skip forwarding for the reserved mesh ports
+				if err := m.skipForwardPorts(family, m.mesh.ReservedPorts...); err != nil {
+					return err
+				}
 			}
-			if err := m.forwardPorts(family, guestIP, "tcp", istio.NonProxiedPorts()...); err != nil {
+			if err := m.forwardPorts(family, guestIP, "tcp", m.mesh.NonProxiedPorts...); err != nil {
 				return err
 			}
-			if family == nft.IPv4 {
-				addressesToSnat = append(addressesToSnat, istio.GetLoopbackAddress())
+			if sidecarMesh && family == nft.IPv4 {
+				addressesToSnat = append(addressesToSnat, m.mesh.ProxySourceAddress)
 			}
 		} else {
 			if err := m.nftable.AddRule(family, natTable, kubevirtPreInboundChain, "counter", "dnat", "to", guestIP); err != nil {
 				return err
 			}
 		}
-		addressesToSnatSpec := fmt.Sprintf("{ %s }", strings.Join(addressesToSnat, ", "))
 		gw := guestIPGateway(family, *bridgeIfaceSpec).String()
-		if err := m.nftable.AddRule(family, natTable, kubevirtPostInboundChain, string(family), "saddr", addressesToSnatSpec, "counter", "snat", "to", gw); err != nil {
+		snatRule := append(m.snatSourceMatch(family, addressesToSnat), "counter", "snat", "to", gw)
+		if err := m.nftable.AddRule(family, natTable, kubevirtPostInboundChain, snatRule...); err != nil {
 			return err
 		}
 		if err := m.nftable.AddRule(family, natTable, outputChain, string(family), "daddr", addressesToDnatSpec, "counter", "dnat", "to", guestIP); err != nil {
@@ -219,6 +226,16 @@ func (m MasqPod) setupNATByFamily(family nft.IPFamily, podIfaceSpec, bridgeIface
 	return nil
 }
 
+// snatSourceMatch returns the match of the inbound traffic whose source is translated to the guest gateway.
+// An ambient mesh proxy delivers the inbound traffic on behalf of the client, using the client address,
+// which is preserved unless the translation of all the inbound traffic is requested.
+func (m MasqPod) snatSourceMatch(family nft.IPFamily, addressesToSnat []string) []string {
+	if m.mesh.Mode == istio.AmbientMesh && m.mesh.TranslateSourceIP {
+		return nil
+	}
+	return []string{string(family), "saddr", fmt.Sprintf("{ %s }", strings.Join(addressesToSnat, ", "))}
+}
+
 func (m MasqPod) skipForwardPorts(family nft.IPFamily, ports ...int) error {
 	loopback := ipLoopback(family)
 	fmtPorts := formatPorts(ports)
@@ -28,8 +28,10 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/network/driver/nft"
 	"kubevirt.io/kubevirt/pkg/network/driver/nmstate"
+	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/network/setup/netpod/masquerade"
 	"kubevirt.io/kubevirt/pkg/pointer"
 )
@@ -340,9 +342,11 @@ family ip6 table nat chain output rulespec [ip6 daddr { ::1 } tcp dport 8080 cou
 	})
 
 	Context("with ISTIO", func() {
+		istioSidecarMesh := istio.NewMesh(libvmi.New(libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true")), nil)
+
 		It("setup with IPv4 and IPv6, no ports", func() {
 			nftStub := &nftableStub{}
-			masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub), masquerade.WithIstio(true))
+			masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub), masquerade.WithServiceMesh(istioSidecarMesh))
 
 			err := masqPod.Setup(
 				&nmstate.Interface{
@@ -431,7 +435,7 @@ family ip6 table nat chain output rulespec [ip6 daddr { ::1 } counter dnat to fd
 			nftStub := &nftableStub{}
 			masqPod := masquerade.New(
 				masquerade.WithNftableAdapter(nftStub),
-				masquerade.WithIstio(true),
+				masquerade.WithServiceMesh(istioSidecarMesh),
 				masquerade.WithLegacyMigrationPorts(),
 			)
 
@@ -520,6 +524,99 @@ family ip6 table nat chain KUBEVIRT_POSTINBOUND rulespec [tcp dport 80 ip6 saddr
 family ip6 table nat chain output rulespec [ip6 daddr { ::1 } tcp dport 80 counter dnat to fd10:0:2::2]
 family ip6 table nat chain KUBEVIRT_POSTINBOUND rulespec [tcp dport 8080 ip6 saddr { ::1 } counter snat to fd10:0:2::1]
 family ip6 table nat chain output rulespec [ip6 daddr { ::1 } tcp dport 8080 counter dnat to fd10:0:2::2]
+`
+			Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
+		})
+	})
+	Context("with a service mesh", func() {
+		const expectedChains = `tables:
+family ip name nat
+chains:
+family ip table nat name prerouting chainspec [{ type nat hook prerouting priority -100; }]
+family ip table nat name input chainspec [{ type nat hook input priority 100; }]
+family ip table nat name output chainspec [{ type nat hook output priority -100; }]
+family ip table nat name postrouting chainspec [{ type nat hook postrouting priority 100; }]
+family ip table nat name KUBEVIRT_PREINBOUND chainspec []
+family ip table nat name KUBEVIRT_POSTINBOUND chainspec []
+rules:
+family ip table nat chain postrouting rulespec [ip saddr 10.0.2.2 counter masquerade]
+family ip table nat chain prerouting rulespec [iifname eth0 counter jump KUBEVIRT_PREINBOUND]
+family ip table nat chain postrouting rulespec [oifname k6t-eth0 counter jump KUBEVIRT_POSTINBOUND]
+`
+
+		setupIPv4 := func(masqPod masquerade.MasqPod) error {
+			return masqPod.Setup(
+				&nmstate.Interface{
+					Name:     "k6t-eth0",
+					TypeName: nmstate.TypeBridge,
+					IPv4: nmstate.IP{
+						Enabled: pointer.P(true),
+						Address: []nmstate.IPAddress{{IP: "10.0.2.1", PrefixLen: 24}},
+					},
+				},
+				&nmstate.Interface{
+					Name:     "eth0",
+					TypeName: nmstate.TypeVETH,
+					IPv4: nmstate.IP{
+						Enabled: pointer.P(true),
+						Address: []nmstate.IPAddress{{IP: "10.222.222.1", PrefixLen: 30}},
+					},
+				},
+				v1.Interface{
+					Name:                   "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				},
+			)
+		}
+
+		It("setup a sidecar mesh with configured ports and proxy source address", func() {
+			nftStub := &nftableStub{}
+			mesh := istio.NewMesh(
+				libvmi.New(libvmi.WithAnnotation("linkerd.io/inject", "enabled")),
+				&v1.ServiceMeshConfiguration{
+					InjectionAnnotations: map[string]string{"linkerd.io/inject": "enabled"},
+					ReservedPorts:        []int32{4140, 4143, 4191},
+					NonProxiedPorts:      []int32{22, 2222},
+					ProxySourceAddress:   "127.0.0.9",
+				},
+			)
+			masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub), masquerade.WithServiceMesh(mesh))
+
+			Expect(setupIPv4(masqPod)).To(Succeed())
+			expectedConfig := expectedChains + `family ip table nat chain output rulespec [tcp dport { 4140, 4143, 4191 } ip saddr 127.0.0.1 counter return]
+family ip table nat chain KUBEVIRT_POSTINBOUND rulespec [tcp dport { 4140, 4143, 4191 } ip saddr 127.0.0.1 counter return]
+family ip table nat chain KUBEVIRT_PREINBOUND rulespec [tcp dport { 22, 2222 } counter dnat to 10.0.2.2]
+family ip table nat chain KUBEVIRT_POSTINBOUND rulespec [ip saddr { 127.0.0.1, 127.0.0.9 } counter snat to 10.0.2.1]
+family ip table nat chain output rulespec [ip daddr { 127.0.0.1, 10.222.222.1 } counter dnat to 10.0.2.2]
+`
+			Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
+		})
+
+		It("setup an ambient mesh, preserving the client source IP", func() {
+			nftStub := &nftableStub{}
+			mesh := istio.NewMesh(libvmi.New(libvmi.WithLabel(istio.DataplaneModeLabel, "ambient")), nil)
+			masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub), masquerade.WithServiceMesh(mesh))
+
+			Expect(setupIPv4(masqPod)).To(Succeed())
+			expectedConfig := expectedChains + `family ip table nat chain KUBEVIRT_PREINBOUND rulespec [tcp dport { 22 } counter dnat to 10.0.2.2]
+family ip table nat chain KUBEVIRT_POSTINBOUND rulespec [ip saddr { 127.0.0.1 } counter snat to 10.0.2.1]
+family ip table nat chain output rulespec [ip daddr { 127.0.0.1, 10.222.222.1 } counter dnat to 10.0.2.2]
+`
+			Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
+		})
+
+		It("setup an ambient mesh, translating the source of all inbound traffic", func() {
+			nftStub := &nftableStub{}
+			mesh := istio.NewMesh(
+				libvmi.New(libvmi.WithLabel(istio.DataplaneModeLabel, "ambient")),
+				&v1.ServiceMeshConfiguration{TranslateSourceIP: true},
+			)
+			masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub), masquerade.WithServiceMesh(mesh))
+
+			Expect(setupIPv4(masqPod)).To(Succeed())
+			expectedConfig := expectedChains + `family ip table nat chain KUBEVIRT_PREINBOUND rulespec [tcp dport { 22 } counter dnat to 10.0.2.2]
+family ip table nat chain KUBEVIRT_POSTINBOUND rulespec [counter snat to 10.0.2.1]
+family ip table nat chain output rulespec [ip daddr { 127.0.0.1, 10.222.222.1 } counter dnat to 10.0.2.2]
 `
 			Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
 		})
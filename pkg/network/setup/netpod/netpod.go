@@ -83,6 +83,7 @@ type NetPod struct {
 	state        *State
 
 	bindingPluginsByName map[string]v1.InterfaceBindingPlugin
+	dhcpServerLeases     map[string]string
 
 	log *log.FilteredLogger
 }
@@ -143,6 +144,13 @@ func WithLogger(logger *log.FilteredLogger) option {
 	}
 }
 
+// WithDHCPServerLeases sets the addresses handed out by the in-pod DHCP server, indexed by the VMI network name.
+func WithDHCPServerLeases(leases map[string]string) option {
+	return func(n *NetPod) {
+		n.dhcpServerLeases = leases
+	}
+}
+
 func WithVMIIfaceStatuses(vmiIfaceStatuses []v1.VirtualMachineInstanceNetworkInterface) option {
 	return func(n *NetPod) {
 		n.vmiIfaceStatuses = vmiIfaceStatuses
@@ -356,7 +364,8 @@ func (n NetPod) bridgeBindingSpec(podIfaceName string, vmiIfaceIndex int, ifaceS
 		podStatusIface = ifaceStatusByName[podIfaceName]
 	}
 
-	if hasIPGlobalUnicast(podStatusIface.IPv4) {
+	_, hasDHCPServerLease := n.dhcpServerLeases[vmiNetworkName]
+	if hasIPGlobalUnicast(podStatusIface.IPv4) || hasDHCPServerLease {
 		bridgeIface.IPv4 = nmstate.IP{
 			Enabled: pointer.P(true),
 			Address: []nmstate.IPAddress{
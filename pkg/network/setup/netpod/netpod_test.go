@@ -893,6 +893,42 @@ var _ = Describe("netpod", func() {
 			Entry("with hotplug (second invoke adds a network)", hotplugEnabled),
 		)
 
+		It("setup secondary bridge binding without IP and with a DHCP server lease", func() {
+			specNetworks[1].Multus.DHCPServer = &v1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}
+			netPod := netpod.NewNetPod(
+				specNetworks,
+				specInterfaces,
+				vmiUID, 0, 0, 0, state,
+				netpod.WithNMStateAdapter(&nmstatestub),
+				netpod.WithMasqueradeAdapter(&masqstub),
+				netpod.WithCacheCreator(&baseCacheCreator),
+				netpod.WithDHCPServerLeases(map[string]string{secondaryNetworkName: "10.10.0.2/24"}),
+			)
+			Expect(netPod.Setup()).To(Succeed())
+
+			Expect(nmstatestub.spec.Interfaces).To(ContainElement(nmstate.Interface{
+				Name:     "k6t-914f438d88d",
+				TypeName: nmstate.TypeBridge,
+				State:    nmstate.IfaceStateUp,
+				Ethtool:  nmstate.Ethtool{Feature: nmstate.Feature{TxChecksum: pointer.P(false)}},
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{IP: "169.254.75.11", PrefixLen: 32}},
+				},
+				Metadata: &nmstate.IfaceMetadata{Pid: 0, NetworkName: secondaryNetworkName},
+			}))
+
+			expectedIP, err := vishnetlink.ParseAddr("10.10.0.2/24")
+			Expect(err).NotTo(HaveOccurred())
+			expectedMAC, err := net.ParseMAC(secondaryPodIfaceOrignalMAC)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cache.ReadDHCPInterfaceCache(&baseCacheCreator, "0", secondaryPodInterfaceName)).To(Equal(&cache.DHCPConfig{
+				IP:      *expectedIP,
+				MAC:     expectedMAC,
+				Gateway: net.ParseIP("10.10.0.1"),
+			}))
+		})
+
 		It("setup secondary bridge binding with hashed pod interfaces and absent set", func() {
 			specInterfaces[1].State = v1.InterfaceStateAbsent
 			netPod := netpod.NewNetPod(
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2024 The KubeVirt Authors.
+ *
+ */
+
+package vhostuser
+
+import (
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+)
+
+const (
+	// SocketsVolumeName is the name of the launcher pod emptyDir volume, into which
+	// virt-handler mounts the node directory holding the vhost-user sockets.
+	SocketsVolumeName = "vhostuser-sockets"
+	// SocketsVolumeMountDir is the mount point of the sockets volume in the launcher compute container.
+	SocketsVolumeMountDir = "/var/run/kubevirt/vhostuser"
+	// SocketsSubDir is the directory of the sockets volume the node directory is mounted on.
+	SocketsSubDir = "sockets"
+	// SocketsDir is the directory in the launcher compute container holding the vhost-user sockets.
+	SocketsDir = SocketsVolumeMountDir + "/" + SocketsSubDir
+
+	// HostSocketsBaseDir is the node directory, below the directory shared by virt-handler
+	// with the node, which holds the vhost-user sockets directories of the VMIs.
+	HostSocketsBaseDir = "/var/run/kubevirt/vhostuser"
+)
+
+// HostSocketsDir returns the node directory, shared with the userspace datapath,
+// which holds the vhost-user sockets of the given VMI.
+func HostSocketsDir(vmiUID types.UID) string {
+	return filepath.Join(HostSocketsBaseDir, string(vmiUID))
+}
+
+// SocketPath returns the path of the vhost-user socket of the given network,
+// as seen from the launcher compute container.
+// The socket is named after the hashed pod interface name of the network,
+// keeping it short and predictable for the datapath plugin.
+func SocketPath(networkName string) string {
+	return filepath.Join(SocketsDir, namescheme.GenerateHashedInterfaceName(networkName)+".sock")
+}
@@ -188,3 +188,20 @@ func HasBindingPluginDeviceInfo(iface v1.Interface, bindingPlugins map[string]v1
 	}
 	return false
 }
+
+func BindingPluginNetworkWithVhostUserExist(ifaces []v1.Interface, bindingPlugins map[string]v1.InterfaceBindingPlugin) bool {
+	for _, iface := range ifaces {
+		if HasBindingPluginVhostUser(iface, bindingPlugins) {
+			return true
+		}
+	}
+	return false
+}
+
+func HasBindingPluginVhostUser(iface v1.Interface, bindingPlugins map[string]v1.InterfaceBindingPlugin) bool {
+	if iface.Binding != nil {
+		binding, exist := bindingPlugins[iface.Binding.Name]
+		return exist && binding.DomainAttachmentType == v1.VhostUser
+	}
+	return false
+}
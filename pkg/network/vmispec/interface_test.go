@@ -270,6 +270,28 @@ var _ = Describe("VMI network spec", func() {
 			Expect(netvmispec.BindingPluginNetworkWithDeviceInfoExist(ifaces, bindingPlugins)).To(BeTrue())
 		})
 	})
+	Context("binding plugin network with vhost-user exist", func() {
+		const vhostUserPlugin = "vhostuser"
+		vhostUserBindingPlugins := map[string]v1.InterfaceBindingPlugin{
+			vhostUserPlugin:     {DomainAttachmentType: v1.VhostUser},
+			nonDeviceInfoPlugin: {DomainAttachmentType: v1.Tap},
+		}
+
+		It("returns false when there is no network with vhost-user plugin", func() {
+			ifaces := []v1.Interface{
+				libvmi.InterfaceDeviceWithBridgeBinding("net1"),
+				interfaceWithBindingPlugin("net2", nonDeviceInfoPlugin),
+			}
+			Expect(netvmispec.BindingPluginNetworkWithVhostUserExist(ifaces, vhostUserBindingPlugins)).To(BeFalse())
+		})
+		It("returns true when there is at least one network with vhost-user plugin", func() {
+			ifaces := []v1.Interface{
+				interfaceWithBindingPlugin("net1", nonDeviceInfoPlugin),
+				interfaceWithBindingPlugin("net2", vhostUserPlugin),
+			}
+			Expect(netvmispec.BindingPluginNetworkWithVhostUserExist(ifaces, vhostUserBindingPlugins)).To(BeTrue())
+		})
+	})
 })
 
 func podNetwork(name string) v1.Network {
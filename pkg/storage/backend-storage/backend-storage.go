@@ -144,8 +144,15 @@ func HasPersistentEFI(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
 		*vmiSpec.Domain.Firmware.Bootloader.EFI.Persistent
 }
 
+// HasSerialConsoleLogOnBackendStorage returns whether the serial console log is persisted
+// to the backend storage instead of a PVC of its own
+func HasSerialConsoleLogOnBackendStorage(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
+	return vmiSpec.Domain.Devices.SerialConsoleLogPersistence != nil &&
+		vmiSpec.Domain.Devices.SerialConsoleLogPersistence.ClaimName == ""
+}
+
 func IsBackendStorageNeededForVMI(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
-	return HasPersistentTPMDevice(vmiSpec) || HasPersistentEFI(vmiSpec)
+	return HasPersistentTPMDevice(vmiSpec) || HasPersistentEFI(vmiSpec) || HasSerialConsoleLogOnBackendStorage(vmiSpec)
 }
 
 func IsBackendStorageNeededForVM(vm *corev1.VirtualMachine) bool {
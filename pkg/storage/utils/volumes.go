@@ -24,7 +24,9 @@ import (
 	"fmt"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1 "kubevirt.io/api/core/v1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/kubecli"
@@ -82,29 +84,22 @@ func GetVolumes(obj interface{}, client kubecli.KubevirtClient, opts ...VolumeOp
 
 // GetVirtualMachineVolumes returns all volumes of a VM except the special ones based on volume options
 func GetVirtualMachineVolumes(vm *v1.VirtualMachine, client kubecli.KubevirtClient, opts ...VolumeOption) ([]v1.Volume, error) {
-	var err error
-	vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: vm.Name}, Spec: vm.Spec.Template.Spec}
-	if needsBackendPVC(vm.Spec.Template.Spec, opts) {
-		if client == nil {
-			return []v1.Volume{}, fmt.Errorf("no client provided")
-		}
-		vmi, err = client.VirtualMachineInstance(vm.Namespace).Get(context.Background(), vm.Name, metav1.GetOptions{})
-		if err != nil {
-			return []v1.Volume{}, err
-		}
-	}
-	return GetVirtualMachineInstanceVolumes(vmi, opts...), nil
+	return getTemplateVolumes(vm.Namespace, vm.Name, vm.Spec.Template.Spec, client, opts...)
 }
 
 // GetSnapshotVirtualMachineVolumes returns all volumes of a Snapshot VM except the special ones based on volume options
 func GetSnapshotVirtualMachineVolumes(vm *snapshotv1.VirtualMachine, client kubecli.KubevirtClient, opts ...VolumeOption) ([]v1.Volume, error) {
-	var err error
-	vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: vm.Name}, Spec: vm.Spec.Template.Spec}
-	if needsBackendPVC(vm.Spec.Template.Spec, opts) {
+	return getTemplateVolumes(vm.Namespace, vm.Name, vm.Spec.Template.Spec, client, opts...)
+}
+
+func getTemplateVolumes(namespace, name string, spec v1.VirtualMachineInstanceSpec, client kubecli.KubevirtClient, opts ...VolumeOption) ([]v1.Volume, error) {
+	vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	if needsBackendPVC(spec, opts) {
 		if client == nil {
 			return []v1.Volume{}, fmt.Errorf("no client provided")
 		}
-		vmi, err = client.VirtualMachineInstance(vm.Namespace).Get(context.Background(), vm.Name, metav1.GetOptions{})
+		var err error
+		vmi, err = backendVolumeVMI(vmi, client)
 		if err != nil {
 			return []v1.Volume{}, err
 		}
@@ -112,6 +107,29 @@ func GetSnapshotVirtualMachineVolumes(vm *snapshotv1.VirtualMachine, client kube
 	return GetVirtualMachineInstanceVolumes(vmi, opts...), nil
 }
 
+// backendVolumeVMI returns the running VMI of the VM, since its status points to the backend PVC in use.
+// When the VM is stopped, the backend PVC is found through the label it carries instead.
+func backendVolumeVMI(vmi *v1.VirtualMachineInstance, client kubecli.KubevirtClient) (*v1.VirtualMachineInstance, error) {
+	runningVMI, err := client.VirtualMachineInstance(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+	if err == nil {
+		return runningVMI, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(vmi.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.Set{backendstorage.PVCPrefix: vmi.Name}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{Name: pvc.Name})
+	}
+	return vmi, nil
+}
+
 // GetVirtualMachineInstanceVolumes returns all volumes of a VMI except the special ones based on volume options
 func GetVirtualMachineInstanceVolumes(vmi *v1.VirtualMachineInstance, opts ...VolumeOption) []v1.Volume {
 	var enumeratedVolumes []v1.Volume
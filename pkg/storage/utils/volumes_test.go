@@ -20,9 +20,17 @@
 package utils
 
 import (
+	"context"
+
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
 )
@@ -107,3 +115,82 @@ var _ = Describe("GetVirtualMachineInstanceVolumes", func() {
 		),
 	)
 })
+
+var _ = Describe("GetVirtualMachineVolumes", func() {
+	const (
+		vmName     = "testvm"
+		backendPVC = "persistent-state-for-testvm-abcde"
+	)
+
+	var (
+		virtClient     *kubecli.MockKubevirtClient
+		k8sClient      *k8sfake.Clientset
+		kubevirtClient *kubevirtfake.Clientset
+		vm             *v1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		k8sClient = k8sfake.NewSimpleClientset()
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(kubevirtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: metav1.NamespaceDefault},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								TPM: &v1.TPMDevice{Persistent: pointer.P(true)},
+							},
+						},
+						Volumes: []v1.Volume{{Name: "rootdisk"}},
+					},
+				},
+			},
+		}
+	})
+
+	It("should take the backend volume from the running VMI", func() {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: metav1.NamespaceDefault},
+			Spec:       vm.Spec.Template.Spec,
+			Status: v1.VirtualMachineInstanceStatus{
+				VolumeStatus: []v1.VolumeStatus{{Name: backendPVC}},
+			},
+		}
+		_, err := kubevirtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		volumes, err := GetVirtualMachineVolumes(vm, virtClient, WithBackendVolume)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[1].PersistentVolumeClaim.ClaimName).To(Equal(backendPVC))
+	})
+
+	It("should find the backend volume of a stopped VM by its label", func() {
+		pvc := &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backendPVC,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{"persistent-state-for": vmName},
+			},
+		}
+		_, err := k8sClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Create(context.Background(), pvc, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		volumes, err := GetVirtualMachineVolumes(vm, virtClient, WithBackendVolume)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[1].PersistentVolumeClaim.ClaimName).To(Equal(backendPVC))
+	})
+
+	It("should not return a backend volume when a stopped VM has none", func() {
+		volumes, err := GetVirtualMachineVolumes(vm, virtClient, WithBackendVolume)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(volumes).To(HaveLen(1))
+	})
+})
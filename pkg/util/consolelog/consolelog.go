@@ -0,0 +1,224 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package consolelog writes and reads the persisted serial console log of a VM.
+// Every line is prefixed with the time it was logged at, and the log file is
+// rotated once it exceeds its maximum size.
+package consolelog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FileName of the current log file, rotated files get a numeric suffix, the highest being the oldest
+	FileName = "serial0.log"
+	// MountPath is where the directory holding the persisted log is mounted in the virt-launcher pod
+	MountPath = "/var/run/kubevirt-serial-console-log"
+	// BackendStorageSubPath is the directory of the backend storage holding the persisted log
+	BackendStorageSubPath = "serial-console-log"
+
+	DefaultMaxFileSize = 1024 * 1024
+	DefaultMaxFiles    = 3
+	MaxRotatedFiles    = 100
+	MaxFileSizeLimit   = 10 * 1024 * 1024
+	// MaxTailLines is the number of newest lines the consolelog subresource returns at most
+	MaxTailLines = 10000
+
+	maxLineSize = 1024 * 1024
+)
+
+// Writer appends timestamped lines to the log file of a directory
+type Writer struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	file        *os.File
+	size        int64
+}
+
+// NewWriter opens the log file in dir for appending. The file is rotated once it exceeds
+// maxFileSize, keeping maxFiles rotated files.
+func NewWriter(dir string, maxFileSize int64, maxFiles int) (*Writer, error) {
+	w := &Writer{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	file, err := os.OpenFile(filepath.Join(w.dir, FileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// WriteLine logs a line of the serial console with the time it was read at
+func (w *Writer) WriteLine(t time.Time, line string) error {
+	entry := t.UTC().Format(time.RFC3339Nano) + " " + line + "\n"
+	if w.size > 0 && w.size+int64(len(entry)) > w.maxFileSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.file.WriteString(entry)
+	w.size += int64(n)
+	return err
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	for i := w.maxFiles; i > 0; i-- {
+		src := filepath.Join(w.dir, FileName)
+		if i > 1 {
+			src = rotatedFile(w.dir, i-1)
+		}
+		if err := os.Rename(src, rotatedFile(w.dir, i)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return w.open()
+}
+
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
+
+func rotatedFile(dir string, index int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s.%d", FileName, index))
+}
+
+// FileNames returns the names of the persisted log files among names, from the oldest to the newest
+func FileNames(names []string) []string {
+	type rotated struct {
+		index int
+		name  string
+	}
+	var files []rotated
+	current := false
+	for _, name := range names {
+		if name == FileName {
+			current = true
+			continue
+		}
+		suffix, found := strings.CutPrefix(name, FileName+".")
+		if !found {
+			continue
+		}
+		index, err := strconv.Atoi(suffix)
+		if err != nil || index <= 0 {
+			continue
+		}
+		files = append(files, rotated{index, name})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].index > files[j].index })
+
+	ordered := make([]string, 0, len(files)+1)
+	for _, file := range files {
+		ordered = append(ordered, file.name)
+	}
+	if current {
+		ordered = append(ordered, FileName)
+	}
+	return ordered
+}
+
+// Open returns the persisted log read from the opened log files, ordered from the oldest to the newest.
+// Closing the log closes the files.
+func Open(files []*os.File) io.ReadCloser {
+	readers := make([]io.Reader, 0, len(files))
+	for _, file := range files {
+		readers = append(readers, file)
+	}
+	return &multiFileReader{Reader: io.MultiReader(readers...), files: files}
+}
+
+type multiFileReader struct {
+	io.Reader
+	files []*os.File
+}
+
+func (r *multiFileReader) Close() error {
+	var errs []error
+	for _, file := range r.files {
+		errs = append(errs, file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Filter copies the lines of a persisted log which were logged after since to out, without their timestamp.
+// When tail is greater than 0 only the newest tail lines are copied, and only those are kept in memory.
+func Filter(log io.Reader, since time.Time, tail int, out io.Writer) error {
+	var newest []string
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		timestamp, text, found := strings.Cut(line, " ")
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); found && err == nil {
+			if t.Before(since) {
+				continue
+			}
+			line = text
+		}
+		if tail > 0 {
+			if len(newest) == tail {
+				newest = newest[1:]
+			}
+			newest = append(newest, line)
+			continue
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, line := range newest {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
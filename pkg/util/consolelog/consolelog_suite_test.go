@@ -0,0 +1,11 @@
+package consolelog_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestConsoleLog(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,147 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package consolelog_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
+)
+
+var _ = Describe("Persisted serial console log", func() {
+	var (
+		dir   string
+		start time.Time
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	writeLines := func(w *consolelog.Writer, count int) {
+		for i := 0; i < count; i++ {
+			Expect(w.WriteLine(start.Add(time.Duration(i)*time.Second), fmt.Sprintf("line %02d", i))).To(Succeed())
+		}
+	}
+
+	openLog := func() io.ReadCloser {
+		entries, err := os.ReadDir(dir)
+		Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		var files []*os.File
+		for _, name := range consolelog.FileNames(names) {
+			file, err := os.Open(filepath.Join(dir, name))
+			Expect(err).ToNot(HaveOccurred())
+			files = append(files, file)
+		}
+		return consolelog.Open(files)
+	}
+
+	readLog := func(since time.Time, tail int) []string {
+		log := openLog()
+		defer log.Close()
+		var out bytes.Buffer
+		Expect(consolelog.Filter(log, since, tail, &out)).To(Succeed())
+		return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	}
+
+	It("should rotate the log file and keep the configured number of rotated files", func() {
+		// Every entry takes 29 bytes, so every file holds 3 lines
+		w, err := consolelog.NewWriter(dir, 90, 2)
+		Expect(err).ToNot(HaveOccurred())
+		writeLines(w, 10)
+		Expect(w.Close()).To(Succeed())
+
+		Expect(filepath.Join(dir, consolelog.FileName)).To(BeAnExistingFile())
+		Expect(filepath.Join(dir, consolelog.FileName+".1")).To(BeAnExistingFile())
+		Expect(filepath.Join(dir, consolelog.FileName+".2")).To(BeAnExistingFile())
+		Expect(filepath.Join(dir, consolelog.FileName+".3")).ToNot(BeAnExistingFile())
+
+		Expect(readLog(time.Time{}, 0)).To(Equal([]string{
+			"line 03", "line 04", "line 05", "line 06", "line 07", "line 08", "line 09",
+		}))
+	})
+
+	It("should append to the log of a previous run", func() {
+		w, err := consolelog.NewWriter(dir, consolelog.DefaultMaxFileSize, consolelog.DefaultMaxFiles)
+		Expect(err).ToNot(HaveOccurred())
+		writeLines(w, 2)
+		Expect(w.Close()).To(Succeed())
+
+		w, err = consolelog.NewWriter(dir, consolelog.DefaultMaxFileSize, consolelog.DefaultMaxFiles)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(w.WriteLine(start.Add(time.Minute), "rebooted")).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+
+		Expect(readLog(time.Time{}, 0)).To(Equal([]string{"line 00", "line 01", "rebooted"}))
+	})
+
+	It("should only return the lines logged since the given time", func() {
+		w, err := consolelog.NewWriter(dir, consolelog.DefaultMaxFileSize, consolelog.DefaultMaxFiles)
+		Expect(err).ToNot(HaveOccurred())
+		writeLines(w, 5)
+		Expect(w.Close()).To(Succeed())
+
+		Expect(readLog(start.Add(3*time.Second), 0)).To(Equal([]string{"line 03", "line 04"}))
+	})
+
+	It("should only return the newest lines when tailing the log", func() {
+		w, err := consolelog.NewWriter(dir, 90, 2)
+		Expect(err).ToNot(HaveOccurred())
+		writeLines(w, 10)
+		Expect(w.Close()).To(Succeed())
+
+		Expect(readLog(time.Time{}, 2)).To(Equal([]string{"line 08", "line 09"}))
+		Expect(readLog(start.Add(8*time.Second), 5)).To(Equal([]string{"line 08", "line 09"}))
+	})
+
+	It("should return an empty log when nothing was logged yet", func() {
+		log := openLog()
+		defer log.Close()
+		var out bytes.Buffer
+		Expect(consolelog.Filter(log, time.Time{}, 0, &out)).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should order the log files from the oldest to the newest and ignore other files", func() {
+		Expect(consolelog.FileNames([]string{
+			"serial0.log", "serial0.log.1", "other.log", "serial0.log.10", "serial0.log.2", "serial0.log.tmp", "serial0.log.0",
+		})).To(Equal([]string{"serial0.log.10", "serial0.log.2", "serial0.log.1", "serial0.log"}))
+		Expect(consolelog.FileNames(nil)).To(BeEmpty())
+	})
+
+	It("should keep lines without a timestamp", func() {
+		Expect(os.WriteFile(filepath.Join(dir, consolelog.FileName), []byte("no timestamp\n"), 0640)).To(Succeed())
+		Expect(readLog(time.Time{}, 0)).To(Equal([]string{"no timestamp"}))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -105,6 +106,26 @@ func IsSEVESVMI(vmi *v1.VirtualMachineInstance) bool {
 		*vmi.Spec.Domain.LaunchSecurity.SEV.Policy.EncryptedState
 }
 
+// Check if a VMI spec requests AMD SEV-SNP
+func IsSEVSNPVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.LaunchSecurity != nil && vmi.Spec.Domain.LaunchSecurity.SNP != nil
+}
+
+// Check if a VMI spec requests Intel TDX
+func IsTDXVMI(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.LaunchSecurity != nil && vmi.Spec.Domain.LaunchSecurity.TDX != nil
+}
+
+// Check if a VMI spec requests AMD SEV or SEV-SNP, which both need the SEV device of the host
+func UsesSEVDevice(vmi *v1.VirtualMachineInstance) bool {
+	return IsSEVVMI(vmi) || IsSEVSNPVMI(vmi)
+}
+
+// Check if a VMI spec requests any kind of launch security
+func IsConfidentialVMI(vmi *v1.VirtualMachineInstance) bool {
+	return IsSEVVMI(vmi) || IsSEVSNPVMI(vmi) || IsTDXVMI(vmi)
+}
+
 // Check if a VMI spec requests SEV with attestation
 func IsSEVAttestationRequested(vmi *v1.VirtualMachineInstance) bool {
 	return IsSEVVMI(vmi) && vmi.Spec.Domain.LaunchSecurity.SEV.Attestation != nil
@@ -158,6 +179,29 @@ func NeedTunDevice(vmi *v1.VirtualMachineInstance) bool {
 		(*vmi.Spec.Domain.Devices.AutoattachPodInterface)
 }
 
+// Check if a VMI spec requests a 3D accelerated video device
+func IsVideoAccel3DVMI(vmi *v1.VirtualMachineInstance) bool {
+	video := vmi.Spec.Domain.Devices.Video
+	return video != nil && video.Accel3D != nil && *video.Accel3D
+}
+
+// FindRenderNode returns the path of the first GPU render node found below root,
+// relative to root. Nodes and containers may expose render nodes with any minor number.
+func FindRenderNode(root string) (string, error) {
+	renderNodes, err := filepath.Glob(filepath.Join(root, "dev", "dri", "renderD*"))
+	if err != nil {
+		return "", err
+	}
+	if len(renderNodes) == 0 {
+		return "", fmt.Errorf("no GPU render node found")
+	}
+	renderNode, err := filepath.Rel(root, renderNodes[0])
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join("/", renderNode), nil
+}
+
 func IsAutoAttachVSOCK(vmi *v1.VirtualMachineInstance) bool {
 	return vmi.Spec.Domain.Devices.AutoattachVSOCK != nil && *vmi.Spec.Domain.Devices.AutoattachVSOCK
 }
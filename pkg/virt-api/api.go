@@ -361,6 +361,11 @@ func (app *virtAPIApp) composeSubresources() {
 			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).Param(definitions.MoveCursorParam(subws)).
 			Operation(version.Version + "VNCScreenshot").
 			Doc("Get a PNG VNC screenshot of the specified VirtualMachineInstance."))
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR) + definitions.SubResourcePath("spice")).
+			To(subresourceApp.SpiceRequestHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version + "Spice").
+			Doc("Open a websocket connection to connect to SPICE on the specified VirtualMachineInstance."))
 		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR) + definitions.SubResourcePath("usbredir")).
 			To(subresourceApp.USBRedirRequestHandler).
 			Param(definitions.NamespaceParam(subws)).
@@ -553,6 +558,26 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.SEVMeasurementInfo{}).
 			Returns(http.StatusOK, "OK", v1.SEVMeasurementInfo{}))
 
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("launchsecurity/report")).
+			To(subresourceApp.LaunchSecurityReportHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"LaunchSecurityReport").
+			Doc("Get the launch security report of a SEV or SEV-SNP Virtual Machine").
+			Writes(v1.LaunchSecurityReport{}).
+			Returns(http.StatusOK, "OK", v1.LaunchSecurityReport{}))
+
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("consolelog")).
+			To(subresourceApp.ConsoleLogHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).Param(definitions.SinceTimeParam(subws)).Param(definitions.TailLinesParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"ConsoleLog").
+			Doc("Get the persisted serial console log of a Virtual Machine").
+			Writes(v1.SerialConsoleLog{}).
+			Returns(http.StatusOK, "OK", v1.SerialConsoleLog{}))
+
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("sev/setupsession")).
 			To(subresourceApp.SEVSetupSessionHandler).
 			Consumes(mime.MIME_ANY).
@@ -595,6 +620,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/vnc",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/spice",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
@@ -671,6 +700,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/sev/querylaunchmeasurement",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/launchsecurity/report",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/consolelog",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/sev/setupsession",
 						Namespaced: true,
@@ -568,6 +568,8 @@ const (
 	NamespaceParamName  = "namespace"
 	NameParamName       = "name"
 	MoveCursorParamName = "moveCursor"
+	SinceTimeParamName  = "sinceTime"
+	TailLinesParamName  = "tailLines"
 )
 
 func NameParam(ws *restful.WebService) *restful.Parameter {
@@ -582,6 +584,14 @@ func MoveCursorParam(ws *restful.WebService) *restful.Parameter {
 	return ws.QueryParameter(MoveCursorParamName, "Move the cursor on the VNC display to wake up the screen").DataType("boolean").DefaultValue("false")
 }
 
+func SinceTimeParam(ws *restful.WebService) *restful.Parameter {
+	return ws.QueryParameter(SinceTimeParamName, "RFC3339 timestamp, only the serial console log lines logged after it are returned")
+}
+
+func TailLinesParam(ws *restful.WebService) *restful.Parameter {
+	return ws.QueryParameter(TailLinesParamName, "Number of the newest serial console log lines to return, at most 10000").DataType("integer")
+}
+
 func labelSelectorParam(ws *restful.WebService) *restful.Parameter {
 	return ws.QueryParameter("labelSelector", "A selector to restrict the list of returned objects by their labels. Defaults to everything")
 }
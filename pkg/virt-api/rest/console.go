@@ -2,6 +2,8 @@ package rest
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 
 	restful "github.com/emicklei/go-restful/v3"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -19,17 +21,50 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 
 	defer apimetrics.SetVMILastConnectionTimestamp(request.PathParameter("namespace"), request.PathParameter("name"))
 
+	query, statusErr := consoleSessionQuery(request)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
 	streamer := NewRawStreamer(
 		app.FetchVirtualMachineInstance,
 		validateVMIForConsole,
 		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-			return conn.ConsoleURI(vmi)
+			uri, err := conn.ConsoleURI(vmi)
+			if err != nil || len(query) == 0 {
+				return uri, err
+			}
+			return uri + "?" + query.Encode(), nil
 		}),
 	)
 
 	streamer.Handle(request, response)
 }
 
+// consoleSessionQuery validates how the client wants to join the serial console session
+// and returns the query passed on to virt-handler.
+func consoleSessionQuery(request *restful.Request) (url.Values, *errors.StatusError) {
+	query := url.Values{}
+	for _, name := range []string{"readOnly", "takeOver"} {
+		param := request.QueryParameter(name)
+		if param == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid %s parameter %q", name, param))
+		}
+		if value {
+			query.Set(name, "true")
+		}
+	}
+	if query.Has("readOnly") && query.Has("takeOver") {
+		return nil, errors.NewBadRequest("a read-only client can not take over the serial console")
+	}
+	return query, nil
+}
+
 func validateVMIForConsole(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 	if vmi.Spec.Domain.Devices.AutoattachSerialConsole != nil && !*vmi.Spec.Domain.Devices.AutoattachSerialConsole {
 		err := fmt.Errorf("No serial consoles are present.")
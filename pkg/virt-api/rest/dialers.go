@@ -2,13 +2,15 @@ package rest
 
 import (
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"strings"
 
 	"github.com/gorilla/websocket"
 
 	restful "github.com/emicklei/go-restful/v3"
 	"k8s.io/apimachinery/pkg/api/errors"
-	netutils "k8s.io/utils/net"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -32,13 +34,30 @@ func (h handlerDial) Dial(vmi *v1.VirtualMachineInstance) (*websocket.Conn, *err
 	if statusError != nil {
 		return nil, statusError
 	}
-	conn, _, err := kvcorev1.Dial(url, h.app.handlerTLSConfiguration)
+	conn, resp, err := kvcorev1.Dial(url, h.app.handlerTLSConfiguration)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			// virt-handler refuses to share a connection, e.g. a serial console already in use
+			return nil, errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, handlerResponseError(resp))
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
 		return nil, errors.NewInternalError(fmt.Errorf("dialing virt-handler: %w", err))
 	}
 	return conn, nil
 }
 
+// handlerResponseError returns the reason virt-handler gave for refusing a websocket upgrade
+func handlerResponseError(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(strings.TrimSpace(string(body))) == 0 {
+		return fmt.Errorf("virt-handler responded with %s", resp.Status)
+	}
+	return fmt.Errorf("%s", strings.TrimSpace(string(body)))
+}
+
 func (h handlerDial) DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *errors.StatusError) {
 	conn, err := h.Dial(vmi)
 	if err != nil {
@@ -70,10 +89,7 @@ func (n netDial) DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *erro
 		protocol = protocolParam
 	}
 
-	addr := fmt.Sprintf("%s:%s", targetIP, port)
-	if netutils.IsIPv6String(targetIP) {
-		addr = fmt.Sprintf("[%s]:%s", targetIP, port)
-	}
+	addr := net.JoinHostPort(targetIP, port)
 	conn, err := net.Dial(protocol, addr)
 	if err != nil {
 		logger.Reason(err).Errorf("Can't dial %s %s", protocol, addr)
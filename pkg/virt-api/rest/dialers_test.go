@@ -2,10 +2,12 @@ package rest
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 
 	"github.com/emicklei/go-restful/v3"
 	. "github.com/onsi/ginkgo/v2"
@@ -85,4 +87,24 @@ var _ = Describe("NetDialer", func() {
 		Entry("with ipv4 ip address", "127.0.0.1"),
 		Entry("with ipv6 ip address", "[::1]"),
 	)
+
+	DescribeTable("should return the reason virt-handler refused the connection and close the response body", func(body, expectedErr string) {
+		respBody := &closeRecorder{Reader: strings.NewReader(body)}
+		err := handlerResponseError(&http.Response{Status: "409 Conflict", Body: respBody})
+		Expect(err).To(MatchError(expectedErr))
+		Expect(respBody.closed).To(BeTrue())
+	},
+		Entry("with a reason", "the serial console is already in use\n", "the serial console is already in use"),
+		Entry("without a reason", "", "virt-handler responded with 409 Conflict"),
+	)
 })
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+
+	restful "github.com/emicklei/go-restful/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	apimetrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-api"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// SpiceRequestHandler streams a single SPICE channel. Clients open one connection per channel.
+func (app *SubresourceAPIApp) SpiceRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.SpiceEnabled() {
+		writeError(errors.NewBadRequest(fmt.Sprintf(featureGateDisabledErrFmt, virtconfig.SpiceGate)), response)
+		return
+	}
+	defer apimetrics.SetVMILastConnectionTimestamp(request.PathParameter("namespace"), request.PathParameter("name"))
+
+	streamer := NewRawStreamer(
+		app.FetchVirtualMachineInstance,
+		validateVMIForSpice,
+		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+			return conn.SpiceURI(vmi)
+		}),
+	)
+
+	streamer.Handle(request, response)
+}
+
+func validateVMIForSpice(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if vmi.Spec.Domain.Devices.Spice == nil {
+		err := fmt.Errorf("No SPICE graphics device is present.")
+		log.Log.Object(vmi).Reason(err).Error("Can't establish SPICE connection.")
+		return errors.NewBadRequest(err.Error())
+	}
+	return validateVMIForVNC(vmi)
+}
@@ -26,6 +26,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -54,6 +56,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/pointer"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-api/definitions"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -1408,6 +1411,12 @@ func (app *SubresourceAPIApp) validateMemoryDumpClaim(vmi *v1.VirtualMachineInst
 }
 
 func (app *SubresourceAPIApp) validateMemoryDumpRequest(vm *v1.VirtualMachine, memoryDumpReq *v1.VirtualMachineMemoryDumpRequest) *errors.StatusError {
+	switch memoryDumpReq.Format {
+	case "", v1.MemoryDumpFormatELF, v1.MemoryDumpFormatKdumpZlib, v1.MemoryDumpFormatKdumpLzo, v1.MemoryDumpFormatKdumpSnappy:
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("Unsupported memory dump format %s", memoryDumpReq.Format))
+	}
+
 	if memoryDumpReq.ClaimName == "" && vm.Status.MemoryDumpRequest == nil {
 		return errors.NewBadRequest("Memory dump requires claim name to be set")
 	} else if vm.Status.MemoryDumpRequest != nil && memoryDumpReq.ClaimName != "" {
@@ -1559,6 +1568,83 @@ func (app *SubresourceAPIApp) SEVQueryLaunchMeasurementHandler(request *restful.
 	app.httpGetRequestHandler(request, response, validateVMIForSEVAttestation, getURL, v1.SEVMeasurementInfo{})
 }
 
+func (app *SubresourceAPIApp) LaunchSecurityReportHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !kutil.IsConfidentialVMI(vmi) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not use launch security"))
+		}
+		if kutil.IsTDXVMI(vmi) {
+			// The host has no access to the measurement of a TD, it is part of the TD quote obtained within the guest
+			return errors.NewBadRequest("the launch security report is not available for TDX guests")
+		}
+		if featureGate, enabled := app.launchSecurityFeatureGate(vmi); !enabled {
+			return errors.NewBadRequest(fmt.Sprintf(featureGateDisabledErrFmt, featureGate))
+		}
+		if !vmi.IsRunning() {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotRunning))
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.LaunchSecurityReportURI(vmi)
+	}
+
+	app.httpGetRequestHandler(request, response, validate, getURL, v1.LaunchSecurityReport{})
+}
+
+func (app *SubresourceAPIApp) ConsoleLogHandler(request *restful.Request, response *restful.Response) {
+	query := url.Values{}
+	if sinceTime := request.QueryParameter(definitions.SinceTimeParamName); sinceTime != "" {
+		if _, err := time.Parse(time.RFC3339, sinceTime); err != nil {
+			writeError(errors.NewBadRequest(fmt.Sprintf("invalid %s parameter: %v", definitions.SinceTimeParamName, err)), response)
+			return
+		}
+		query.Set(definitions.SinceTimeParamName, sinceTime)
+	}
+	if tailLines := request.QueryParameter(definitions.TailLinesParamName); tailLines != "" {
+		if lines, err := strconv.Atoi(tailLines); err != nil || lines <= 0 {
+			writeError(errors.NewBadRequest(fmt.Sprintf("invalid %s parameter %q, it must be a positive integer", definitions.TailLinesParamName, tailLines)), response)
+			return
+		}
+		query.Set(definitions.TailLinesParamName, tailLines)
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Spec.Domain.Devices.SerialConsoleLogPersistence == nil {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not persist its serial console log"))
+		}
+		if !app.clusterConfig.PersistentSerialConsoleLogEnabled() {
+			return errors.NewBadRequest(fmt.Sprintf(featureGateDisabledErrFmt, virtconfig.PersistentSerialConsoleLogGate))
+		}
+		if !vmi.IsRunning() {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotRunning))
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		uri, err := conn.ConsoleLogURI(vmi)
+		if err != nil || len(query) == 0 {
+			return uri, err
+		}
+		return uri + "?" + query.Encode(), nil
+	}
+
+	app.httpGetRequestHandler(request, response, validate, getURL, v1.SerialConsoleLog{})
+}
+
+func (app *SubresourceAPIApp) launchSecurityFeatureGate(vmi *v1.VirtualMachineInstance) (string, bool) {
+	switch {
+	case kutil.IsSEVSNPVMI(vmi):
+		return virtconfig.WorkloadEncryptionSEVSNP, app.clusterConfig.WorkloadEncryptionSEVSNPEnabled()
+	case kutil.IsTDXVMI(vmi):
+		return virtconfig.WorkloadEncryptionTDX, app.clusterConfig.WorkloadEncryptionTDXEnabled()
+	default:
+		return virtconfig.WorkloadEncryptionSEV, app.clusterConfig.WorkloadEncryptionSEVEnabled()
+	}
+}
+
 func (app *SubresourceAPIApp) SEVSetupSessionHandler(request *restful.Request, response *restful.Response) {
 	if !app.ensureSEVEnabled(response) {
 		return
@@ -29,6 +29,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -154,7 +155,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Timeout: 10 * time.Second,
 		}
 
-		request = restful.NewRequest(&http.Request{})
+		request = restful.NewRequest(&http.Request{URL: &url.URL{}})
 		recorder = httptest.NewRecorder()
 		response = restful.NewResponse(recorder)
 		// Make sure that any unexpected call to the client will fail
@@ -358,6 +359,39 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			)
 		})
 
+		Context("SPICE", func() {
+			It("should fail if the Spice feature gate is disabled", func() {
+				request.PathParameters()["name"] = testVMIName
+				request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+
+				app.SpiceRequestHandler(request, response)
+
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				ExpectMessage(recorder, fmt.Sprintf(featureGateDisabledErrFmt, virtconfig.SpiceGate))
+			})
+
+			DescribeTable("request validation", func(spice *v1.Spice, phase v1.VirtualMachineInstancePhase, expectedMessage string) {
+				enableFeatureGate(virtconfig.SpiceGate)
+				request.PathParameters()["name"] = testVMIName
+				request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+
+				vmi := api.NewMinimalVMI(testVMIName)
+				vmi.Status.Phase = phase
+				vmi.ObjectMeta.SetUID(uuid.NewUUID())
+				vmi.Spec.Domain.Devices.Spice = spice
+
+				vmiClient.EXPECT().Get(context.Background(), testVMIName, k8smetav1.GetOptions{}).Return(vmi, nil)
+
+				app.SpiceRequestHandler(request, response)
+
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				ExpectMessage(recorder, expectedMessage)
+			},
+				Entry("should fail if there is no SPICE graphics device", nil, v1.Running, "No SPICE graphics device is present."),
+				Entry("should fail if vmi is not running", &v1.Spice{}, v1.Scheduling, vmiNotRunning),
+			)
+		})
+
 		Context("PortForward", func() {
 			It("should fail with no 'name' path param", func() {
 
@@ -431,6 +465,48 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 				ExpectStatusErrorWithCode(recorder, http.StatusConflict)
 			})
 
+			DescribeTable("should reject invalid session parameters", func(query string) {
+				request = restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: query}})
+				request.PathParameters()["name"] = testVMIName
+				request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+
+				app.ConsoleRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			},
+				Entry("with an invalid readOnly value", "readOnly=maybe"),
+				Entry("with an invalid takeOver value", "takeOver=maybe"),
+				Entry("with readOnly and takeOver", "readOnly=true&takeOver=true"),
+			)
+
+			It("should pass the session parameters to virt-handler and forward its conflict", func() {
+				request = restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: "takeOver=false&readOnly=false"}})
+				backend.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/console", ""),
+						ghttp.RespondWith(http.StatusConflict, "the serial console is already in use"),
+					),
+				)
+				expectVMI(Running, UnPaused)
+
+				app.ConsoleRequestHandler(request, response)
+				statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+				Expect(statusErr.Error()).To(ContainSubstring("the serial console is already in use"))
+			})
+
+			It("should ask virt-handler for a read-only session", func() {
+				request = restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: "readOnly=true"}})
+				backend.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/console", "readOnly=true"),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+				expectVMI(Running, UnPaused)
+
+				app.ConsoleRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+				Expect(backend.ReceivedRequests()).To(HaveLen(1))
+			})
 		})
 
 		Context("restart", func() {
@@ -1389,6 +1465,14 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Entry("VM with a memory dump request pvc size too small should fail", &v1.VirtualMachineMemoryDumpRequest{
 				ClaimName: testPVCName,
 			}, http.StatusConflict, true, true, createTestPVC("1Gi", fs, notReadOnly)),
+			Entry("VM with a memory dump request in kdump format should succeed", &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: testPVCName,
+				Format:    v1.MemoryDumpFormatKdumpZlib,
+			}, http.StatusAccepted, true, true, createTestPVC("2Gi", fs, notReadOnly)),
+			Entry("VM with a memory dump request in an unsupported format should fail", &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: testPVCName,
+				Format:    "kdump-gzip",
+			}, http.StatusBadRequest, true, true, createTestPVC("2Gi", fs, notReadOnly)),
 		)
 
 		DescribeTable("With memory dump request", func(memDumpReq, prevMemDumpReq *v1.VirtualMachineMemoryDumpRequest, statusCode int) {
@@ -2404,6 +2488,73 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Subresource api - serial console log", func() {
+		withSerialConsoleLogPersistence := func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{}
+		}
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.PersistentSerialConsoleLogGate)
+		})
+
+		It("Should forward the sinceTime parameter to virt-handler", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/consolelog", "sinceTime=2024-01-02T03%3A04%3A05Z"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.SerialConsoleLog{Lines: []string{"login:"}}),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+			request.Request.URL.RawQuery = "sinceTime=2024-01-02T03:04:05Z"
+
+			expectVMI(Running, UnPaused, withSerialConsoleLogPersistence)
+			app.ConsoleLogHandler(request, response)
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should reject an invalid sinceTime parameter", func() {
+			request.Request.URL.RawQuery = "sinceTime=yesterday"
+			app.ConsoleLogHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		It("Should forward the tailLines parameter to virt-handler", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/consolelog", "tailLines=100"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.SerialConsoleLog{Lines: []string{"login:"}}),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+			request.Request.URL.RawQuery = "tailLines=100"
+
+			expectVMI(Running, UnPaused, withSerialConsoleLogPersistence)
+			app.ConsoleLogHandler(request, response)
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		DescribeTable("Should reject an invalid tailLines parameter", func(tailLines string) {
+			request.Request.URL.RawQuery = "tailLines=" + tailLines
+			app.ConsoleLogHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		},
+			Entry("not a number", "all"),
+			Entry("zero", "0"),
+			Entry("negative", "-1"),
+		)
+
+		DescribeTable("Should fail to get the serial console log", func(running bool, vmiWarpFunctions ...func(vmi *v1.VirtualMachineInstance)) {
+			expectVMI(running, UnPaused, vmiWarpFunctions...)
+			app.ConsoleLogHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+		},
+			Entry("when VMI is not running", NotRunning, withSerialConsoleLogPersistence),
+			Entry("when VMI does not persist its serial console log", Running),
+		)
+	})
+
 	Context("Subresource api - AMD SEV attestation", func() {
 		withSEVAttestation := func(vmi *v1.VirtualMachineInstance) {
 			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
@@ -2477,6 +2628,47 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Entry("when attestation is not requested ", Running, Paused),
 		)
 
+		It("Should allow to get the launch security report of a running SEV-SNP VMI", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEVSNP)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/launchsecurity/report"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.LaunchSecurityReport{Type: v1.LaunchSecurityTypeSEVSNP}),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+
+			expectVMI(Running, UnPaused, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}
+			})
+			app.LaunchSecurityReportHandler(request, response)
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		DescribeTable("Should fail to get the launch security report", func(running bool, vmiWarpFunctions ...func(vmi *v1.VirtualMachineInstance)) {
+			expectVMI(running, UnPaused, vmiWarpFunctions...)
+			app.LaunchSecurityReportHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+		},
+			Entry("when VMI is not running", NotRunning, withSEVAttestation),
+			Entry("when VMI does not use launch security", Running),
+			Entry("when the TDX feature gate is disabled", Running, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			}),
+		)
+
+		It("Should refuse to get the launch security report of a TDX VMI", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionTDX)
+			expectVMI(Running, UnPaused, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			})
+			app.LaunchSecurityReportHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(ContainSubstring("not available for TDX guests"))
+		})
+
 		It("Should allow to setup SEV session parameters for a paused VMI", func() {
 			sevSessionOptions := &v1.SEVSessionOptions{
 				Session: "AAABBB",
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -35,6 +36,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/defaults"
+	"kubevirt.io/kubevirt/pkg/network/istio"
 	kvpointer "kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/util"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
@@ -45,6 +47,7 @@ import (
 type VMIsMutator struct {
 	ClusterConfig           *virtconfig.ClusterConfig
 	VMIPresetInformer       cache.SharedIndexInformer
+	NamespaceInformer       cache.SharedIndexInformer
 	KubeVirtServiceAccounts map[string]struct{}
 }
 
@@ -80,6 +83,8 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			}
 		}
 
+		mutator.applyNamespaceDataplaneMode(newVMI, ar.Request.Namespace)
+
 		// Set VirtualMachineInstance defaults
 		log.Log.Object(newVMI).V(4).Info("Apply defaults")
 		if err = defaults.SetDefaultVirtualMachineInstance(mutator.ClusterConfig, newVMI); err != nil {
@@ -158,3 +163,18 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 
 	return response
 }
+
+// applyNamespaceDataplaneMode labels the VMI with the ambient mesh data plane mode of its namespace,
+// so that the masquerade binding, which only sees the VMI, cooperates with the mesh.
+func (mutator *VMIsMutator) applyNamespaceDataplaneMode(vmi *v1.VirtualMachineInstance, namespace string) {
+	if mutator.NamespaceInformer == nil {
+		return
+	}
+	obj, exists, err := mutator.NamespaceInformer.GetStore().GetByKey(namespace)
+	if err != nil || !exists {
+		return
+	}
+	if ns, ok := obj.(*k8sv1.Namespace); ok {
+		istio.InheritDataplaneMode(vmi, ns.Labels)
+	}
+}
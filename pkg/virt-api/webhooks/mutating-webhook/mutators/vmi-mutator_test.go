@@ -42,6 +42,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -192,6 +193,28 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		})
 	})
 
+	Context("with an ambient mesh namespace", func() {
+		BeforeEach(func() {
+			vmi.Namespace = "mesh"
+			namespaceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+			Expect(namespaceInformer.GetStore().Add(&k8sv1.Namespace{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "mesh", Labels: map[string]string{istio.DataplaneModeLabel: "ambient"}},
+			})).To(Succeed())
+			mutator.NamespaceInformer = namespaceInformer
+		})
+
+		It("should label the VMI with the ambient data plane mode of the namespace", func() {
+			vmiMeta, _, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+			Expect(vmiMeta.Labels).To(HaveKeyWithValue(istio.DataplaneModeLabel, "ambient"))
+		})
+
+		It("should keep the data plane mode chosen by the VMI", func() {
+			vmi.Labels[istio.DataplaneModeLabel] = "none"
+			vmiMeta, _, _ := getMetaSpecStatusFromAdmit(rt.GOARCH)
+			Expect(vmiMeta.Labels).To(HaveKeyWithValue(istio.DataplaneModeLabel, "none"))
+		})
+	})
+
 	DescribeTable("should apply defaults on VMI create", func(arch string, cpuModel string) {
 		// no limits wanted on this test, to not copy the limit to requests
 
@@ -47,6 +47,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -125,6 +126,7 @@ func (admitter *VMICreateAdmitter) Admit(_ context.Context, ar *admissionv1.Admi
 	causes = append(causes, validateVirtualMachineInstanceSpecVolumeDisks(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMandatoryFields(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
+	causes = append(causes, validatePanicDumpOwner(k8sfield.NewPath("spec"), vmi)...)
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHyperv(k8sfield.NewPath("spec").Child("domain").Child("features").Child("hyperv"), &vmi.Spec)...)
 	if webhooks.IsARM64(&vmi.Spec) {
 		// Check if there is any unsupported setting if the arch is Arm64
@@ -163,6 +165,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateMemoryLimitsNegativeOrNull(field, spec)...)
 	causes = append(causes, validateHugepagesMemoryRequests(field, spec)...)
 	causes = append(causes, validateGuestMemoryLimit(field, spec, config)...)
+	causes = append(causes, validateMemoryReclaim(field, spec)...)
 	causes = append(causes, validateEmulatedMachine(field, spec, config)...)
 	causes = append(causes, validateFirmwareSerial(field, spec)...)
 	causes = append(causes, validateCPURequestNotNegative(field, spec)...)
@@ -208,10 +211,16 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateMDEVRamFB(field, spec)...)
 	causes = append(causes, validateHostDevicesWithPassthroughEnabled(field, spec, config)...)
 	causes = append(causes, validateSoundDevices(field, spec)...)
+	causes = append(causes, validateWatchdog(field, spec, config)...)
+	causes = append(causes, validatePanicDevice(field, spec, config)...)
+	causes = append(causes, validateVideoDevice(field, spec, config)...)
+	causes = append(causes, validateSpice(field, spec, config)...)
+	causes = append(causes, validateSerialConsoleLogPersistence(field, spec, config)...)
 	causes = append(causes, validateLaunchSecurity(field, spec, config)...)
 	causes = append(causes, validateVSOCK(field, spec, config)...)
 	causes = append(causes, validatePersistentReservation(field, spec, config)...)
 	causes = append(causes, validatePersistentState(field, spec, config)...)
+	causes = append(causes, validateTPM(field, spec)...)
 	causes = append(causes, validateDownwardMetrics(field, spec, config)...)
 
 	return causes
@@ -488,9 +497,294 @@ func validateSoundDevices(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 	return causes
 }
 
+func validateWatchdog(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	watchdog := spec.Domain.Devices.Watchdog
+	if watchdog == nil {
+		return causes
+	}
+	watchdogField := field.Child("domain", "devices", "watchdog")
+	arch := spec.Architecture
+	if arch == "" {
+		arch = config.GetDefaultArchitecture()
+	}
+
+	models := 0
+	var action v1.WatchdogAction
+	if watchdog.I6300ESB != nil {
+		models++
+		action = watchdog.I6300ESB.Action
+		if virtconfig.IsS390X(arch) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "i6300esb watchdog is not supported on s390x, use diag288 instead",
+				Field:   watchdogField.Child("i6300esb").String(),
+			})
+		}
+	}
+	if watchdog.Diag288 != nil {
+		models++
+		action = watchdog.Diag288.Action
+		if !virtconfig.IsS390X(arch) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "diag288 watchdog is only supported on s390x",
+				Field:   watchdogField.Child("diag288").String(),
+			})
+		}
+	}
+	if watchdog.ITCO != nil {
+		models++
+		action = watchdog.ITCO.Action
+		if !virtconfig.IsAMD64(arch) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "itco watchdog is only supported on amd64",
+				Field:   watchdogField.Child("itco").String(),
+			})
+		}
+	}
+	if models > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "only one watchdog type can be set",
+			Field:   watchdogField.String(),
+		})
+	}
+
+	switch action {
+	case "", v1.WatchdogActionPoweroff, v1.WatchdogActionReset, v1.WatchdogActionShutdown:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("watchdog action %s is not supported. Options: 'poweroff', 'reset' or 'shutdown'", action),
+			Field:   watchdogField.String(),
+		})
+	}
+	return causes
+}
+
+// validatePanicDumpOwner rejects the dump panic action on VMIs which are not controlled by a
+// VirtualMachine, since the dump is handled as a memory dump request of the owning VirtualMachine.
+func validatePanicDumpOwner(field *k8sfield.Path, vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+	panicDevice := vmi.Spec.Domain.Devices.Panic
+	if panicDevice == nil || panicDevice.Action != v1.PanicActionDump {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(vmi); owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: "the dump panic action is only supported on VMIs owned by a VirtualMachine",
+		Field:   field.Child("domain", "devices", "panic", "action").String(),
+	}}
+}
+
+func validatePanicDevice(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	panicDevice := spec.Domain.Devices.Panic
+	if panicDevice == nil {
+		return causes
+	}
+	panicField := field.Child("domain", "devices", "panic")
+	arch := spec.Architecture
+	if arch == "" {
+		arch = config.GetDefaultArchitecture()
+	}
+
+	if panicDevice.Model != nil {
+		switch *panicDevice.Model {
+		case v1.PanicDeviceModelPVPanic:
+		case v1.PanicDeviceModelISA:
+			if !virtconfig.IsAMD64(arch) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "isa panic device is only supported on amd64",
+					Field:   panicField.Child("model").String(),
+				})
+			}
+		case v1.PanicDeviceModelS390:
+			if !virtconfig.IsS390X(arch) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: "s390 panic device is only supported on s390x",
+					Field:   panicField.Child("model").String(),
+				})
+			}
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("panic device model %s is not supported. Options: 'isa', 'pvpanic' or 's390'", *panicDevice.Model),
+				Field:   panicField.Child("model").String(),
+			})
+		}
+	}
+
+	switch panicDevice.Action {
+	case "", v1.PanicActionRestart, v1.PanicActionPause:
+		if panicDevice.DumpClaimName != "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "dumpClaimName can only be set with the dump panic action",
+				Field:   panicField.Child("dumpClaimName").String(),
+			})
+		}
+	case v1.PanicActionDump:
+		if panicDevice.DumpClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "dumpClaimName is required with the dump panic action",
+				Field:   panicField.Child("dumpClaimName").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("panic action %s is not supported. Options: 'restart', 'pause' or 'dump'", panicDevice.Action),
+			Field:   panicField.Child("action").String(),
+		})
+	}
+	return causes
+}
+
+const maxVideoHeads = 16
+
+func validateVideoDevice(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	video := spec.Domain.Devices.Video
+	if video == nil {
+		return causes
+	}
+	videoField := field.Child("domain", "devices", "video")
+
+	if !config.VirtIOGPUEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VirtIOGPUGate),
+			Field:   videoField.String(),
+		})
+	}
+
+	if spec.Domain.Devices.AutoattachGraphicsDevice != nil && !*spec.Domain.Devices.AutoattachGraphicsDevice {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "a video device can not be configured when autoattachGraphicsDevice is disabled",
+			Field:   videoField.String(),
+		})
+	}
+
+	if video.Type != v1.VideoTypeVirtIO {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("video device type %s is not supported. Options: 'virtio'", video.Type),
+			Field:   videoField.Child("type").String(),
+		})
+	}
+
+	if video.Heads != nil && (*video.Heads < 1 || *video.Heads > maxVideoHeads) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("video heads must be between 1 and %d", maxVideoHeads),
+			Field:   videoField.Child("heads").String(),
+		})
+	}
+
+	if video.Resolution != nil && (video.Resolution.Width == 0 || video.Resolution.Height == 0) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "video resolution width and height must be greater than 0",
+			Field:   videoField.Child("resolution").String(),
+		})
+	}
+	return causes
+}
+
+func validateSpice(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	spice := spec.Domain.Devices.Spice
+	if spice == nil {
+		return causes
+	}
+	spiceField := field.Child("domain", "devices", "spice")
+
+	if !config.SpiceEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.SpiceGate),
+			Field:   spiceField.String(),
+		})
+	}
+
+	if spec.Domain.Devices.AutoattachGraphicsDevice != nil && !*spec.Domain.Devices.AutoattachGraphicsDevice {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "SPICE can not be configured when autoattachGraphicsDevice is disabled",
+			Field:   spiceField.String(),
+		})
+	}
+
+	switch spice.StreamingMode {
+	case "", v1.SpiceStreamingModeFilter, v1.SpiceStreamingModeAll, v1.SpiceStreamingModeOff:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("SPICE streaming mode %s is not supported. Options: 'filter', 'all' or 'off'", spice.StreamingMode),
+			Field:   spiceField.Child("streamingMode").String(),
+		})
+	}
+	return causes
+}
+
+func validateSerialConsoleLogPersistence(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	persistence := spec.Domain.Devices.SerialConsoleLogPersistence
+	if persistence == nil {
+		return causes
+	}
+	persistenceField := field.Child("domain", "devices", "serialConsoleLogPersistence")
+
+	if !config.PersistentSerialConsoleLogEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.PersistentSerialConsoleLogGate),
+			Field:   persistenceField.String(),
+		})
+	}
+
+	if (spec.Domain.Devices.AutoattachSerialConsole != nil && !*spec.Domain.Devices.AutoattachSerialConsole) ||
+		(spec.Domain.Devices.LogSerialConsole != nil && !*spec.Domain.Devices.LogSerialConsole) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "the serial console log can not be persisted when the serial console or its log is disabled",
+			Field:   persistenceField.String(),
+		})
+	}
+
+	if persistence.MaxFileSize != nil && (persistence.MaxFileSize.Value() <= 0 || persistence.MaxFileSize.Value() > consolelog.MaxFileSizeLimit) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("the maximum size of a serial console log file must be greater than 0 and at most %s", resource.NewQuantity(consolelog.MaxFileSizeLimit, resource.BinarySI)),
+			Field:   persistenceField.Child("maxFileSize").String(),
+		})
+	}
+
+	if persistence.MaxFiles != nil && (*persistence.MaxFiles == 0 || *persistence.MaxFiles > consolelog.MaxRotatedFiles) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("the number of rotated serial console log files must be between 1 and %d", consolelog.MaxRotatedFiles),
+			Field:   persistenceField.Child("maxFiles").String(),
+		})
+	}
+	return causes
+}
+
 func validateLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	launchSecurity := spec.Domain.LaunchSecurity
+	if launchSecurity != nil && (launchSecurity.SNP != nil || launchSecurity.TDX != nil) {
+		return validateConfidentialLaunchSecurity(field, spec, config)
+	}
 	if launchSecurity != nil && !config.WorkloadEncryptionSEVEnabled() {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
@@ -535,6 +829,63 @@ func validateLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanc
 	return causes
 }
 
+func validateConfidentialLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	launchSecurity := spec.Domain.LaunchSecurity
+	launchSecurityField := field.Child("domain", "launchSecurity").String()
+	configured := 0
+	for _, set := range []bool{launchSecurity.SEV != nil, launchSecurity.SNP != nil, launchSecurity.TDX != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "only one of sev, snp and tdx can be set",
+			Field:   launchSecurityField,
+		}}
+	}
+
+	name, featureGate, enabled := "SEV-SNP", virtconfig.WorkloadEncryptionSEVSNP, config.WorkloadEncryptionSEVSNPEnabled()
+	if launchSecurity.TDX != nil {
+		name, featureGate, enabled = "TDX", virtconfig.WorkloadEncryptionTDX, config.WorkloadEncryptionTDXEnabled()
+	}
+	if !enabled {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", featureGate),
+			Field:   launchSecurityField,
+		}}
+	}
+
+	var causes []metav1.StatusCause
+	firmware := spec.Domain.Firmware
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires OVMF (UEFI)", name),
+			Field:   launchSecurityField,
+		})
+	} else {
+		efi := firmware.Bootloader.EFI
+		if efi.SecureBoot == nil || *efi.SecureBoot {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s does not work along with SecureBoot", name),
+				Field:   launchSecurityField,
+			})
+		}
+		if efi.Persistent != nil && *efi.Persistent {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s boots a stateless firmware and does not support a persistent EFI", name),
+				Field:   launchSecurityField,
+			})
+		}
+	}
+	return causes
+}
+
 func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	// used to validate uniqueness of boot orders among disks and interfaces
@@ -1058,6 +1409,33 @@ func validateMemoryRequestsNegativeOrNull(field *k8sfield.Path, spec *v1.Virtual
 	return causes
 }
 
+func validateMemoryReclaim(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Reclaim == nil {
+		return causes
+	}
+
+	if spec.Domain.Devices.AutoattachMemBalloon != nil && !*spec.Domain.Devices.AutoattachMemBalloon {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires the memory balloon, %s must not be false",
+				field.Child("domain", "memory", "reclaim").String(),
+				field.Child("domain", "devices", "autoattachMemBalloon").String()),
+			Field: field.Child("domain", "memory", "reclaim").String(),
+		})
+	}
+
+	if floor := spec.Domain.Memory.Reclaim.Floor; floor != nil && floor.Value() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s': must be greater than 0.", field.Child("domain", "memory", "reclaim", "floor").String(),
+				floor),
+			Field: field.Child("domain", "memory", "reclaim", "floor").String(),
+		})
+	}
+	return causes
+}
+
 func validateSubdomainDNSSubdomainRules(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if spec.Subdomain == "" {
@@ -1384,6 +1762,33 @@ func validateBootloader(field *k8sfield.Path, bootloader *v1.Bootloader) []metav
 		})
 	}
 
+	if bootloader != nil && bootloader.EFI != nil && bootloader.EFI.SecureBootKeys != nil {
+		causes = append(causes, validateSecureBootKeys(field.Child("efi"), bootloader.EFI)...)
+	}
+
+	return causes
+}
+
+func validateSecureBootKeys(field *k8sfield.Path, efi *v1.EFI) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if efi.SecureBoot != nil && !*efi.SecureBoot {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires %s to be enabled", field.Child("secureBootKeys").String(), field.Child("secureBoot").String()),
+			Field:   field.Child("secureBootKeys").String(),
+		})
+	}
+
+	keys := efi.SecureBootKeys
+	if (keys.Secret == nil) == (keys.ConfigMap == nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must reference exactly one Secret or ConfigMap", field.Child("secureBootKeys").String()),
+			Field:   field.Child("secureBootKeys").String(),
+		})
+	}
+
 	return causes
 }
 
@@ -2310,6 +2715,42 @@ func validatePersistentState(field *k8sfield.Path, spec *v1.VirtualMachineInstan
 	return causes
 }
 
+func validateTPM(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	tpm := spec.Domain.Devices.TPM
+	if tpm == nil {
+		return causes
+	}
+
+	tpmField := field.Child("domain", "devices", "tpm")
+	if tpm.Encryption != nil {
+		if !backendstorage.HasPersistentTPMDevice(spec) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires %s to be true", tpmField.Child("encryption").String(), tpmField.Child("persistent").String()),
+				Field:   tpmField.Child("encryption").String(),
+			})
+		}
+		if tpm.Encryption.SecretName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must not be empty", tpmField.Child("encryption", "secretName").String()),
+				Field:   tpmField.Child("encryption", "secretName").String(),
+			})
+		}
+	}
+
+	if tpm.EKCertificateAuthority != nil && tpm.EKCertificateAuthority.SecretName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must not be empty", tpmField.Child("ekCertificateAuthority", "secretName").String()),
+			Field:   tpmField.Child("ekCertificateAuthority", "secretName").String(),
+		})
+	}
+
+	return causes
+}
+
 func validateCPUHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if spec.Domain.CPU != nil && spec.Domain.CPU.MaxSockets != 0 {
@@ -79,9 +79,9 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 	vmiCreateAdmitter := &VMICreateAdmitter{ClusterConfig: config}
 
 	dnsConfigTestOption := "test"
-	enableFeatureGate := func(featureGate string) {
+	enableFeatureGate := func(featureGates ...string) {
 		kvConfig := kv.DeepCopy()
-		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{featureGate}
+		kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = featureGates
 		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
 	}
 	disableFeatureGates := func() {
@@ -140,6 +140,42 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		Expect(resp.Result.Message).To(ContainSubstring("no memory requested"))
 	})
 
+	DescribeTable("should validate the owner of VMIs with the dump panic action", func(ownerReferences []metav1.OwnerReference, allowed bool) {
+		vmi := newBaseVmi()
+		vmi.OwnerReferences = ownerReferences
+		vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{Action: v1.PanicActionDump, DumpClaimName: "dump"}
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+		resp := vmiCreateAdmitter.Admit(context.Background(), ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+		if !allowed {
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.domain.devices.panic.action"))
+		}
+	},
+		Entry("accept a VMI controlled by a VirtualMachine", []metav1.OwnerReference{{
+			APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+			Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+			Name:       "testvm",
+			Controller: pointer.P(true),
+		}}, true),
+		Entry("reject a VMI without owner", nil, false),
+		Entry("reject a VMI which is not controlled by a VirtualMachine", []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+			Name:       "testrs",
+			Controller: pointer.P(true),
+		}}, false),
+	)
+
 	It("should allow Clock without Timer", func() {
 		vmi := api.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Clock = &v1.Clock{
@@ -591,6 +627,55 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.Sound"))
 		})
+		DescribeTable("should validate the watchdog model against the architecture", func(arch string, device v1.WatchdogDevice, expectedField string) {
+			enableFeatureGate(virtconfig.MultiArchitecture)
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Architecture = arch
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{Name: "watchdog", WatchdogDevice: device}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accept i6300esb on amd64", "amd64", v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{}}, ""),
+			Entry("accept itco on amd64", "amd64", v1.WatchdogDevice{ITCO: &v1.ITCOWatchdog{}}, ""),
+			Entry("accept diag288 on s390x", "s390x", v1.WatchdogDevice{Diag288: &v1.Diag288Watchdog{}}, ""),
+			Entry("reject i6300esb on s390x", "s390x", v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{}}, "fake.domain.devices.watchdog.i6300esb"),
+			Entry("reject itco on s390x", "s390x", v1.WatchdogDevice{ITCO: &v1.ITCOWatchdog{}}, "fake.domain.devices.watchdog.itco"),
+			Entry("reject diag288 on amd64", "amd64", v1.WatchdogDevice{Diag288: &v1.Diag288Watchdog{}}, "fake.domain.devices.watchdog.diag288"),
+			Entry("reject multiple models", "amd64", v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{}, ITCO: &v1.ITCOWatchdog{}}, "fake.domain.devices.watchdog"),
+			Entry("reject an unknown action", "amd64", v1.WatchdogDevice{ITCO: &v1.ITCOWatchdog{Action: "explode"}}, "fake.domain.devices.watchdog"),
+		)
+		DescribeTable("should validate the panic device", func(arch string, panicDevice *v1.PanicDevice, expectedField string) {
+			enableFeatureGate(virtconfig.MultiArchitecture)
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Architecture = arch
+			vmi.Spec.Domain.Devices.Panic = panicDevice
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accept the default model", "amd64", &v1.PanicDevice{}, ""),
+			Entry("accept isa on amd64", "amd64", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModelISA)}, ""),
+			Entry("accept pvpanic on amd64", "amd64", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModelPVPanic)}, ""),
+			Entry("accept s390 on s390x", "s390x", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModelS390)}, ""),
+			Entry("reject isa on s390x", "s390x", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModelISA)}, "fake.domain.devices.panic.model"),
+			Entry("reject s390 on amd64", "amd64", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModelS390)}, "fake.domain.devices.panic.model"),
+			Entry("reject an unknown model", "amd64", &v1.PanicDevice{Model: pointer.P(v1.PanicDeviceModel("hyperv"))}, "fake.domain.devices.panic.model"),
+			Entry("accept the dump action with a claim", "amd64", &v1.PanicDevice{Action: v1.PanicActionDump, DumpClaimName: "dump"}, ""),
+			Entry("reject the dump action without a claim", "amd64", &v1.PanicDevice{Action: v1.PanicActionDump}, "fake.domain.devices.panic.dumpClaimName"),
+			Entry("reject a claim without the dump action", "amd64", &v1.PanicDevice{Action: v1.PanicActionRestart, DumpClaimName: "dump"}, "fake.domain.devices.panic.dumpClaimName"),
+			Entry("reject an unknown action", "amd64", &v1.PanicDevice{Action: "explode"}, "fake.domain.devices.panic.action"),
+		)
 		It("should reject volume with missing disk / file system", func() {
 			vmi := api.NewMinimalVMI("testvmi")
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
@@ -771,6 +856,59 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.resources.requests.memory"))
 		})
+		DescribeTable("should validate memory reclaim", func(autoattachMemBalloon *bool, floor string, expectedField string) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.AutoattachMemBalloon = autoattachMemBalloon
+			vm.Spec.Domain.Memory = &v1.Memory{Reclaim: &v1.MemoryReclaim{}}
+			if floor != "" {
+				floorQuantity := resource.MustParse(floor)
+				vm.Spec.Domain.Memory.Reclaim.Floor = &floorQuantity
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accept the default floor", nil, "", ""),
+			Entry("accept a positive floor", pointer.P(true), "512Mi", ""),
+			Entry("reject a zero floor", nil, "0", "fake.domain.memory.reclaim.floor"),
+			Entry("reject a disabled memory balloon", pointer.P(false), "", "fake.domain.memory.reclaim"),
+		)
+		DescribeTable("should validate SecureBoot keys", func(secureBoot *bool, keys *v1.SecureBootKeysSource, expectedField string) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Features = &v1.Features{SMM: &v1.FeatureState{Enabled: pointer.P(true)}}
+			vm.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{SecureBoot: secureBoot, SecureBootKeys: keys},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accept keys from a Secret", nil,
+				&v1.SecureBootKeysSource{Secret: &k8sv1.LocalObjectReference{Name: "keys"}}, ""),
+			Entry("accept keys from a ConfigMap", pointer.P(true),
+				&v1.SecureBootKeysSource{ConfigMap: &k8sv1.LocalObjectReference{Name: "keys"}}, ""),
+			Entry("reject keys when SecureBoot is disabled", pointer.P(false),
+				&v1.SecureBootKeysSource{Secret: &k8sv1.LocalObjectReference{Name: "keys"}}, "fake.domain.firmware.bootloader.efi.secureBootKeys"),
+			Entry("reject keys without a source", nil,
+				&v1.SecureBootKeysSource{}, "fake.domain.firmware.bootloader.efi.secureBootKeys"),
+			Entry("reject keys with two sources", nil,
+				&v1.SecureBootKeysSource{
+					Secret:    &k8sv1.LocalObjectReference{Name: "keys"},
+					ConfigMap: &k8sv1.LocalObjectReference{Name: "keys"},
+				}, "fake.domain.firmware.bootloader.efi.secureBootKeys"),
+		)
 		It("should reject negative limits.memory size value", func() {
 			vm := api.NewMinimalVMI("testvm")
 
@@ -3096,6 +3234,177 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with a video device", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Video = &v1.VideoDevice{
+				Type:       v1.VideoTypeVirtIO,
+				Heads:      pointer.P(uint32(2)),
+				Resolution: &v1.VideoResolution{Width: 1920, Height: 1080},
+				Accel3D:    pointer.P(true),
+			}
+			enableFeatureGate(virtconfig.VirtIOGPUGate)
+		})
+
+		It("should accept a virtio video device", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		DescribeTable("should reject", func(updateVMI func(*v1.VirtualMachineInstance), expectedField, expectedMessage string) {
+			updateVMI(vmi)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+			Expect(causes[0].Message).To(ContainSubstring(expectedMessage))
+		},
+			Entry("when the feature gate is disabled", func(*v1.VirtualMachineInstance) { disableFeatureGates() },
+				"fake.domain.devices.video", fmt.Sprintf("%s feature gate is not enabled", virtconfig.VirtIOGPUGate)),
+			Entry("when the graphics device is not attached", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = pointer.P(false)
+			}, "fake.domain.devices.video", "autoattachGraphicsDevice is disabled"),
+			Entry("an unsupported type", func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Devices.Video.Type = "qxl" },
+				"fake.domain.devices.video.type", "video device type qxl is not supported"),
+			Entry("too many heads", func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Devices.Video.Heads = pointer.P(uint32(17)) },
+				"fake.domain.devices.video.heads", "between 1 and 16"),
+			Entry("an empty resolution", func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Devices.Video.Resolution.Height = 0 },
+				"fake.domain.devices.video.resolution", "greater than 0"),
+		)
+	})
+
+	Context("with SPICE", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Spice = &v1.Spice{StreamingMode: v1.SpiceStreamingModeAll}
+			enableFeatureGate(virtconfig.SpiceGate)
+		})
+
+		It("should accept a SPICE graphics device", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		DescribeTable("should reject", func(updateVMI func(*v1.VirtualMachineInstance), expectedField, expectedMessage string) {
+			updateVMI(vmi)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+			Expect(causes[0].Message).To(ContainSubstring(expectedMessage))
+		},
+			Entry("when the feature gate is disabled", func(*v1.VirtualMachineInstance) { disableFeatureGates() },
+				"fake.domain.devices.spice", fmt.Sprintf("%s feature gate is not enabled", virtconfig.SpiceGate)),
+			Entry("when the graphics device is not attached", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = pointer.P(false)
+			}, "fake.domain.devices.spice", "autoattachGraphicsDevice is disabled"),
+			Entry("an unsupported streaming mode", func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Devices.Spice.StreamingMode = "sometimes" },
+				"fake.domain.devices.spice.streamingMode", "SPICE streaming mode sometimes is not supported"),
+		)
+	})
+
+	Context("with a persisted serial console log", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{
+				ClaimName:   "console-log",
+				MaxFileSize: pointer.P(resource.MustParse("10Mi")),
+				MaxFiles:    pointer.P(uint32(5)),
+			}
+			enableFeatureGate(virtconfig.PersistentSerialConsoleLogGate)
+		})
+
+		It("should accept persisting the serial console log", func() {
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		DescribeTable("should reject", func(updateVMI func(*v1.VirtualMachineInstance), expectedField, expectedMessage string) {
+			updateVMI(vmi)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+			Expect(causes[0].Message).To(ContainSubstring(expectedMessage))
+		},
+			Entry("when the feature gate is disabled", func(*v1.VirtualMachineInstance) { disableFeatureGates() },
+				"fake.domain.devices.serialConsoleLogPersistence", fmt.Sprintf("%s feature gate is not enabled", virtconfig.PersistentSerialConsoleLogGate)),
+			Entry("when the serial console log is disabled", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.LogSerialConsole = pointer.P(false)
+			}, "fake.domain.devices.serialConsoleLogPersistence", "serial console or its log is disabled"),
+			Entry("an empty maximum file size", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence.MaxFileSize = pointer.P(resource.MustParse("0"))
+			}, "fake.domain.devices.serialConsoleLogPersistence.maxFileSize", "greater than 0"),
+			Entry("a too large maximum file size", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence.MaxFileSize = pointer.P(resource.MustParse("11Mi"))
+			}, "fake.domain.devices.serialConsoleLogPersistence.maxFileSize", "at most 10Mi"),
+			Entry("no rotated files", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence.MaxFiles = pointer.P(uint32(0))
+			}, "fake.domain.devices.serialConsoleLogPersistence.maxFiles", "between 1 and 100"),
+			Entry("too many rotated files", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence.MaxFiles = pointer.P(uint32(101))
+			}, "fake.domain.devices.serialConsoleLogPersistence.maxFiles", "between 1 and 100"),
+		)
+	})
+
+	Context("with SEV-SNP and TDX LaunchSecurity", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBoot: pointer.P(false),
+					},
+				},
+			}
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEVSNP, virtconfig.WorkloadEncryptionTDX)
+		})
+
+		DescribeTable("should accept when the feature gate is enabled and OVMF is configured", func(launchSecurity *v1.LaunchSecurity) {
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		},
+			Entry("with SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}),
+			Entry("with SEV-SNP and SMT disallowed", &v1.LaunchSecurity{SNP: &v1.SEVSNP{Policy: &v1.SEVSNPPolicy{SMT: pointer.P(false)}}}),
+			Entry("with TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}),
+		)
+
+		DescribeTable("should reject", func(launchSecurity *v1.LaunchSecurity, updateVMI func(*v1.VirtualMachineInstance), expectedMessage string) {
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			updateVMI(vmi)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(ContainElement(And(
+				HaveField("Field", "fake.domain.launchSecurity"),
+				HaveField("Message", ContainSubstring(expectedMessage)),
+			)))
+		},
+			Entry("SEV-SNP when the feature gate is disabled", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}},
+				func(*v1.VirtualMachineInstance) { enableFeatureGate(virtconfig.WorkloadEncryptionTDX) },
+				fmt.Sprintf("%s feature gate is not enabled", virtconfig.WorkloadEncryptionSEVSNP)),
+			Entry("TDX when the feature gate is disabled", &v1.LaunchSecurity{TDX: &v1.TDX{}},
+				func(*v1.VirtualMachineInstance) { enableFeatureGate(virtconfig.WorkloadEncryptionSEVSNP) },
+				fmt.Sprintf("%s feature gate is not enabled", virtconfig.WorkloadEncryptionTDX)),
+			Entry("more than one launch security type", &v1.LaunchSecurity{SEV: &v1.SEV{}, TDX: &v1.TDX{}},
+				func(*v1.VirtualMachineInstance) {}, "only one of sev, snp and tdx"),
+			Entry("SEV-SNP without UEFI", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}},
+				func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Firmware = nil }, "SEV-SNP requires OVMF"),
+			Entry("TDX with SecureBoot", &v1.LaunchSecurity{TDX: &v1.TDX{}},
+				func(vmi *v1.VirtualMachineInstance) { vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot = nil },
+				"TDX does not work along with SecureBoot"),
+			Entry("SEV-SNP with a persistent EFI", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}},
+				func(vmi *v1.VirtualMachineInstance) {
+					vmi.Spec.Domain.Firmware.Bootloader.EFI.Persistent = pointer.P(true)
+				},
+				"does not support a persistent EFI"),
+		)
+	})
+
 	Context("with vsocks defined", func() {
 		var vmi *v1.VirtualMachineInstance
 		BeforeEach(func() {
@@ -3956,6 +4265,27 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				Entry("with persistent EFI", addPersistentEFI),
 			)
 		})
+		DescribeTable("should validate the TPM secrets", func(tpm *v1.TPMDevice, expectedField string) {
+			vmi.Spec.Domain.Devices.TPM = tpm
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			Entry("accept encryption of a persistent TPM",
+				&v1.TPMDevice{Persistent: pointer.P(true), Encryption: &v1.TPMEncryption{SecretName: "tpm-key"}}, ""),
+			Entry("reject encryption of a non persistent TPM",
+				&v1.TPMDevice{Encryption: &v1.TPMEncryption{SecretName: "tpm-key"}}, "fake.domain.devices.tpm.encryption"),
+			Entry("reject encryption without a secret",
+				&v1.TPMDevice{Persistent: pointer.P(true), Encryption: &v1.TPMEncryption{}}, "fake.domain.devices.tpm.encryption.secretName"),
+			Entry("accept an EK certificate authority",
+				&v1.TPMDevice{EKCertificateAuthority: &v1.TPMEKCertificateAuthority{SecretName: "tpm-ca"}}, ""),
+			Entry("reject an EK certificate authority without a secret",
+				&v1.TPMDevice{EKCertificateAuthority: &v1.TPMEKCertificateAuthority{}}, "fake.domain.devices.tpm.ekCertificateAuthority.secretName"),
+		)
 	})
 
 	Context("with multi-threaded QEMU migrations", func() {
@@ -31,6 +31,7 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/network/ipam"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -122,6 +123,10 @@ func (admitter *VMIUpdateAdmitter) Admit(_ context.Context, ar *admissionv1.Admi
 		return reviewResponse
 	}
 
+	if reviewResponse := admitVMILeasesUpdate(newVMI, oldVMI, ar); reviewResponse != nil {
+		return reviewResponse
+	}
+
 	return &admissionv1.AdmissionResponse{
 		Allowed:  true,
 		Warnings: warnDeprecatedAPIs(&newVMI.Spec, admitter.clusterConfig),
@@ -389,6 +394,29 @@ func admitVMILabelsUpdate(
 	return nil
 }
 
+// admitVMILeasesUpdate prevents users from changing the DHCP server leases, virt-handler serves them as allocated by virt-controller
+func admitVMILeasesUpdate(
+	newVMI *v1.VirtualMachineInstance,
+	oldVMI *v1.VirtualMachineInstance,
+	ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+
+	if webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) {
+		return nil
+	}
+
+	if oldVMI.Annotations[ipam.LeasesAnnotation] != newVMI.Annotations[ipam.LeasesAnnotation] {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("modification of the reserved %s annotation on a VMI object is prohibited", ipam.LeasesAnnotation),
+				Field:   k8sfield.NewPath("metadata", "annotations").Key(ipam.LeasesAnnotation).String(),
+			},
+		})
+	}
+
+	return nil
+}
+
 func filterKubevirtLabels(labels map[string]string) map[string]string {
 	m := make(map[string]string)
 	if len(labels) == 0 {
@@ -40,6 +40,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/network/ipam"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
@@ -425,6 +426,50 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		),
 	)
 
+	DescribeTable("Should only allow kubevirt service accounts to modify the DHCP server leases annotation",
+		func(originalAnnotations, updateAnnotations map[string]string, serviceAccount string, allowed bool) {
+			vmi := api.NewMinimalVMI("testvmi")
+			updateVmi := vmi.DeepCopy()
+			vmi.Annotations = originalAnnotations
+			updateVmi.Annotations = updateAnnotations
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UserInfo:  authv1.UserInfo{Username: serviceAccount},
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					Operation: admissionv1.Update,
+				},
+			}
+			resp := admitVMILeasesUpdate(updateVmi, vmi, ar)
+			if allowed {
+				Expect(resp).To(BeNil())
+				return
+			}
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("modification of the reserved kubevirt.io/dhcp-server-leases annotation on a VMI object is prohibited"))
+		},
+		Entry("Add the annotation by a user",
+			nil,
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"10.10.0.2/24"}`},
+			"system:serviceaccount:someNamespace:someUser", false,
+		),
+		Entry("Change the annotation by a user",
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"10.10.0.2/24"}`},
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"192.168.0.1/16"}`},
+			"system:serviceaccount:someNamespace:someUser", false,
+		),
+		Entry("Change other annotations by a user",
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"10.10.0.2/24"}`},
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"10.10.0.2/24"}`, "other": "value"},
+			"system:serviceaccount:someNamespace:someUser", true,
+		),
+		Entry("Set the annotation by the controller",
+			nil,
+			map[string]string{ipam.LeasesAnnotation: `{"blue":"10.10.0.2/24"}`},
+			"system:serviceaccount:kubevirt:"+components.ControllerServiceAccountName, true,
+		),
+	)
+
 	emptyResult := func() map[string]v1.Volume {
 		return make(map[string]v1.Volume, 0)
 	}
@@ -85,6 +85,43 @@ const (
 	// InstancetypeReferencePolicy allows a cluster admin to control how a VirtualMachine references instance types and preferences
 	// through the kv.spec.configuration.instancetype.referencePolicy configurable.
 	InstancetypeReferencePolicy = "InstancetypeReferencePolicy"
+	// Alpha: v1.4.0
+	//
+	// DHCPServerIPAM enables an in-pod DHCP server for bridge bound secondary interfaces,
+	// handing out addresses from a per-network range managed by KubeVirt.
+	DHCPServerIPAMGate = "DHCPServerIPAM"
+	// Alpha: v1.4.0
+	//
+	// SecondaryNetworkEndpointSlices mirrors the guest reported addresses of a VMI secondary network
+	// into the EndpointSlices of a selector-less Service.
+	SecondaryNetworkEndpointSlicesGate = "SecondaryNetworkEndpointSlices"
+	// Alpha: v1.4.0
+	//
+	// GuestAgentConnectedLabel reflects the AgentConnected condition of VMIs in the
+	// kubevirt.io/guest-agent-connected label of the VMIs and their virt-launcher pods.
+	GuestAgentConnectedLabelGate = "GuestAgentConnectedLabel"
+	// Alpha: v1.4.0
+	//
+	// WorkloadEncryptionSEVSNP allows to run VMIs protected by AMD SEV-SNP.
+	WorkloadEncryptionSEVSNP = "WorkloadEncryptionSEVSNP"
+	// Alpha: v1.4.0
+	//
+	// WorkloadEncryptionTDX allows to run VMIs protected by Intel TDX.
+	WorkloadEncryptionTDX = "WorkloadEncryptionTDX"
+	// Alpha: v1.4.0
+	//
+	// VirtIOGPU allows to attach a virtio-gpu video device to VMIs and exposes the GPU render
+	// nodes of the nodes for 3D acceleration.
+	VirtIOGPUGate = "VirtIOGPU"
+	// Alpha: v1.4.0
+	//
+	// Spice allows to attach a SPICE graphics device to VMIs and to connect to it through the spice subresource.
+	SpiceGate = "Spice"
+	// Alpha: v1.4.0
+	//
+	// PersistentSerialConsoleLog allows to persist the serial console log of VMIs to a PVC or
+	// the backend storage of the VM and to retrieve it through the consolelog subresource.
+	PersistentSerialConsoleLogGate = "PersistentSerialConsoleLog"
 )
 
 func (config *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
@@ -252,3 +289,35 @@ func (config *ClusterConfig) NodeRestrictionEnabled() bool {
 func (config *ClusterConfig) DynamicPodInterfaceNamingEnabled() bool {
 	return config.isFeatureGateEnabled(DynamicPodInterfaceNamingGate)
 }
+
+func (config *ClusterConfig) DHCPServerIPAMEnabled() bool {
+	return config.isFeatureGateEnabled(DHCPServerIPAMGate)
+}
+
+func (config *ClusterConfig) SecondaryNetworkEndpointSlicesEnabled() bool {
+	return config.isFeatureGateEnabled(SecondaryNetworkEndpointSlicesGate)
+}
+
+func (config *ClusterConfig) GuestAgentConnectedLabelEnabled() bool {
+	return config.isFeatureGateEnabled(GuestAgentConnectedLabelGate)
+}
+
+func (config *ClusterConfig) WorkloadEncryptionSEVSNPEnabled() bool {
+	return config.isFeatureGateEnabled(WorkloadEncryptionSEVSNP)
+}
+
+func (config *ClusterConfig) WorkloadEncryptionTDXEnabled() bool {
+	return config.isFeatureGateEnabled(WorkloadEncryptionTDX)
+}
+
+func (config *ClusterConfig) VirtIOGPUEnabled() bool {
+	return config.isFeatureGateEnabled(VirtIOGPUGate)
+}
+
+func (config *ClusterConfig) SpiceEnabled() bool {
+	return config.isFeatureGateEnabled(SpiceGate)
+}
+
+func (config *ClusterConfig) PersistentSerialConsoleLogEnabled() bool {
+	return config.isFeatureGateEnabled(PersistentSerialConsoleLogGate)
+}
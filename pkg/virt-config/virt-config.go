@@ -25,6 +25,7 @@ package virtconfig
 
 import (
 	"strings"
+	"time"
 
 	"kubevirt.io/client-go/log"
 
@@ -89,6 +90,7 @@ const (
 
 	DefaultMaxHotplugRatio   = 4
 	DefaultVMRolloutStrategy = v1.VMRolloutStrategyStage
+	DefaultHotUnplugTimeout  = 5 * time.Minute
 )
 
 func IsAMD64(arch string) bool {
@@ -426,6 +428,10 @@ func (c *ClusterConfig) GetKSMConfiguration() *v1.KSMConfiguration {
 	return c.GetConfig().KSMConfiguration
 }
 
+func (c *ClusterConfig) GetMemoryReclaimConfiguration() *v1.MemoryReclaimConfiguration {
+	return c.GetConfig().MemoryReclaimConfiguration
+}
+
 func (c *ClusterConfig) GetMaximumCpuSockets() (numOfSockets uint32) {
 	liveConfig := c.GetConfig().LiveUpdateConfiguration
 	if liveConfig != nil && liveConfig.MaxCpuSockets != nil {
@@ -452,6 +458,14 @@ func (c *ClusterConfig) GetMaxHotplugRatio() uint32 {
 	return liveConfig.MaxHotplugRatio
 }
 
+func (c *ClusterConfig) GetHotUnplugTimeout() time.Duration {
+	liveConfig := c.GetConfig().LiveUpdateConfiguration
+	if liveConfig != nil && liveConfig.HotUnplugTimeout != nil {
+		return liveConfig.HotUnplugTimeout.Duration
+	}
+	return DefaultHotUnplugTimeout
+}
+
 func (c *ClusterConfig) IsVMRolloutStrategyLiveUpdate() bool {
 	liveConfig := c.GetConfig().VMRolloutStrategy
 	return liveConfig != nil && *liveConfig == v1.VMRolloutStrategyLiveUpdate
@@ -465,6 +479,14 @@ func (c *ClusterConfig) GetNetworkBindings() map[string]v1.InterfaceBindingPlugi
 	return nil
 }
 
+func (c *ClusterConfig) GetServiceMeshConfiguration() *v1.ServiceMeshConfiguration {
+	networkConfig := c.GetConfig().NetworkConfiguration
+	if networkConfig != nil {
+		return networkConfig.ServiceMesh
+	}
+	return nil
+}
+
 func (config *ClusterConfig) VGADisplayForEFIGuestsEnabled() bool {
 	VGADisplayForEFIGuestsAnnotationExists := false
 	kv := config.GetConfigFromKubeVirtCR()
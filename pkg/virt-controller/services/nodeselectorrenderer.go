@@ -22,6 +22,8 @@ type NodeSelectorRenderer struct {
 	realtimeEnabled  bool
 	sevEnabled       bool
 	sevESEnabled     bool
+	sevSNPEnabled    bool
+	tdxEnabled       bool
 }
 
 type NodeSelectorRendererOption func(renderer *NodeSelectorRenderer)
@@ -73,6 +75,12 @@ func (nsr *NodeSelectorRenderer) Render() map[string]string {
 	if nsr.sevESEnabled {
 		nsr.enableSelectorLabel(v1.SEVESLabel)
 	}
+	if nsr.sevSNPEnabled {
+		nsr.enableSelectorLabel(v1.SEVSNPLabel)
+	}
+	if nsr.tdxEnabled {
+		nsr.enableSelectorLabel(v1.TDXLabel)
+	}
 
 	return nsr.podNodeSelectors
 }
@@ -101,6 +109,18 @@ func WithSEVESSelector() NodeSelectorRendererOption {
 	}
 }
 
+func WithSEVSNPSelector() NodeSelectorRendererOption {
+	return func(renderer *NodeSelectorRenderer) {
+		renderer.sevSNPEnabled = true
+	}
+}
+
+func WithTDXSelector() NodeSelectorRendererOption {
+	return func(renderer *NodeSelectorRenderer) {
+		renderer.tdxEnabled = true
+	}
+}
+
 func WithDedicatedCPU() NodeSelectorRendererOption {
 	return func(renderer *NodeSelectorRenderer) {
 		renderer.hasDedicatedCPU = true
@@ -276,6 +276,15 @@ func WithSEV() ResourceRendererOption {
 	}
 }
 
+func WithRenderNode() ResourceRendererOption {
+	return func(renderer *ResourceRenderer) {
+		resources := renderer.ResourceRequirements()
+		requestResource(&resources, RenderDevice)
+		copyResources(resources.Limits, renderer.calculatedLimits)
+		copyResources(resources.Requests, renderer.calculatedRequests)
+	}
+}
+
 func WithPersistentReservation() ResourceRendererOption {
 	return func(renderer *ResourceRenderer) {
 		resources := renderer.ResourceRequirements()
@@ -376,9 +385,9 @@ func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additiona
 
 	addProbeOverheads(vmi, &overhead)
 
-	// Consider memory overhead for SEV guests.
+	// Consider memory overhead for SEV and SEV-SNP guests.
 	// Additional information can be found here: https://libvirt.org/kbase/launch_security_sev.html#memory
-	if util.IsSEVVMI(vmi) {
+	if util.UsesSEVDevice(vmi) {
 		overhead.Add(resource.MustParse("256Mi"))
 	}
 
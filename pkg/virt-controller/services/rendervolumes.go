@@ -17,8 +17,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/network/downwardapi"
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
 	"kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
@@ -368,7 +370,7 @@ func withBackendStorage(vmi *v1.VirtualMachineInstance, backendStoragePVCName st
 			return nil
 		}
 
-		volumeName := "vm-state"
+		volumeName := backendStorageVolumeName
 		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
 			Name: volumeName,
 			VolumeSource: k8sv1.VolumeSource{
@@ -427,6 +429,103 @@ func withBackendStorage(vmi *v1.VirtualMachineInstance, backendStoragePVCName st
 	}
 }
 
+func withTPMSecrets(tpm *v1.TPMDevice) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		if tpm == nil {
+			return nil
+		}
+		if tpm.Encryption != nil {
+			renderer.addTPMSecret("tpm-encryption", tpm.Encryption.SecretName, config.TPMEncryptionSecretDir)
+		}
+		if tpm.EKCertificateAuthority != nil {
+			renderer.addTPMSecret("tpm-ek-ca", tpm.EKCertificateAuthority.SecretName, config.TPMEKCertificateAuthorityDir)
+		}
+		return nil
+	}
+}
+
+func (vr *VolumeRenderer) addTPMSecret(volumeName, secretName, mountPath string) {
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: volumeName,
+		VolumeSource: k8sv1.VolumeSource{
+			Secret: &k8sv1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	vr.podVolumeMounts = append(vr.podVolumeMounts, k8sv1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
+
+func withSecureBootKeys(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		if !vmi.IsBootloaderEFI() || vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBootKeys == nil {
+			return nil
+		}
+		keys := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBootKeys
+		var volumeSource k8sv1.VolumeSource
+		if keys.Secret != nil {
+			volumeSource.Secret = &k8sv1.SecretVolumeSource{SecretName: keys.Secret.Name}
+		} else if keys.ConfigMap != nil {
+			volumeSource.ConfigMap = &k8sv1.ConfigMapVolumeSource{LocalObjectReference: *keys.ConfigMap}
+		} else {
+			return fmt.Errorf("SecureBoot keys must have Secret or ConfigMap reference set")
+		}
+		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+			Name:         "secure-boot-keys",
+			VolumeSource: volumeSource,
+		})
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+			Name:      "secure-boot-keys",
+			MountPath: config.SecureBootKeysDir,
+			ReadOnly:  true,
+		})
+		return nil
+	}
+}
+
+// withSerialConsoleLogPersistence mounts the persisted serial console log read-only into the
+// compute container, which allows virt-handler to serve it while the VMI is running.
+func withSerialConsoleLogPersistence(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		persistence := vmi.Spec.Domain.Devices.SerialConsoleLogPersistence
+		if persistence == nil {
+			return nil
+		}
+		if persistence.ClaimName != "" {
+			renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+				Name: serialConsoleLogVolumeName,
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: persistence.ClaimName,
+					},
+				},
+			})
+		}
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, serialConsoleLogVolumeMount(vmi, true))
+		return nil
+	}
+}
+
+func serialConsoleLogVolumeMount(vmi *v1.VirtualMachineInstance, readOnly bool) k8sv1.VolumeMount {
+	if backendstorage.HasSerialConsoleLogOnBackendStorage(&vmi.Spec) {
+		return k8sv1.VolumeMount{
+			Name:      backendStorageVolumeName,
+			MountPath: consolelog.MountPath,
+			SubPath:   consolelog.BackendStorageSubPath,
+			ReadOnly:  readOnly,
+		}
+	}
+	return k8sv1.VolumeMount{
+		Name:      serialConsoleLogVolumeName,
+		MountPath: consolelog.MountPath,
+		ReadOnly:  readOnly,
+	}
+}
+
 func withSidecarVolumes(hookSidecars hooks.HookSidecarList) VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		if len(hookSidecars) != 0 {
@@ -448,6 +547,21 @@ func withVirioFS() VolumeRendererOption {
 	}
 }
 
+// withVhostUserSockets adds the volume virt-handler mounts the node vhost-user sockets directory
+// of the VMI into, so that the launcher pod does not need a hostPath volume.
+func withVhostUserSockets() VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		prop := k8sv1.MountPropagationHostToContainer
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+			Name:             vhostuser.SocketsVolumeName,
+			MountPath:        vhostuser.SocketsVolumeMountDir,
+			MountPropagation: &prop,
+		})
+		renderer.podVolumes = append(renderer.podVolumes, emptyDirVolume(vhostuser.SocketsVolumeName))
+		return nil
+	}
+}
+
 func withHugepages() VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		hugepagesBasePath := "/dev/hugepages"
@@ -10,6 +10,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -46,6 +47,23 @@ func generateSerialConsoleLogContainer(vmi *v1.VirtualMachineInstance, image str
 
 		guestConsoleLog.Env = append(guestConsoleLog.Env, k8sv1.EnvVar{Name: ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY, Value: fmt.Sprint(virtLauncherLogVerbosity)})
 
+		if persistence := vmi.Spec.Domain.Devices.SerialConsoleLogPersistence; persistence != nil {
+			maxFileSize := int64(consolelog.DefaultMaxFileSize)
+			if persistence.MaxFileSize != nil {
+				maxFileSize = persistence.MaxFileSize.Value()
+			}
+			maxFiles := uint32(consolelog.DefaultMaxFiles)
+			if persistence.MaxFiles != nil {
+				maxFiles = *persistence.MaxFiles
+			}
+			guestConsoleLog.Args = append(guestConsoleLog.Args,
+				"--persist-dir", consolelog.MountPath,
+				"--max-file-size", fmt.Sprint(maxFileSize),
+				"--max-files", fmt.Sprint(maxFiles),
+			)
+			guestConsoleLog.VolumeMounts = append(guestConsoleLog.VolumeMounts, serialConsoleLogVolumeMount(vmi, false))
+		}
+
 		return guestConsoleLog
 	}
 
@@ -59,6 +77,10 @@ func isSerialConsoleLogEnabled(vmi *v1.VirtualMachineInstance, config *virtconfi
 	if vmi.Spec.Domain.Devices.LogSerialConsole != nil {
 		return *vmi.Spec.Domain.Devices.LogSerialConsole
 	}
+	if vmi.Spec.Domain.Devices.SerialConsoleLogPersistence != nil {
+		// Persisting the log asks for it, whatever the cluster-wide default is
+		return true
+	}
 	return !config.IsSerialConsoleLogDisabled()
 }
 
@@ -72,6 +72,9 @@ const (
 	virtBinDir       = "virt-bin-share-dir"
 	hotplugDisk      = "hotplug-disk"
 	virtExporter     = "virt-exporter"
+
+	backendStorageVolumeName   = "vm-state"
+	serialConsoleLogVolumeName = "serial-console-log"
 )
 
 const KvmDevice = "devices.kubevirt.io/kvm"
@@ -80,6 +83,7 @@ const VhostNetDevice = "devices.kubevirt.io/vhost-net"
 const SevDevice = "devices.kubevirt.io/sev"
 const VhostVsockDevice = "devices.kubevirt.io/vhost-vsock"
 const PrDevice = "devices.kubevirt.io/pr-helper"
+const RenderDevice = "devices.kubevirt.io/render"
 
 const debugLogs = "debugLogs"
 const logVerbosity = "logVerbosity"
@@ -342,6 +346,11 @@ func computePodSecurityContext(vmi *v1.VirtualMachineInstance, seccomp *k8sv1.Se
 	} else {
 		rootUser := int64(util.RootUser)
 		psc.RunAsUser = &rootUser
+		if vmi.Spec.Domain.Devices.SerialConsoleLogPersistence != nil {
+			// Lets the non-root guest-console-log container write the persisted log
+			nonRootGroup := int64(util.NonRootUID)
+			psc.FSGroup = &nonRootGroup
+		}
 	}
 	psc.SeccompProfile = seccomp
 
@@ -657,7 +666,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		pod.Spec.ServiceAccountName = serviceAccountName
 		automount := true
 		pod.Spec.AutomountServiceAccountToken = &automount
-	} else if istio.ProxyInjectionEnabled(vmi) {
+	} else if istio.SidecarInjectionEnabled(vmi, t.clusterConfig.GetServiceMeshConfiguration()) {
 		automount := true
 		pod.Spec.AutomountServiceAccountToken = &automount
 	} else {
@@ -702,6 +711,14 @@ func (t *templateService) newNodeSelectorRenderer(vmi *v1.VirtualMachineInstance
 		log.Log.V(4).Info("Add SEV-ES node label selector")
 		opts = append(opts, WithSEVESSelector())
 	}
+	if util.IsSEVSNPVMI(vmi) {
+		log.Log.V(4).Info("Add SEV-SNP node label selector")
+		opts = append(opts, WithSEVSNPSelector())
+	}
+	if util.IsTDXVMI(vmi) {
+		log.Log.V(4).Info("Add TDX node label selector")
+		opts = append(opts, WithTDXSelector())
+	}
 
 	return NewNodeSelectorRenderer(
 		vmi.Spec.NodeSelector,
@@ -805,6 +822,8 @@ func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, name
 		withVMIVolumes(t.persistentVolumeClaimStore, vmi.Spec.Volumes, vmi.Status.VolumeStatus),
 		withAccessCredentials(vmi.Spec.AccessCredentials),
 		withBackendStorage(vmi, backendStoragePVCName),
+		withTPMSecrets(vmi.Spec.Domain.Devices.TPM),
+		withSecureBootKeys(vmi),
 	}
 	if len(requestedHookSidecarList) != 0 {
 		volumeOpts = append(volumeOpts, withSidecarVolumes(requestedHookSidecarList))
@@ -831,6 +850,14 @@ func (t *templateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, name
 		volumeOpts = append(volumeOpts, withVirioFS())
 	}
 
+	if isSerialConsoleLogEnabled(vmi, t.clusterConfig) {
+		volumeOpts = append(volumeOpts, withSerialConsoleLogPersistence(vmi))
+	}
+
+	if vmispec.BindingPluginNetworkWithVhostUserExist(vmi.Spec.Domain.Devices.Interfaces, t.clusterConfig.GetNetworkBindings()) {
+		volumeOpts = append(volumeOpts, withVhostUserSockets())
+	}
+
 	volumeRenderer, err := NewVolumeRenderer(
 		namespace,
 		t.ephemeralDiskDir,
@@ -1487,8 +1514,9 @@ func (t *templateService) VMIResourcePredicates(vmi *v1.VirtualMachineInstance,
 			}, WithNetworkResources(networkToResourceMap)),
 			NewVMIResourceRule(util.IsGPUVMI, WithGPUs(vmi.Spec.Domain.Devices.GPUs)),
 			NewVMIResourceRule(util.IsHostDevVMI, WithHostDevices(vmi.Spec.Domain.Devices.HostDevices)),
-			NewVMIResourceRule(util.IsSEVVMI, WithSEV()),
+			NewVMIResourceRule(util.UsesSEVDevice, WithSEV()),
 			NewVMIResourceRule(reservation.HasVMIPersistentReservation, WithPersistentReservation()),
+			NewVMIResourceRule(util.IsVideoAccel3DVMI, WithRenderNode()),
 		},
 	}
 }
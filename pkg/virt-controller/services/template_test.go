@@ -56,6 +56,7 @@ import (
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-config/deprecation"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
@@ -986,6 +987,25 @@ var _ = Describe("Template", func() {
 				Expect(*pod.Spec.AutomountServiceAccountToken).To(BeTrue())
 			})
 		})
+		Context("With a configured service mesh injection annotation", func() {
+			It("should mount default serviceAccountToken", func() {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{
+					ServiceMesh: &v1.ServiceMeshConfiguration{
+						InjectionAnnotations: map[string]string{"linkerd.io/inject": "enabled"},
+					},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+
+				vmi := libvmi.New(
+					libvmi.WithNamespace("default"),
+					libvmi.WithAnnotation("linkerd.io/inject", "enabled"),
+				)
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(*pod.Spec.AutomountServiceAccountToken).To(BeTrue())
+			})
+		})
 		Context("with node selectors", func() {
 			DescribeTable("should add node selectors to template", func(arch string, ovmfPath string) {
 				config, kvStore, svc = configFactory(arch)
@@ -1206,6 +1226,25 @@ var _ = Describe("Template", func() {
 					Entry("when no SEV-ES policy bit is set", &v1.SEVPolicy{EncryptedState: nil}),
 					Entry("when SEV-ES policy bit is set to false", &v1.SEVPolicy{EncryptedState: pointer.P(false)}),
 				)
+
+				It("should add the SEV-SNP node label selector and request the SEV device with SEV-SNP workload", func() {
+					vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}
+
+					pod, err := svc.RenderLaunchManifest(vmi)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.SEVSNPLabel, "true"))
+					Expect(pod.Spec.NodeSelector).To(Not(HaveKey(v1.SEVLabel)))
+					Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(k8sv1.ResourceName(SevDevice)))
+				})
+
+				It("should add the TDX node label selector with TDX workload", func() {
+					vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+
+					pod, err := svc.RenderLaunchManifest(vmi)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.TDXLabel, "true"))
+					Expect(pod.Spec.Containers[0].Resources.Limits).To(Not(HaveKey(k8sv1.ResourceName(SevDevice))))
+				})
 			})
 
 			It("should not add node selector for hyperv nodes if VMI does not request hyperv features", func() {
@@ -4349,6 +4388,77 @@ var _ = Describe("Template", func() {
 				expectStateMounts(pod)
 			})
 
+			It("should mount the TPM encryption key and EK certificate authority Secrets", func() {
+				pvc.Labels = map[string]string{"persistent-state-for": vmiName}
+				err := pvcCache.Add(pvc)
+				Expect(err).NotTo(HaveOccurred())
+
+				config, kvStore, svc = configFactory(defaultArch)
+				vmi := api.NewMinimalVMI(vmiName)
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{
+					Persistent:             pointer.P(true),
+					Encryption:             &v1.TPMEncryption{SecretName: "tpm-key"},
+					EKCertificateAuthority: &v1.TPMEKCertificateAuthority{SecretName: "tpm-ca"},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				expectStateMounts(pod)
+				Expect(pod.Spec.Volumes).To(ContainElements(
+					k8sv1.Volume{
+						Name:         "tpm-encryption",
+						VolumeSource: k8sv1.VolumeSource{Secret: &k8sv1.SecretVolumeSource{SecretName: "tpm-key"}},
+					},
+					k8sv1.Volume{
+						Name:         "tpm-ek-ca",
+						VolumeSource: k8sv1.VolumeSource{Secret: &k8sv1.SecretVolumeSource{SecretName: "tpm-ca"}},
+					},
+				))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElements(
+					k8sv1.VolumeMount{
+						Name:      "tpm-encryption",
+						MountPath: "/var/run/kubevirt-private/tpm-encryption",
+						ReadOnly:  true,
+					},
+					k8sv1.VolumeMount{
+						Name:      "tpm-ek-ca",
+						MountPath: "/var/run/kubevirt-private/tpm-ek-ca",
+						ReadOnly:  true,
+					},
+				))
+			})
+
+			It("should mount the SecureBoot keys with a persistent EFI", func() {
+				pvc.Labels = map[string]string{"persistent-state-for": vmiName}
+				err := pvcCache.Add(pvc)
+				Expect(err).NotTo(HaveOccurred())
+
+				config, kvStore, svc = configFactory(defaultArch)
+				vmi := api.NewMinimalVMI(vmiName)
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{
+						EFI: &v1.EFI{
+							Persistent:     pointer.P(true),
+							SecureBootKeys: &v1.SecureBootKeysSource{ConfigMap: &k8sv1.LocalObjectReference{Name: "sb-keys"}},
+						},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(k8sv1.Volume{
+					Name: "secure-boot-keys",
+					VolumeSource: k8sv1.VolumeSource{ConfigMap: &k8sv1.ConfigMapVolumeSource{
+						LocalObjectReference: k8sv1.LocalObjectReference{Name: "sb-keys"},
+					}},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+					Name:      "secure-boot-keys",
+					MountPath: "/var/run/kubevirt-private/secure-boot-keys",
+					ReadOnly:  true,
+				}))
+			})
+
 			It("should add the pvc to Pod of a migration target", func() {
 				migration := &v1.VirtualMachineInstanceMigration{
 					ObjectMeta: metav1.ObjectMeta{
@@ -4467,6 +4577,24 @@ var _ = Describe("Template", func() {
 		})
 	})
 
+	Context("with a virtio video device", func() {
+		DescribeTable("should request a GPU render node", func(accel3D *bool, expectRenderNode bool) {
+			vmi := api.NewMinimalVMI("fake-vmi")
+			vmi.Spec.Domain.Devices.Video = &v1.VideoDevice{Type: v1.VideoTypeVirtIO, Accel3D: accel3D}
+
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).NotTo(HaveOccurred())
+			if expectRenderNode {
+				Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(k8sv1.ResourceName(RenderDevice)))
+			} else {
+				Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(k8sv1.ResourceName(RenderDevice)))
+			}
+		},
+			Entry("with 3D acceleration", pointer.P(true), true),
+			Entry("without 3D acceleration", nil, false),
+		)
+	})
+
 	Context("with auto CPU limits", func() {
 		const (
 			rqNamespace   = "rq-namespace"
@@ -4941,6 +5069,147 @@ var _ = Describe("Template", func() {
 			Entry("without AutoattachSerialConsole but with LogSerialConsole", false, true, false),
 			Entry("without AutoattachSerialConsole and without LogSerialConsole", false, false, false),
 		)
+
+		Context("with a persisted log", func() {
+			BeforeEach(func() {
+				config, kvStore, svc = configFactory(defaultArch)
+			})
+
+			guestConsoleLog := func(pod *k8sv1.Pod) k8sv1.Container {
+				for _, container := range pod.Spec.Containers {
+					if container.Name == "guest-console-log" {
+						return container
+					}
+				}
+				Fail("the guest-console-log container is missing")
+				return k8sv1.Container{}
+			}
+
+			It("should persist the log to the claim", func() {
+				vmi := api.NewMinimalVMI("fake-vmi")
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{
+					ClaimName:   "console-log",
+					MaxFileSize: pointer.P(resource.MustParse("2Mi")),
+					MaxFiles:    pointer.P(uint32(5)),
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(k8sv1.Volume{
+					Name: "serial-console-log",
+					VolumeSource: k8sv1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "console-log",
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+					Name:      "serial-console-log",
+					MountPath: consolelog.MountPath,
+					ReadOnly:  true,
+				}))
+				container := guestConsoleLog(pod)
+				Expect(container.Args).To(HaveExactElements(
+					"--logfile", ContainSubstring("virt-serial0-log"),
+					"--persist-dir", consolelog.MountPath,
+					"--max-file-size", "2097152",
+					"--max-files", "5",
+				))
+				Expect(container.VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+					Name:      "serial-console-log",
+					MountPath: consolelog.MountPath,
+				}))
+			})
+
+			It("should write the log as non-root user of a root VMI through the pod fsGroup", func() {
+				vmi := api.NewMinimalVMI("fake-vmi")
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{ClaimName: "console-log"}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Spec.SecurityContext.RunAsUser).To(HaveValue(BeEquivalentTo(util.RootUser)))
+				Expect(pod.Spec.SecurityContext.FSGroup).To(HaveValue(BeEquivalentTo(util.NonRootUID)))
+				container := guestConsoleLog(pod)
+				Expect(container.SecurityContext.RunAsUser).To(HaveValue(BeEquivalentTo(util.NonRootUID)))
+				Expect(container.SecurityContext.RunAsNonRoot).To(HaveValue(BeTrue()))
+			})
+
+			It("should persist the log to the backend storage without a claim", func() {
+				pvc := &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "persistent-state-for-fake-vmi",
+						Namespace: metav1.NamespaceDefault,
+						Labels:    map[string]string{"persistent-state-for": "fake-vmi"},
+					},
+				}
+				Expect(pvcCache.Add(pvc)).To(Succeed())
+				DeferCleanup(pvcCache.Delete, pvc)
+
+				vmi := api.NewMinimalVMI("fake-vmi")
+				vmi.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(HaveField("Name", "vm-state")))
+				Expect(pod.Spec.Volumes).ToNot(ContainElement(HaveField("Name", "serial-console-log")))
+				container := guestConsoleLog(pod)
+				Expect(container.Args).To(ContainElements("--max-file-size", "1048576", "--max-files", "3"))
+				Expect(container.VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+					Name:      "vm-state",
+					MountPath: consolelog.MountPath,
+					SubPath:   consolelog.BackendStorageSubPath,
+				}))
+			})
+		})
+	})
+
+	Context("vhost-user sockets", func() {
+		const vhostUserPlugin = "vhostuser"
+		BeforeEach(func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{
+				Binding: map[string]v1.InterfaceBindingPlugin{vhostUserPlugin: {DomainAttachmentType: v1.VhostUser}},
+			}
+			_, kvStore, svc = configFactory(defaultArch)
+			testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+		})
+
+		It("should add the vhost-user sockets volume virt-handler mounts the node directory into", func() {
+			vmi := libvmi.New(libvmi.WithNamespace("default"),
+				libvmi.WithNetwork(libvmi.MultusNetwork("network1", "default/default")),
+				libvmi.WithInterface(libvmi.InterfaceWithBindingPlugin("network1", v1.PluginBinding{Name: vhostUserPlugin})),
+			)
+
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.Volumes).To(ContainElement(k8sv1.Volume{
+				Name: "vhostuser-sockets",
+				VolumeSource: k8sv1.VolumeSource{
+					EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+				},
+			}))
+			prop := k8sv1.MountPropagationHostToContainer
+			Expect(pod.Spec.Containers[0].Name).To(Equal("compute"))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+				Name:             "vhostuser-sockets",
+				MountPath:        "/var/run/kubevirt/vhostuser",
+				MountPropagation: &prop,
+			}))
+		})
+
+		It("should not mount the vhost-user sockets directory without a vhost-user interface", func() {
+			vmi := libvmi.New(libvmi.WithNamespace("default"),
+				libvmi.WithNetwork(v1.DefaultPodNetwork()),
+				libvmi.WithInterface(*v1.DefaultBridgeNetworkInterface()),
+			)
+
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			for _, volume := range pod.Spec.Volumes {
+				Expect(volume.Name).ToNot(Equal("vhostuser-sockets"))
+			}
+		})
 	})
 
 	Context("network-info", func() {
@@ -38,6 +38,7 @@ import (
 	clonev1alpha1 "kubevirt.io/api/clone/v1alpha1"
 
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/endpointslice"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/migration"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/node"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/pool"
@@ -148,6 +149,10 @@ type VirtControllerApp struct {
 	nodeInformer   cache.SharedIndexInformer
 	nodeController *node.Controller
 
+	secondaryNetworkServiceInformer       cache.SharedIndexInformer
+	secondaryNetworkEndpointSliceInformer cache.SharedIndexInformer
+	endpointSliceController               *endpointslice.Controller
+
 	vmiCache      cache.Store
 	vmiController *vmi.Controller
 	vmiInformer   cache.SharedIndexInformer
@@ -242,6 +247,7 @@ type VirtControllerApp struct {
 
 	// number of threads for each controller
 	nodeControllerThreads             int
+	endpointSliceControllerThreads    int
 	vmiControllerThreads              int
 	rsControllerThreads               int
 	poolControllerThreads             int
@@ -359,6 +365,8 @@ func Execute() {
 	app.vmiInformer = app.informerFactory.VMI()
 	app.kvPodInformer = app.informerFactory.KubeVirtPod()
 	app.nodeInformer = app.informerFactory.KubeVirtNode()
+	app.secondaryNetworkServiceInformer = app.informerFactory.SecondaryNetworkService()
+	app.secondaryNetworkEndpointSliceInformer = app.informerFactory.SecondaryNetworkEndpointSlice()
 	app.namespaceStore = app.informerFactory.Namespace().GetStore()
 	app.namespaceInformer = app.informerFactory.Namespace()
 	app.vmiCache = app.vmiInformer.GetStore()
@@ -563,6 +571,7 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.evacuationController.Run(vca.evacuationControllerThreads, stop)
 		go vca.disruptionBudgetController.Run(vca.disruptionBudgetControllerThreads, stop)
 		go vca.nodeController.Run(vca.nodeControllerThreads, stop)
+		go vca.endpointSliceController.Run(vca.endpointSliceControllerThreads, stop)
 		go vca.vmiController.Run(vca.vmiControllerThreads, stop)
 		go vca.rsController.Run(vca.rsControllerThreads, stop)
 		go vca.poolController.Run(vca.poolControllerThreads, stop)
@@ -660,6 +669,7 @@ func (vca *VirtControllerApp) initCommon() {
 		func(clusterConfig *virtconfig.ClusterConfig, vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) error {
 			return netvmicontroller.UpdateStatus(clusterConfig, vmi, pod)
 		},
+		vca.kubevirtNamespace,
 	)
 	if err != nil {
 		panic(err)
@@ -670,6 +680,17 @@ func (vca *VirtControllerApp) initCommon() {
 	if err != nil {
 		panic(err)
 	}
+	vca.endpointSliceController, err = endpointslice.NewController(
+		vca.clientSet,
+		vca.secondaryNetworkServiceInformer,
+		vca.vmiInformer,
+		vca.secondaryNetworkEndpointSliceInformer,
+		vca.newRecorder(k8sv1.NamespaceAll, "endpointslice-controller"),
+		vca.clusterConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
 	// Adding a timeout to the clientSet of the migration controller, to avoid potential deadlocks
 	clientSet, err := vca.clientSet.SetRestTimeout(migrationControllerRestTimeout)
 	if err != nil {
@@ -959,6 +980,9 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.nodeControllerThreads, "node-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for node controller")
 
+	flag.IntVar(&vca.endpointSliceControllerThreads, "endpointslice-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for the secondary network endpointslice controller")
+
 	flag.IntVar(&vca.vmiControllerThreads, "vmi-controller-threads", defaultVMIControllerThreads,
 		"Number of goroutines to run for vmi controller")
 
@@ -0,0 +1,453 @@
+package endpointslice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// ManagedBy is the EndpointSlice manager identity of this controller.
+	ManagedBy = "kubevirt.io/endpointslice-controller"
+
+	// InvalidSelectorReason is used for events when the Service VMI selector cannot be parsed.
+	InvalidSelectorReason = "InvalidVMISelector"
+)
+
+// Controller mirrors the guest reported addresses of a VMI secondary network into the
+// EndpointSlices of a selector-less Service.
+// A Service opts in by setting the network name in the EndpointsNetworkLabel label and
+// the selector of its backing VMIs in the EndpointsVMISelectorAnnotation annotation.
+type Controller struct {
+	clientset          kubecli.KubevirtClient
+	Queue              workqueue.RateLimitingInterface
+	serviceIndexer     cache.Indexer
+	vmiIndexer         cache.Indexer
+	endpointSliceStore cache.Store
+	recorder           record.EventRecorder
+	clusterConfig      *virtconfig.ClusterConfig
+	hasSynced          func() bool
+}
+
+func NewController(
+	clientset kubecli.KubevirtClient,
+	serviceInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	endpointSliceInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clusterConfig *virtconfig.ClusterConfig,
+) (*Controller, error) {
+	c := &Controller{
+		clientset:          clientset,
+		Queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-endpointslice"),
+		serviceIndexer:     serviceInformer.GetIndexer(),
+		vmiIndexer:         vmiInformer.GetIndexer(),
+		endpointSliceStore: endpointSliceInformer.GetStore(),
+		recorder:           recorder,
+		clusterConfig:      clusterConfig,
+	}
+
+	c.hasSynced = func() bool {
+		return serviceInformer.HasSynced() && vmiInformer.HasSynced() && endpointSliceInformer.HasSynced()
+	}
+
+	_, err := serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueService,
+		DeleteFunc: c.enqueueService,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueService(curr) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueNamespaceServices,
+		DeleteFunc: c.enqueueNamespaceServices,
+		UpdateFunc: c.updateVMI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = endpointSliceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueEndpointSliceService,
+		DeleteFunc: c.enqueueEndpointSliceService,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueEndpointSliceService(curr) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) enqueueService(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from service.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *Controller) updateVMI(old, curr interface{}) {
+	oldVMI := old.(*virtv1.VirtualMachineInstance)
+	currVMI := curr.(*virtv1.VirtualMachineInstance)
+	if equality.Semantic.DeepEqual(oldVMI.Labels, currVMI.Labels) &&
+		equality.Semantic.DeepEqual(oldVMI.Status.Interfaces, currVMI.Status.Interfaces) &&
+		equality.Semantic.DeepEqual(oldVMI.Status.Conditions, currVMI.Status.Conditions) &&
+		oldVMI.Status.NodeName == currVMI.Status.NodeName &&
+		oldVMI.DeletionTimestamp.Equal(currVMI.DeletionTimestamp) {
+		return
+	}
+	c.enqueueNamespaceServices(curr)
+}
+
+// enqueueNamespaceServices enqueues all the opted-in Services of the VMI namespace,
+// the VMI selector of each Service is evaluated when it is processed.
+func (c *Controller) enqueueNamespaceServices(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+	services, err := c.serviceIndexer.ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to list services of the VMI namespace.")
+		return
+	}
+	for _, service := range services {
+		c.enqueueService(service)
+	}
+}
+
+func (c *Controller) enqueueEndpointSliceService(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	endpointSlice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return
+	}
+	if serviceName, exists := endpointSlice.Labels[discoveryv1.LabelServiceName]; exists {
+		c.Queue.Add(controller.NamespacedKey(endpointSlice.Namespace, serviceName))
+	}
+}
+
+// Run runs the passed in EndpointSlice controller.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting endpointslice controller.")
+
+	cache.WaitForCacheSync(stopCh, c.hasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping endpointslice controller.")
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+// Execute runs commands from the controller queue, if there is
+// an error it requeues the command. Returns false if the queue
+// is empty.
+func (c *Controller) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+
+	if err := c.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing service %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed service %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) error {
+	if !c.clusterConfig.SecondaryNetworkEndpointSlicesEnabled() {
+		return nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	obj, exists, err := c.serviceIndexer.GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// The Service is gone or no longer opts in, the EndpointSlices of a deleted Service
+		// are garbage collected with it, those of a Service which opted out are removed here
+		return c.deleteStaleEndpointSlices(namespace, name, nil)
+	}
+	service := obj.(*k8sv1.Service)
+
+	if len(service.Spec.Selector) > 0 {
+		log.Log.Object(service).V(4).Infof("service has a pod selector, removing the mirrored endpointslices")
+		return c.deleteStaleEndpointSlices(namespace, name, nil)
+	}
+
+	selector, err := labels.Parse(service.Annotations[virtv1.EndpointsVMISelectorAnnotation])
+	if err != nil {
+		c.recorder.Eventf(service, k8sv1.EventTypeWarning, InvalidSelectorReason, "Failed to parse the %s annotation: %v", virtv1.EndpointsVMISelectorAnnotation, err)
+		return nil
+	}
+
+	vmis, err := c.listVMIs(service.Namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	desiredNames := map[string]struct{}{}
+	for _, desired := range desiredEndpointSlices(service, vmis) {
+		desiredNames[desired.Name] = struct{}{}
+		if err := c.reconcileEndpointSlice(desired); err != nil {
+			return err
+		}
+	}
+	return c.deleteStaleEndpointSlices(namespace, name, desiredNames)
+}
+
+// deleteStaleEndpointSlices removes the managed EndpointSlices of the Service which are not desired,
+// like those of IP families the Service no longer has.
+func (c *Controller) deleteStaleEndpointSlices(namespace, serviceName string, desiredNames map[string]struct{}) error {
+	for _, obj := range c.endpointSliceStore.List() {
+		endpointSlice := obj.(*discoveryv1.EndpointSlice)
+		if endpointSlice.Namespace != namespace ||
+			endpointSlice.Labels[discoveryv1.LabelServiceName] != serviceName ||
+			endpointSlice.Labels[discoveryv1.LabelManagedBy] != ManagedBy {
+			continue
+		}
+		if _, desired := desiredNames[endpointSlice.Name]; desired {
+			continue
+		}
+		err := c.clientset.DiscoveryV1().EndpointSlices(endpointSlice.Namespace).Delete(context.Background(), endpointSlice.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) listVMIs(namespace string, selector labels.Selector) ([]*virtv1.VirtualMachineInstance, error) {
+	var vmis []*virtv1.VirtualMachineInstance
+	if selector.Empty() {
+		// An empty selector does not select any VMI, as with the Service pod selector
+		return vmis, nil
+	}
+
+	objs, err := c.vmiIndexer.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if selector.Matches(labels.Set(vmi.Labels)) {
+			vmis = append(vmis, vmi)
+		}
+	}
+	sort.Slice(vmis, func(i, j int) bool { return vmis[i].Name < vmis[j].Name })
+	return vmis, nil
+}
+
+func (c *Controller) reconcileEndpointSlice(desired *discoveryv1.EndpointSlice) error {
+	obj, exists, err := c.endpointSliceStore.GetByKey(controller.NamespacedKey(desired.Namespace, desired.Name))
+	if err != nil {
+		return err
+	}
+
+	endpointSlices := c.clientset.DiscoveryV1().EndpointSlices(desired.Namespace)
+	if !exists {
+		_, err = endpointSlices.Create(context.Background(), desired, metav1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			return fmt.Errorf("endpointslice %s/%s is not in the cache yet", desired.Namespace, desired.Name)
+		}
+		return err
+	}
+
+	current := obj.(*discoveryv1.EndpointSlice)
+	if equality.Semantic.DeepEqual(current.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(current.Endpoints, desired.Endpoints) &&
+		equality.Semantic.DeepEqual(current.Ports, desired.Ports) {
+		return nil
+	}
+
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Endpoints = desired.Endpoints
+	updated.Ports = desired.Ports
+	_, err = endpointSlices.Update(context.Background(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// desiredEndpointSlices returns an EndpointSlice for each IP family of the Service,
+// listing the addresses of the selected network reported by the guest of each VMI.
+func desiredEndpointSlices(service *k8sv1.Service, vmis []*virtv1.VirtualMachineInstance) []*discoveryv1.EndpointSlice {
+	networkName := service.Labels[virtv1.EndpointsNetworkLabel]
+	ports := endpointPorts(service)
+
+	ipFamilies := service.Spec.IPFamilies
+	if len(ipFamilies) == 0 {
+		ipFamilies = []k8sv1.IPFamily{k8sv1.IPv4Protocol}
+	}
+
+	var endpointSlices []*discoveryv1.EndpointSlice
+	for _, ipFamily := range ipFamilies {
+		addressType := discoveryv1.AddressTypeIPv4
+		if ipFamily == k8sv1.IPv6Protocol {
+			addressType = discoveryv1.AddressTypeIPv6
+		}
+
+		endpoints := []discoveryv1.Endpoint{}
+		for _, vmi := range vmis {
+			endpoints = append(endpoints, vmiEndpoints(vmi, networkName, addressType)...)
+		}
+
+		endpointSlices = append(endpointSlices, &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-kubevirt-%s", service.Name, strings.ToLower(string(ipFamily))),
+				Namespace: service.Namespace,
+				Labels: map[string]string{
+					discoveryv1.LabelServiceName: service.Name,
+					discoveryv1.LabelManagedBy:   ManagedBy,
+					virtv1.EndpointsNetworkLabel: networkName,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(service, k8sv1.SchemeGroupVersion.WithKind("Service")),
+				},
+			},
+			AddressType: addressType,
+			Endpoints:   endpoints,
+			Ports:       ports,
+		})
+	}
+	return endpointSlices
+}
+
+func vmiEndpoints(vmi *virtv1.VirtualMachineInstance, networkName string, addressType discoveryv1.AddressType) []discoveryv1.Endpoint {
+	if vmi.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var endpoints []discoveryv1.Endpoint
+	for _, iface := range vmi.Status.Interfaces {
+		if iface.Name != networkName {
+			continue
+		}
+		for _, address := range interfaceAddresses(iface) {
+			if addressFamily(address) != addressType {
+				continue
+			}
+			endpoint := discoveryv1.Endpoint{
+				Addresses:  []string{address},
+				Conditions: discoveryv1.EndpointConditions{Ready: pointerTo(vmiReady(vmi))},
+				TargetRef: &k8sv1.ObjectReference{
+					APIVersion: virtv1.GroupVersion.String(),
+					Kind:       virtv1.VirtualMachineInstanceGroupVersionKind.Kind,
+					Namespace:  vmi.Namespace,
+					Name:       vmi.Name,
+					UID:        vmi.UID,
+				},
+			}
+			if vmi.Status.NodeName != "" {
+				endpoint.NodeName = pointerTo(vmi.Status.NodeName)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func interfaceAddresses(iface virtv1.VirtualMachineInstanceNetworkInterface) []string {
+	if len(iface.IPs) > 0 {
+		return iface.IPs
+	}
+	if iface.IP != "" {
+		return []string{iface.IP}
+	}
+	return nil
+}
+
+// addressFamily returns the EndpointSlice address type of a routable address,
+// or an empty type for link local and malformed addresses.
+func addressFamily(address string) discoveryv1.AddressType {
+	ip := net.ParseIP(address)
+	if ip == nil || ip.IsLinkLocalUnicast() {
+		return ""
+	}
+	if ip.To4() != nil {
+		return discoveryv1.AddressTypeIPv4
+	}
+	return discoveryv1.AddressTypeIPv6
+}
+
+func vmiReady(vmi *virtv1.VirtualMachineInstance) bool {
+	if vmi.Status.Phase != virtv1.Running {
+		return false
+	}
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == virtv1.VirtualMachineInstanceReady {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// endpointPorts maps the Service ports to the guest ports.
+// Named target ports cannot be resolved against a guest and fall back to the Service port.
+func endpointPorts(service *k8sv1.Service) []discoveryv1.EndpointPort {
+	ports := []discoveryv1.EndpointPort{}
+	for _, servicePort := range service.Spec.Ports {
+		port := servicePort.Port
+		if targetPort := servicePort.TargetPort.IntValue(); targetPort > 0 {
+			port = int32(targetPort)
+		}
+		ports = append(ports, discoveryv1.EndpointPort{
+			Name:     pointerTo(servicePort.Name),
+			Protocol: pointerTo(servicePort.Protocol),
+			Port:     pointerTo(port),
+		})
+	}
+	return ports
+}
+
+func pointerTo[T any](t T) *T {
+	return &t
+}
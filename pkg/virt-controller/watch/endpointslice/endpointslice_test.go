@@ -0,0 +1,262 @@
+package endpointslice
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("EndpointSlice controller", func() {
+	const (
+		namespace   = metav1.NamespaceDefault
+		networkName = "blue"
+	)
+
+	var (
+		controller            *Controller
+		kubeClient            *fake.Clientset
+		serviceInformer       cache.SharedIndexInformer
+		vmiInformer           cache.SharedIndexInformer
+		endpointSliceInformer cache.SharedIndexInformer
+		recorder              *record.FakeRecorder
+	)
+
+	newController := func(featureGates ...string) {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().DiscoveryV1().Return(kubeClient.DiscoveryV1()).AnyTimes()
+
+		namespaceIndexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+		serviceInformer, _ = testutils.NewFakeInformerWithIndexersFor(&k8sv1.Service{}, namespaceIndexers)
+		vmiInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, namespaceIndexers)
+		endpointSliceInformer, _ = testutils.NewFakeInformerFor(&discoveryv1.EndpointSlice{})
+		recorder = record.NewFakeRecorder(10)
+
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+		})
+
+		var err error
+		controller, err = NewController(virtClient, serviceInformer, vmiInformer, endpointSliceInformer, recorder, clusterConfig)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	newService := func() *k8sv1.Service {
+		return &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "svc",
+				Namespace:   namespace,
+				UID:         "svc-uid",
+				Labels:      map[string]string{v1.EndpointsNetworkLabel: networkName},
+				Annotations: map[string]string{v1.EndpointsVMISelectorAnnotation: "app=web"},
+			},
+			Spec: k8sv1.ServiceSpec{
+				IPFamilies: []k8sv1.IPFamily{k8sv1.IPv4Protocol, k8sv1.IPv6Protocol},
+				Ports: []k8sv1.ServicePort{
+					{Name: "http", Protocol: k8sv1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080)},
+				},
+			},
+		}
+	}
+
+	newVMI := func(name string, ips ...string) *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				UID:       "vmi-uid",
+				Labels:    map[string]string{"app": "web"},
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase:    v1.Running,
+				NodeName: "node01",
+				Conditions: []v1.VirtualMachineInstanceCondition{
+					{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue},
+				},
+				Interfaces: []v1.VirtualMachineInstanceNetworkInterface{
+					{Name: "default", IP: "10.244.0.10", IPs: []string{"10.244.0.10"}},
+					{Name: networkName, IPs: ips},
+				},
+			},
+		}
+	}
+
+	getEndpointSlice := func(name string) *discoveryv1.EndpointSlice {
+		endpointSlice, err := kubeClient.DiscoveryV1().EndpointSlices(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return endpointSlice
+	}
+
+	Context("with the SecondaryNetworkEndpointSlices feature gate", func() {
+		BeforeEach(func() {
+			newController(virtconfig.SecondaryNetworkEndpointSlicesGate)
+		})
+
+		It("should create an EndpointSlice per IP family with the secondary network addresses", func() {
+			service := newService()
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10", "fe80::1", "fd10::10"))).To(Succeed())
+
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			ipv4Slice := getEndpointSlice("svc-kubevirt-ipv4")
+			Expect(ipv4Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+			Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, "svc"))
+			Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelManagedBy, ManagedBy))
+			Expect(ipv4Slice.OwnerReferences).To(HaveLen(1))
+			Expect(ipv4Slice.OwnerReferences[0].UID).To(Equal(service.UID))
+			Expect(ipv4Slice.Endpoints).To(HaveLen(1))
+			Expect(ipv4Slice.Endpoints[0].Addresses).To(Equal([]string{"192.168.1.10"}))
+			Expect(*ipv4Slice.Endpoints[0].Conditions.Ready).To(BeTrue())
+			Expect(*ipv4Slice.Endpoints[0].NodeName).To(Equal("node01"))
+			Expect(ipv4Slice.Endpoints[0].TargetRef.Name).To(Equal("vmi1"))
+			Expect(ipv4Slice.Ports).To(HaveLen(1))
+			Expect(*ipv4Slice.Ports[0].Port).To(Equal(int32(8080)))
+
+			ipv6Slice := getEndpointSlice("svc-kubevirt-ipv6")
+			Expect(ipv6Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv6))
+			Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+			Expect(ipv6Slice.Endpoints[0].Addresses).To(Equal([]string{"fd10::10"}))
+		})
+
+		It("should mark endpoints of a VMI which is not ready as not ready", func() {
+			Expect(serviceInformer.GetStore().Add(newService())).To(Succeed())
+			vmi := newVMI("vmi1", "192.168.1.10")
+			vmi.Status.Conditions = nil
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			endpointSlice := getEndpointSlice("svc-kubevirt-ipv4")
+			Expect(endpointSlice.Endpoints).To(HaveLen(1))
+			Expect(*endpointSlice.Endpoints[0].Conditions.Ready).To(BeFalse())
+		})
+
+		It("should not select VMIs which do not match the selector", func() {
+			Expect(serviceInformer.GetStore().Add(newService())).To(Succeed())
+			vmi := newVMI("vmi1", "192.168.1.10")
+			vmi.Labels = map[string]string{"app": "db"}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			Expect(getEndpointSlice("svc-kubevirt-ipv4").Endpoints).To(BeEmpty())
+		})
+
+		It("should update an existing EndpointSlice when the guest addresses change", func() {
+			Expect(serviceInformer.GetStore().Add(newService())).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10"))).To(Succeed())
+			Expect(controller.execute("default/svc")).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv4"))).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv6"))).To(Succeed())
+
+			Expect(vmiInformer.GetStore().Update(newVMI("vmi1", "192.168.1.20"))).To(Succeed())
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			endpointSlice := getEndpointSlice("svc-kubevirt-ipv4")
+			Expect(endpointSlice.Endpoints).To(HaveLen(1))
+			Expect(endpointSlice.Endpoints[0].Addresses).To(Equal([]string{"192.168.1.20"}))
+		})
+
+		It("should delete the EndpointSlice of an IP family removed from the service", func() {
+			service := newService()
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10"))).To(Succeed())
+			Expect(controller.execute("default/svc")).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv4"))).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv6"))).To(Succeed())
+
+			service.Spec.IPFamilies = []k8sv1.IPFamily{k8sv1.IPv4Protocol}
+			Expect(serviceInformer.GetStore().Update(service)).To(Succeed())
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			_, err := kubeClient.DiscoveryV1().EndpointSlices(namespace).Get(context.Background(), "svc-kubevirt-ipv6", metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			getEndpointSlice("svc-kubevirt-ipv4")
+		})
+
+		DescribeTable("should delete the managed EndpointSlices of a service which no longer opts in", func(optOut func(service *k8sv1.Service)) {
+			service := newService()
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10"))).To(Succeed())
+			Expect(controller.execute("default/svc")).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv4"))).To(Succeed())
+			Expect(endpointSliceInformer.GetStore().Add(getEndpointSlice("svc-kubevirt-ipv6"))).To(Succeed())
+			foreignSlice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-foreign",
+				Namespace: namespace,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc", v1.EndpointsNetworkLabel: networkName},
+			}}
+			_, err := kubeClient.DiscoveryV1().EndpointSlices(namespace).Create(context.Background(), foreignSlice, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpointSliceInformer.GetStore().Add(foreignSlice)).To(Succeed())
+
+			optOut(service)
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			endpointSlices, err := kubeClient.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpointSlices.Items).To(HaveLen(1))
+			Expect(endpointSlices.Items[0].Name).To(Equal("svc-foreign"))
+		},
+			Entry("when the network label is removed", func(service *k8sv1.Service) {
+				// the informer only watches services with the network label
+				Expect(serviceInformer.GetStore().Delete(service)).To(Succeed())
+			}),
+			Entry("when a pod selector is added", func(service *k8sv1.Service) {
+				service.Spec.Selector = map[string]string{"app": "web"}
+				Expect(serviceInformer.GetStore().Update(service)).To(Succeed())
+			}),
+		)
+
+		It("should ignore services with a pod selector", func() {
+			service := newService()
+			service.Spec.Selector = map[string]string{"app": "web"}
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10"))).To(Succeed())
+
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			Expect(kubeClient.Actions()).To(BeEmpty())
+		})
+
+		It("should emit an event when the VMI selector is invalid", func() {
+			service := newService()
+			service.Annotations[v1.EndpointsVMISelectorAnnotation] = "app in (web"
+			Expect(serviceInformer.GetStore().Add(service)).To(Succeed())
+
+			Expect(controller.execute("default/svc")).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring(InvalidSelectorReason)))
+			Expect(kubeClient.Actions()).To(BeEmpty())
+		})
+	})
+
+	It("should not create EndpointSlices when the feature gate is disabled", func() {
+		newController()
+		Expect(serviceInformer.GetStore().Add(newService())).To(Succeed())
+		Expect(vmiInformer.GetStore().Add(newVMI("vmi1", "192.168.1.10"))).To(Succeed())
+
+		Expect(controller.execute("default/svc")).To(Succeed())
+
+		Expect(kubeClient.Actions()).To(BeEmpty())
+	})
+})
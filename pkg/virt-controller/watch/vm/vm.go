@@ -27,6 +27,7 @@ import (
 	"maps"
 	"math"
 	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -535,9 +536,13 @@ func removeMemoryDumpVolumeFromVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpe
 	return vmiSpec
 }
 
-func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, claimName string) *virtv1.VirtualMachineInstanceSpec {
-	for _, volume := range vmiSpec.Volumes {
+func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanceSpec, claimName string, format virtv1.MemoryDumpFormat) *virtv1.VirtualMachineInstanceSpec {
+	for i, volume := range vmiSpec.Volumes {
 		if volume.Name == claimName {
+			// The volume is kept between dumps, the format of the current request applies
+			if volume.MemoryDump != nil {
+				vmiSpec.Volumes[i].MemoryDump.Format = format
+			}
 			return vmiSpec
 		}
 	}
@@ -549,6 +554,7 @@ func applyMemoryDumpVolumeRequestOnVMISpec(vmiSpec *virtv1.VirtualMachineInstanc
 			},
 			Hotpluggable: true,
 		},
+		Format: format,
 	}
 
 	newVolume := virtv1.Volume{
@@ -579,7 +585,7 @@ func (c *Controller) generateVMIMemoryDumpVolumePatch(vmi *virtv1.VirtualMachine
 
 	vmiCopy := vmi.DeepCopy()
 	if addVolume {
-		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request.ClaimName)
+		vmiCopy.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vmiCopy.Spec, request.ClaimName, request.Format)
 	} else {
 		vmiCopy.Spec = *removeMemoryDumpVolumeFromVMISpec(&vmiCopy.Spec, request.ClaimName)
 	}
@@ -684,6 +690,19 @@ func (c *Controller) VMICPUsPatch(vm *virtv1.VirtualMachine, vmi *virtv1.Virtual
 	return err
 }
 
+// vcpuHotUnplugUnsupportedReason returns why the guest vCPUs of the VMI cannot be unplugged live
+func vcpuHotUnplugUnsupportedReason(vmi *virtv1.VirtualMachineInstance) string {
+	switch {
+	case vmi.Spec.Architecture == "arm64":
+		return "on arm64"
+	case vmi.IsCPUDedicated():
+		return "when dedicated CPUs are used"
+	case vmi.IsRealtimeEnabled():
+		return "when realtime is enabled"
+	}
+	return ""
+}
+
 func (c *Controller) handleCPUChangeRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if vmi == nil || vmi.DeletionTimestamp != nil {
 		return nil
@@ -720,8 +739,10 @@ func (c *Controller) handleCPUChangeRequest(vm *virtv1.VirtualMachine, vmi *virt
 	}
 
 	if vmCopyWithInstancetype.Spec.Template.Spec.Domain.CPU.Sockets < vmi.Spec.Domain.CPU.Sockets {
-		setRestartRequired(vm, "Reduction of CPU socket count requires a restart")
-		return nil
+		if reason := vcpuHotUnplugUnsupportedReason(vmi); reason != "" {
+			setRestartRequired(vm, fmt.Sprintf("Reduction of CPU socket count requires a restart %s", reason))
+			return nil
+		}
 	}
 
 	networkInterfaceMultiQueue := vmCopyWithInstancetype.Spec.Template.Spec.Domain.Devices.NetworkInterfaceMultiQueue
@@ -890,7 +911,7 @@ func (c *Controller) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *vir
 		// When in state associating we want to add the memory dump pvc
 		// as a volume in the vm and in the vmi to trigger the mount
 		// to virt launcher and the memory dump
-		vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest.ClaimName)
+		vm.Spec.Template.Spec = *applyMemoryDumpVolumeRequestOnVMISpec(&vm.Spec.Template.Spec, vm.Status.MemoryDumpRequest.ClaimName, vm.Status.MemoryDumpRequest.Format)
 		if _, exists := vmiVolumeMap[vm.Status.MemoryDumpRequest.ClaimName]; exists {
 			return nil
 		}
@@ -2583,6 +2604,8 @@ func (c *Controller) updateStatus(vm, vmOrig *virtv1.VirtualMachine, vmi *virtv1
 
 	c.trimDoneVolumeRequests(vm)
 	c.updateMemoryDumpRequest(vm, vmi)
+	c.syncHotUnplugStatus(vm, vmi)
+	requestGuestPanicMemoryDump(vm, vmi)
 
 	if c.isTrimFirstChangeRequestNeeded(vm, vmi) {
 		popStateChangeRequest(vm)
@@ -3025,6 +3048,118 @@ func (c *Controller) updateMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *vir
 	vm.Status.MemoryDumpRequest = updatedMemoryDumpReq
 }
 
+// syncHotUnplugStatus reports the progress of vCPUs and memory the guest is releasing and
+// falls back to requiring a restart once the guest did not release them within the timeout.
+func (c *Controller) syncHotUnplugStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if vmi == nil || !vmi.IsRunning() {
+		vm.Status.HotUnplug = nil
+		return
+	}
+
+	var previous virtv1.VirtualMachineHotUnplugStatus
+	if vm.Status.HotUnplug != nil {
+		previous = *vm.Status.HotUnplug
+	}
+
+	status := &virtv1.VirtualMachineHotUnplugStatus{}
+	if vmi.Spec.Domain.CPU != nil && vmi.Status.GuestCurrentVCPUs != nil {
+		requested := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU)
+		current := int64(*vmi.Status.GuestCurrentVCPUs)
+		if current > requested {
+			status.CPU = hotUnplugProgress(previous.CPU, *resource.NewQuantity(requested, resource.DecimalSI), *resource.NewQuantity(current, resource.DecimalSI))
+		}
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil &&
+		vmi.Status.Memory != nil && vmi.Status.Memory.GuestCurrent != nil &&
+		vmi.Status.Memory.GuestCurrent.Cmp(*vmi.Spec.Domain.Memory.Guest) > 0 {
+		status.Memory = hotUnplugProgress(previous.Memory, *vmi.Spec.Domain.Memory.Guest, *vmi.Status.Memory.GuestCurrent)
+	}
+
+	if status.CPU == nil && status.Memory == nil {
+		vm.Status.HotUnplug = nil
+		return
+	}
+	vm.Status.HotUnplug = status
+
+	if controller.NewVirtualMachineConditionManager().HasCondition(vm, virtv1.VirtualMachineRestartRequired) {
+		return
+	}
+	timeout := c.clusterConfig.GetHotUnplugTimeout()
+	var timeLeft []time.Duration
+	if status.CPU != nil {
+		if left := timeout - time.Since(status.CPU.StartTimestamp.Time); left > 0 {
+			timeLeft = append(timeLeft, left)
+		} else {
+			setRestartRequired(vm, fmt.Sprintf("vCPU hot-unplug did not complete within %s, the guest still uses %s vCPUs", timeout, status.CPU.Current.String()))
+		}
+	}
+	if status.Memory != nil {
+		if left := timeout - time.Since(status.Memory.StartTimestamp.Time); left > 0 {
+			timeLeft = append(timeLeft, left)
+		} else {
+			setRestartRequired(vm, fmt.Sprintf("memory hot-unplug did not complete within %s, the guest still uses %s", timeout, status.Memory.Current.String()))
+		}
+	}
+
+	// The guest may not report any progress anymore, so requeue the VM to
+	// apply the restart fallback without depending on another event.
+	if len(timeLeft) > 0 && !controller.NewVirtualMachineConditionManager().HasCondition(vm, virtv1.VirtualMachineRestartRequired) {
+		key, err := controller.KeyFunc(vm)
+		if err != nil {
+			log.Log.Object(vm).Reason(err).Error("Failed to requeue the VM for the hot-unplug timeout")
+			return
+		}
+		c.Queue.AddAfter(key, slices.Min(timeLeft))
+	}
+}
+
+func hotUnplugProgress(previous *virtv1.HotUnplugProgress, requested, current resource.Quantity) *virtv1.HotUnplugProgress {
+	startTimestamp := metav1.Now()
+	if previous != nil && previous.Requested.Equal(requested) {
+		startTimestamp = previous.StartTimestamp
+	}
+	return &virtv1.HotUnplugProgress{
+		Requested:      requested,
+		Current:        current,
+		StartTimestamp: startTimestamp,
+	}
+}
+
+// requestGuestPanicMemoryDump issues a memory dump request to the claim
+// configured on the panic device once the guest panicked, unless a dump was
+// already taken for this panic.
+func requestGuestPanicMemoryDump(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if vmi == nil {
+		return
+	}
+	panicDevice := vmi.Spec.Domain.Devices.Panic
+	if panicDevice == nil || panicDevice.Action != virtv1.PanicActionDump || panicDevice.DumpClaimName == "" {
+		return
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	cond := condManager.GetCondition(vmi, virtv1.VirtualMachineInstanceGuestPanicked)
+	if cond == nil || cond.Status != k8score.ConditionTrue {
+		return
+	}
+
+	if request := vm.Status.MemoryDumpRequest; request != nil {
+		switch request.Phase {
+		case virtv1.MemoryDumpCompleted, virtv1.MemoryDumpFailed:
+			if request.EndTimestamp != nil && !request.EndTimestamp.Before(&cond.LastTransitionTime) {
+				return
+			}
+		default:
+			return
+		}
+	}
+
+	log.Log.Object(vm).Infof("Guest panicked, requesting memory dump to claim %s", panicDevice.DumpClaimName)
+	vm.Status.MemoryDumpRequest = &virtv1.VirtualMachineMemoryDumpRequest{
+		ClaimName: panicDevice.DumpClaimName,
+		Phase:     virtv1.MemoryDumpAssociating,
+	}
+}
+
 func (c *Controller) trimDoneVolumeRequests(vm *virtv1.VirtualMachine) {
 	if len(vm.Status.VolumeRequests) == 0 {
 		return
@@ -3263,6 +3263,88 @@ var _ = Describe("VirtualMachine", func() {
 				Expect(vm.Spec.Template.Spec.Volumes[0].Name).To(Equal(testPVCName))
 			})
 
+			It("should pass the requested format to the memory dump volumes", func() {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+					ClaimName: testPVCName,
+					Phase:     v1.MemoryDumpAssociating,
+					Format:    v1.MemoryDumpFormatKdumpZlib,
+				}
+				// The volume of a previous dump is kept in the template
+				vm.Spec.Template.Spec = *applyVMIMemoryDumpVol(&vm.Spec.Template.Spec)
+
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				watchtesting.MarkAsReady(vmi)
+				vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				controller.vmiIndexer.Add(vmi)
+
+				sanityExecute(vm)
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(vm.Spec.Template.Spec.Volumes[0].MemoryDump.Format).To(Equal(v1.MemoryDumpFormatKdumpZlib))
+
+				vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(vmi.Spec.Volumes).To(HaveLen(1))
+				Expect(vmi.Spec.Volumes[0].MemoryDump.Format).To(Equal(v1.MemoryDumpFormatKdumpZlib))
+			})
+
+			DescribeTable("when the guest panicked with the dump panic action", func(existingRequest *v1.VirtualMachineMemoryDumpRequest, expectNewRequest bool) {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				vm.Status.MemoryDumpRequest = existingRequest
+				vm.Spec.Template.Spec.Domain.Devices.Panic = &v1.PanicDevice{
+					Action:        v1.PanicActionDump,
+					DumpClaimName: testPVCName,
+				}
+
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				vmi.Spec = vm.Spec.Template.Spec
+				watchtesting.MarkAsReady(vmi)
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:               v1.VirtualMachineInstanceGuestPanicked,
+					Status:             k8sv1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+				})
+				controller.vmiIndexer.Add(vmi)
+
+				sanityExecute(vm)
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				if expectNewRequest {
+					Expect(vm.Status.MemoryDumpRequest).To(Equal(&v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: testPVCName,
+						Phase:     v1.MemoryDumpAssociating,
+					}))
+				} else {
+					Expect(vm.Status.MemoryDumpRequest).To(Equal(existingRequest))
+				}
+			},
+				Entry("should request a memory dump", nil, true),
+				Entry("should request a memory dump if the previous dump completed before the panic", &v1.VirtualMachineMemoryDumpRequest{
+					ClaimName:    testPVCName,
+					Phase:        v1.MemoryDumpCompleted,
+					EndTimestamp: pointer.P(metav1.NewTime(time.Now().Add(-time.Hour))),
+				}, true),
+				Entry("should not request another memory dump for the same panic", &v1.VirtualMachineMemoryDumpRequest{
+					ClaimName:    testPVCName,
+					Phase:        v1.MemoryDumpCompleted,
+					EndTimestamp: pointer.P(metav1.NewTime(time.Now().Add(time.Hour))),
+				}, false),
+			)
+
 			It("should update memory dump phase to InProgress when memory dump in vm volumes", func() {
 				vm, vmi := watchtesting.DefaultVirtualMachine(true)
 				vm.Status.Created = true
@@ -6076,6 +6158,169 @@ var _ = Describe("VirtualMachine", func() {
 						"Status":  Equal(k8sv1.ConditionTrue),
 					}))
 				})
+
+				It("should patch VMI when a reduction of CPU sockets is requested", func() {
+					resources := v1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU: resource.MustParse("300m"),
+						},
+					}
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Spec.Template.Spec.Domain.Resources = resources
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+						Sockets: 1,
+					}
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					vmi.Spec.Domain.CPU = &v1.CPU{
+						Sockets:    3,
+						MaxSockets: 4,
+					}
+					vmi.Spec.Domain.Resources = resources
+
+					vmi, err := virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(controller.handleCPUChangeRequest(vm, vmi)).To(Succeed())
+
+					updatedVMI, err := virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updatedVMI.Spec.Domain.CPU.Sockets).To(Equal(uint32(1)))
+					Expect(updatedVMI.Spec.Domain.Resources.Requests.Cpu().Cmp(*resources.Requests.Cpu())).To(Equal(-1))
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(vm, v1.VirtualMachineRestartRequired)).To(BeFalse())
+				})
+
+				DescribeTable("should set a restartRequired condition if vCPUs cannot be unplugged", func(updateVMI func(*v1.VirtualMachineInstance), expectedMessage string) {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+						Sockets: 1,
+					}
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					vmi.Spec.Domain.CPU = &v1.CPU{
+						Sockets:    2,
+						MaxSockets: 4,
+					}
+					updateVMI(vmi)
+
+					Expect(controller.handleCPUChangeRequest(vm, vmi)).To(Succeed())
+
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(vm, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Message).To(ContainSubstring(expectedMessage))
+				},
+					Entry("on arm64", func(vmi *v1.VirtualMachineInstance) {
+						vmi.Spec.Architecture = "arm64"
+					}, "on arm64"),
+					Entry("with dedicated CPUs", func(vmi *v1.VirtualMachineInstance) {
+						vmi.Spec.Domain.CPU.DedicatedCPUPlacement = true
+					}, "when dedicated CPUs are used"),
+				)
+			})
+
+			Context("Hot-unplug status", func() {
+				newRunningVMI := func(sockets, guestCurrentVCPUs uint32, guest, guestCurrent string) *v1.VirtualMachineInstance {
+					vmi := api.NewMinimalVMI("testvmi")
+					vmi.Status.Phase = v1.Running
+					vmi.Spec.Domain.CPU = &v1.CPU{Sockets: sockets, Cores: 1, Threads: 1}
+					vmi.Status.GuestCurrentVCPUs = pointer.P(guestCurrentVCPUs)
+					vmi.Spec.Domain.Memory = &v1.Memory{Guest: pointer.P(resource.MustParse(guest))}
+					vmi.Status.Memory = &v1.MemoryStatus{GuestCurrent: pointer.P(resource.MustParse(guestCurrent))}
+					return vmi
+				}
+
+				It("should report the progress of vCPUs and memory being released by the guest", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vmi := newRunningVMI(2, 4, "1Gi", "2Gi")
+
+					controller.syncHotUnplugStatus(vm, vmi)
+
+					Expect(vm.Status.HotUnplug).ToNot(BeNil())
+					Expect(vm.Status.HotUnplug.CPU).ToNot(BeNil())
+					Expect(vm.Status.HotUnplug.CPU.Requested.Value()).To(Equal(int64(2)))
+					Expect(vm.Status.HotUnplug.CPU.Current.Value()).To(Equal(int64(4)))
+					Expect(vm.Status.HotUnplug.Memory).ToNot(BeNil())
+					Expect(vm.Status.HotUnplug.Memory.Requested).To(Equal(resource.MustParse("1Gi")))
+					Expect(vm.Status.HotUnplug.Memory.Current).To(Equal(resource.MustParse("2Gi")))
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(vm, v1.VirtualMachineRestartRequired)).To(BeFalse())
+				})
+
+				It("should clear the progress once the guest released the resources", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Status.HotUnplug = &v1.VirtualMachineHotUnplugStatus{
+						CPU: &v1.HotUnplugProgress{StartTimestamp: metav1.Now()},
+					}
+					vmi := newRunningVMI(2, 2, "1Gi", "1Gi")
+
+					controller.syncHotUnplugStatus(vm, vmi)
+
+					Expect(vm.Status.HotUnplug).To(BeNil())
+				})
+
+				It("should keep the start timestamp while the hot-unplug is in progress", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					startTimestamp := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+					vm.Status.HotUnplug = &v1.VirtualMachineHotUnplugStatus{
+						CPU: &v1.HotUnplugProgress{
+							Requested:      *resource.NewQuantity(2, resource.DecimalSI),
+							Current:        *resource.NewQuantity(4, resource.DecimalSI),
+							StartTimestamp: startTimestamp,
+						},
+					}
+					vmi := newRunningVMI(2, 3, "1Gi", "1Gi")
+
+					controller.syncHotUnplugStatus(vm, vmi)
+
+					Expect(vm.Status.HotUnplug.CPU.StartTimestamp).To(Equal(startTimestamp))
+					Expect(vm.Status.HotUnplug.CPU.Current.Value()).To(Equal(int64(3)))
+				})
+
+				It("should set a restartRequired condition when the hot-unplug times out", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Status.HotUnplug = &v1.VirtualMachineHotUnplugStatus{
+						Memory: &v1.HotUnplugProgress{
+							Requested:      resource.MustParse("1Gi"),
+							Current:        resource.MustParse("2Gi"),
+							StartTimestamp: metav1.NewTime(time.Now().Add(-virtconfig.DefaultHotUnplugTimeout - time.Minute)),
+						},
+					}
+					vmi := newRunningVMI(2, 2, "1Gi", "2Gi")
+
+					controller.syncHotUnplugStatus(vm, vmi)
+
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(vm, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Message).To(ContainSubstring("memory hot-unplug did not complete"))
+				})
+
+				It("should requeue the VM to set a restartRequired condition without another event", func() {
+					testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
+						Spec: v1.KubeVirtSpec{
+							Configuration: v1.KubeVirtConfiguration{
+								LiveUpdateConfiguration: &v1.LiveUpdateConfiguration{
+									HotUnplugTimeout: &metav1.Duration{Duration: 100 * time.Millisecond},
+								},
+							},
+						},
+					})
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vmi := newRunningVMI(2, 4, "1Gi", "1Gi")
+
+					controller.syncHotUnplugStatus(vm, vmi)
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(vm, v1.VirtualMachineRestartRequired)).To(BeFalse())
+					Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+
+					By("waiting for the VM to be requeued once the timeout expired")
+					Eventually(mockQueue.Len).WithTimeout(time.Second).Should(Equal(1))
+					key, _ := mockQueue.Get()
+					Expect(key).To(Equal(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name)))
+					mockQueue.Done(key)
+
+					controller.syncHotUnplugStatus(vm, vmi)
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(vm, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Message).To(ContainSubstring("vCPU hot-unplug did not complete"))
+				})
 			})
 
 			Context("Memory", func() {
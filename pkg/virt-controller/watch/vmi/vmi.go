@@ -53,6 +53,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
 	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
+	"kubevirt.io/kubevirt/pkg/network/ipam"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
@@ -83,6 +84,7 @@ func NewController(templateService services.TemplateService,
 	topologyHinter topology.Hinter,
 	netAnnotationsGenerator annotationsGenerator,
 	netStatusUpdater statusUpdater,
+	kubevirtNamespace string,
 ) (*Controller, error) {
 
 	c := &Controller{
@@ -106,6 +108,7 @@ func NewController(templateService services.TemplateService,
 		backendStorage:          backendstorage.NewBackendStorage(clientset, clusterConfig, storageClassInformer.GetStore(), storageProfileInformer.GetStore(), pvcInformer.GetIndexer()),
 		netAnnotationsGenerator: netAnnotationsGenerator,
 		updateNetworkStatus:     netStatusUpdater,
+		dhcpServerIPAM:          ipam.NewAllocator(clientset, kubevirtNamespace),
 	}
 
 	c.hasSynced = func() bool {
@@ -175,6 +178,11 @@ type annotationsGenerator interface {
 
 type statusUpdater func(clusterConfig *virtconfig.ClusterConfig, vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error
 
+type ipAllocator interface {
+	Allocate(vmi *virtv1.VirtualMachineInstance) error
+	Release(vmi *virtv1.VirtualMachineInstance) error
+}
+
 type Controller struct {
 	templateService         services.TemplateService
 	clientset               kubecli.KubevirtClient
@@ -197,6 +205,7 @@ type Controller struct {
 	hasSynced               func() bool
 	netAnnotationsGenerator annotationsGenerator
 	updateNetworkStatus     statusUpdater
+	dhcpServerIPAM          ipAllocator
 }
 
 func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
@@ -331,6 +340,7 @@ func (c *Controller) syncDynamicLabelsToPod(vmi *virtv1.VirtualMachineInstance,
 	dynamicLabels := []string{
 		virtv1.NodeNameLabel,
 		virtv1.OutdatedLauncherImageLabel,
+		virtv1.GuestAgentConnectedLabel,
 	}
 
 	podMeta := pod.ObjectMeta.DeepCopy()
@@ -428,6 +438,26 @@ func (c *Controller) setLauncherContainerInfo(vmi *virtv1.VirtualMachineInstance
 
 }
 
+// syncGuestAgentConnectedLabel reflects the AgentConnected condition in a label when the
+// GuestAgentConnectedLabel feature gate is enabled, so that Services can select only the VMIs
+// with a connected guest agent. The labels are only touched when the value changes.
+func (c *Controller) syncGuestAgentConnectedLabel(vmi *virtv1.VirtualMachineInstance) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	wantLabel := c.clusterConfig.GuestAgentConnectedLabelEnabled() &&
+		conditionManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue)
+	value, hasLabel := vmi.Labels[virtv1.GuestAgentConnectedLabel]
+
+	switch {
+	case wantLabel && value != "true":
+		if vmi.Labels == nil {
+			vmi.Labels = map[string]string{}
+		}
+		vmi.Labels[virtv1.GuestAgentConnectedLabel] = "true"
+	case !wantLabel && hasLabel:
+		delete(vmi.Labels, virtv1.GuestAgentConnectedLabel)
+	}
+}
+
 func (c *Controller) hasOwnerVM(vmi *virtv1.VirtualMachineInstance) bool {
 	controllerRef := v1.GetControllerOf(vmi)
 	if controllerRef == nil || controllerRef.Kind != virtv1.VirtualMachineGroupVersionKind.Kind {
@@ -476,6 +506,7 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 			}
 		}
 		vmiCopy = c.setLauncherContainerInfo(vmiCopy, foundImage)
+		c.syncGuestAgentConnectedLabel(vmiCopy)
 
 		if err := c.syncPausedConditionToPod(vmiCopy, pod); err != nil {
 			return fmt.Errorf("error syncing paused condition to pod: %v", err)
@@ -615,6 +646,11 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 						return err
 					}
 				}
+
+				// Allocate the addresses served by the in-pod DHCP server before virt-handler sets up the network.
+				if err := c.dhcpServerIPAM.Allocate(vmiCopy); err != nil {
+					return err
+				}
 			} else if controller.IsPodDownOrGoingDown(pod) {
 				vmiCopy.Status.Phase = virtv1.Failed
 			}
@@ -629,6 +665,12 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 		}
 
 		if allDeleted {
+			// The finalizer is removed only once the addresses are released, so they are not leaked
+			if controller.HasFinalizer(vmi, virtv1.VirtualMachineInstanceFinalizer) {
+				if err := c.dhcpServerIPAM.Release(vmi); err != nil {
+					return err
+				}
+			}
 			log.Log.V(3).Object(vmi).Infof("All pods have been deleted, removing finalizer")
 			controller.RemoveFinalizer(vmiCopy, virtv1.VirtualMachineInstanceFinalizer)
 			if vmiCopy.Labels != nil {
@@ -2186,7 +2228,8 @@ func (c *Controller) requireCPUHotplug(vmi *virtv1.VirtualMachineInstance) bool
 		Threads: vmi.Status.CurrentCPUTopology.Threads,
 	}
 
-	return hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU) != hardware.GetNumberOfVCPUs(cpuTopoLogyFromStatus)
+	// A reduction is unplugged in place by virt-handler and does not need a new pod
+	return hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU) > hardware.GetNumberOfVCPUs(cpuTopoLogyFromStatus)
 }
 
 func (c *Controller) requireMemoryHotplug(vmi *virtv1.VirtualMachineInstance) bool {
@@ -2197,7 +2240,8 @@ func (c *Controller) requireMemoryHotplug(vmi *virtv1.VirtualMachineInstance) bo
 		return false
 	}
 
-	return vmi.Spec.Domain.Memory.Guest.Value() != vmi.Status.Memory.GuestRequested.Value()
+	// A reduction is unplugged in place by virt-handler and does not need a new pod
+	return vmi.Spec.Domain.Memory.Guest.Value() > vmi.Status.Memory.GuestRequested.Value()
 }
 
 func (c *Controller) syncMemoryHotplug(vmi *virtv1.VirtualMachineInstance) {
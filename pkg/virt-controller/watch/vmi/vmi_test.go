@@ -51,6 +51,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	kvcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/ipam"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -64,6 +65,8 @@ import (
 )
 
 var _ = Describe("VirtualMachineInstance watcher", func() {
+	const kubevirtNamespace = "kubevirt"
+
 	var config *virtconfig.ClusterConfig
 
 	var controller *Controller
@@ -228,6 +231,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, config),
 			stubNetworkAnnotationsGenerator{},
 			stubNetStatusUpdate,
+			kubevirtNamespace,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -1631,6 +1635,54 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			Expect(updatedVmi.Labels).To(BeEmpty())
 		})
 
+		DescribeTable("should sync the guest agent connected label to the VMI and pod", func(gateEnabled bool, agentStatus k8sv1.ConditionStatus, expectLabel bool) {
+			if gateEnabled {
+				kvCR := testutils.GetFakeKubeVirtClusterConfig(kvStore)
+				kvCR.Spec.Configuration.DeveloperConfiguration = &virtv1.DeveloperConfiguration{
+					FeatureGates: []string{virtconfig.GuestAgentConnectedLabelGate},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvCR)
+			}
+			vmi := newPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionTrue, "")
+			vmi.Status.Conditions = append(vmi.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+				Type:   virtv1.VirtualMachineInstanceAgentConnected,
+				Status: agentStatus,
+			})
+			vmi.Status.Phase = virtv1.Running
+			if !expectLabel {
+				vmi.Labels = map[string]string{virtv1.GuestAgentConnectedLabel: "true"}
+			}
+			pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = append(pod.Status.Conditions, k8sv1.PodCondition{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue})
+			pod.Spec.Containers = append(pod.Spec.Containers, k8sv1.Container{
+				Image: controller.templateService.GetLauncherImage(),
+				Name:  "compute",
+			})
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			addPod(pod)
+
+			controller.Execute()
+
+			updatedVmi, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			updatedPod, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			if expectLabel {
+				Expect(updatedVmi.Labels).To(HaveKeyWithValue(virtv1.GuestAgentConnectedLabel, "true"))
+				Expect(updatedPod.Labels).To(HaveKeyWithValue(virtv1.GuestAgentConnectedLabel, "true"))
+			} else {
+				Expect(updatedVmi.Labels).ToNot(HaveKey(virtv1.GuestAgentConnectedLabel))
+				Expect(updatedPod.Labels).ToNot(HaveKey(virtv1.GuestAgentConnectedLabel))
+			}
+		},
+			Entry("when the agent is connected", true, k8sv1.ConditionTrue, true),
+			Entry("when the agent is disconnected", true, k8sv1.ConditionFalse, false),
+			Entry("when the agent is connected but the feature gate is disabled", false, k8sv1.ConditionTrue, false),
+		)
+
 		It("should add a ready condition if it is present on the pod and the VMI is in running state", func() {
 			vmi := newPendingVirtualMachine("testvmi")
 			vmi.Status.Conditions = nil
@@ -2020,6 +2072,36 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				)
 			})
 
+			It("should not add MemoryChange condition when guest memory is reduced", func() {
+				currentGuestMemory := resource.MustParse("512Mi")
+				requestedGuestMemory := resource.MustParse("256Mi")
+
+				vmi := newPendingVirtualMachine("testvmi")
+				vmi.Status.Phase = virtv1.Running
+				vmi.Status.Memory = &virtv1.MemoryStatus{
+					GuestAtBoot:    &requestedGuestMemory,
+					GuestCurrent:   &currentGuestMemory,
+					GuestRequested: &currentGuestMemory,
+				}
+				vmi.Spec.Domain.Memory = &virtv1.Memory{
+					Guest:    &requestedGuestMemory,
+					MaxGuest: &currentGuestMemory,
+				}
+
+				pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+				addActivePods(vmi, pod.UID, "")
+
+				addVirtualMachine(vmi)
+				addPod(pod)
+
+				controller.Execute()
+				expectVMIWithMatcherConditions(vmi.Namespace, vmi.Name, Not(ContainElement(MatchFields(IgnoreExtras,
+					Fields{
+						"Type": BeEquivalentTo(virtv1.VirtualMachineInstanceMemoryChange),
+					}))),
+				)
+			})
+
 			It("should store guestMemoryOverheadRatio if used during memory hotplug", func() {
 				currentGuestMemory := resource.MustParse("128Mi")
 				requestedGuestMemory := resource.MustParse("512Mi")
@@ -3312,6 +3394,57 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 	})
 
+	Context("DHCP server IPAM", func() {
+		const netName = "blue"
+
+		withDHCPServerNetwork := func(vmi *virtv1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces = []virtv1.Interface{{
+				Name:                   netName,
+				InterfaceBindingMethod: virtv1.InterfaceBindingMethod{Bridge: &virtv1.InterfaceBridge{}},
+			}}
+			vmi.Spec.Networks = []virtv1.Network{{
+				Name: netName,
+				NetworkSource: virtv1.NetworkSource{Multus: &virtv1.MultusNetwork{
+					NetworkName: "isolated",
+					DHCPServer:  &virtv1.DHCPServerIPAM{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"},
+				}},
+			}}
+		}
+
+		It("should allocate the DHCP server addresses when VirtualMachineInstance is scheduled", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, virtv1.GuestNotRunningReason)
+			vmi.Status.Phase = virtv1.Scheduling
+			withDHCPServerNetwork(vmi)
+			pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+			addVirtualMachine(vmi)
+			addPod(pod)
+			controller.Execute()
+
+			expectVMIScheduledState(vmi)
+			updatedVmi, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipam.LeasesFromVMI(updatedVmi)).To(Equal(ipam.Leases{netName: "10.10.0.2/24"}))
+		})
+
+		It("should release the DHCP server addresses when the pods are deleted", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			withDHCPServerNetwork(vmi)
+			Expect(ipam.NewAllocator(kubeClient, kubevirtNamespace).Allocate(vmi)).To(Succeed())
+			vmi.Status.Phase = virtv1.Succeeded
+			vmi.Finalizers = []string{virtv1.VirtualMachineInstanceFinalizer}
+			addVirtualMachine(vmi)
+
+			controller.Execute()
+
+			allocationObjects, err := kubeClient.CoreV1().ConfigMaps(kubevirtNamespace).List(context.Background(), metav1.ListOptions{LabelSelector: ipam.AllocationLabel})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allocationObjects.Items).To(HaveLen(1))
+			Expect(allocationObjects.Items[0].Data).To(BeEmpty())
+		})
+	})
+
 	Context("Aggregating DataVolume conditions", func() {
 
 		dvVolumeSource1 := virtv1.VolumeSource{
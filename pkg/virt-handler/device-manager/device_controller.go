@@ -33,6 +33,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -133,6 +134,7 @@ type DeviceController struct {
 	stop                chan struct{}
 	mdevTypesManager    *MDEVTypesManager
 	clientset           k8scli.CoreV1Interface
+	hostRoot            string
 }
 
 func NewDeviceController(
@@ -158,6 +160,7 @@ func NewDeviceController(
 		virtConfig:       clusterConfig,
 		mdevTypesManager: NewMDEVTypesManager(),
 		clientset:        clientset,
+		hostRoot:         util.HostRootMount,
 	}
 
 	return controller
@@ -190,6 +193,15 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() []Device {
 		}
 	}
 
+	if c.virtConfig.VirtIOGPUEnabled() {
+		renderNode, err := util.FindRenderNode(c.hostRoot)
+		if err != nil {
+			log.Log.V(4).Reason(err).Info("Not exposing a GPU render node")
+		} else {
+			permittedDevices = append(permittedDevices, NewGenericDevicePlugin("render", renderNode, c.maxDevices, c.permissions, true))
+		}
+	}
+
 	if c.virtConfig.PersistentReservationEnabled() {
 		permittedDevices = append(permittedDevices, NewSocketDevicePlugin(reservation.GetPrResourceName(), reservation.GetPrHelperSocketDir(), reservation.GetPrHelperSocket(), c.maxDevices))
 	}
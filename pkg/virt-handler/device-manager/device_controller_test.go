@@ -126,6 +126,35 @@ var _ = Describe("Device Controller", func() {
 			res = deviceController.NodeHasDevice(devicePath)
 			Expect(res).To(BeTrue())
 		})
+
+		DescribeTable("should expose the GPU render node found on the node", func(renderNodes []string, expectedPath string) {
+			clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					FeatureGates: []string{virtconfig.VirtIOGPUGate},
+				},
+			})
+			Expect(os.MkdirAll(path.Join(workDir, "dev", "dri"), 0755)).To(Succeed())
+			for _, renderNode := range renderNodes {
+				Expect(os.WriteFile(path.Join(workDir, "dev", "dri", renderNode), nil, 0644)).To(Succeed())
+			}
+			deviceController := NewDeviceController(host, maxDevices, permissions, nil, clusterConfig, clientTest.CoreV1())
+			deviceController.hostRoot = workDir
+
+			var renderPaths []string
+			for _, device := range deviceController.updatePermittedHostDevicePlugins() {
+				if plugin, ok := device.(*GenericDevicePlugin); ok && plugin.deviceName == "render" {
+					renderPaths = append(renderPaths, plugin.devicePath)
+				}
+			}
+			if expectedPath == "" {
+				Expect(renderPaths).To(BeEmpty())
+			} else {
+				Expect(renderPaths).To(Equal([]string{expectedPath}))
+			}
+		},
+			Entry("with the first render node", []string{"renderD130", "renderD129"}, "/dev/dri/renderD129"),
+			Entry("not without a render node", []string{"card0"}, ""),
+		)
 	})
 
 	Context("Multiple Plugins", func() {
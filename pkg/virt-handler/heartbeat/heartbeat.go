@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,7 @@ type HeartBeat struct {
 	cpuManagerPaths           []string
 	devicePluginPollIntervall time.Duration
 	devicePluginWaitTimeout   time.Duration
+	memoryPressure            atomic.Bool
 }
 
 func NewHeartBeat(clientset k8scli.CoreV1Interface, deviceManager device_manager.DeviceControllerInterface, clusterConfig *virtconfig.ClusterConfig, host string) *HeartBeat {
@@ -142,6 +144,7 @@ func (h *HeartBeat) do() {
 		return
 	}
 	ksmEnabled, ksmEnabledByUs := handleKSM(node, h.clusterConfig)
+	h.memoryPressure.Store(handleMemoryReclaim(node, h.clusterConfig))
 
 	data = []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%s", "%s": "%t", "%s": "%t"}, "annotations": {"%s": %s, "%s": "%t"}}}`,
 		v1.NodeSchedulable, kubevirtSchedulable,
@@ -167,6 +170,12 @@ func (h *HeartBeat) do() {
 	log.DefaultLogger().V(4).Infof("Heartbeat sent")
 }
 
+// MemoryPressure reports whether guest memory should be reclaimed on the node,
+// as measured during the last heartbeat.
+func (h *HeartBeat) MemoryPressure() bool {
+	return h.memoryPressure.Load()
+}
+
 func (h *HeartBeat) isCPUManagerEnabled(cpuManagerPaths []string) bool {
 	var cpuManagerOptions map[string]interface{}
 	cpuManagerPath, err := detectCPUManagerFile(cpuManagerPaths)
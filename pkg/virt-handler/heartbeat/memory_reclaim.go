@@ -0,0 +1,64 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package heartbeat
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"kubevirt.io/client-go/log"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const reclaimFreeMemoryPercentDefault uint32 = 20
+
+// handleMemoryReclaim reports whether guest memory should be reclaimed on the node.
+// This is the case when the memoryReclaimConfiguration selects the node and the available
+// memory dropped below its free memory percentage.
+func handleMemoryReclaim(node *v1.Node, clusterConfig *virtconfig.ClusterConfig) bool {
+	reclaimConfig := clusterConfig.GetMemoryReclaimConfiguration()
+	if reclaimConfig == nil {
+		return false
+	}
+
+	if reclaimConfig.NodeLabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(reclaimConfig.NodeLabelSelector)
+		if err != nil {
+			log.DefaultLogger().Errorf("An error occurred while converting the memory reclaim selector: %s", err)
+			return false
+		}
+		if !selector.Matches(labels.Set(node.ObjectMeta.Labels)) {
+			return false
+		}
+	}
+
+	total, available, err := getTotalAndAvailableMem()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("An error occurred while reading the node memory")
+		return false
+	}
+	freePercent := reclaimFreeMemoryPercentDefault
+	if reclaimConfig.FreeMemoryPercent != nil {
+		freePercent = *reclaimConfig.FreeMemoryPercent
+	}
+
+	return available*100 <= total*uint64(freePercent)
+}
@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package heartbeat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Memory reclaim", func() {
+	var originalMemInfoPath string
+
+	writeMemInfo := func(available int) {
+		path := filepath.Join(GinkgoT().TempDir(), "meminfo")
+		content := fmt.Sprintf("MemTotal:       %d kB\nMemAvailable:   %d kB\n", memTotal, available)
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		memInfoPath = path
+	}
+
+	BeforeEach(func() {
+		originalMemInfoPath = memInfoPath
+	})
+
+	AfterEach(func() {
+		memInfoPath = originalMemInfoPath
+	})
+
+	DescribeTable("should report memory pressure", func(reclaimConfig *kubevirtv1.MemoryReclaimConfiguration, nodeLabels, nodeAnnotations map[string]string, available int, expected bool) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&kubevirtv1.KubeVirtConfiguration{
+			MemoryReclaimConfiguration: reclaimConfig,
+		})
+		node := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mynode",
+				Labels:      nodeLabels,
+				Annotations: nodeAnnotations,
+			},
+		}
+		writeMemInfo(available)
+
+		Expect(handleMemoryReclaim(node, clusterConfig)).To(Equal(expected))
+	},
+		Entry("never without memoryReclaimConfiguration",
+			nil, nil, nil, memAvailablePressure, false),
+		Entry("when the node is low on memory",
+			&kubevirtv1.MemoryReclaimConfiguration{}, nil, nil, memAvailablePressure, true),
+		Entry("not when the node has enough memory",
+			&kubevirtv1.MemoryReclaimConfiguration{}, nil, nil, memAvailableNoPressure, false),
+		Entry("when the node labels match the nodeLabelSelector",
+			&kubevirtv1.MemoryReclaimConfiguration{
+				NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"test_label": "true"}},
+			}, map[string]string{"test_label": "true"}, nil, memAvailablePressure, true),
+		Entry("not when the node labels do not match the nodeLabelSelector",
+			&kubevirtv1.MemoryReclaimConfiguration{
+				NodeLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"test_label": "true"}},
+			}, nil, nil, memAvailablePressure, false),
+		Entry("with the free memory percent of the memoryReclaimConfiguration",
+			&kubevirtv1.MemoryReclaimConfiguration{FreeMemoryPercent: pointer.P(uint32(100))}, nil, nil, memAvailableNoPressure, true),
+		Entry("not with the KSM free percent override",
+			&kubevirtv1.MemoryReclaimConfiguration{}, nil,
+			map[string]string{kubevirtv1.KSMFreePercentOverride: "1.0"}, memAvailableNoPressure, false),
+	)
+})
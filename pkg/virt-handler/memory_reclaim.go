@@ -0,0 +1,112 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virthandler
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const memoryReclaimInterval = 30 * time.Second
+
+// runMemoryReclaim re-enqueues the VMIs which opted into memory reclaim whenever
+// the memory pressure of the node changes, so that their balloon target is updated.
+func (d *VirtualMachineController) runMemoryReclaim(stopCh chan struct{}) {
+	underPressure := false
+	wait.Until(func() {
+		pressure := d.memoryPressure()
+		if pressure == underPressure {
+			return
+		}
+		underPressure = pressure
+		log.Log.Infof("node memory pressure changed to %t, updating balloon targets", pressure)
+		for _, obj := range d.vmiSourceStore.List() {
+			vmi := obj.(*v1.VirtualMachineInstance)
+			if isMemoryReclaimEnabled(vmi) || hasBalloonTarget(vmi) {
+				d.queue.Add(controller.VirtualMachineInstanceKey(vmi))
+			}
+		}
+	}, memoryReclaimInterval, stopCh)
+}
+
+func isMemoryReclaimEnabled(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Reclaim != nil
+}
+
+func hasBalloonTarget(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Status.Memory != nil && vmi.Status.Memory.BalloonTarget != nil
+}
+
+// memoryReclaimFloor returns how much memory is always left to the guest,
+// half of the guest memory unless the VMI asks for something else.
+func memoryReclaimFloor(vmi *v1.VirtualMachineInstance, guestMemory *resource.Quantity) *resource.Quantity {
+	floor := vmi.Spec.Domain.Memory.Reclaim.Floor
+	if floor == nil {
+		return resource.NewQuantity(guestMemory.Value()/2, resource.BinarySI)
+	}
+	if floor.Cmp(*guestMemory) > 0 {
+		return guestMemory
+	}
+	floorCopy := floor.DeepCopy()
+	return &floorCopy
+}
+
+// updateBalloonTarget picks the size the memory balloon should shrink the guest to.
+// Under memory pressure the guest is shrunk down to its floor. Once the pressure is gone
+// the guest first gets all of its memory back before the balloon target is dropped.
+func (d *VirtualMachineController) updateBalloonTarget(vmi *v1.VirtualMachineInstance, domain *api.Domain, syncError error) {
+	if domain == nil || !vmi.IsRunning() || domain.Spec.Memory.Value == 0 {
+		return
+	}
+	guestMemory := parseLibvirtQuantity(int64(domain.Spec.Memory.Value), domain.Spec.Memory.Unit)
+	if guestMemory == nil {
+		return
+	}
+
+	if isMemoryReclaimEnabled(vmi) && d.memoryPressure() {
+		floor := memoryReclaimFloor(vmi, guestMemory)
+		if vmi.Status.Memory == nil {
+			vmi.Status.Memory = &v1.MemoryStatus{}
+		}
+		if !hasBalloonTarget(vmi) || !vmi.Status.Memory.BalloonTarget.Equal(*floor) {
+			log.Log.Object(vmi).Infof("reclaiming guest memory down to %s", floor.String())
+			vmi.Status.Memory.BalloonTarget = floor
+		}
+		return
+	}
+
+	if !hasBalloonTarget(vmi) {
+		return
+	}
+	if vmi.Status.Memory.BalloonTarget.Cmp(*guestMemory) < 0 {
+		log.Log.Object(vmi).Infof("returning reclaimed memory to the guest")
+		vmi.Status.Memory.BalloonTarget = guestMemory
+	} else if syncError == nil {
+		vmi.Status.Memory.BalloonTarget = nil
+	}
+}
@@ -121,6 +121,7 @@ func (n *NodeLabeller) loadDomCapabilities() error {
 
 	n.hostCapabilities.items = usableModels
 	n.SEV = hostDomCapabilities.SEV
+	n.LaunchSecurity = hostDomCapabilities.LaunchSecurity
 
 	return nil
 }
@@ -200,6 +200,22 @@ var _ = Describe("Node-labeller config", func() {
 			Entry("when both SEV and SEV-ES are supported", true, true),
 			Entry("when neither SEV nor SEV-ES are supported", false, false),
 		)
+
+		It("should detect SEV-SNP and TDX from the launch security types", func() {
+			nlController.domCapabilitiesFileName = "virsh_domcapabilities.xml"
+			Expect(nlController.loadDomCapabilities()).To(Succeed())
+
+			Expect(nlController.LaunchSecurity.SupportsType("sev-snp")).To(BeTrue())
+			Expect(nlController.LaunchSecurity.SupportsType("tdx")).To(BeTrue())
+		})
+
+		It("should not detect SEV-SNP nor TDX without launch security types", func() {
+			nlController.domCapabilitiesFileName = "domcapabilities_sev.xml"
+			Expect(nlController.loadDomCapabilities()).To(Succeed())
+
+			Expect(nlController.LaunchSecurity.SupportsType("sev-snp")).To(BeFalse())
+			Expect(nlController.LaunchSecurity.SupportsType("tdx")).To(BeFalse())
+		})
 	})
 
 	It("Make sure proper labels are removed on removeLabellerLabels()", func() {
@@ -18,6 +18,10 @@
 
 package nodelabeller
 
+import (
+	"slices"
+)
+
 type cpuFeatures map[string]bool
 
 type supportedFeatures struct {
@@ -32,8 +36,9 @@ type hostCPUModel struct {
 
 // HostDomCapabilities represents structure for parsing output of virsh capabilities
 type HostDomCapabilities struct {
-	CPU CPU              `xml:"cpu"`
-	SEV SEVConfiguration `xml:"features>sev"`
+	CPU            CPU                        `xml:"cpu"`
+	SEV            SEVConfiguration           `xml:"features>sev"`
+	LaunchSecurity LaunchSecurityCapabilities `xml:"features>launchSecurity"`
 }
 
 // CPU represents slice of cpu modes
@@ -92,3 +97,27 @@ type SEVConfiguration struct {
 	MaxESGuests     uint   `xml:"maxESGuests"`
 	SupportedES     string `xml:"-"`
 }
+
+// LaunchSecurityCapabilities lists the supported launch security types
+type LaunchSecurityCapabilities struct {
+	Supported string `xml:"supported,attr"`
+	Enums     []Enum `xml:"enum"`
+}
+
+type Enum struct {
+	Name   string   `xml:"name,attr"`
+	Values []string `xml:"value"`
+}
+
+// SupportsType checks whether the host can launch guests with the given launch security type
+func (l LaunchSecurityCapabilities) SupportsType(securityType string) bool {
+	if l.Supported != "yes" {
+		return false
+	}
+	for _, enum := range l.Enums {
+		if enum.Name == "sectype" {
+			return slices.Contains(enum.Values, securityType)
+		}
+	}
+	return false
+}
@@ -54,6 +54,8 @@ var nodeLabellerLabels = []string{
 	kubevirtv1.RealtimeLabel,
 	kubevirtv1.SEVLabel,
 	kubevirtv1.SEVESLabel,
+	kubevirtv1.SEVSNPLabel,
+	kubevirtv1.TDXLabel,
 	kubevirtv1.HostModelCPULabel,
 	kubevirtv1.HostModelRequiredFeaturesLabel,
 	kubevirtv1.NodeHostModelIsObsoleteLabel,
@@ -76,6 +78,7 @@ type NodeLabeller struct {
 	cpuCounter              *libvirtxml.CapsHostCPUCounter
 	hostCPUModel            hostCPUModel
 	SEV                     SEVConfiguration
+	LaunchSecurity          LaunchSecurityCapabilities
 	arch                    string
 }
 
@@ -283,6 +286,14 @@ func (n *NodeLabeller) prepareLabels(node *v1.Node, cpuModels []string, cpuFeatu
 		newLabels[kubevirtv1.SEVESLabel] = ""
 	}
 
+	if n.LaunchSecurity.SupportsType(string(kubevirtv1.LaunchSecurityTypeSEVSNP)) {
+		newLabels[kubevirtv1.SEVSNPLabel] = ""
+	}
+
+	if n.LaunchSecurity.SupportsType(string(kubevirtv1.LaunchSecurityTypeTDX)) {
+		newLabels[kubevirtv1.TDXLabel] = ""
+	}
+
 	return newLabels
 }
 
@@ -20,6 +20,7 @@
 package rest
 
 import (
+	"bytes"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -29,7 +30,9 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful/v3"
 	"github.com/mdlayher/vsock"
@@ -41,15 +44,16 @@ import (
 	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/safepath"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 )
 
 type ConsoleHandler struct {
 	podIsolationDetector isolation.PodIsolationDetector
-	serialStopChans      map[types.UID](chan struct{})
+	serialSessions       *consoleSessions
 	vncStopChans         map[types.UID](chan struct{})
-	serialLock           *sync.Mutex
 	vncLock              *sync.Mutex
 	vmiStore             cache.Store
 	usbredir             map[types.UID]UsbredirHandlerVMI
@@ -64,9 +68,8 @@ type UsbredirHandlerVMI struct {
 func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiStore cache.Store, certManager certificate.Manager) *ConsoleHandler {
 	return &ConsoleHandler{
 		podIsolationDetector: podIsolationDetector,
-		serialStopChans:      make(map[types.UID](chan struct{})),
+		serialSessions:       newConsoleSessions(),
 		vncStopChans:         make(map[types.UID](chan struct{})),
-		serialLock:           &sync.Mutex{},
 		vncLock:              &sync.Mutex{},
 		usbredirLock:         &sync.Mutex{},
 		vmiStore:             vmiStore,
@@ -157,6 +160,28 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 	t.stream(vmi, request, response, unixSocketDialer(vmi, unixSocketPath), stopChn)
 }
 
+func (t *ConsoleHandler) SpiceHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiStore)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error(failedRetrieveVMI)
+		response.WriteError(code, err)
+		return
+	}
+	if vmi.Spec.Domain.Devices.Spice == nil {
+		response.WriteError(http.StatusBadRequest, errors.New("VMI doesn't have SPICE enabled"))
+		return
+	}
+	unixSocketPath, err := t.getUnixSocketPath(vmi, "virt-spice")
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for SPICE")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	// A SPICE session opens one connection per channel, so unlike VNC a new connection
+	// must not close the previous ones.
+	t.stream(vmi, request, response, unixSocketDialer(vmi, unixSocketPath), make(chan struct{}))
+}
+
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
 	vmi, code, err := getVMI(request, t.vmiStore)
 	if err != nil {
@@ -170,10 +195,38 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 		response.WriteError(http.StatusBadRequest, err)
 		return
 	}
+	readOnly, err := boolQueryParameter(request, "readOnly")
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	takeOver, err := boolQueryParameter(request, "takeOver")
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if readOnly && takeOver {
+		response.WriteError(http.StatusBadRequest, errors.New("a read-only client can not take over the serial console"))
+		return
+	}
+
+	// The serial console is shared between one read-write client and any number of
+	// read-only clients, refuse a second read-write client before upgrading the connection.
 	uid := vmi.GetUID()
-	stopCh := newStopChan(uid, t.serialLock, t.serialStopChans)
-	defer deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
-	t.stream(vmi, request, response, unixSocketDialer(vmi, unixSocketPath), stopCh)
+	session, client, err := t.serialSessions.attach(uid, unixSocketDialer(vmi, unixSocketPath), readOnly, takeOver)
+	if errors.Is(err, errConsoleInUse) {
+		response.WriteError(http.StatusConflict, err)
+		return
+	} else if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	conn := t.serialSessions.conn(uid, session, client)
+	defer conn.Close()
+	if takeOver {
+		log.Log.Object(vmi).Info("Serial console taken over by a new client")
+	}
+	t.stream(vmi, request, response, func() (net.Conn, error) { return conn, nil }, client.stop)
 }
 
 func (t *ConsoleHandler) VSOCKHandler(request *restful.Request, response *restful.Response) {
@@ -240,6 +293,141 @@ func (t *ConsoleHandler) VSOCKHandler(request *restful.Request, response *restfu
 	}, make(chan struct{})) // It is legitimate and up to the guest-application to accept multiple connections.
 }
 
+// ConsoleLogHandler returns the newest lines of the persisted serial console log of a VMI,
+// read from the directory mounted into its virt-launcher pod.
+func (t *ConsoleHandler) ConsoleLogHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiStore)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error(failedRetrieveVMI)
+		response.WriteError(code, err)
+		return
+	}
+	var since time.Time
+	if sinceTime := request.QueryParameter("sinceTime"); sinceTime != "" {
+		if since, err = time.Parse(time.RFC3339, sinceTime); err != nil {
+			response.WriteError(http.StatusBadRequest, err)
+			return
+		}
+	}
+	tail := consolelog.MaxTailLines
+	if tailLines := request.QueryParameter("tailLines"); tailLines != "" {
+		requested, err := strconv.Atoi(tailLines)
+		if err != nil || requested <= 0 {
+			response.WriteError(http.StatusBadRequest, fmt.Errorf("invalid tailLines parameter %q", tailLines))
+			return
+		}
+		tail = min(requested, consolelog.MaxTailLines)
+	}
+
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod of the VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	root, err := result.MountRoot()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the root of the virt-launcher pod of the VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	serialConsoleLog, err := readConsoleLog(root, since, tail)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to read the persisted serial console log")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	response.WriteEntity(serialConsoleLog)
+}
+
+func readConsoleLog(root *safepath.Path, since time.Time, tail int) (*v1.SerialConsoleLog, error) {
+	files, err := openConsoleLogFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	persisted := consolelog.Open(files)
+	defer persisted.Close()
+
+	var buf bytes.Buffer
+	if err := consolelog.Filter(persisted, since, tail, &buf); err != nil {
+		return nil, err
+	}
+	serialConsoleLog := &v1.SerialConsoleLog{Lines: []string{}}
+	if buf.Len() > 0 {
+		serialConsoleLog.Lines = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+	return serialConsoleLog, nil
+}
+
+// openConsoleLogFiles opens the persisted log files below the root of the virt-launcher pod, from the oldest
+// to the newest. The directory is writable from within the pod, so symlinks and other non-regular files are refused.
+func openConsoleLogFiles(root *safepath.Path) ([]*os.File, error) {
+	dir, err := root.AppendAndResolveWithRelativeRoot(consolelog.MountPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	if err := dir.ExecuteNoFollow(func(safePath string) error {
+		entries, err := os.ReadDir(safePath)
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var files []*os.File
+	for _, name := range consolelog.FileNames(names) {
+		file, err := openConsoleLogFile(dir, name)
+		if errors.Is(err, os.ErrNotExist) {
+			// The log got rotated in the meantime
+			continue
+		} else if err != nil {
+			for _, file := range files {
+				file.Close()
+			}
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func openConsoleLogFile(dir *safepath.Path, name string) (*os.File, error) {
+	path, err := safepath.JoinNoFollow(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	var file *os.File
+	err = path.ExecuteNoFollow(func(safePath string) error {
+		info, err := os.Stat(safePath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("serial console log file %s is not a regular file", name)
+		}
+		file, err = os.Open(safePath)
+		return err
+	})
+	return file, err
+}
+
+func boolQueryParameter(request *restful.Request, name string) (bool, error) {
+	param := request.QueryParameter(name)
+	if param == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s parameter %q: %v", name, param, err)
+	}
+	return value, nil
+}
+
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
 	lock.Lock()
 	defer lock.Unlock()
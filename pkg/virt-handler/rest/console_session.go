@@ -0,0 +1,239 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// consoleClientBufferSize is the number of serial console reads queued for a client
+// before it is considered too slow and disconnected.
+const consoleClientBufferSize = 256
+
+var (
+	errConsoleInUse     = errors.New("the serial console is already in use by a read-write client, attach read-only or take it over")
+	errConsoleTakenOver = errors.New("the serial console was taken over by another client")
+)
+
+// consoleSessions tracks the shared serial console connections of all VMIs
+type consoleSessions struct {
+	lock     sync.Mutex
+	sessions map[types.UID]*consoleSession
+}
+
+// consoleSession multiplexes the serial console of a VMI between one read-write
+// owner and any number of read-only viewers. Everything the guest writes is sent
+// to all clients, only the input of the owner reaches the guest.
+type consoleSession struct {
+	conn    net.Conn
+	owner   *consoleClient
+	clients map[*consoleClient]struct{}
+	// done is closed once the serial console connection is gone
+	done chan struct{}
+}
+
+type consoleClient struct {
+	readOnly bool
+	output   chan []byte
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newConsoleSessions() *consoleSessions {
+	return &consoleSessions{
+		sessions: make(map[types.UID]*consoleSession),
+	}
+}
+
+// attach registers a new client on the console session of the VMI, connecting to the
+// serial console when the VMI has no session yet. A read-write client is refused while
+// another one is attached, unless it takes the console over and disconnects the owner.
+func (s *consoleSessions) attach(uid types.UID, dial func() (net.Conn, error), readOnly, takeOver bool) (*consoleSession, *consoleClient, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	session := s.sessions[uid]
+	if !readOnly && session != nil && session.owner != nil {
+		if !takeOver {
+			return nil, nil, errConsoleInUse
+		}
+		session.owner.disconnect()
+		session.owner = nil
+	}
+
+	if session == nil {
+		conn, err := dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		session = &consoleSession{
+			conn:    conn,
+			clients: make(map[*consoleClient]struct{}),
+			done:    make(chan struct{}),
+		}
+		s.sessions[uid] = session
+		go s.pump(uid, session)
+	}
+
+	client := &consoleClient{
+		readOnly: readOnly,
+		output:   make(chan []byte, consoleClientBufferSize),
+		stop:     make(chan struct{}),
+	}
+	session.clients[client] = struct{}{}
+	if !readOnly {
+		session.owner = client
+	}
+	return session, client, nil
+}
+
+// detach unregisters a client and closes the serial console connection once the last client is gone
+func (s *consoleSessions) detach(uid types.UID, session *consoleSession, client *consoleClient) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(session.clients, client)
+	if session.owner == client {
+		session.owner = nil
+	}
+	if len(session.clients) == 0 {
+		session.conn.Close()
+		if s.sessions[uid] == session {
+			delete(s.sessions, uid)
+		}
+	}
+}
+
+// isOwner returns whether the client is the read-write owner of the session
+func (s *consoleSessions) isOwner(session *consoleSession, client *consoleClient) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return session.owner == client
+}
+
+// pump fans out the serial console output to all clients of the session
+func (s *consoleSessions) pump(uid types.UID, session *consoleSession) {
+	defer func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.sessions[uid] == session {
+			delete(s.sessions, uid)
+		}
+		close(session.done)
+	}()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := session.conn.Read(buf)
+		if n > 0 {
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			s.broadcast(session, data)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *consoleSessions) broadcast(session *consoleSession, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for client := range session.clients {
+		select {
+		case client.output <- data:
+		default:
+			// Do not let a slow client hold back the others
+			client.disconnect()
+		}
+	}
+}
+
+// conn returns the connection of the client to the serial console. Reads return the
+// output of the guest, writes reach the guest only while the client owns the console
+// and are discarded for read-only clients. Closing it detaches the client.
+func (s *consoleSessions) conn(uid types.UID, session *consoleSession, client *consoleClient) net.Conn {
+	return &consoleClientConn{
+		Conn:     session.conn,
+		sessions: s,
+		uid:      uid,
+		session:  session,
+		client:   client,
+	}
+}
+
+type consoleClientConn struct {
+	net.Conn
+	sessions  *consoleSessions
+	uid       types.UID
+	session   *consoleSession
+	client    *consoleClient
+	pending   []byte
+	closeOnce sync.Once
+}
+
+func (c *consoleClientConn) Read(p []byte) (int, error) {
+	if len(c.pending) == 0 {
+		select {
+		case c.pending = <-c.client.output:
+		case <-c.client.stop:
+			return 0, io.EOF
+		case <-c.session.done:
+			// Hand out what the guest wrote before the connection was gone
+			select {
+			case c.pending = <-c.client.output:
+			default:
+				return 0, io.EOF
+			}
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (c *consoleClientConn) Write(p []byte) (int, error) {
+	if c.client.readOnly {
+		return len(p), nil
+	}
+	if !c.sessions.isOwner(c.session, c.client) {
+		return 0, errConsoleTakenOver
+	}
+	return c.session.conn.Write(p)
+}
+
+func (c *consoleClientConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.client.disconnect()
+		c.sessions.detach(c.uid, c.session, c.client)
+	})
+	return nil
+}
+
+func (c *consoleClient) disconnect() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"io"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Serial console sessions", func() {
+	const uid = types.UID("1234")
+
+	var (
+		sessions *consoleSessions
+		guest    net.Conn
+		dials    int
+	)
+
+	dial := func() (net.Conn, error) {
+		dials++
+		var serial net.Conn
+		serial, guest = net.Pipe()
+		return serial, nil
+	}
+
+	attach := func(readOnly, takeOver bool) (net.Conn, *consoleClient) {
+		session, client, err := sessions.attach(uid, dial, readOnly, takeOver)
+		Expect(err).ToNot(HaveOccurred())
+		return sessions.conn(uid, session, client), client
+	}
+
+	readFrom := func(conn net.Conn, n int) string {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(conn, buf)
+		Expect(err).ToNot(HaveOccurred())
+		return string(buf)
+	}
+
+	BeforeEach(func() {
+		sessions = newConsoleSessions()
+		dials = 0
+	})
+
+	It("should share the serial console output with read-only clients", func() {
+		owner, _ := attach(false, false)
+		defer owner.Close()
+		viewer, _ := attach(true, false)
+		defer viewer.Close()
+		Expect(dials).To(Equal(1))
+
+		_, err := guest.Write([]byte("login:"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFrom(owner, 6)).To(Equal("login:"))
+		Expect(readFrom(viewer, 6)).To(Equal("login:"))
+	})
+
+	It("should forward only the input of the read-write client", func() {
+		owner, _ := attach(false, false)
+		defer owner.Close()
+		viewer, _ := attach(true, false)
+		defer viewer.Close()
+
+		n, err := viewer.Write([]byte("ignored"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(7))
+
+		go owner.Write([]byte("root"))
+		Expect(readFrom(guest, 4)).To(Equal("root"))
+	})
+
+	It("should refuse a second read-write client", func() {
+		owner, _ := attach(false, false)
+		defer owner.Close()
+
+		_, _, err := sessions.attach(uid, dial, false, false)
+		Expect(err).To(MatchError(errConsoleInUse))
+	})
+
+	It("should disconnect the owner when the console is taken over", func() {
+		owner, ownerClient := attach(false, false)
+		defer owner.Close()
+		newOwner, _ := attach(false, true)
+		defer newOwner.Close()
+
+		Expect(ownerClient.stop).To(BeClosed())
+		_, err := owner.Write([]byte("x"))
+		Expect(err).To(MatchError(errConsoleTakenOver))
+		_, err = owner.Read(make([]byte, 1))
+		Expect(err).To(MatchError(io.EOF))
+
+		go newOwner.Write([]byte("y"))
+		Expect(readFrom(guest, 1)).To(Equal("y"))
+	})
+
+	It("should close the serial console connection once the last client is gone", func() {
+		owner, _ := attach(false, false)
+		viewer, _ := attach(true, false)
+
+		Expect(owner.Close()).To(Succeed())
+		Expect(sessions.sessions).To(HaveKey(uid))
+		Expect(viewer.Close()).To(Succeed())
+		Expect(sessions.sessions).ToNot(HaveKey(uid))
+
+		_, err := guest.Read(make([]byte, 1))
+		Expect(err).To(MatchError(io.EOF))
+
+		reconnected, _ := attach(false, false)
+		defer reconnected.Close()
+		Expect(dials).To(Equal(2))
+	})
+})
@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
+)
+
+var _ = Describe("Persisted serial console log", func() {
+	var (
+		rootDir string
+		logDir  string
+		root    *safepath.Path
+	)
+
+	BeforeEach(func() {
+		rootDir = GinkgoT().TempDir()
+		logDir = filepath.Join(rootDir, consolelog.MountPath)
+		Expect(os.MkdirAll(logDir, 0755)).To(Succeed())
+		var err error
+		root, err = safepath.JoinAndResolveWithRelativeRoot(rootDir)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should read the rotated log files from the oldest to the newest", func() {
+		Expect(os.WriteFile(filepath.Join(logDir, consolelog.FileName+".1"), []byte("2024-01-01T00:00:00Z first\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(logDir, consolelog.FileName), []byte("2024-01-01T00:00:01Z second\n"), 0644)).To(Succeed())
+
+		serialConsoleLog, err := readConsoleLog(root, time.Time{}, consolelog.MaxTailLines)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serialConsoleLog.Lines).To(Equal([]string{"first", "second"}))
+	})
+
+	It("should return an empty log when the log directory does not exist", func() {
+		Expect(os.RemoveAll(logDir)).To(Succeed())
+
+		serialConsoleLog, err := readConsoleLog(root, time.Time{}, consolelog.MaxTailLines)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serialConsoleLog.Lines).To(BeEmpty())
+	})
+
+	DescribeTable("should refuse a log file replaced by a symlink", func(name string) {
+		hostFile := filepath.Join(GinkgoT().TempDir(), "shadow")
+		Expect(os.WriteFile(hostFile, []byte("host secret\n"), 0600)).To(Succeed())
+		if name != consolelog.FileName {
+			Expect(os.WriteFile(filepath.Join(logDir, consolelog.FileName), []byte("2024-01-01T00:00:00Z line\n"), 0644)).To(Succeed())
+		}
+		Expect(os.Symlink(hostFile, filepath.Join(logDir, name))).To(Succeed())
+
+		serialConsoleLog, err := readConsoleLog(root, time.Time{}, consolelog.MaxTailLines)
+		Expect(err).To(MatchError(ContainSubstring("is not a regular file")))
+		Expect(serialConsoleLog).To(BeNil())
+	},
+		Entry("for the current log file", consolelog.FileName),
+		Entry("for a rotated log file", consolelog.FileName+".1"),
+	)
+})
@@ -33,6 +33,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	kutil "kubevirt.io/kubevirt/pkg/util"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
 
@@ -280,6 +281,39 @@ func (lh *LifecycleHandler) SEVQueryLaunchMeasurementHandler(request *restful.Re
 	response.WriteEntity(sevMeasurementInfo)
 }
 
+func (lh *LifecycleHandler) LaunchSecurityReportHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+
+	log.Log.Object(vmi).Infof("Retrieving launch security report")
+
+	measurementInfo, err := client.GetLaunchMeasurement(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get VMI launch security report")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(launchSecurityReport(vmi, measurementInfo))
+}
+
+func launchSecurityReport(vmi *v1.VirtualMachineInstance, measurementInfo *v1.SEVMeasurementInfo) *v1.LaunchSecurityReport {
+	report := &v1.LaunchSecurityReport{
+		Policy:    uint64(measurementInfo.Policy),
+		LoaderSHA: measurementInfo.LoaderSHA,
+	}
+	switch {
+	case kutil.IsSEVSNPVMI(vmi):
+		report.Type = v1.LaunchSecurityTypeSEVSNP
+	default:
+		report.Type = v1.LaunchSecurityTypeSEV
+		report.Measurement = measurementInfo.Measurement
+	}
+	return report
+}
+
 func (lh *LifecycleHandler) SEVInjectLaunchSecretHandler(request *restful.Request, response *restful.Response) {
 	vmi, client, err := lh.getVMILauncherClient(request, response)
 	if err != nil {
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vhostuser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	netvhostuser "kubevirt.io/kubevirt/pkg/network/vhostuser"
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/unsafepath"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	virt_chroot "kubevirt.io/kubevirt/pkg/virt-handler/virt-chroot"
+)
+
+const nodeRoot = "/proc/1/root"
+
+var (
+	mountCommand = func(sourcePath, targetPath *safepath.Path) ([]byte, error) {
+		return virt_chroot.MountChroot(sourcePath, targetPath, false).CombinedOutput()
+	}
+
+	unmountCommand = func(path *safepath.Path) ([]byte, error) {
+		return virt_chroot.UmountChroot(path).CombinedOutput()
+	}
+
+	isMounted = func(path *safepath.Path) (bool, error) {
+		return isolation.IsMounted(path)
+	}
+)
+
+// SocketsMounter shares the node directory holding the vhost-user sockets of a VMI,
+// which the userspace datapath connects to, with the launcher pods of the VMI.
+// The directory is created and owned by virt-handler and bind mounted into the
+// sockets emptyDir volume of the launcher pod, so the pod needs no hostPath volume.
+type SocketsMounter interface {
+	Mount(vmi *v1.VirtualMachineInstance) error
+	Unmount(vmi *v1.VirtualMachineInstance) error
+}
+
+type socketsMounter struct {
+	nodeRoot       string
+	kubeletPodsDir string
+}
+
+func NewSocketsMounter(kubeletPodsDir string) SocketsMounter {
+	return &socketsMounter{
+		nodeRoot:       nodeRoot,
+		kubeletPodsDir: kubeletPodsDir,
+	}
+}
+
+// Mount creates the node sockets directory of the VMI, hands it over to the qemu user
+// and mounts it into the launcher pods of the VMI on this node.
+func (m *socketsMounter) Mount(vmi *v1.VirtualMachineInstance) error {
+	hostDir, err := m.createHostDir(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to create the vhost-user sockets directory: %v", err)
+	}
+
+	for podUID := range vmi.Status.ActivePods {
+		target, err := m.podSocketsDir(string(podUID))
+		if errors.Is(err, os.ErrNotExist) {
+			// the pod is not running on this node
+			continue
+		} else if err != nil {
+			return err
+		}
+		if mounted, err := isMounted(target); err != nil {
+			return err
+		} else if mounted {
+			continue
+		}
+		if out, err := mountCommand(hostDir, target); err != nil {
+			return fmt.Errorf("failed to mount the vhost-user sockets directory: %s: %v", string(out), err)
+		}
+		log.Log.Object(vmi).Infof("Mounted vhost-user sockets directory into pod %s", podUID)
+	}
+	return nil
+}
+
+// Unmount removes the node sockets directory of the VMI after unmounting it from the launcher pods.
+func (m *socketsMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
+	if vmi.UID == "" {
+		return nil
+	}
+	for podUID := range vmi.Status.ActivePods {
+		target, err := m.podSocketsDir(string(podUID))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if mounted, err := isMounted(target); err != nil {
+			return err
+		} else if !mounted {
+			continue
+		}
+		if out, err := unmountCommand(target); err != nil {
+			return fmt.Errorf("failed to unmount the vhost-user sockets directory: %s: %v", string(out), err)
+		}
+	}
+
+	hostDir, err := safepath.JoinAndResolveWithRelativeRoot(m.nodeRoot, netvhostuser.HostSocketsDir(vmi.UID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return os.RemoveAll(unsafepath.UnsafeAbsolute(hostDir.Raw()))
+}
+
+func (m *socketsMounter) createHostDir(vmi *v1.VirtualMachineInstance) (*safepath.Path, error) {
+	parent, err := safepath.JoinAndResolveWithRelativeRoot(m.nodeRoot, filepath.Dir(netvhostuser.HostSocketsBaseDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{filepath.Base(netvhostuser.HostSocketsBaseDir), string(vmi.UID)} {
+		if err := safepath.MkdirAtNoFollow(parent, dir, 0750); err != nil && !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if parent, err = safepath.JoinNoFollow(parent, dir); err != nil {
+			return nil, err
+		}
+	}
+	if err := diskutils.DefaultOwnershipManager.SetFileOwnership(parent); err != nil {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// podSocketsDir returns the directory of the sockets emptyDir volume of the launcher pod
+// the node sockets directory is mounted on, creating it if needed.
+func (m *socketsMounter) podSocketsDir(podUID string) (*safepath.Path, error) {
+	volumeDir, err := safepath.JoinAndResolveWithRelativeRoot(m.nodeRoot, m.kubeletPodsDir,
+		podUID, "volumes", "kubernetes.io~empty-dir", netvhostuser.SocketsVolumeName)
+	if err != nil {
+		return nil, err
+	}
+	if err := safepath.MkdirAtNoFollow(volumeDir, netvhostuser.SocketsSubDir, 0750); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, err
+	}
+	return safepath.JoinNoFollow(volumeDir, netvhostuser.SocketsSubDir)
+}
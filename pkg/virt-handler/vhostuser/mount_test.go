@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vhostuser
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/unsafepath"
+)
+
+var _ = Describe("vhost-user sockets mounter", func() {
+	const (
+		kubeletPodsDir = "/var/lib/kubelet/pods"
+		podUID         = "pod-uid"
+		vmiUID         = "vmi-uid"
+	)
+
+	var (
+		root    string
+		mounter *socketsMounter
+		mounts  map[string]string
+		vmi     *v1.VirtualMachineInstance
+	)
+
+	hostDir := func() string {
+		return filepath.Join(root, "var/run/kubevirt/vhostuser", vmiUID)
+	}
+	podSocketsDir := func() string {
+		return filepath.Join(root, kubeletPodsDir, podUID, "volumes/kubernetes.io~empty-dir/vhostuser-sockets/sockets")
+	}
+
+	BeforeEach(func() {
+		diskutils.MockDefaultOwnershipManager()
+		root = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(root, "var/run/kubevirt"), 0755)).To(Succeed())
+		mounter = &socketsMounter{nodeRoot: root, kubeletPodsDir: kubeletPodsDir}
+
+		mounts = map[string]string{}
+		mountCommand = func(sourcePath, targetPath *safepath.Path) ([]byte, error) {
+			mounts[unsafepath.UnsafeAbsolute(targetPath.Raw())] = unsafepath.UnsafeAbsolute(sourcePath.Raw())
+			return nil, nil
+		}
+		unmountCommand = func(path *safepath.Path) ([]byte, error) {
+			delete(mounts, unsafepath.UnsafeAbsolute(path.Raw()))
+			return nil, nil
+		}
+		isMounted = func(path *safepath.Path) (bool, error) {
+			_, mounted := mounts[unsafepath.UnsafeAbsolute(path.Raw())]
+			return mounted, nil
+		}
+
+		vmi = libvmi.New()
+		vmi.UID = vmiUID
+		vmi.Status.ActivePods = map[types.UID]string{podUID: "node01", "remote-pod-uid": "node02"}
+	})
+
+	It("should create the node directory and mount it into the launcher pods on the node", func() {
+		Expect(os.MkdirAll(filepath.Dir(podSocketsDir()), 0755)).To(Succeed())
+
+		Expect(mounter.Mount(vmi)).To(Succeed())
+		Expect(mounter.Mount(vmi)).To(Succeed())
+
+		Expect(hostDir()).To(BeADirectory())
+		Expect(mounts).To(Equal(map[string]string{podSocketsDir(): hostDir()}))
+	})
+
+	It("should unmount and remove the node directory", func() {
+		Expect(os.MkdirAll(filepath.Dir(podSocketsDir()), 0755)).To(Succeed())
+		Expect(mounter.Mount(vmi)).To(Succeed())
+
+		Expect(mounter.Unmount(vmi)).To(Succeed())
+
+		Expect(mounts).To(BeEmpty())
+		Expect(hostDir()).ToNot(BeAnExistingFile())
+	})
+
+	It("should succeed unmounting a VMI without a node directory", func() {
+		Expect(mounter.Unmount(vmi)).To(Succeed())
+	})
+})
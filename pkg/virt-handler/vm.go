@@ -55,6 +55,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/config"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/unsafepath"
 	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -101,6 +102,7 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	"kubevirt.io/kubevirt/pkg/virt-handler/vhostuser"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -247,6 +249,7 @@ func NewController(
 		podIsolationDetector:             podIsolationDetector,
 		containerDiskMounter:             container_disk.NewMounter(podIsolationDetector, containerDiskState, clusterConfig),
 		hotplugVolumeMounter:             hotplug_volume.NewVolumeMounter(hotplugState, kubeletPodsDir),
+		vhostUserSocketsMounter:          vhostuser.NewSocketsMounter(kubeletPodsDir),
 		clusterConfig:                    clusterConfig,
 		virtLauncherFSRunDirPattern:      "/proc/%d/root/var/run",
 		capabilities:                     capabilities,
@@ -316,6 +319,7 @@ func NewController(
 		clusterConfig,
 		clientset.CoreV1())
 	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host)
+	c.memoryPressure = c.heartBeat.MemoryPressure
 
 	return c, nil
 }
@@ -342,6 +346,7 @@ type VirtualMachineController struct {
 	podIsolationDetector     isolation.PodIsolationDetector
 	containerDiskMounter     container_disk.Mounter
 	hotplugVolumeMounter     hotplug_volume.VolumeMounter
+	vhostUserSocketsMounter  vhostuser.SocketsMounter
 	clusterConfig            *virtconfig.ClusterConfig
 	sriovHotplugExecutorPool *executor.RateLimitedExecutorPool
 	downwardMetricsManager   downwardMetricsManager
@@ -353,6 +358,7 @@ type VirtualMachineController struct {
 	domainNotifyPipes           map[string]string
 	virtLauncherFSRunDirPattern string
 	heartBeat                   *heartbeat.HeartBeat
+	memoryPressure              func() bool
 	capabilities                *libvirtxml.Caps
 	hostCpuModel                string
 	vmiExpectations             *controller.UIDTrackingControllerExpectations
@@ -1226,8 +1232,9 @@ func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 
 func (d *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
 
-	// Update paused condition in case VMI was paused / unpaused
-	if domain != nil && domain.Status.Status == api.Paused {
+	// Update paused condition in case VMI was paused / unpaused.
+	// A panicked guest preserved by the pause or dump panic action is reported as paused too.
+	if domain != nil && (domain.Status.Status == api.Paused || domainGuestPanicked(domain)) {
 		if !condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
 			calculatePausedCondition(vmi, domain.Status.Reason)
 		}
@@ -1237,6 +1244,46 @@ func (d *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachine
 	}
 }
 
+func (d *VirtualMachineController) updateGuestPanickedCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
+	if domainGuestPanicked(domain) {
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestPanicked) {
+			log.Log.Object(vmi).V(3).Info("Adding guest panicked condition")
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:               v1.VirtualMachineInstanceGuestPanicked,
+				Status:             k8sv1.ConditionTrue,
+				LastProbeTime:      metav1.Now(),
+				LastTransitionTime: metav1.Now(),
+				Reason:             "GuestPanicked",
+				Message:            "The guest operating system reported a panic",
+			})
+		}
+	} else if condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestPanicked) {
+		log.Log.Object(vmi).V(3).Info("Removing guest panicked condition")
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceGuestPanicked)
+	}
+}
+
+// setRenderNodeOwnership hands the GPU render node allocated to the launcher pod over to the qemu user
+func setRenderNodeOwnership(virtLauncherRootMount *safepath.Path) error {
+	renderNode, err := virtutil.FindRenderNode(unsafepath.UnsafeAbsolute(virtLauncherRootMount.Raw()))
+	if err != nil {
+		return err
+	}
+	renderNodePath, err := safepath.JoinNoFollow(virtLauncherRootMount, renderNode)
+	if err != nil {
+		return err
+	}
+	return diskutils.DefaultOwnershipManager.SetFileOwnership(renderNodePath)
+}
+
+// domainGuestPanicked returns true if the guest panicked and the domain was
+// preserved in the crashed state by the configured crash action.
+func domainGuestPanicked(domain *api.Domain) bool {
+	return domain != nil &&
+		domain.Status.Status == api.Crashed &&
+		domain.Status.Reason == api.ReasonPanicked
+}
+
 func dumpTargetFile(vmiName, volName string) string {
 	targetFileName := fmt.Sprintf("%s-%s-%s.memory.dump", vmiName, volName, time.Now().Format("20060102-150405"))
 	return targetFileName
@@ -1410,6 +1457,7 @@ func (d *VirtualMachineController) updateVMIStatusFromDomain(vmi *v1.VirtualMach
 	d.updateVolumeStatusesFromDomain(vmi, domain)
 	d.updateFSFreezeStatus(vmi, domain)
 	d.updateMachineType(vmi, domain)
+	d.updateCPUInfo(vmi, domain)
 	if err = d.updateMemoryInfo(vmi, domain); err != nil {
 		return err
 	}
@@ -1425,6 +1473,7 @@ func (d *VirtualMachineController) updateVMIConditions(vmi *v1.VirtualMachineIns
 		return err
 	}
 	d.updatePausedConditions(vmi, domain, condManager)
+	d.updateGuestPanickedCondition(vmi, domain, condManager)
 
 	return nil
 }
@@ -1464,6 +1513,12 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		return err
 	}
 
+	// Scale the guest down in place when vCPUs or memory were reduced
+	d.hotUnplugResources(vmi, domain)
+
+	// Shrink or grow the guest through the memory balloon depending on the node memory pressure
+	d.updateBalloonTarget(vmi, domain, syncError)
+
 	// Store containerdisks and kernelboot checksums
 	if err := d.updateChecksumInfo(vmi, syncError); err != nil {
 		return err
@@ -1604,6 +1659,17 @@ func calculatePausedCondition(vmi *v1.VirtualMachineInstance, reason api.StateCh
 			Reason:             "PausedIOError",
 			Message:            "VMI was paused, low-level IO error detected",
 		})
+	case api.ReasonPanicked:
+		log.Log.Object(vmi).V(3).Info("Adding paused condition")
+		now := metav1.NewTime(time.Now())
+		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstancePaused,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             "GuestPanicked",
+			Message:            "VMI was paused, the guest operating system panicked",
+		})
 	default:
 		log.Log.Object(vmi).V(3).Infof("Domain is paused for unknown reason, %s", reason)
 	}
@@ -1645,6 +1711,10 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		return newNonMigratableCondition("VMI uses SEV", v1.VirtualMachineInstanceReasonSEVNotMigratable), isBlockMigration
 	}
 
+	if util.IsSEVSNPVMI(vmi) || util.IsTDXVMI(vmi) {
+		return newNonMigratableCondition("VMI uses SEV-SNP or TDX", v1.VirtualMachineInstanceReasonConfidentialComputingNotMigratable), isBlockMigration
+	}
+
 	if reservation.HasVMIPersistentReservation(vmi) {
 		return newNonMigratableCondition("VMI uses SCSI persitent reservation", v1.VirtualMachineInstanceReasonPRNotMigratable), isBlockMigration
 	}
@@ -1732,6 +1802,10 @@ func (d *VirtualMachineController) calculateLiveStorageMigrationCondition(vmi *v
 		multiCond.addNonMigratableCondition(v1.VirtualMachineInstanceReasonSEVNotMigratable, "VMI uses SEV")
 	}
 
+	if util.IsSEVSNPVMI(vmi) || util.IsTDXVMI(vmi) {
+		multiCond.addNonMigratableCondition(v1.VirtualMachineInstanceReasonConfidentialComputingNotMigratable, "VMI uses SEV-SNP or TDX")
+	}
+
 	if reservation.HasVMIPersistentReservation(vmi) {
 		multiCond.addNonMigratableCondition(v1.VirtualMachineInstanceReasonPRNotMigratable, "VMI uses SCSI persitent reservation")
 	}
@@ -1782,6 +1856,8 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 
 	go c.ioErrorRetryManager.Run(stopCh)
 
+	go c.runMemoryReclaim(stopCh)
+
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -2001,7 +2077,7 @@ func (d *VirtualMachineController) defaultExecute(key string,
 
 	domainAlive := domainExists &&
 		domain.Status.Status != api.Shutoff &&
-		domain.Status.Status != api.Crashed &&
+		(domain.Status.Status != api.Crashed || domainGuestPanicked(domain)) &&
 		domain.Status.Status != ""
 
 	domainMigrated := domainExists && domainMigrated(domain)
@@ -2272,6 +2348,12 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 		return err
 	}
 
+	if d.hasVhostUserInterfaces(vmi) {
+		if err := d.vhostUserSocketsMounter.Unmount(vmi); err != nil {
+			return err
+		}
+	}
+
 	d.teardownNetwork(vmi)
 
 	d.sriovHotplugExecutorPool.Delete(vmi.UID)
@@ -2290,6 +2372,10 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 	return d.domainStore.Delete(domain)
 }
 
+func (d *VirtualMachineController) hasVhostUserInterfaces(vmi *v1.VirtualMachineInstance) bool {
+	return netvmispec.BindingPluginNetworkWithVhostUserExist(vmi.Spec.Domain.Devices.Interfaces, d.clusterConfig.GetNetworkBindings())
+}
+
 func (d *VirtualMachineController) closeLauncherClient(vmi *v1.VirtualMachineInstance) error {
 
 	// UID is required in order to close socket
@@ -2896,6 +2982,12 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationTarget(origVMI *v1.Vir
 		}
 	}
 
+	if d.hasVhostUserInterfaces(vmi) {
+		if err := d.vhostUserSocketsMounter.Mount(vmi); err != nil {
+			return err
+		}
+	}
+
 	// configure network inside virt-launcher compute container
 	if err := d.setupNetwork(vmi, vmi.Spec.Networks); err != nil {
 		return fmt.Errorf("failed to configure vmi network for migration target: %w", err)
@@ -3121,6 +3213,12 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		})
 		nonAbsentNets := netvmispec.FilterNetworksByInterfaces(vmi.Spec.Networks, nonAbsentIfaces)
 
+		if d.hasVhostUserInterfaces(vmi) {
+			if err := d.vhostUserSocketsMounter.Mount(vmi); err != nil {
+				return err
+			}
+		}
+
 		if err := d.setupNetwork(vmi, nonAbsentNets); err != nil {
 			return fmt.Errorf("failed to configure vmi network: %w", err)
 		}
@@ -3154,7 +3252,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return fmt.Errorf("preparing host-disks failed: %v", err)
 		}
 
-		if virtutil.IsSEVVMI(vmi) {
+		if virtutil.UsesSEVDevice(vmi) {
 			sevDevice, err := safepath.JoinNoFollow(virtLauncherRootMount, filepath.Join("dev", "sev"))
 			if err != nil {
 				return err
@@ -3164,6 +3262,12 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			}
 		}
 
+		if virtutil.IsVideoAccel3DVMI(vmi) {
+			if err := setRenderNodeOwnership(virtLauncherRootMount); err != nil {
+				return fmt.Errorf("failed to set GPU render node owner: %v", err)
+			}
+		}
+
 		if virtutil.IsNonRootVMI(vmi) {
 			if err := d.nonRootSetup(origVMI, vmi); err != nil {
 				return err
@@ -3417,7 +3521,14 @@ func (d *VirtualMachineController) calculateVmPhaseForStatusReason(domain *api.D
 		switch domain.Status.Status {
 		case api.Shutoff, api.Crashed:
 			switch domain.Status.Reason {
-			case api.ReasonCrashed, api.ReasonPanicked:
+			case api.ReasonPanicked:
+				// A panicked guest which is preserved by the crash action is kept
+				// around for inspection or dumping and is still considered running.
+				if domainGuestPanicked(domain) {
+					return v1.Running, nil
+				}
+				return v1.Failed, nil
+			case api.ReasonCrashed:
 				return v1.Failed, nil
 			case api.ReasonDestroyed:
 				// When ACPI is available, the domain was tried to be shutdown,
@@ -3743,6 +3854,71 @@ func (d *VirtualMachineController) hotplugMemory(vmi *v1.VirtualMachineInstance,
 	return nil
 }
 
+func isVCPUUnplugRequested(vmi *v1.VirtualMachineInstance) bool {
+	if vmi.Spec.Domain.CPU == nil || vmi.Status.CurrentCPUTopology == nil {
+		return false
+	}
+	current := &v1.CPU{
+		Sockets: vmi.Status.CurrentCPUTopology.Sockets,
+		Cores:   vmi.Status.CurrentCPUTopology.Cores,
+		Threads: vmi.Status.CurrentCPUTopology.Threads,
+	}
+	return hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU) < hardware.GetNumberOfVCPUs(current)
+}
+
+func isMemoryUnplugRequested(vmi *v1.VirtualMachineInstance) bool {
+	if vmi.Spec.Domain.Memory == nil ||
+		vmi.Spec.Domain.Memory.Guest == nil ||
+		vmi.Spec.Domain.Memory.MaxGuest == nil ||
+		vmi.Status.Memory == nil ||
+		vmi.Status.Memory.GuestRequested == nil {
+		return false
+	}
+	return vmi.Spec.Domain.Memory.Guest.Cmp(*vmi.Status.Memory.GuestRequested) < 0
+}
+
+// hotUnplugResources asks the guest to release vCPUs and memory which were removed from the VMI spec.
+// Unlike hotplug this does not need a migration, the pod keeps its resources until the next one.
+func (d *VirtualMachineController) hotUnplugResources(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || !vmi.IsRunning() || migrations.IsMigrating(vmi) {
+		return
+	}
+	unplugCPU := isVCPUUnplugRequested(vmi)
+	unplugMemory := isMemoryUnplugRequested(vmi)
+	if !unplugCPU && !unplugMemory {
+		return
+	}
+
+	client, err := d.getVerifiedLauncherClient(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to connect to virt-launcher for hot-unplug")
+		return
+	}
+	options := virtualMachineOptions(nil, 0, nil, d.capabilities, nil, d.clusterConfig)
+
+	if unplugCPU {
+		if err := client.SyncVirtualMachineCPUs(vmi, options); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to unplug vCPUs")
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, "VCPUHotUnplugFailed", err.Error())
+		} else {
+			log.Log.Object(vmi).Infof("unplugging vCPUs down to %d sockets", vmi.Spec.Domain.CPU.Sockets)
+			vmi.Status.CurrentCPUTopology.Sockets = vmi.Spec.Domain.CPU.Sockets
+			vmi.Status.CurrentCPUTopology.Cores = vmi.Spec.Domain.CPU.Cores
+			vmi.Status.CurrentCPUTopology.Threads = vmi.Spec.Domain.CPU.Threads
+		}
+	}
+
+	if unplugMemory {
+		if err := client.SyncVirtualMachineMemory(vmi, options); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to unplug guest memory")
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, "MemoryHotUnplugFailed", err.Error())
+		} else {
+			log.Log.Object(vmi).Infof("unplugging guest memory down to %s", vmi.Spec.Domain.Memory.Guest.String())
+			vmi.Status.Memory.GuestRequested = vmi.Spec.Domain.Memory.Guest
+		}
+	}
+}
+
 func removeMigratedVolumes(vmi *v1.VirtualMachineInstance) {
 	vmiConditions := controller.NewVirtualMachineInstanceConditionManager()
 	vmiConditions.RemoveCondition(vmi, v1.VirtualMachineInstanceVolumesChange)
@@ -3773,6 +3949,24 @@ func parseLibvirtQuantity(value int64, unit string) *resource.Quantity {
 	return nil
 }
 
+// updateCPUInfo reports how many vCPUs are enabled in the guest, which drops
+// only once the guest released hot-unplugged vCPUs.
+func (d *VirtualMachineController) updateCPUInfo(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || vmi == nil || domain.Spec.VCPU == nil {
+		return
+	}
+	enabled := domain.Spec.VCPU.CPUs
+	if domain.Spec.VCPUs != nil && len(domain.Spec.VCPUs.VCPU) > 0 {
+		enabled = 0
+		for _, vcpu := range domain.Spec.VCPUs.VCPU {
+			if vcpu.Enabled == "yes" {
+				enabled++
+			}
+		}
+	}
+	vmi.Status.GuestCurrentVCPUs = &enabled
+}
+
 func (d *VirtualMachineController) updateMemoryInfo(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 	if domain == nil || vmi == nil || domain.Spec.CurrentMemory == nil {
 		return nil
@@ -997,6 +997,82 @@ var _ = Describe("VirtualMachineInstance", func() {
 			))
 		})
 
+		It("should add and remove guest panicked condition and keep a preserved panicked guest running", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{Action: v1.PanicActionPause}
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+
+			By("panicking the guest")
+			domain.Status.Status = api.Crashed
+			domain.Status.Reason = api.ReasonPanicked
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+			createVMI(vmi)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any(), mockCgroupManager).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any(), mockCgroupManager).Return(nil)
+
+			controller.Execute()
+
+			updatedVMI, err := virtfakeClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedVMI.Status.Phase).To(Equal(v1.Running))
+			Expect(updatedVMI.Status.Conditions).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(v1.VirtualMachineInstanceGuestPanicked),
+					"Status": Equal(k8sv1.ConditionTrue),
+					"Reason": Equal("GuestPanicked")},
+				),
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(v1.VirtualMachineInstancePaused),
+					"Status": Equal(k8sv1.ConditionTrue),
+					"Reason": Equal("GuestPanicked")},
+				),
+			))
+
+			By("resetting the guest")
+			domain.Status.Status = api.Running
+			domain.Status.Reason = ""
+
+			vmiFeeder.Add(updatedVMI)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(gomock.Any(), gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any(), mockCgroupManager).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any(), mockCgroupManager).Return(nil)
+
+			controller.Execute()
+
+			updatedVMI, err = virtfakeClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedVMI.Status.Conditions).ToNot(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type": Or(Equal(v1.VirtualMachineInstanceGuestPanicked), Equal(v1.VirtualMachineInstancePaused))},
+				),
+			))
+		})
+
+		It("should move VirtualMachineInstance to Failed if the domain panicked and was shut off", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = v1.Running
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Shutoff
+			domain.Status.Reason = api.ReasonPanicked
+
+			phase, err := controller.calculateVmPhaseForStatusReason(domain, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(phase).To(Equal(v1.Failed))
+		})
+
 		It("should move VirtualMachineInstance from Scheduled to Failed if watchdog file is missing", func() {
 			cmdclient.MarkSocketUnresponsive(sockFile)
 			vmi := api2.NewMinimalVMI("testvmi")
@@ -2206,6 +2282,137 @@ var _ = Describe("VirtualMachineInstance", func() {
 		})))
 	})
 
+	Context("memory reclaim", func() {
+		var vmi *v1.VirtualMachineInstance
+		var domain *api.Domain
+		var memoryPressure bool
+
+		BeforeEach(func() {
+			memoryPressure = false
+			controller.memoryPressure = func() bool { return memoryPressure }
+
+			vmi = api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Memory = &v1.Memory{Reclaim: &v1.MemoryReclaim{}}
+
+			domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Memory = api.Memory{Value: 2097152, Unit: "KiB"}
+		})
+
+		It("should shrink the guest down to half of its memory under memory pressure", func() {
+			memoryPressure = true
+
+			controller.updateBalloonTarget(vmi, domain, nil)
+
+			Expect(vmi.Status.Memory.BalloonTarget.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+		})
+
+		It("should shrink the guest down to the configured floor under memory pressure", func() {
+			memoryPressure = true
+			floor := resource.MustParse("1536Mi")
+			vmi.Spec.Domain.Memory.Reclaim.Floor = &floor
+
+			controller.updateBalloonTarget(vmi, domain, nil)
+
+			Expect(vmi.Status.Memory.BalloonTarget.Value()).To(Equal(floor.Value()))
+		})
+
+		It("should not reclaim memory from VMIs which did not opt in", func() {
+			memoryPressure = true
+			vmi.Spec.Domain.Memory = nil
+
+			controller.updateBalloonTarget(vmi, domain, nil)
+
+			Expect(vmi.Status.Memory).To(BeNil())
+		})
+
+		It("should give the memory back to the guest before dropping the balloon target", func() {
+			target := resource.MustParse("1Gi")
+			vmi.Status.Memory = &v1.MemoryStatus{BalloonTarget: &target}
+
+			By("growing the guest back to its full memory")
+			controller.updateBalloonTarget(vmi, domain, nil)
+			Expect(vmi.Status.Memory.BalloonTarget.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+
+			By("keeping the balloon target while the sync fails")
+			controller.updateBalloonTarget(vmi, domain, fmt.Errorf("sync failed"))
+			Expect(vmi.Status.Memory.BalloonTarget).ToNot(BeNil())
+
+			By("dropping the balloon target once the guest got its memory back")
+			controller.updateBalloonTarget(vmi, domain, nil)
+			Expect(vmi.Status.Memory.BalloonTarget).To(BeNil())
+		})
+	})
+
+	Context("hot-unplug", func() {
+		var vmi *v1.VirtualMachineInstance
+		var domain *api.Domain
+
+		BeforeEach(func() {
+			vmi = api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, Cores: 1, Threads: 1, MaxSockets: 4}
+			vmi.Status.CurrentCPUTopology = &v1.CPUTopology{Sockets: 4, Cores: 1, Threads: 1}
+			guest := resource.MustParse("1Gi")
+			requested := resource.MustParse("2Gi")
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest, MaxGuest: &requested}
+			vmi.Status.Memory = &v1.MemoryStatus{GuestAtBoot: &guest, GuestCurrent: &requested, GuestRequested: &requested}
+
+			domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+		})
+
+		It("should unplug vCPUs and memory in place when the spec was reduced", func() {
+			client.EXPECT().Ping()
+			client.EXPECT().SyncVirtualMachineCPUs(vmi, gomock.Any())
+			client.EXPECT().SyncVirtualMachineMemory(vmi, gomock.Any())
+
+			controller.hotUnplugResources(vmi, domain)
+
+			Expect(vmi.Status.CurrentCPUTopology.Sockets).To(Equal(uint32(2)))
+			Expect(vmi.Status.Memory.GuestRequested.String()).To(Equal("1Gi"))
+		})
+
+		It("should not unplug anything while the VMI is migrating", func() {
+			now := metav1.Now()
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{StartTimestamp: &now}
+
+			controller.hotUnplugResources(vmi, domain)
+
+			Expect(vmi.Status.CurrentCPUTopology.Sockets).To(Equal(uint32(4)))
+			Expect(vmi.Status.Memory.GuestRequested.String()).To(Equal("2Gi"))
+		})
+
+		It("should keep the current topology and emit an event when unplugging vCPUs fails", func() {
+			vmi.Spec.Domain.Memory.Guest = vmi.Status.Memory.GuestRequested
+			client.EXPECT().Ping()
+			client.EXPECT().SyncVirtualMachineCPUs(vmi, gomock.Any()).Return(fmt.Errorf("some error"))
+
+			controller.hotUnplugResources(vmi, domain)
+
+			testutils.ExpectEvent(recorder, "VCPUHotUnplugFailed")
+			Expect(vmi.Status.CurrentCPUTopology.Sockets).To(Equal(uint32(4)))
+		})
+
+		It("should report the vCPUs enabled in the guest", func() {
+			domain.Spec.VCPU = &api.VCPU{CPUs: 4}
+			domain.Spec.VCPUs = &api.VCPUs{VCPU: []api.VCPUsVCPU{
+				{ID: 0, Enabled: "yes"},
+				{ID: 1, Enabled: "yes"},
+				{ID: 2, Enabled: "yes"},
+				{ID: 3, Enabled: "no"},
+			}}
+
+			controller.updateCPUInfo(vmi, domain)
+
+			Expect(vmi.Status.GuestCurrentVCPUs).To(HaveValue(Equal(uint32(3))))
+		})
+	})
+
 	Context("check if migratable", func() {
 
 		var testBlockPvc *k8sv1.PersistentVolumeClaim
@@ -339,6 +339,11 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 				event := watch.Event{Type: watch.Added, Object: domain}
 				client.SendDomainEvent(event)
 				updateEvents(event, domain, events)
+			} else if vmi != nil && libvirtEvent.Event.Event == libvirt.DOMAIN_EVENT_CRASHED && libvirt.DomainEventCrashedDetailType(libvirtEvent.Event.Detail) == libvirt.DOMAIN_EVENT_CRASHED_PANICKED {
+				err := client.SendK8sEvent(vmi, "Warning", "GuestPanicked", guestPanickedMessage(vmi))
+				if err != nil {
+					log.Log.Reason(err).Error("Could not send k8s event")
+				}
 			}
 		}
 		if interfaceStatus != nil {
@@ -359,6 +364,14 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 	}
 }
 
+func guestPanickedMessage(vmi *v1.VirtualMachineInstance) string {
+	action := v1.PanicActionPause
+	if vmi.Spec.Domain.Devices.Panic != nil && vmi.Spec.Domain.Devices.Panic.Action != "" {
+		action = vmi.Spec.Domain.Devices.Panic.Action
+	}
+	return fmt.Sprintf("The guest operating system panicked, panic action: %s", action)
+}
+
 var updateEvents = updateEventsClosure()
 
 func updateEventsClosure() func(event watch.Event, domain *api.Domain, events chan watch.Event) {
@@ -346,6 +346,35 @@ var _ = Describe("Notify", func() {
 			Expect(event).To(Equal(fmt.Sprintf("%s %s %s involvedObject{kind=VirtualMachineInstance,apiVersion=kubevirt.io/v1}", eventType, eventReason, eventMessage)))
 		})
 
+		It("Should generate a k8s event when the guest panicked", func() {
+			domain := api.NewMinimalDomain("test")
+			x, err := xml.Marshal(domain.Spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			ctrl := gomock.NewController(GinkgoT())
+			mockCon := cli.NewMockConnection(ctrl)
+			mockDomain := cli.NewMockVirDomain(ctrl)
+			mockCon.EXPECT().LookupDomainByName(gomock.Any()).Return(mockDomain, nil).AnyTimes()
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_CRASHED, int(libvirt.DOMAIN_CRASHED_PANICKED), nil)
+			mockDomain.EXPECT().Free()
+			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).Return(string(x), nil)
+
+			vmi := api2.NewMinimalVMI("fake-vmi")
+			vmi.UID = "4321"
+			vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{Action: v1.PanicActionDump, DumpClaimName: "dump"}
+			vmiStore.Add(vmi)
+			metadataCache := metadata.NewCache()
+			panicEvent := libvirtEvent{
+				Event: &libvirt.DomainEventLifecycle{
+					Event:  libvirt.DOMAIN_EVENT_CRASHED,
+					Detail: int(libvirt.DOMAIN_EVENT_CRASHED_PANICKED),
+				},
+			}
+			eventCallback(mockCon, domain, panicEvent, client, deleteNotificationSent, nil, nil, vmi, nil, metadataCache)
+			event := <-recorder.Events
+			Expect(event).To(Equal("Warning GuestPanicked The guest operating system panicked, panic action: dump involvedObject{kind=VirtualMachineInstance,apiVersion=kubevirt.io/v1}"))
+		})
+
 	})
 
 	Describe("Version mismatch", func() {
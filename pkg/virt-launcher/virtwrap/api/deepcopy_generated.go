@@ -759,6 +759,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Panics != nil {
+		in, out := &in.Panics, &out.Panics
+		*out = make([]PanicDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -786,7 +793,9 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.TPMs != nil {
 		in, out := &in.TPMs, &out.TPMs
 		*out = make([]TPM, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.VSOCK != nil {
 		in, out := &in.VSOCK, &out.VSOCK
@@ -1763,6 +1772,16 @@ func (in *Graphics) DeepCopyInto(out *Graphics) {
 		*out = new(GraphicsListen)
 		**out = **in
 	}
+	if in.GL != nil {
+		in, out := &in.GL, &out.GL
+		*out = new(GraphicsGL)
+		**out = **in
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(GraphicsStreaming)
+		**out = **in
+	}
 	return
 }
 
@@ -1776,6 +1795,22 @@ func (in *Graphics) DeepCopy() *Graphics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphicsGL) DeepCopyInto(out *GraphicsGL) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphicsGL.
+func (in *GraphicsGL) DeepCopy() *GraphicsGL {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphicsGL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GraphicsListen) DeepCopyInto(out *GraphicsListen) {
 	*out = *in
@@ -1792,6 +1827,22 @@ func (in *GraphicsListen) DeepCopy() *GraphicsListen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphicsStreaming) DeepCopyInto(out *GraphicsStreaming) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphicsStreaming.
+func (in *GraphicsStreaming) DeepCopy() *GraphicsStreaming {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphicsStreaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuestOSInfo) DeepCopyInto(out *GuestOSInfo) {
 	*out = *in
@@ -2814,6 +2865,32 @@ func (in *OSType) DeepCopy() *OSType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PanicDevice) DeepCopyInto(out *PanicDevice) {
+	*out = *in
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(Alias)
+		**out = **in
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(Address)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PanicDevice.
+func (in *PanicDevice) DeepCopy() *PanicDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(PanicDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadOnly) DeepCopyInto(out *ReadOnly) {
 	*out = *in
@@ -3312,7 +3389,7 @@ func (in *SysInfo) DeepCopy() *SysInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TPM) DeepCopyInto(out *TPM) {
 	*out = *in
-	out.Backend = in.Backend
+	in.Backend.DeepCopyInto(&out.Backend)
 	return
 }
 
@@ -3329,6 +3406,11 @@ func (in *TPM) DeepCopy() *TPM {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TPMBackend) DeepCopyInto(out *TPMBackend) {
 	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(TPMEncryption)
+		**out = **in
+	}
 	return
 }
 
@@ -3342,6 +3424,22 @@ func (in *TPMBackend) DeepCopy() *TPMBackend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMEncryption) DeepCopyInto(out *TPMEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMEncryption.
+func (in *TPMEncryption) DeepCopy() *TPMEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -3477,6 +3575,22 @@ func (in *Video) DeepCopy() *Video {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VideoAcceleration) DeepCopyInto(out *VideoAcceleration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoAcceleration.
+func (in *VideoAcceleration) DeepCopy() *VideoAcceleration {
+	if in == nil {
+		return nil
+	}
+	out := new(VideoAcceleration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VideoModel) DeepCopyInto(out *VideoModel) {
 	*out = *in
@@ -3500,6 +3614,16 @@ func (in *VideoModel) DeepCopyInto(out *VideoModel) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.Acceleration != nil {
+		in, out := &in.Acceleration, &out.Acceleration
+		*out = new(VideoAcceleration)
+		**out = **in
+	}
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(VideoResolution)
+		**out = **in
+	}
 	return
 }
 
@@ -3513,6 +3637,22 @@ func (in *VideoModel) DeepCopy() *VideoModel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VideoResolution) DeepCopyInto(out *VideoResolution) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoResolution.
+func (in *VideoResolution) DeepCopy() *VideoResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(VideoResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Watchdog) DeepCopyInto(out *Watchdog) {
 	*out = *in
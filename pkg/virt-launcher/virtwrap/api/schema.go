@@ -206,6 +206,7 @@ type DomainSpec struct {
 	MemoryBacking  *MemoryBacking  `xml:"memoryBacking,omitempty"`
 	OS             OS              `xml:"os"`
 	SysInfo        *SysInfo        `xml:"sysinfo,omitempty"`
+	OnCrash        string          `xml:"on_crash,omitempty"`
 	Devices        Devices         `xml:"devices"`
 	Clock          *Clock          `xml:"clock,omitempty"`
 	Resource       *Resource       `xml:"resource,omitempty"`
@@ -528,6 +529,7 @@ type Devices struct {
 	Serials     []Serial           `xml:"serial"`
 	Consoles    []Console          `xml:"console"`
 	Watchdogs   []Watchdog         `xml:"watchdog,omitempty"`
+	Panics      []PanicDevice      `xml:"panic,omitempty"`
 	Rng         *Rng               `xml:"rng,omitempty"`
 	Filesystems []FilesystemDevice `xml:"filesystem,omitempty"`
 	Redirs      []RedirectedDevice `xml:"redirdev,omitempty"`
@@ -543,9 +545,14 @@ type TPM struct {
 }
 
 type TPMBackend struct {
-	Type            string `xml:"type,attr"`
-	Version         string `xml:"version,attr"`
-	PersistentState string `xml:"persistent_state,attr,omitempty"`
+	Type            string         `xml:"type,attr"`
+	Version         string         `xml:"version,attr"`
+	PersistentState string         `xml:"persistent_state,attr,omitempty"`
+	Encryption      *TPMEncryption `xml:"encryption,omitempty"`
+}
+
+type TPMEncryption struct {
+	Secret string `xml:"secret,attr"`
 }
 
 // RedirectedDevice describes a device to be redirected
@@ -876,6 +883,8 @@ type FilterRef struct {
 }
 
 type InterfaceSource struct {
+	Type    string   `xml:"type,attr,omitempty"`
+	Path    string   `xml:"path,attr,omitempty"`
 	Network string   `xml:"network,attr,omitempty"`
 	Device  string   `xml:"dev,attr,omitempty"`
 	Bridge  string   `xml:"bridge,attr,omitempty"`
@@ -1006,10 +1015,11 @@ type BootMenu struct {
 }
 
 type Loader struct {
-	ReadOnly string `xml:"readonly,attr,omitempty"`
-	Secure   string `xml:"secure,attr,omitempty"`
-	Type     string `xml:"type,attr,omitempty"`
-	Path     string `xml:",chardata"`
+	ReadOnly  string `xml:"readonly,attr,omitempty"`
+	Secure    string `xml:"secure,attr,omitempty"`
+	Type      string `xml:"type,attr,omitempty"`
+	Stateless string `xml:"stateless,attr,omitempty"`
+	Path      string `xml:",chardata"`
 }
 
 // TODO <bios rebootTimeout='0'/>
@@ -1101,21 +1111,42 @@ type Video struct {
 }
 
 type VideoModel struct {
-	Type   string `xml:"type,attr"`
-	Heads  *uint  `xml:"heads,attr,omitempty"`
-	Ram    *uint  `xml:"ram,attr,omitempty"`
-	VRam   *uint  `xml:"vram,attr,omitempty"`
-	VGAMem *uint  `xml:"vgamem,attr,omitempty"`
+	Type         string             `xml:"type,attr"`
+	Heads        *uint              `xml:"heads,attr,omitempty"`
+	Ram          *uint              `xml:"ram,attr,omitempty"`
+	VRam         *uint              `xml:"vram,attr,omitempty"`
+	VGAMem       *uint              `xml:"vgamem,attr,omitempty"`
+	Acceleration *VideoAcceleration `xml:"acceleration,omitempty"`
+	Resolution   *VideoResolution   `xml:"resolution,omitempty"`
+}
+
+type VideoAcceleration struct {
+	Accel3D string `xml:"accel3d,attr,omitempty"`
+}
+
+type VideoResolution struct {
+	X uint `xml:"x,attr"`
+	Y uint `xml:"y,attr"`
 }
 
 type Graphics struct {
-	AutoPort      string          `xml:"autoport,attr,omitempty"`
-	DefaultMode   string          `xml:"defaultMode,attr,omitempty"`
-	Listen        *GraphicsListen `xml:"listen,omitempty"`
-	PasswdValidTo string          `xml:"passwdValidTo,attr,omitempty"`
-	Port          int32           `xml:"port,attr,omitempty"`
-	TLSPort       int             `xml:"tlsPort,attr,omitempty"`
-	Type          string          `xml:"type,attr"`
+	AutoPort      string             `xml:"autoport,attr,omitempty"`
+	DefaultMode   string             `xml:"defaultMode,attr,omitempty"`
+	Listen        *GraphicsListen    `xml:"listen,omitempty"`
+	PasswdValidTo string             `xml:"passwdValidTo,attr,omitempty"`
+	Port          int32              `xml:"port,attr,omitempty"`
+	TLSPort       int                `xml:"tlsPort,attr,omitempty"`
+	Type          string             `xml:"type,attr"`
+	GL            *GraphicsGL        `xml:"gl,omitempty"`
+	Streaming     *GraphicsStreaming `xml:"streaming,omitempty"`
+}
+
+type GraphicsStreaming struct {
+	Mode string `xml:"mode,attr"`
+}
+
+type GraphicsGL struct {
+	RenderNode string `xml:"rendernode,attr,omitempty"`
 }
 
 type GraphicsListen struct {
@@ -1177,6 +1208,12 @@ type Watchdog struct {
 	Address *Address `xml:"address,omitempty"`
 }
 
+type PanicDevice struct {
+	Model   string   `xml:"model,attr"`
+	Alias   *Alias   `xml:"alias,omitempty"`
+	Address *Address `xml:"address,omitempty"`
+}
+
 // Rng represents the source of entropy from host to VM
 type Rng struct {
 	// Model attribute specifies what type of RNG device is provided
@@ -111,3 +111,7 @@ func (archConverterAMD64) requiresMPXCPUValidation() bool {
 func (archConverterAMD64) shouldVerboseLogsBeEnabled() bool {
 	return true
 }
+
+func (archConverterAMD64) defaultPanicModel() v1.PanicDeviceModel {
+	return v1.PanicDeviceModelISA
+}
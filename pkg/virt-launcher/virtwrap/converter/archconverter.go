@@ -40,6 +40,7 @@ type ArchConverter interface {
 	isROMTuningSupported() bool
 	requiresMPXCPUValidation() bool
 	shouldVerboseLogsBeEnabled() bool
+	defaultPanicModel() v1.PanicDeviceModel
 }
 
 func NewArchConverter(arch string) ArchConverter {
@@ -93,6 +93,8 @@ type EFIConfiguration struct {
 	EFICode      string
 	EFIVars      string
 	SecureLoader bool
+	// Stateless firmware is loaded as ROM and has no NVRAM
+	Stateless bool
 }
 
 type ConverterContext struct {
@@ -123,6 +125,7 @@ type ConverterContext struct {
 	BochsForEFIGuests               bool
 	SerialConsoleLog                bool
 	DomainAttachmentByInterfaceName map[string]string
+	RenderNode                      string
 }
 
 func contains(volumes []string, name string) bool {
@@ -891,12 +894,37 @@ func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, disk *api.D
 
 func Convert_v1_Watchdog_To_api_Watchdog(source *v1.Watchdog, watchdog *api.Watchdog, _ *ConverterContext) error {
 	watchdog.Alias = api.NewUserDefinedAlias(source.Name)
-	if source.I6300ESB != nil {
+	switch {
+	case source.I6300ESB != nil:
 		watchdog.Model = "i6300esb"
 		watchdog.Action = string(source.I6300ESB.Action)
-		return nil
+	case source.Diag288 != nil:
+		watchdog.Model = "diag288"
+		watchdog.Action = string(source.Diag288.Action)
+	case source.ITCO != nil:
+		watchdog.Model = "itco"
+		watchdog.Action = string(source.ITCO.Action)
+	default:
+		return fmt.Errorf("watchdog %s can't be mapped, no watchdog type specified", source.Name)
+	}
+	return nil
+}
+
+func Convert_v1_PanicDevice_To_api_PanicDevice(source *v1.PanicDevice, panicDevice *api.PanicDevice, c *ConverterContext) {
+	if source.Model != nil {
+		panicDevice.Model = string(*source.Model)
+	} else {
+		panicDevice.Model = string(c.Architecture.defaultPanicModel())
 	}
-	return fmt.Errorf("watchdog %s can't be mapped, no watchdog type specified", source.Name)
+}
+
+// panicOnCrashAction returns the libvirt on_crash action implementing the panic action.
+// The pause and dump actions preserve the panicked guest, the dump itself is requested by virt-controller.
+func panicOnCrashAction(action v1.PanicAction) string {
+	if action == v1.PanicActionRestart {
+		return "restart"
+	}
+	return "preserve"
 }
 
 func Convert_v1_Rng_To_api_Rng(_ *v1.Rng, rng *api.Rng, c *ConverterContext) error {
@@ -1210,7 +1238,14 @@ func Convert_v1_Firmware_To_related_apis(vmi *v1.VirtualMachineInstance, domain
 		},
 	}
 
-	if util.IsEFIVMI(vmi) {
+	if util.IsEFIVMI(vmi) && c.EFIConfiguration.Stateless {
+		domain.Spec.OS.BootLoader = &api.Loader{
+			Path:      c.EFIConfiguration.EFICode,
+			ReadOnly:  "yes",
+			Type:      "rom",
+			Stateless: "yes",
+		}
+	} else if util.IsEFIVMI(vmi) {
 		domain.Spec.OS.BootLoader = &api.Loader{
 			Path:     c.EFIConfiguration.EFICode,
 			ReadOnly: "yes",
@@ -1447,16 +1482,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		return err
 	}
 
-	// Set SEV launch security parameters: https://libvirt.org/formatdomain.html#launch-security
+	// Set launch security parameters: https://libvirt.org/formatdomain.html#launch-security
 	if c.UseLaunchSecurity {
-		sevPolicyBits := launchsecurity.SEVPolicyToBits(vmi.Spec.Domain.LaunchSecurity.SEV.Policy)
-		// Cbitpos and ReducedPhysBits will be filled automatically by libvirt from the domain capabilities
-		domain.Spec.LaunchSecurity = &api.LaunchSecurity{
-			Type:    "sev",
-			Policy:  "0x" + strconv.FormatUint(uint64(sevPolicyBits), 16),
-			DHCert:  vmi.Spec.Domain.LaunchSecurity.SEV.DHCert,
-			Session: vmi.Spec.Domain.LaunchSecurity.SEV.Session,
-		}
+		domain.Spec.LaunchSecurity = convertLaunchSecurity(vmi)
 		controllerDriver = &api.ControllerDriver{
 			IOMMU: "on",
 		}
@@ -1541,6 +1569,16 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 		isMemfdRequired = true
 	}
+	// vhost-user requires the guest memory to be shared with the userspace datapath
+	if hasVhostUserAttachment(c.DomainAttachmentByInterfaceName) {
+		if domain.Spec.MemoryBacking == nil {
+			domain.Spec.MemoryBacking = &api.MemoryBacking{}
+		}
+		domain.Spec.MemoryBacking.Access = &api.MemoryBackingAccess{
+			Mode: "shared",
+		}
+		isMemfdRequired = true
+	}
 
 	if isMemfdRequired {
 		// Set memfd as memory backend to solve SELinux restrictions
@@ -1634,6 +1672,13 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Watchdogs = append(domain.Spec.Devices.Watchdogs, *newWatchdog)
 	}
 
+	if vmi.Spec.Domain.Devices.Panic != nil {
+		newPanic := api.PanicDevice{}
+		Convert_v1_PanicDevice_To_api_PanicDevice(vmi.Spec.Domain.Devices.Panic, &newPanic, c)
+		domain.Spec.Devices.Panics = append(domain.Spec.Devices.Panics, newPanic)
+		domain.Spec.OnCrash = panicOnCrashAction(vmi.Spec.Domain.Devices.Panic.Action)
+	}
+
 	if vmi.Spec.Domain.Devices.Rng != nil {
 		newRng := &api.Rng{}
 		err := Convert_v1_Rng_To_api_Rng(vmi.Spec.Domain.Devices.Rng, newRng, c)
@@ -1828,6 +1873,22 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				Type: "vnc",
 			},
 		}
+		if spice := vmi.Spec.Domain.Devices.Spice; spice != nil {
+			domain.Spec.Devices.Graphics = append(domain.Spec.Devices.Graphics, convertSpice(vmi, spice))
+		}
+		if video := vmi.Spec.Domain.Devices.Video; video != nil {
+			domain.Spec.Devices.Video = []api.Video{convertVideoDevice(video)}
+			if video.Accel3D != nil && *video.Accel3D {
+				if c.RenderNode == "" {
+					return fmt.Errorf("3D acceleration requires a GPU render node, none was allocated")
+				}
+				// VNC can not display an OpenGL scanout, egl-headless renders it and copies it back
+				domain.Spec.Devices.Graphics = append(domain.Spec.Devices.Graphics, api.Graphics{
+					Type: "egl-headless",
+					GL:   &api.GraphicsGL{RenderNode: c.RenderNode},
+				})
+			}
+		}
 	}
 
 	domainInterfaces, err := CreateDomainInterfaces(vmi, c)
@@ -1883,6 +1944,11 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			//   as it is now the generally preferred model
 			domain.Spec.Devices.TPMs[0].Model = "tpm-crb"
 		}
+		if vmi.Spec.Domain.Devices.TPM.Encryption != nil {
+			domain.Spec.Devices.TPMs[0].Backend.Encryption = &api.TPMEncryption{
+				Secret: TPMEncryptionSecretUUID(vmi),
+			}
+		}
 	}
 
 	// Handle VSOCK CID
@@ -2034,6 +2100,12 @@ func hasTabletDevice(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// TPMEncryptionSecretUUID returns the UUID of the libvirt secret which holds
+// the key encrypting the TPM state of the VMI.
+func TPMEncryptionSecretUUID(vmi *v1.VirtualMachineInstance) string {
+	return string(vmi.UID)
+}
+
 func GracePeriodSeconds(vmi *v1.VirtualMachineInstance) int64 {
 	gracePeriodSeconds := v1.DefaultGracePeriodSeconds
 	if vmi.Spec.TerminationGracePeriodSeconds != nil {
@@ -2077,3 +2149,63 @@ func domainVCPUTopologyForHotplug(vmi *v1.VirtualMachineInstance, domain *api.Do
 		CPUs:      cpuCount,
 	}
 }
+
+func convertLaunchSecurity(vmi *v1.VirtualMachineInstance) *api.LaunchSecurity {
+	launchSecurity := vmi.Spec.Domain.LaunchSecurity
+	switch {
+	case launchSecurity.SNP != nil:
+		sevSNPPolicyBits := launchsecurity.SEVSNPPolicyToBits(launchSecurity.SNP.Policy)
+		return &api.LaunchSecurity{
+			Type:   string(v1.LaunchSecurityTypeSEVSNP),
+			Policy: "0x" + strconv.FormatUint(sevSNPPolicyBits, 16),
+		}
+	case launchSecurity.TDX != nil:
+		return &api.LaunchSecurity{
+			Type:   string(v1.LaunchSecurityTypeTDX),
+			Policy: "0x" + strconv.FormatUint(launchsecurity.TDXPolicyToBits(), 16),
+		}
+	default:
+		sevPolicyBits := launchsecurity.SEVPolicyToBits(launchSecurity.SEV.Policy)
+		// Cbitpos and ReducedPhysBits will be filled automatically by libvirt from the domain capabilities
+		return &api.LaunchSecurity{
+			Type:    string(v1.LaunchSecurityTypeSEV),
+			Policy:  "0x" + strconv.FormatUint(uint64(sevPolicyBits), 16),
+			DHCert:  launchSecurity.SEV.DHCert,
+			Session: launchSecurity.SEV.Session,
+		}
+	}
+}
+
+func convertVideoDevice(video *v1.VideoDevice) api.Video {
+	model := api.VideoModel{
+		Type:  string(video.Type),
+		Heads: pointer.P(graphicsDeviceDefaultHeads),
+	}
+	if video.Heads != nil {
+		model.Heads = pointer.P(uint(*video.Heads))
+	}
+	if video.Resolution != nil {
+		model.Resolution = &api.VideoResolution{
+			X: uint(video.Resolution.Width),
+			Y: uint(video.Resolution.Height),
+		}
+	}
+	if video.Accel3D != nil && *video.Accel3D {
+		model.Acceleration = &api.VideoAcceleration{Accel3D: "yes"}
+	}
+	return api.Video{Model: model}
+}
+
+func convertSpice(vmi *v1.VirtualMachineInstance, spice *v1.Spice) api.Graphics {
+	graphics := api.Graphics{
+		Listen: &api.GraphicsListen{
+			Type:   "socket",
+			Socket: fmt.Sprintf("/var/run/kubevirt-private/%s/virt-spice", vmi.ObjectMeta.UID),
+		},
+		Type: "spice",
+	}
+	if spice.StreamingMode != "" {
+		graphics.Streaming = &api.GraphicsStreaming{Mode: string(spice.StreamingMode)}
+	}
+	return graphics
+}
@@ -51,6 +51,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/downwardmetrics"
 	"kubevirt.io/kubevirt/pkg/ephemeral-disk/fake"
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 
@@ -1652,6 +1653,35 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
 			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("ethernet"))
 		})
+		It("Should create vhost-user network configuration for an interface using a binding plugin with vhostuser domain attachment", func() {
+			bindingName := "BindingName"
+			c.DomainAttachmentByInterfaceName[netName1] = string(v1.VhostUser)
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+			iface1 := v1.Interface{Name: netName1, Binding: &v1.PluginBinding{Name: bindingName}, MacAddress: "de:ad:00:00:be:af"}
+			net1 := v1.Network{
+				Name:          netName1,
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "dpdk-net"}},
+			}
+
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork(), net1}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface(), iface1}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(2))
+			vhostUserIface := domain.Spec.Devices.Interfaces[1]
+			Expect(vhostUserIface.Type).To(Equal("vhostuser"))
+			Expect(vhostUserIface.Source).To(Equal(api.InterfaceSource{
+				Type: "unix",
+				Path: vhostuser.SocketPath(netName1),
+				Mode: "server",
+			}))
+			Expect(vhostUserIface.MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+			Expect(domain.Spec.MemoryBacking.HugePages).ToNot(BeNil())
+			Expect(domain.Spec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+		})
 		It("Shouldn't create network configuration for an interface using a binding plugin with non-tap domain attachment", func() {
 			bindingName := "BindingName"
 			c.DomainAttachmentByInterfaceName[bindingName] = "non-tap"
@@ -1745,6 +1775,111 @@ var _ = Describe("Converter", func() {
 				"Type": Equal("vnc"),
 			})))
 		})
+
+		DescribeTable("should convert a virtio video device", func(arch string, video *v1.VideoDevice, expectedModel api.VideoModel, expectedGraphics []string) {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{Video: video},
+					},
+				},
+			}
+
+			domain := vmiToDomain(&vmi, &ConverterContext{Architecture: NewArchConverter(arch), AllowEmulation: true, RenderNode: "/dev/dri/renderD129"})
+			Expect(domain.Spec.Devices.Video).To(Equal([]api.Video{{Model: expectedModel}}))
+			var graphics []string
+			for _, g := range domain.Spec.Devices.Graphics {
+				graphics = append(graphics, g.Type)
+			}
+			Expect(graphics).To(Equal(expectedGraphics))
+		},
+			Entry("with defaults on amd64", "amd64",
+				&v1.VideoDevice{Type: v1.VideoTypeVirtIO},
+				api.VideoModel{Type: "virtio", Heads: kubevirtpointer.P(uint(1))},
+				[]string{"vnc"}),
+			Entry("with heads and resolution on arm64", "arm64",
+				&v1.VideoDevice{Type: v1.VideoTypeVirtIO, Heads: kubevirtpointer.P(uint32(2)), Resolution: &v1.VideoResolution{Width: 2560, Height: 1440}},
+				api.VideoModel{Type: "virtio", Heads: kubevirtpointer.P(uint(2)), Resolution: &api.VideoResolution{X: 2560, Y: 1440}},
+				[]string{"vnc"}),
+			Entry("with 3D acceleration", "amd64",
+				&v1.VideoDevice{Type: v1.VideoTypeVirtIO, Accel3D: kubevirtpointer.P(true)},
+				api.VideoModel{Type: "virtio", Heads: kubevirtpointer.P(uint(1)), Acceleration: &api.VideoAcceleration{Accel3D: "yes"}},
+				[]string{"vnc", "egl-headless"}),
+		)
+
+		DescribeTable("should add a SPICE graphics device next to VNC", func(spice *v1.Spice, expectedStreaming *api.GraphicsStreaming) {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{Spice: spice},
+					},
+				},
+			}
+
+			domain := vmiToDomain(&vmi, &ConverterContext{Architecture: NewArchConverter(runtime.GOARCH), AllowEmulation: true})
+			Expect(domain.Spec.Devices.Graphics).To(HaveLen(2))
+			Expect(domain.Spec.Devices.Graphics[0].Type).To(Equal("vnc"))
+			Expect(domain.Spec.Devices.Graphics[1]).To(Equal(api.Graphics{
+				Type: "spice",
+				Listen: &api.GraphicsListen{
+					Type:   "socket",
+					Socket: "/var/run/kubevirt-private/1234/virt-spice",
+				},
+				Streaming: expectedStreaming,
+			}))
+		},
+			Entry("with the default streaming mode", &v1.Spice{}, nil),
+			Entry("with video streaming disabled", &v1.Spice{StreamingMode: v1.SpiceStreamingModeOff}, &api.GraphicsStreaming{Mode: "off"}),
+		)
+
+		It("should not add a SPICE graphics device without a graphics device", func() {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{Name: "testvmi", Namespace: "default", UID: "1234"},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{Spice: &v1.Spice{}, AutoattachGraphicsDevice: kubevirtpointer.P(false)},
+					},
+				},
+			}
+
+			domain := vmiToDomain(&vmi, &ConverterContext{Architecture: NewArchConverter(runtime.GOARCH), AllowEmulation: true})
+			Expect(domain.Spec.Devices.Graphics).To(BeEmpty())
+		})
+
+		It("should render the GPU render node of a 3D accelerated video device", func() {
+			domain := &api.Domain{}
+			domain.Spec.Devices.Video = []api.Video{convertVideoDevice(&v1.VideoDevice{Type: v1.VideoTypeVirtIO, Accel3D: kubevirtpointer.P(true)})}
+			domain.Spec.Devices.Graphics = []api.Graphics{{Type: "egl-headless", GL: &api.GraphicsGL{RenderNode: "/dev/dri/renderD129"}}}
+
+			out, err := xml.Marshal(domain.Spec.Devices)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring(`<video><model type="virtio" heads="1"><acceleration accel3d="yes"></acceleration></model></video>`))
+			Expect(string(out)).To(ContainSubstring(`<graphics type="egl-headless"><gl rendernode="/dev/dri/renderD129"></gl></graphics>`))
+		})
+
+		It("should fail to convert a 3D accelerated video device without a render node", func() {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{Name: "testvmi", Namespace: "default"},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{Video: &v1.VideoDevice{Type: v1.VideoTypeVirtIO, Accel3D: kubevirtpointer.P(true)}},
+					},
+				},
+			}
+
+			err := Convert_v1_VirtualMachineInstance_To_api_Domain(&vmi, &api.Domain{}, &ConverterContext{Architecture: NewArchConverter(runtime.GOARCH), AllowEmulation: true})
+			Expect(err).To(MatchError(ContainSubstring("requires a GPU render node")))
+		})
 	})
 
 	Context("HyperV", func() {
@@ -3042,6 +3177,32 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces[1].Rom).ToNot(BeNil())
 			Expect(domain.Spec.Devices.Interfaces[1].Rom.Enabled).To(Equal("no"))
 		})
+
+		It("should set LaunchSecurity domain element with 'sev-snp' type and boot the stateless firmware", func() {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{Policy: &v1.SEVSNPPolicy{SMT: kubevirtpointer.P(false)}},
+			}
+			c.EFIConfiguration = &EFIConfiguration{EFICode: "/usr/share/OVMF/OVMF.amdsev.fd", Stateless: true}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity).To(Equal(&api.LaunchSecurity{Type: "sev-snp", Policy: "0x20000"}))
+			Expect(domain.Spec.OS.BootLoader).To(Equal(&api.Loader{
+				Path:      "/usr/share/OVMF/OVMF.amdsev.fd",
+				ReadOnly:  "yes",
+				Type:      "rom",
+				Stateless: "yes",
+			}))
+			Expect(domain.Spec.OS.NVRam).To(BeNil())
+		})
+
+		It("should set LaunchSecurity domain element with 'tdx' type", func() {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{TDX: &v1.TDX{}}
+			c.EFIConfiguration = &EFIConfiguration{EFICode: "/usr/share/OVMF/OVMF.inteltdx.fd", Stateless: true}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity).To(Equal(&api.LaunchSecurity{Type: "tdx", Policy: "0x10000000"}))
+			Expect(domain.Spec.Devices.Interfaces[0].Driver.IOMMU).To(Equal("on"))
+		})
 	})
 
 	Context("when TSC Frequency", func() {
@@ -3148,6 +3309,79 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("with watchdog and panic devices", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = kvapi.NewMinimalVMI("testvmi")
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		newContext := func(arch string) *ConverterContext {
+			return &ConverterContext{
+				Architecture:   NewArchConverter(arch),
+				AllowEmulation: true,
+			}
+		}
+
+		DescribeTable("should convert the watchdog model", func(device v1.WatchdogDevice, expectedModel string, expectPCIAddress bool) {
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{Name: "mywatchdog", WatchdogDevice: device}
+			domain := vmiToDomain(vmi, newContext("amd64"))
+			Expect(domain.Spec.Devices.Watchdogs).To(HaveLen(1))
+			watchdog := domain.Spec.Devices.Watchdogs[0]
+			Expect(watchdog.Model).To(Equal(expectedModel))
+			Expect(watchdog.Action).To(Equal("reset"))
+
+			spec := domain.Spec.DeepCopy()
+			Expect(PlacePCIDevicesOnRootComplex(spec)).To(Succeed())
+			Expect(spec.Devices.Watchdogs[0].Address != nil).To(Equal(expectPCIAddress))
+		},
+			Entry("i6300esb", v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{Action: v1.WatchdogActionReset}}, "i6300esb", true),
+			Entry("diag288", v1.WatchdogDevice{Diag288: &v1.Diag288Watchdog{Action: v1.WatchdogActionReset}}, "diag288", false),
+			Entry("itco", v1.WatchdogDevice{ITCO: &v1.ITCOWatchdog{Action: v1.WatchdogActionReset}}, "itco", false),
+		)
+
+		DescribeTable("should default the panic device model by architecture", func(arch string, expectedModel string) {
+			vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{}
+			domain := vmiToDomain(vmi, newContext(arch))
+			Expect(domain.Spec.Devices.Panics).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Panics[0].Model).To(Equal(expectedModel))
+		},
+			Entry("on amd64", "amd64", "isa"),
+			Entry("on arm64", "arm64", "pvpanic"),
+			Entry("on ppc64le", "ppc64le", "pvpanic"),
+			Entry("on s390x", "s390x", "s390"),
+		)
+
+		It("should place a pvpanic device on the root complex", func() {
+			model := v1.PanicDeviceModelPVPanic
+			vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{Model: &model}
+			domain := vmiToDomain(vmi, newContext("amd64"))
+			spec := domain.Spec.DeepCopy()
+			Expect(PlacePCIDevicesOnRootComplex(spec)).To(Succeed())
+			Expect(spec.Devices.Panics).To(HaveLen(1))
+			Expect(spec.Devices.Panics[0].Address).ToNot(BeNil())
+			Expect(spec.Devices.Panics[0].Address.Type).To(Equal("pci"))
+		})
+
+		DescribeTable("should set the crash action according to the panic action", func(action v1.PanicAction, expectedOnCrash string) {
+			vmi.Spec.Domain.Devices.Panic = &v1.PanicDevice{Action: action}
+			domain := vmiToDomain(vmi, newContext("amd64"))
+			Expect(domain.Spec.OnCrash).To(Equal(expectedOnCrash))
+		},
+			Entry("restart", v1.PanicActionRestart, "restart"),
+			Entry("pause", v1.PanicActionPause, "preserve"),
+			Entry("dump", v1.PanicActionDump, "preserve"),
+			Entry("default", v1.PanicAction(""), "preserve"),
+		)
+
+		It("should not add a panic device or crash action when no panic device is requested", func() {
+			domain := vmiToDomain(vmi, newContext("amd64"))
+			Expect(domain.Spec.Devices.Panics).To(BeEmpty())
+			Expect(domain.Spec.OnCrash).To(BeEmpty())
+		})
+	})
+
 	Context("with Paused strategy", func() {
 		var (
 			vmi *v1.VirtualMachineInstance
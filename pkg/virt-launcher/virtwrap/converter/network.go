@@ -27,6 +27,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
 
+	"kubevirt.io/kubevirt/pkg/network/vhostuser"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
@@ -48,7 +49,8 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, c *ConverterContext)
 			return nil, fmt.Errorf("failed to find network %s", iface.Name)
 		}
 
-		if (iface.Binding != nil && c.DomainAttachmentByInterfaceName[iface.Name] != string(v1.Tap)) || iface.SRIOV != nil {
+		domainAttachment := c.DomainAttachmentByInterfaceName[iface.Name]
+		if (iface.Binding != nil && domainAttachment != string(v1.Tap) && domainAttachment != string(v1.VhostUser)) || iface.SRIOV != nil {
 			continue
 		}
 
@@ -77,7 +79,8 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, c *ConverterContext)
 			domainIface.ACPI = &api.ACPI{Index: uint(iface.ACPIIndex)}
 		}
 
-		if c.DomainAttachmentByInterfaceName[iface.Name] == string(v1.Tap) {
+		switch domainAttachment {
+		case string(v1.Tap):
 			// use "ethernet" interface type, since we're using pre-configured tap devices
 			// https://libvirt.org/formatdomain.html#elementsNICSEthernet
 			domainIface.Type = "ethernet"
@@ -87,6 +90,21 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, c *ConverterContext)
 				// s390x does not support setting ROM tuning, as it is for PCI Devices only
 				domainIface.Rom = &api.Rom{Enabled: "no"}
 			}
+		case string(v1.VhostUser):
+			// the userspace datapath connects as a client to the socket QEMU is serving
+			// https://libvirt.org/formatdomain.html#vhost-user-connection
+			domainIface.Type = "vhostuser"
+			domainIface.Source = api.InterfaceSource{
+				Type: "unix",
+				Path: vhostuser.SocketPath(iface.Name),
+				Mode: "server",
+			}
+			if iface.MacAddress != "" {
+				domainIface.MAC = &api.MAC{MAC: iface.MacAddress}
+			}
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			}
 		}
 
 		if c.UseLaunchSecurity {
@@ -106,6 +124,15 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, c *ConverterContext)
 	return domainInterfaces, nil
 }
 
+func hasVhostUserAttachment(domainAttachmentByInterfaceName map[string]string) bool {
+	for _, domainAttachment := range domainAttachmentByInterfaceName {
+		if domainAttachment == string(v1.VhostUser) {
+			return true
+		}
+	}
+	return false
+}
+
 func GetInterfaceType(iface *v1.Interface) string {
 	if iface.Model != "" {
 		return iface.Model
@@ -54,11 +54,24 @@ func PlacePCIDevicesOnRootComplex(spec *api.DomainSpec) (err error) {
 		}
 	}
 	for i, watchdog := range spec.Devices.Watchdogs {
+		// diag288 and itco are not PCI devices
+		if watchdog.Model != "i6300esb" {
+			continue
+		}
 		spec.Devices.Watchdogs[i].Address, err = assigner.PlacePCIDeviceAtNextSlot(watchdog.Address)
 		if err != nil {
 			return err
 		}
 	}
+	for i, panicDevice := range spec.Devices.Panics {
+		if panicDevice.Model != string(v1.PanicDeviceModelPVPanic) {
+			continue
+		}
+		spec.Devices.Panics[i].Address, err = assigner.PlacePCIDeviceAtNextSlot(panicDevice.Address)
+		if err != nil {
+			return err
+		}
+	}
 	if spec.Devices.Rng != nil {
 		spec.Devices.Rng.Address, err = assigner.PlacePCIDeviceAtNextSlot(spec.Devices.Rng.Address)
 		if err != nil {
@@ -81,3 +81,7 @@ func (archConverterPPC64) requiresMPXCPUValidation() bool {
 func (archConverterPPC64) shouldVerboseLogsBeEnabled() bool {
 	return false
 }
+
+func (archConverterPPC64) defaultPanicModel() v1.PanicDeviceModel {
+	return v1.PanicDeviceModelPVPanic
+}
@@ -82,3 +82,7 @@ func (archConverterS390X) requiresMPXCPUValidation() bool {
 func (archConverterS390X) shouldVerboseLogsBeEnabled() bool {
 	return false
 }
+
+func (archConverterS390X) defaultPanicModel() v1.PanicDeviceModel {
+	return v1.PanicDeviceModelS390
+}
@@ -33,6 +33,9 @@ const (
 	EFIVarsSecureBoot = "OVMF_VARS.secboot.fd"
 	EFICodeSEV        = "OVMF_CODE.cc.fd"
 	EFIVarsSEV        = EFIVars
+	// SEV-SNP and TDX guests boot from stateless firmware images without vars
+	EFICodeSEVSNP = "OVMF.amdsev.fd"
+	EFICodeTDX    = "OVMF.inteltdx.fd"
 )
 
 type EFIEnvironment struct {
@@ -42,6 +45,8 @@ type EFIEnvironment struct {
 	varsSecureBoot string
 	codeSEV        string
 	varsSEV        string
+	codeSEVSNP     string
+	codeTDX        string
 }
 
 func (e *EFIEnvironment) Bootable(secureBoot, sev bool) bool {
@@ -74,6 +79,16 @@ func (e *EFIEnvironment) EFIVars(secureBoot, sev bool) string {
 	}
 }
 
+// EFICodeSEVSNP returns the stateless firmware for SEV-SNP guests, empty if not available
+func (e *EFIEnvironment) EFICodeSEVSNP() string {
+	return e.codeSEVSNP
+}
+
+// EFICodeTDX returns the stateless firmware for TDX guests, empty if not available
+func (e *EFIEnvironment) EFICodeTDX() string {
+	return e.codeTDX
+}
+
 func DetectEFIEnvironment(arch, ovmfPath string) *EFIEnvironment {
 	if arch == "arm64" {
 		codeArm64 := getEFIBinaryIfExists(ovmfPath, EFICodeAARCH64)
@@ -103,6 +118,8 @@ func DetectEFIEnvironment(arch, ovmfPath string) *EFIEnvironment {
 	varsWithSEV := getEFIBinaryIfExists(ovmfPath, EFIVarsSEV)
 
 	return &EFIEnvironment{
+		codeSEVSNP:     getEFIBinaryIfExists(ovmfPath, EFICodeSEVSNP),
+		codeTDX:        getEFIBinaryIfExists(ovmfPath, EFICodeTDX),
 		codeSecureBoot: codeWithSB,
 		varsSecureBoot: varsWithSB,
 		code:           code,
@@ -0,0 +1,323 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package efi
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+)
+
+// Names of the entries holding the PEM encoded SecureBoot certificates
+const (
+	SecureBootPK  = "PK"
+	SecureBootKEK = "KEK"
+	SecureBootDB  = "db"
+	SecureBootDBX = "dbx"
+)
+
+const (
+	fvLengthOffset       = 32
+	fvSignatureOffset    = 40
+	fvHeaderLengthOffset = 48
+	varStoreHeaderSize   = 28
+	varHeaderSize        = 60
+	varStartID           = 0x55AA
+
+	varStoreFormatted = 0x5A
+	varStoreHealthy   = 0xFE
+	erasedByte        = 0xFF
+
+	varAdded   = 0x3F
+	varDeleted = 0xFD
+
+	attrNonVolatile           = 0x01
+	attrBootServiceAccess     = 0x02
+	attrRuntimeAccess         = 0x04
+	attrTimeBasedAuthenticate = 0x20
+
+	attrSecureBootKey = attrNonVolatile | attrBootServiceAccess | attrRuntimeAccess | attrTimeBasedAuthenticate
+	attrSetupMode     = attrNonVolatile | attrBootServiceAccess
+)
+
+var (
+	authenticatedVariableStoreGUID = efiGUID("aaf32c78-947b-439a-a180-2e144ec37792")
+	globalVariableGUID             = efiGUID("8be4df61-93ca-11d2-aa0d-00e098032b8c")
+	imageSecurityDatabaseGUID      = efiGUID("d719b2cb-3d3a-4596-a3bc-dad00e67656f")
+	secureBootEnableDisableGUID    = efiGUID("f0a30bc7-af08-4556-99c4-001009c93a44")
+	customModeGUID                 = efiGUID("c076ec0c-7028-4399-a072-71ee5c448b9f")
+	certX509GUID                   = efiGUID("a5c059a1-94e4-4aa7-87b5-ab155c2bf072")
+	// signatureOwnerGUID identifies KubeVirt as the owner of the enrolled certificates
+	signatureOwnerGUID = efiGUID("bb6ef8ec-6b1c-4b3b-a2a5-6d8f3d9c1e70")
+)
+
+// SecureBootKeys holds the DER encoded certificates enrolled in the SecureBoot databases
+type SecureBootKeys struct {
+	PK  []byte
+	KEK [][]byte
+	DB  [][]byte
+	DBX [][]byte
+}
+
+type variable struct {
+	offset     int
+	state      byte
+	attributes uint32
+	name       string
+	vendor     [16]byte
+	data       []byte
+}
+
+// ReadSecureBootKeys reads the SecureBoot certificates from the directory a Secret or ConfigMap is mounted to
+func ReadSecureBootKeys(dir string) (*SecureBootKeys, error) {
+	readCerts := func(name string, optional bool) ([][]byte, error) {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) && optional {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		certs, err := decodeCertificates(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		return certs, nil
+	}
+
+	pk, err := readCerts(SecureBootPK, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(pk) != 1 {
+		return nil, fmt.Errorf("%s must contain exactly one certificate, found %d", SecureBootPK, len(pk))
+	}
+	keys := &SecureBootKeys{PK: pk[0]}
+	if keys.KEK, err = readCerts(SecureBootKEK, false); err != nil {
+		return nil, err
+	}
+	if keys.DB, err = readCerts(SecureBootDB, false); err != nil {
+		return nil, err
+	}
+	if keys.DBX, err = readCerts(SecureBootDBX, true); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// EnrollSecureBootKeys writes a copy of the NVRAM vars template to output, with the
+// SecureBoot databases replaced by the given keys and SecureBoot enabled.
+func EnrollSecureBootKeys(template, output string, keys *SecureBootKeys) error {
+	content, err := os.ReadFile(template)
+	if err != nil {
+		return err
+	}
+
+	timestamp := efiTime(time.Now().UTC())
+	enrolled := []variable{
+		{name: SecureBootPK, vendor: globalVariableGUID, attributes: attrSecureBootKey, data: signatureLists(keys.PK)},
+		{name: SecureBootKEK, vendor: globalVariableGUID, attributes: attrSecureBootKey, data: signatureLists(keys.KEK...)},
+		{name: SecureBootDB, vendor: imageSecurityDatabaseGUID, attributes: attrSecureBootKey, data: signatureLists(keys.DB...)},
+		{name: "SecureBootEnable", vendor: secureBootEnableDisableGUID, attributes: attrSetupMode, data: []byte{1}},
+		{name: "CustomMode", vendor: customModeGUID, attributes: attrSetupMode, data: []byte{0}},
+	}
+	if len(keys.DBX) > 0 {
+		enrolled = append(enrolled, variable{name: SecureBootDBX, vendor: imageSecurityDatabaseGUID, attributes: attrSecureBootKey, data: signatureLists(keys.DBX...)})
+	}
+
+	existing, free, end, err := readVariables(content)
+	if err != nil {
+		return fmt.Errorf("invalid NVRAM vars template %s: %v", template, err)
+	}
+	for _, v := range existing {
+		for _, e := range enrolled {
+			if v.name == e.name && v.vendor == e.vendor {
+				content[v.offset+2] &= varDeleted
+			}
+		}
+	}
+
+	for _, v := range enrolled {
+		raw := v.marshal(timestamp)
+		if free+len(raw) > end {
+			return fmt.Errorf("not enough space in the NVRAM vars template %s", template)
+		}
+		copy(content[free:], raw)
+		free = align4(free + len(raw))
+	}
+
+	return os.WriteFile(output, content, 0600)
+}
+
+// readVariables returns the variables of the authenticated variable store found in an
+// OVMF vars firmware volume, the offset of its free space and the end of the store.
+// Anything which does not match the expected layout is refused, since writing to a
+// store which is not fully understood could corrupt the NVRAM of the guest.
+func readVariables(content []byte) ([]variable, int, int, error) {
+	if len(content) < fvHeaderLengthOffset+2 || string(content[fvSignatureOffset:fvSignatureOffset+4]) != "_FVH" {
+		return nil, 0, 0, fmt.Errorf("firmware volume header not found")
+	}
+	if fvLength := binary.LittleEndian.Uint64(content[fvLengthOffset:]); fvLength != uint64(len(content)) {
+		return nil, 0, 0, fmt.Errorf("firmware volume length %d does not match the file size %d", fvLength, len(content))
+	}
+	storeOffset := int(binary.LittleEndian.Uint16(content[fvHeaderLengthOffset:]))
+	if len(content) < storeOffset+varStoreHeaderSize || !bytes.Equal(content[storeOffset:storeOffset+16], authenticatedVariableStoreGUID[:]) {
+		return nil, 0, 0, fmt.Errorf("authenticated variable store not found")
+	}
+	if content[storeOffset+20] != varStoreFormatted || content[storeOffset+21] != varStoreHealthy {
+		return nil, 0, 0, fmt.Errorf("variable store is not formatted or not healthy")
+	}
+	end := storeOffset + int(binary.LittleEndian.Uint32(content[storeOffset+16:]))
+	if end > len(content) {
+		return nil, 0, 0, fmt.Errorf("variable store exceeds the firmware volume")
+	}
+
+	var variables []variable
+	offset := align4(storeOffset + varStoreHeaderSize)
+	for offset+varHeaderSize <= end && binary.LittleEndian.Uint16(content[offset:]) == varStartID {
+		nameSize := int(binary.LittleEndian.Uint32(content[offset+36:]))
+		dataSize := int(binary.LittleEndian.Uint32(content[offset+40:]))
+		nameStart := offset + varHeaderSize
+		if nameStart+nameSize+dataSize > end {
+			return nil, 0, 0, fmt.Errorf("variable at offset %d exceeds the variable store", offset)
+		}
+		v := variable{
+			offset:     offset,
+			state:      content[offset+2],
+			attributes: binary.LittleEndian.Uint32(content[offset+4:]),
+			name:       decodeName(content[nameStart : nameStart+nameSize]),
+			data:       content[nameStart+nameSize : nameStart+nameSize+dataSize],
+		}
+		copy(v.vendor[:], content[offset+44:offset+60])
+		variables = append(variables, v)
+		offset = align4(nameStart + nameSize + dataSize)
+	}
+	for i := offset; i < end; i++ {
+		if content[i] != erasedByte {
+			return nil, 0, 0, fmt.Errorf("unexpected data at offset %d of the variable store", i)
+		}
+	}
+	return variables, offset, end, nil
+}
+
+func (v *variable) marshal(timestamp []byte) []byte {
+	name := encodeName(v.name)
+	buf := make([]byte, varHeaderSize, varHeaderSize+len(name)+len(v.data))
+	binary.LittleEndian.PutUint16(buf[0:], varStartID)
+	buf[2] = varAdded
+	binary.LittleEndian.PutUint32(buf[4:], v.attributes)
+	if v.attributes&attrTimeBasedAuthenticate != 0 {
+		copy(buf[16:32], timestamp)
+	}
+	binary.LittleEndian.PutUint32(buf[36:], uint32(len(name)))
+	binary.LittleEndian.PutUint32(buf[40:], uint32(len(v.data)))
+	copy(buf[44:60], v.vendor[:])
+	buf = append(buf, name...)
+	return append(buf, v.data...)
+}
+
+// signatureLists encodes every certificate as an EFI_SIGNATURE_LIST of type EFI_CERT_X509
+func signatureLists(certs ...[]byte) []byte {
+	var buf []byte
+	for _, cert := range certs {
+		list := make([]byte, 28)
+		copy(list, certX509GUID[:])
+		binary.LittleEndian.PutUint32(list[16:], uint32(28+16+len(cert)))
+		binary.LittleEndian.PutUint32(list[24:], uint32(16+len(cert)))
+		list = append(list, signatureOwnerGUID[:]...)
+		buf = append(buf, append(list, cert...)...)
+	}
+	return buf
+}
+
+func decodeCertificates(content []byte) ([][]byte, error) {
+	var certs [][]byte
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+		certs = append(certs, block.Bytes)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// efiGUID returns the mixed-endian binary encoding of a GUID used by UEFI
+func efiGUID(s string) [16]byte {
+	guid := uuid.MustParse(s)
+	binary.LittleEndian.PutUint32(guid[0:], binary.BigEndian.Uint32(guid[0:]))
+	binary.LittleEndian.PutUint16(guid[4:], binary.BigEndian.Uint16(guid[4:]))
+	binary.LittleEndian.PutUint16(guid[6:], binary.BigEndian.Uint16(guid[6:]))
+	return guid
+}
+
+func efiTime(t time.Time) []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint16(buf[0:], uint16(t.Year()))
+	buf[2] = byte(t.Month())
+	buf[3] = byte(t.Day())
+	buf[4] = byte(t.Hour())
+	buf[5] = byte(t.Minute())
+	buf[6] = byte(t.Second())
+	return buf
+}
+
+func encodeName(name string) []byte {
+	chars := append(utf16.Encode([]rune(name)), 0)
+	buf := make([]byte, 2*len(chars))
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(buf[2*i:], c)
+	}
+	return buf
+}
+
+func decodeName(buf []byte) string {
+	chars := make([]uint16, 0, len(buf)/2)
+	for i := 0; i+1 < len(buf); i += 2 {
+		c := binary.LittleEndian.Uint16(buf[i:])
+		if c == 0 {
+			break
+		}
+		chars = append(chars, c)
+	}
+	return string(utf16.Decode(chars))
+}
+
+func align4(offset int) int {
+	return (offset + 3) &^ 3
+}
@@ -0,0 +1,205 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package efi
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecureBoot keys enrollment", func() {
+	const (
+		fvHeaderLength = 72
+		varStoreSize   = 4096
+	)
+
+	newCertificate := func(cn string) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+		return der
+	}
+
+	encodePEM := func(certs ...[]byte) []byte {
+		var buf bytes.Buffer
+		for _, cert := range certs {
+			Expect(pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert})).To(Succeed())
+		}
+		return buf.Bytes()
+	}
+
+	// newVarsContent creates a minimal OVMF vars firmware volume holding the given variables
+	newVarsContent := func(variables ...variable) []byte {
+		content := bytes.Repeat([]byte{0xFF}, fvHeaderLength+varStoreSize)
+		binary.LittleEndian.PutUint64(content[fvLengthOffset:], uint64(len(content)))
+		copy(content[fvSignatureOffset:], "_FVH")
+		binary.LittleEndian.PutUint16(content[fvHeaderLengthOffset:], fvHeaderLength)
+		copy(content[fvHeaderLength:], authenticatedVariableStoreGUID[:])
+		binary.LittleEndian.PutUint32(content[fvHeaderLength+16:], varStoreSize)
+		content[fvHeaderLength+20] = varStoreFormatted
+		content[fvHeaderLength+21] = varStoreHealthy
+
+		offset := align4(fvHeaderLength + varStoreHeaderSize)
+		for _, v := range variables {
+			raw := v.marshal(efiTime(time.Now()))
+			copy(content[offset:], raw)
+			offset = align4(offset + len(raw))
+		}
+		return content
+	}
+
+	writeVarsTemplate := func(content []byte) string {
+		template := filepath.Join(GinkgoT().TempDir(), "OVMF_VARS.fd")
+		Expect(os.WriteFile(template, content, 0644)).To(Succeed())
+		return template
+	}
+
+	newVarsTemplate := func(variables ...variable) string {
+		return writeVarsTemplate(newVarsContent(variables...))
+	}
+
+	liveVariable := func(variables []variable, name string) *variable {
+		var found *variable
+		for i := range variables {
+			if variables[i].name == name && variables[i].state == varAdded {
+				Expect(found).To(BeNil(), "variable %s is set more than once", name)
+				found = &variables[i]
+			}
+		}
+		return found
+	}
+
+	It("should read the certificates from the mounted Secret or ConfigMap", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, SecureBootPK), encodePEM(newCertificate("pk")), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, SecureBootKEK), encodePEM(newCertificate("kek")), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, SecureBootDB), encodePEM(newCertificate("db1"), newCertificate("db2")), 0600)).To(Succeed())
+
+		keys, err := ReadSecureBootKeys(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys.PK).ToNot(BeEmpty())
+		Expect(keys.KEK).To(HaveLen(1))
+		Expect(keys.DB).To(HaveLen(2))
+		Expect(keys.DBX).To(BeEmpty())
+	})
+
+	It("should refuse more than one platform key", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, SecureBootPK), encodePEM(newCertificate("pk1"), newCertificate("pk2")), 0600)).To(Succeed())
+
+		_, err := ReadSecureBootKeys(dir)
+		Expect(err).To(MatchError(ContainSubstring("exactly one certificate")))
+	})
+
+	It("should replace the SecureBoot databases of the template", func() {
+		template := newVarsTemplate(
+			variable{name: SecureBootPK, vendor: globalVariableGUID, attributes: attrSecureBootKey, data: signatureLists(newCertificate("vendor-pk"))},
+			variable{name: "Boot0000", vendor: globalVariableGUID, attributes: attrSetupMode, data: []byte{1, 2, 3}},
+		)
+		keys := &SecureBootKeys{
+			PK:  newCertificate("pk"),
+			KEK: [][]byte{newCertificate("kek")},
+			DB:  [][]byte{newCertificate("db1"), newCertificate("db2")},
+			DBX: [][]byte{newCertificate("dbx")},
+		}
+		output := filepath.Join(GinkgoT().TempDir(), "vm_VARS.fd")
+
+		Expect(EnrollSecureBootKeys(template, output, keys)).To(Succeed())
+
+		content, err := os.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		variables, _, _, err := readVariables(content)
+		Expect(err).ToNot(HaveOccurred())
+
+		pk := liveVariable(variables, SecureBootPK)
+		Expect(pk).ToNot(BeNil())
+		Expect(pk.data).To(Equal(signatureLists(keys.PK)))
+		Expect(liveVariable(variables, SecureBootKEK).data).To(Equal(signatureLists(keys.KEK...)))
+		Expect(liveVariable(variables, SecureBootDB).data).To(Equal(signatureLists(keys.DB...)))
+		Expect(liveVariable(variables, SecureBootDBX).data).To(Equal(signatureLists(keys.DBX...)))
+		Expect(liveVariable(variables, "SecureBootEnable").data).To(Equal([]byte{1}))
+		Expect(liveVariable(variables, "Boot0000").data).To(Equal([]byte{1, 2, 3}))
+	})
+
+	It("should fail when the variable store is full", func() {
+		template := newVarsTemplate()
+		keys := &SecureBootKeys{
+			PK:  newCertificate("pk"),
+			KEK: [][]byte{newCertificate("kek")},
+			DB:  bytes.Split(bytes.Repeat([]byte("x"), 2*varStoreSize), []byte("y")),
+		}
+
+		err := EnrollSecureBootKeys(template, filepath.Join(GinkgoT().TempDir(), "vm_VARS.fd"), keys)
+		Expect(err).To(MatchError(ContainSubstring("not enough space")))
+	})
+
+	It("should reject a template without a variable store", func() {
+		template := filepath.Join(GinkgoT().TempDir(), "OVMF_VARS.fd")
+		Expect(os.WriteFile(template, make([]byte, 128), 0644)).To(Succeed())
+
+		err := EnrollSecureBootKeys(template, filepath.Join(GinkgoT().TempDir(), "vm_VARS.fd"), &SecureBootKeys{})
+		Expect(err).To(MatchError(ContainSubstring("firmware volume header not found")))
+	})
+
+	DescribeTable("should refuse to enroll into an unexpected template", func(corrupt func([]byte) []byte, expectedError string) {
+		template := writeVarsTemplate(corrupt(newVarsContent()))
+		output := filepath.Join(GinkgoT().TempDir(), "vm_VARS.fd")
+
+		err := EnrollSecureBootKeys(template, output, &SecureBootKeys{PK: newCertificate("pk")})
+		Expect(err).To(MatchError(ContainSubstring(expectedError)))
+		Expect(output).ToNot(BeAnExistingFile())
+	},
+		Entry("when the file size does not match the firmware volume", func(content []byte) []byte {
+			return append(content, make([]byte, 512)...)
+		}, "does not match the file size"),
+		Entry("when the variable store is not formatted", func(content []byte) []byte {
+			content[fvHeaderLength+20] = 0
+			return content
+		}, "not formatted or not healthy"),
+		Entry("when the variable store is not healthy", func(content []byte) []byte {
+			content[fvHeaderLength+21] = 0
+			return content
+		}, "not formatted or not healthy"),
+		Entry("when the variable store holds data which is not understood", func(content []byte) []byte {
+			content[fvHeaderLength+varStoreSize/2] = 0
+			return content
+		}, "unexpected data"),
+	)
+})
@@ -47,6 +47,11 @@ func IsInvalidOperation(err error) bool {
 	return checkError(err, libvirt.ERR_OPERATION_INVALID)
 }
 
+// IsOperationTimeout detects libvirt's VIR_ERR_OPERATION_TIMEOUT. It accepts both error and libvirt.Error (as returned by GetLastError function).
+func IsOperationTimeout(err error) bool {
+	return checkError(err, libvirt.ERR_OPERATION_TIMEOUT)
+}
+
 // IsOk detects libvirt's ERR_OK. It accepts both error and libvirt.Error (as returned by GetLastError function).
 func IsOk(err error) bool {
 	return checkError(err, libvirt.ERR_OK)
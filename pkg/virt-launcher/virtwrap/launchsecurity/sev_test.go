@@ -66,3 +66,13 @@ var _ = Describe("LaunchSecurity: AMD Secure Encrypted Virtualization (SEV)", fu
 		)
 	})
 })
+
+var _ = Describe("LaunchSecurity: AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP)", func() {
+	DescribeTable("SEV-SNP policy conversion", func(policy *v1.SEVSNPPolicy, expectedBits uint64) {
+		Expect(launchsecurity.SEVSNPPolicyToBits(policy)).To(Equal(expectedBits))
+	},
+		Entry("should allow SMT by default", nil, uint64(0x30000)),
+		Entry("should allow SMT when requested", &v1.SEVSNPPolicy{SMT: pointer.P(true)}, uint64(0x30000)),
+		Entry("should disallow SMT when requested", &v1.SEVSNPPolicy{SMT: pointer.P(false)}, uint64(0x20000)),
+	)
+})
@@ -28,6 +28,7 @@ package virtwrap
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -108,6 +109,24 @@ const (
 const maxConcurrentHotplugHostDevices = 1
 const maxConcurrentMemoryDumps = 1
 
+const (
+	memoryDumpDomainXMLSuffix = ".domain.xml"
+	memoryDumpMetadataSuffix  = ".metadata.json"
+)
+
+var libvirtCoreDumpFormats = map[v1.MemoryDumpFormat]libvirt.DomainCoreDumpFormat{
+	v1.MemoryDumpFormatELF:         libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW,
+	v1.MemoryDumpFormatKdumpZlib:   libvirt.DOMAIN_CORE_DUMP_FORMAT_KDUMP_ZLIB,
+	v1.MemoryDumpFormatKdumpLzo:    libvirt.DOMAIN_CORE_DUMP_FORMAT_KDUMP_LZO,
+	v1.MemoryDumpFormatKdumpSnappy: libvirt.DOMAIN_CORE_DUMP_FORMAT_KDUMP_SNAPPY,
+}
+
+// memoryDumpMetadata is written next to the memory dump
+type memoryDumpMetadata struct {
+	Format      v1.MemoryDumpFormat                   `json:"format"`
+	GuestOSInfo *v1.VirtualMachineInstanceGuestOSInfo `json:"guestOSInfo,omitempty"`
+}
+
 type contextStore struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -466,7 +485,12 @@ func (l *LibvirtDomainManager) UpdateVCPUs(vmi *v1.VirtualMachineInstance, optio
 	// hot plug/unplug vCPUs
 	if err := dom.SetVcpusFlags(uint(vcpuCount),
 		affectDomainVCPULiveAndConfigLibvirtFlags); err != nil {
-		return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		// libvirt stops waiting for the guest to release unplugged vCPUs after a few seconds,
+		// the unplug carries on in the guest and its progress is reported with the domain.
+		if !domainerrors.IsOperationTimeout(err) {
+			return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		}
+		logger.Reason(err).Info("waiting for the guest to release unplugged vCPUs")
 	}
 
 	// Adjust guest vcpu config. Currently will handle vCPUs to pCPUs pinning
@@ -707,6 +731,69 @@ func (l *LibvirtDomainManager) generateCloudInitEmptyISO(vmi *v1.VirtualMachineI
 	return fmt.Errorf("failed to find the status of volume %s", l.cloudInitDataStore.VolumeName)
 }
 
+// efiConfiguration returns the firmware the domain boots with in EFI mode.
+func (l *LibvirtDomainManager) efiConfiguration(vmi *v1.VirtualMachineInstance) (*converter.EFIConfiguration, error) {
+	if !vmi.IsBootloaderEFI() {
+		return nil, nil
+	}
+
+	if kutil.IsSEVSNPVMI(vmi) || kutil.IsTDXVMI(vmi) {
+		code := l.efiEnvironment.EFICodeSEVSNP()
+		if kutil.IsTDXVMI(vmi) {
+			code = l.efiEnvironment.EFICodeTDX()
+		}
+		if code == "" {
+			log.Log.Object(vmi).Error("EFI OVMF rom missing for booting with SEV-SNP or TDX")
+			return nil, fmt.Errorf("EFI OVMF rom missing for booting with SEV-SNP or TDX")
+		}
+		return &converter.EFIConfiguration{EFICode: code, Stateless: true}, nil
+	}
+
+	secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
+	sev := kutil.IsSEVVMI(vmi)
+
+	if !l.efiEnvironment.Bootable(secureBoot, sev) {
+		log.Log.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
+		return nil, fmt.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
+	}
+
+	return &converter.EFIConfiguration{
+		EFICode:      l.efiEnvironment.EFICode(secureBoot, sev),
+		EFIVars:      l.efiEnvironment.EFIVars(secureBoot, sev),
+		SecureLoader: secureBoot,
+	}, nil
+}
+
+var secureBootKeysDir = config.SecureBootKeysDir
+
+// enrollSecureBootKeys creates the NVRAM of the domain from its vars template with the user
+// provided SecureBoot keys. libvirt only copies the template when the NVRAM does not exist,
+// so a persisted NVRAM keeps the keys and any change the guest made to them.
+func enrollSecureBootKeys(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	if !vmi.IsBootloaderEFI() || vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBootKeys == nil || domain.Spec.OS.NVRam == nil {
+		return nil
+	}
+	nvram := domain.Spec.OS.NVRam.NVRam
+	if _, err := os.Stat(nvram); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	keys, err := efi.ReadSecureBootKeys(secureBootKeysDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(nvram), 0750); err != nil {
+		return err
+	}
+	if err := efi.EnrollSecureBootKeys(domain.Spec.OS.NVRam.Template, nvram, keys); err != nil {
+		return err
+	}
+	log.Log.Object(vmi).Infof("Enrolled custom SecureBoot keys into %s", nvram)
+	return nil
+}
+
 // All local environment setup that needs to occur before VirtualMachineInstance starts
 // can be done in this function. This includes things like...
 //
@@ -836,6 +923,14 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 		return domain, fmt.Errorf("Starting qemu agent access credential propagation failed: %v", err)
 	}
 
+	if err := l.prepareTPM(vmi); err != nil {
+		return domain, fmt.Errorf("preparing the TPM device failed: %v", err)
+	}
+
+	if err := enrollSecureBootKeys(vmi, domain); err != nil {
+		return domain, fmt.Errorf("enrolling the SecureBoot keys failed: %v", err)
+	}
+
 	// expand disk image files if they're too small
 	expandDiskImagesOffline(vmi, domain)
 
@@ -998,21 +1093,9 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		}
 	}
 
-	var efiConf *converter.EFIConfiguration
-	if vmi.IsBootloaderEFI() {
-		secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
-		sev := kutil.IsSEVVMI(vmi)
-
-		if !l.efiEnvironment.Bootable(secureBoot, sev) {
-			log.Log.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
-			return nil, fmt.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
-		}
-
-		efiConf = &converter.EFIConfiguration{
-			EFICode:      l.efiEnvironment.EFICode(secureBoot, sev),
-			EFIVars:      l.efiEnvironment.EFIVars(secureBoot, sev),
-			SecureLoader: secureBoot,
-		}
+	efiConf, err := l.efiConfiguration(vmi)
+	if err != nil {
+		return nil, err
 	}
 
 	// Map the VirtualMachineInstance to the Domain
@@ -1027,11 +1110,18 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
 		PermanentVolumes:      permanentVolumes,
 		EphemeraldiskCreator:  l.ephemeralDiskCreator,
-		UseLaunchSecurity:     kutil.IsSEVVMI(vmi),
+		UseLaunchSecurity:     kutil.IsConfidentialVMI(vmi),
 		FreePageReporting:     isFreePageReportingEnabled(false, vmi),
 		SerialConsoleLog:      isSerialConsoleLogEnabled(false, vmi),
 	}
 
+	if kutil.IsVideoAccel3DVMI(vmi) {
+		// the render device plugin exposes the render node of the node under its own name
+		if c.RenderNode, err = kutil.FindRenderNode("/"); err != nil {
+			return nil, err
+		}
+	}
+
 	if options != nil {
 		c.ExpandDisksEnabled = options.ExpandDisksEnabled
 		if options.VirtualMachineSMBios != nil {
@@ -1163,10 +1253,48 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		return nil, err
 	}
 
+	if err := syncBalloonTarget(oldSpec, dom, vmi); err != nil {
+		return nil, err
+	}
+
 	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
 	return oldSpec, nil
 }
 
+// syncBalloonTarget resizes the memory balloon to the target virt-handler picked
+// while reclaiming guest memory from a node under memory pressure.
+func syncBalloonTarget(spec *api.DomainSpec, dom cli.VirDomain, vmi *v1.VirtualMachineInstance) error {
+	if vmi.Status.Memory == nil || vmi.Status.Memory.BalloonTarget == nil || spec.CurrentMemory == nil {
+		return nil
+	}
+	if spec.Devices.Ballooning == nil || spec.Devices.Ballooning.Model == "none" {
+		return nil
+	}
+
+	target := uint64(vmi.Status.Memory.BalloonTarget.Value()) / 1024
+	if maxMemory := domainMemoryKiB(spec.Memory); target > maxMemory {
+		target = maxMemory
+	}
+	if target == domainMemoryKiB(*spec.CurrentMemory) {
+		return nil
+	}
+
+	if err := dom.SetMemoryFlags(target, libvirt.DOMAIN_MEM_LIVE); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("resizing the memory balloon failed")
+		return err
+	}
+	log.Log.Object(vmi).V(2).Infof("resized the memory balloon to %d KiB", target)
+	return nil
+}
+
+// domainMemoryKiB returns a domain memory size in KiB, the unit libvirt reports it in.
+func domainMemoryKiB(memory api.Memory) uint64 {
+	if memory.Unit == "b" || memory.Unit == "bytes" {
+		return memory.Value / 1024
+	}
+	return memory.Value
+}
+
 func (l *LibvirtDomainManager) syncDiskHotplug(
 	domain *api.Domain,
 	spec *api.DomainSpec,
@@ -1275,6 +1403,8 @@ func (l *LibvirtDomainManager) startDomain(
 	}
 
 	createFlags := getDomainCreateFlags(vmi)
+	// swtpm_setup has created the TPM state and its EK certificate once the domain is started
+	defer removeSwtpmLocalCASigningKey(vmi)
 	if err := dom.CreateWithFlags(createFlags); err != nil {
 		logger.Reason(err).
 			Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
@@ -1540,21 +1670,69 @@ func (l *LibvirtDomainManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPa
 	// keep trying to do memory dump even if remove previous one failed
 	removePreviousMemoryDump(filepath.Dir(dumpPath))
 
-	logger.Infof("Starting memory dump")
+	format := memoryDumpFormat(vmi)
+	logger.Infof("Starting memory dump in format %s", format)
 	failed := false
 	reason := ""
-	err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	err = dom.CoreDumpWithFormat(dumpPath, libvirtCoreDumpFormats[format], libvirt.DUMP_MEMORY_ONLY)
 	if err != nil {
 		failed = true
 		reason = fmt.Sprintf("%s: %s", failedDomainMemoryDump, err)
 	} else {
 		logger.Infof("Completed memory dump successfully")
+		// the dump is usable without its metadata, failing to write it is only logged
+		if err := l.writeMemoryDumpMetadata(dom, dumpPath, format); err != nil {
+			logger.Reason(err).Error("failed to write memory dump metadata")
+		}
 	}
 
 	l.setMemoryDumpResult(failed, reason)
 	return err
 }
 
+// memoryDumpFormat returns the format requested on the memory dump volume of the VMI
+func memoryDumpFormat(vmi *v1.VirtualMachineInstance) v1.MemoryDumpFormat {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.MemoryDump != nil && volume.MemoryDump.Format != "" {
+			return volume.MemoryDump.Format
+		}
+	}
+	return v1.MemoryDumpFormatELF
+}
+
+// writeMemoryDumpMetadata stores the domain XML and the guest OS info next to the memory dump,
+// analysis tools like crash or volatility need them to pick the right symbols
+func (l *LibvirtDomainManager) writeMemoryDumpMetadata(dom cli.VirDomain, dumpPath string, format v1.MemoryDumpFormat) error {
+	domainXML, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dumpPath+memoryDumpDomainXMLSuffix, []byte(domainXML), 0640); err != nil {
+		return err
+	}
+
+	metadata := memoryDumpMetadata{Format: format}
+	if l.agentData != nil {
+		if osInfo := l.agentData.GetGuestOSInfo(); osInfo != nil {
+			metadata.GuestOSInfo = &v1.VirtualMachineInstanceGuestOSInfo{
+				Name:          osInfo.Name,
+				KernelRelease: osInfo.KernelRelease,
+				Version:       osInfo.Version,
+				PrettyName:    osInfo.PrettyName,
+				VersionID:     osInfo.VersionId,
+				KernelVersion: osInfo.KernelVersion,
+				Machine:       osInfo.Machine,
+				ID:            osInfo.Id,
+			}
+		}
+	}
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dumpPath+memoryDumpMetadataSuffix, metadataJSON, 0640)
+}
+
 func (l *LibvirtDomainManager) shouldSkipMemoryDump(dumpPath string) bool {
 	memoryDumpMetadata, _ := l.metadataCache.MemoryDump.Load()
 	if memoryDumpMetadata.FileName == filepath.Base(dumpPath) {
@@ -1869,7 +2047,7 @@ func (l *LibvirtDomainManager) KillVMI(vmi *v1.VirtualMachineInstance) error {
 		return err
 	}
 
-	if domState == libvirt.DOMAIN_RUNNING || domState == libvirt.DOMAIN_PAUSED || domState == libvirt.DOMAIN_SHUTDOWN {
+	if domState == libvirt.DOMAIN_RUNNING || domState == libvirt.DOMAIN_PAUSED || domState == libvirt.DOMAIN_SHUTDOWN || domState == libvirt.DOMAIN_CRASHED {
 		err = dom.DestroyFlags(libvirt.DOMAIN_DESTROY_GRACEFUL)
 		if err != nil {
 			if domainerrors.IsNotFound(err) {
@@ -2253,8 +2431,14 @@ func (l *LibvirtDomainManager) GetLaunchMeasurement(vmi *v1.VirtualMachineInstan
 	if domainLaunchSecurityParameters.SEVPolicySet {
 		sevMeasurementInfo.Policy = domainLaunchSecurityParameters.SEVPolicy
 	}
+	if domainLaunchSecurityParameters.SEVSNPPolicySet {
+		sevMeasurementInfo.Policy = uint(domainLaunchSecurityParameters.SEVSNPPolicy)
+	}
 
 	loader := l.efiEnvironment.EFICode(false, true) // no secureBoot, with sev
+	if efiConf, err := l.efiConfiguration(vmi); err == nil && efiConf != nil {
+		loader = efiConf.EFICode
+	}
 	f, err := os.Open(loader)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Error opening loader binary %s", loader)
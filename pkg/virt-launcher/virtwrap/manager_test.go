@@ -523,6 +523,153 @@ var _ = Describe("Manager", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(newspec).ToNot(BeNil())
 		})
+		It("should resize the memory balloon to the balloon target of a running VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectedDomainFor(vmi)
+			domainSpec.CurrentMemory = &api.Memory{Value: domainSpec.Memory.Value, Unit: domainSpec.Memory.Unit}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			target := resource.NewQuantity(int64(domainSpec.Memory.Value/2), resource.BinarySI)
+			vmi.Status.Memory = &v1.MemoryStatus{BalloonTarget: target}
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			mockDomain.EXPECT().SetMemoryFlags(domainSpec.Memory.Value/2/1024, libvirt.DOMAIN_MEM_LIVE).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			_, err = manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should not resize the memory balloon when it already matches the balloon target", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectedDomainFor(vmi)
+			domainSpec.CurrentMemory = &api.Memory{Value: domainSpec.Memory.Value, Unit: domainSpec.Memory.Unit}
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).NotTo(HaveOccurred())
+
+			vmi.Status.Memory = &v1.MemoryStatus{BalloonTarget: resource.NewQuantity(int64(domainSpec.Memory.Value), resource.BinarySI)}
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			_, err = manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		Context("with a TPM device", func() {
+			var secretDir, caDir, localCADir string
+
+			BeforeEach(func() {
+				secretDir = GinkgoT().TempDir()
+				caDir = GinkgoT().TempDir()
+				localCADir = filepath.Join(GinkgoT().TempDir(), "swtpm-localca")
+
+				origSecretDir, origCADir, origLocalCADir := tpmEncryptionSecretDir, tpmEKCertificateAuthorityDir, swtpmLocalCADir
+				tpmEncryptionSecretDir = secretDir
+				tpmEKCertificateAuthorityDir = caDir
+				swtpmLocalCADir = func(_ *v1.VirtualMachineInstance) string { return localCADir }
+				DeferCleanup(func() {
+					tpmEncryptionSecretDir, tpmEKCertificateAuthorityDir, swtpmLocalCADir = origSecretDir, origCADir, origLocalCADir
+				})
+			})
+
+			It("should define the encryption secret with the key from the mounted Secret", func() {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.UID = "8e8a1f06-0b4c-4c5b-9d5a-6c1b3e7a2f10"
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{
+					Persistent: virtpointer.P(true),
+					Encryption: &v1.TPMEncryption{SecretName: "tpm-key"},
+				}
+				key := []byte("0123456789abcdef0123456789abcdef")
+				Expect(os.WriteFile(filepath.Join(secretDir, "key"), key, 0600)).To(Succeed())
+
+				mockConn.EXPECT().DefineSecret(gomock.Any(), key).DoAndReturn(func(secretXML string, _ []byte) error {
+					Expect(secretXML).To(ContainSubstring("<uuid>8e8a1f06-0b4c-4c5b-9d5a-6c1b3e7a2f10</uuid>"))
+					Expect(secretXML).To(ContainSubstring(`<usage type="vtpm">`))
+					return nil
+				})
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+				Expect(manager.(*LibvirtDomainManager).prepareTPM(vmi)).To(Succeed())
+			})
+
+			It("should reject an encryption key which is not 32 bytes long", func() {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{
+					Persistent: virtpointer.P(true),
+					Encryption: &v1.TPMEncryption{SecretName: "tpm-key"},
+				}
+				Expect(os.WriteFile(filepath.Join(secretDir, "key"), []byte("secret-key"), 0600)).To(Succeed())
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+				Expect(manager.(*LibvirtDomainManager).prepareTPM(vmi)).To(MatchError(ContainSubstring("must be exactly 32 bytes long")))
+			})
+
+			It("should hand the EK certificate authority to swtpm_localca", func() {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{
+					EKCertificateAuthority: &v1.TPMEKCertificateAuthority{SecretName: "tpm-ca"},
+				}
+				Expect(os.WriteFile(filepath.Join(caDir, k8sv1.TLSCertKey), []byte("ca-cert"), 0600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(caDir, k8sv1.TLSPrivateKeyKey), []byte("ca-key"), 0600)).To(Succeed())
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+				Expect(manager.(*LibvirtDomainManager).prepareTPM(vmi)).To(Succeed())
+				Expect(filepath.Join(localCADir, "issuercert.pem")).To(BeARegularFile())
+				signKey, err := os.ReadFile(filepath.Join(localCADir, "signkey.pem"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(signKey)).To(Equal("ca-key"))
+
+				By("checking that the signing key is only linked from the Secret volume")
+				target, err := os.Readlink(filepath.Join(localCADir, "signkey.pem"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(target).To(Equal(filepath.Join(caDir, k8sv1.TLSPrivateKeyKey)))
+
+				By("removing the signing key once the domain started")
+				removeSwtpmLocalCASigningKey(vmi)
+				Expect(filepath.Join(localCADir, "signkey.pem")).ToNot(BeAnExistingFile())
+				Expect(filepath.Join(localCADir, "issuercert.pem")).To(BeARegularFile())
+			})
+		})
+		Context("with SecureBoot keys", func() {
+			var vmi *v1.VirtualMachineInstance
+			var domain *api.Domain
+
+			BeforeEach(func() {
+				origSecureBootKeysDir := secureBootKeysDir
+				secureBootKeysDir = GinkgoT().TempDir()
+				DeferCleanup(func() { secureBootKeysDir = origSecureBootKeysDir })
+
+				vmi = newVMI(testNamespace, testVmName)
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					Bootloader: &v1.Bootloader{
+						EFI: &v1.EFI{
+							SecureBootKeys: &v1.SecureBootKeysSource{Secret: &k8sv1.LocalObjectReference{Name: "sb-keys"}},
+						},
+					},
+				}
+				domain = &api.Domain{}
+				domain.Spec.OS.NVRam = &api.NVRam{
+					Template: "/usr/share/OVMF/OVMF_VARS.secboot.fd",
+					NVRam:    filepath.Join(GinkgoT().TempDir(), "nvram", testVmName+"_VARS.fd"),
+				}
+			})
+
+			It("should keep an existing NVRAM", func() {
+				Expect(os.MkdirAll(filepath.Dir(domain.Spec.OS.NVRam.NVRam), 0750)).To(Succeed())
+				Expect(os.WriteFile(domain.Spec.OS.NVRam.NVRam, []byte("persisted"), 0600)).To(Succeed())
+
+				Expect(enrollSecureBootKeys(vmi, domain)).To(Succeed())
+				content, err := os.ReadFile(domain.Spec.OS.NVRam.NVRam)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal("persisted"))
+			})
+
+			It("should fail when the SecureBoot keys are missing", func() {
+				Expect(enrollSecureBootKeys(vmi, domain)).To(MatchError(ContainSubstring("PK")))
+				Expect(domain.Spec.OS.NVRam.NVRam).ToNot(BeAnExistingFile())
+			})
+		})
 		DescribeTable("should try to start a VirtualMachineInstance in state",
 			func(state libvirt.DomainState) {
 				vmi := newVMI(testNamespace, testVmName)
@@ -644,6 +791,7 @@ var _ = Describe("Manager", func() {
 		It("should update domain with memory dump info when completed successfully", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
 			mockDomain.EXPECT().CoreDumpWithFormat(testDumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return("<domain/>", nil)
 
 			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
 
@@ -660,6 +808,7 @@ var _ = Describe("Manager", func() {
 		It("should skip memory dump if the same dump command already completed successfully", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
 			mockDomain.EXPECT().CoreDumpWithFormat(testDumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).Times(1).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Times(1).Return("<domain/>", nil)
 
 			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
 
@@ -676,6 +825,45 @@ var _ = Describe("Manager", func() {
 			// not to call core dump command again
 			Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
 		})
+		It("should dump the memory in the requested format and write its metadata", func() {
+			dumpPath := filepath.Join(GinkgoT().TempDir(), "vol1.memory.dump")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_KDUMP_ZLIB, libvirt.DUMP_MEMORY_ONLY).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return("<domain/>", nil)
+
+			agentStore := agentpoller.NewAsyncAgentStore()
+			agentStore.Store(agentpoller.GET_OSINFO, api.GuestOSInfo{
+				Name:          "Fedora Linux",
+				KernelRelease: "6.8.5-301.fc40.x86_64",
+				KernelVersion: "#1 SMP PREEMPT_DYNAMIC",
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, &agentStore, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "vol1",
+				VolumeSource: v1.VolumeSource{
+					MemoryDump: &v1.MemoryDumpVolumeSource{Format: v1.MemoryDumpFormatKdumpZlib},
+				},
+			})
+			Expect(manager.MemoryDump(vmi, dumpPath)).To(Succeed())
+			Eventually(func() bool {
+				memoryDump, _ := metadataCache.MemoryDump.Load()
+				return memoryDump.Completed
+			}, 5*time.Second, 2).Should(BeTrue())
+
+			Expect(os.ReadFile(dumpPath + ".domain.xml")).To(Equal([]byte("<domain/>")))
+			metadata, err := os.ReadFile(dumpPath + ".metadata.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metadata).To(MatchJSON(`{
+				"format": "kdump-zlib",
+				"guestOSInfo": {
+					"name": "Fedora Linux",
+					"kernelRelease": "6.8.5-301.fc40.x86_64",
+					"kernelVersion": "#1 SMP PREEMPT_DYNAMIC"
+				}
+			}`))
+		})
 		It("should update domain with memory dump info if memory dump failed", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
 			dumpFailure := fmt.Errorf("Memory dump failed!!")
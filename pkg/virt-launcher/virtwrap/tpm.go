@@ -0,0 +1,153 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virtwrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"libvirt.org/go/libvirtxml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/config"
+	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+)
+
+const (
+	tpmEncryptionKey = "key"
+	// tpmEncryptionKeySize is the size of the AES-256 key libvirt encrypts the TPM state with
+	tpmEncryptionKeySize = 32
+
+	swtpmLocalCASigningKey = "signkey.pem"
+	swtpmLocalCAIssuerCert = "issuercert.pem"
+)
+
+var (
+	// These are vars so they can be changed by the unit tests
+	tpmEncryptionSecretDir       = config.TPMEncryptionSecretDir
+	tpmEKCertificateAuthorityDir = config.TPMEKCertificateAuthorityDir
+	swtpmLocalCADir              = swtpmLocalCAPath
+)
+
+// swtpmLocalCAPath returns the state directory of swtpm_localca, which issues the
+// EK certificate when swtpm_setup creates the TPM state.
+func swtpmLocalCAPath(vmi *v1.VirtualMachineInstance) string {
+	if kutil.IsNonRootVMI(vmi) {
+		return filepath.Join(kutil.VirtPrivateDir, "var", "lib", "swtpm-localca")
+	}
+	return "/var/lib/swtpm-localca"
+}
+
+// prepareTPM makes the TPM encryption key known to libvirt and hands the EK
+// certificate authority to swtpm_localca before the domain is started.
+func (l *LibvirtDomainManager) prepareTPM(vmi *v1.VirtualMachineInstance) error {
+	tpm := vmi.Spec.Domain.Devices.TPM
+	if tpm == nil {
+		return nil
+	}
+
+	if tpm.Encryption != nil {
+		if err := l.defineTPMEncryptionSecret(vmi); err != nil {
+			return err
+		}
+	}
+
+	if tpm.EKCertificateAuthority != nil {
+		if err := seedSwtpmLocalCA(swtpmLocalCADir(vmi)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *LibvirtDomainManager) defineTPMEncryptionSecret(vmi *v1.VirtualMachineInstance) error {
+	key, err := os.ReadFile(filepath.Join(tpmEncryptionSecretDir, tpmEncryptionKey))
+	if err != nil {
+		return fmt.Errorf("failed to read the TPM encryption key: %v", err)
+	}
+	if len(key) != tpmEncryptionKeySize {
+		return fmt.Errorf("the TPM encryption key must be exactly %d bytes long, got %d bytes", tpmEncryptionKeySize, len(key))
+	}
+
+	secret := libvirtxml.Secret{
+		Ephemeral: "yes",
+		Private:   "yes",
+		UUID:      converter.TPMEncryptionSecretUUID(vmi),
+		Usage: &libvirtxml.SecretUsage{
+			Type: "vtpm",
+			Name: api.VMINamespaceKeyFunc(vmi),
+		},
+	}
+	secretXML, err := secret.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := l.virConn.DefineSecret(secretXML, key); err != nil {
+		return fmt.Errorf("failed to define the TPM encryption secret: %v", err)
+	}
+	log.Log.Object(vmi).Info("TPM encryption secret defined")
+	return nil
+}
+
+// seedSwtpmLocalCA places the certificate authority where swtpm_localca expects its
+// signing key and issuer certificate, so that it signs the EK certificate with it
+// instead of creating a self-signed one. The state directory of swtpm_localca lives on
+// the backend storage, so the signing key is only linked from the tmpfs backed Secret
+// volume instead of being copied there, and the link is removed once the domain started.
+func seedSwtpmLocalCA(localCADir string) error {
+	if err := os.MkdirAll(localCADir, 0750); err != nil {
+		return err
+	}
+
+	issuerCert, err := os.ReadFile(filepath.Join(tpmEKCertificateAuthorityDir, k8sv1.TLSCertKey))
+	if err != nil {
+		return fmt.Errorf("failed to read the TPM EK certificate authority: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localCADir, swtpmLocalCAIssuerCert), issuerCert, 0644); err != nil {
+		return err
+	}
+
+	signingKey := filepath.Join(localCADir, swtpmLocalCASigningKey)
+	if err := os.Remove(signingKey); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Symlink(filepath.Join(tpmEKCertificateAuthorityDir, k8sv1.TLSPrivateKeyKey), signingKey)
+}
+
+// removeSwtpmLocalCASigningKey drops the link to the signing key of the EK certificate
+// authority, which is only needed by swtpm_setup while the domain is started.
+func removeSwtpmLocalCASigningKey(vmi *v1.VirtualMachineInstance) {
+	tpm := vmi.Spec.Domain.Devices.TPM
+	if tpm == nil || tpm.EKCertificateAuthority == nil {
+		return
+	}
+	err := os.Remove(filepath.Join(swtpmLocalCADir(vmi), swtpmLocalCASigningKey))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Log.Object(vmi).Reason(err).Error("Failed to remove the TPM EK certificate authority signing key")
+	}
+}
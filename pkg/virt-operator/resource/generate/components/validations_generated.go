@@ -1123,6 +1123,12 @@ var CRDsValidation map[string]string = map[string]string{
               description: LiveUpdateConfiguration holds defaults for live update
                 features
               properties:
+                hotUnplugTimeout:
+                  description: |-
+                    HotUnplugTimeout is how long a vCPU or memory hot-unplug may wait for the guest
+                    to release the resources before the VM falls back to requiring a restart.
+                    defaults to 5m
+                  type: string
                 maxCpuSockets:
                   description: |-
                     MaxCpuSockets provides a MaxSockets value for VMs that do not provide their own.
@@ -1201,6 +1207,69 @@ var CRDsValidation map[string]string = map[string]string{
             memBalloonStatsPeriod:
               format: int32
               type: integer
+            memoryReclaimConfiguration:
+              description: |-
+                MemoryReclaimConfiguration enables reclaiming guest memory through the memory balloon
+                on nodes under memory pressure.
+              properties:
+                freeMemoryPercent:
+                  description: |-
+                    FreeMemoryPercent is the percentage of the node memory which has to be available,
+                    below it guest memory is reclaimed on the node.
+                    Defaults to 20.
+                  format: int32
+                  maximum: 100
+                  minimum: 0
+                  type: integer
+                nodeLabelSelector:
+                  description: |-
+                    NodeLabelSelector is a selector that filters on which nodes guest memory will be reclaimed.
+                    Empty or missing NodeLabelSelector will reclaim memory on every node.
+                  properties:
+                    matchExpressions:
+                      description: matchExpressions is a list of label selector requirements.
+                        The requirements are ANDed.
+                      items:
+                        description: |-
+                          A label selector requirement is a selector that contains values, a key, and an operator that
+                          relates the key and values.
+                        properties:
+                          key:
+                            description: key is the label key that the selector applies
+                              to.
+                            type: string
+                          operator:
+                            description: |-
+                              operator represents a key's relationship to a set of values.
+                              Valid operators are In, NotIn, Exists and DoesNotExist.
+                            type: string
+                          values:
+                            description: |-
+                              values is an array of string values. If the operator is In or NotIn,
+                              the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                              the values array must be empty. This array is replaced during a strategic
+                              merge patch.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                        required:
+                        - key
+                        - operator
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                    matchLabels:
+                      additionalProperties:
+                        type: string
+                      description: |-
+                        matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                        map is equivalent to an element of matchExpressions, whose key field is "key", the
+                        operator is "In", and the values array contains only "value". The requirements are ANDed.
+                      type: object
+                  type: object
+                  x-kubernetes-map-type: atomic
+              type: object
             migrations:
               description: |-
                 MigrationConfiguration holds migration options.
@@ -1323,7 +1392,7 @@ var CRDsValidation map[string]string = map[string]string{
                       domainAttachmentType:
                         description: |-
                           DomainAttachmentType is a standard domain network attachment method kubevirt supports.
-                          Supported values: "tap", "managedTap" (since v1.4).
+                          Supported values: "tap", "managedTap" (since v1.4), "vhostuser" (since v1.5).
                           The standard domain attachment can be used instead or in addition to the sidecarImage.
                           version: 1alphav1
                         type: string
@@ -1368,6 +1437,50 @@ var CRDsValidation map[string]string = map[string]string{
                     DeprecatedPermitSlirpInterface is an alias for the deprecated PermitSlirpInterface.
                     Deprecated: Removed in v1.3.
                   type: boolean
+                serviceMesh:
+                  description: |-
+                    ServiceMesh configures how masquerade bound interfaces cooperate with a service mesh data plane.
+                    When unset, the Istio defaults are used.
+                  properties:
+                    injectionAnnotations:
+                      additionalProperties:
+                        type: string
+                      description: |-
+                        InjectionAnnotations mark a VMI as part of a sidecar based service mesh, e.g. {"linkerd.io/inject": "enabled"}.
+                        The Istio "sidecar.istio.io/inject": "true" annotation is always honored.
+                      type: object
+                    nonProxiedPorts:
+                      description: |-
+                        NonProxiedPorts are the TCP ports forwarded to the guest directly, bypassing the mesh proxy.
+                        Defaults to port 22.
+                      items:
+                        format: int32
+                        type: integer
+                      type: array
+                      x-kubernetes-list-type: set
+                    proxySourceAddress:
+                      description: |-
+                        ProxySourceAddress is the IPv4 loopback address the sidecar proxy uses to deliver inbound traffic.
+                        Defaults to 127.0.0.6.
+                      type: string
+                    reservedPorts:
+                      description: |-
+                        ReservedPorts are the TCP ports used by the mesh data plane in the virt-launcher pod.
+                        They are excluded from the masquerade NAT and are never forwarded to the guest.
+                        Defaults to the Istio proxy ports.
+                      items:
+                        format: int32
+                        type: integer
+                      type: array
+                      x-kubernetes-list-type: set
+                    translateSourceIP:
+                      description: |-
+                        TranslateSourceIP translates the client address of inbound traffic delivered by an ambient mesh proxy
+                        to the guest gateway address, for meshes which do not route the guest replies back through the proxy.
+                        By default the client address is preserved.
+                        Traffic originating from a loopback address is always translated.
+                      type: boolean
+                  type: object
               type: object
             obsoleteCPUModels:
               additionalProperties:
@@ -6478,10 +6591,56 @@ var CRDsValidation map[string]string = map[string]string{
                             depends on additional factors of the VirtualMachineInstance,
                             like the number of guest CPUs.
                           type: boolean
+                        panic:
+                          description: Panic describes a panic notifier device, which
+                            lets the guest report a kernel panic to the host.
+                          properties:
+                            action:
+                              description: |-
+                                The action to take when the guest panics. Valid values are restart, pause, dump.
+                                Defaults to pause.
+                              type: string
+                            dumpClaimName:
+                              description: |-
+                                DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                                It is handled as a memory dump request of the owning VirtualMachine.
+                              type: string
+                            model:
+                              description: |-
+                                Model of the panic device.
+                                Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                              type: string
+                          type: object
                         rng:
                           description: Whether to have random number generator from
                             host
                           type: object
+                        serialConsoleLogPersistence:
+                          description: |-
+                            SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                            still be retrieved once the VMI and its pod are gone.
+                            Requires the serial console log to be enabled.
+                          properties:
+                            claimName:
+                              description: |-
+                                ClaimName of a PVC dedicated to the serial console log of the VM.
+                                When empty, the log is persisted to the backend storage of the VM.
+                              type: string
+                            maxFileSize:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: MaxFileSize is the size of the log file
+                                before it is rotated. Defaults to 1Mi, at most 10Mi.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            maxFiles:
+                              description: MaxFiles is the number of rotated log files
+                                kept next to the current one. Defaults to 3, at most
+                                100.
+                              format: int32
+                              type: integer
+                          type: object
                         sound:
                           description: Whether to emulate a sound device.
                           properties:
@@ -6497,9 +6656,50 @@ var CRDsValidation map[string]string = map[string]string{
                           required:
                           - name
                           type: object
+                        spice:
+                          description: |-
+                            Spice attaches a SPICE graphics device next to the VNC one.
+                            Requires the graphics device to be attached.
+                          properties:
+                            streamingMode:
+                              description: |-
+                                StreamingMode selects which screen regions are encoded as a video stream.
+                                Supported values: filter, all, off. Defaults to filter.
+                              type: string
+                          type: object
                         tpm:
                           description: Whether to emulate a TPM device.
                           properties:
+                            ekCertificateAuthority:
+                              description: |-
+                                EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                                with the given certificate authority instead of a self-signed one, so that
+                                guests can take part in measured-boot attestation.
+                                The certificate is only issued when the TPM state is created.
+                              properties:
+                                secretName:
+                                  description: |-
+                                    SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                                    and the private key of the certificate authority, usually distributed from a
+                                    cluster wide CA into the namespace of the VirtualMachineInstance.
+                                    The private key is never copied to the persistent TPM state.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            encryption:
+                              description: |-
+                                Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                                Requires Persistent to be true.
+                              properties:
+                                secretName:
+                                  description: |-
+                                    SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                                    The key must be exactly 32 bytes long.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
                             persistent:
                               description: |-
                                 Persistent indicates the state of the TPM device should be kept accross reboots
@@ -6512,10 +6712,58 @@ var CRDsValidation map[string]string = map[string]string{
                             This is helpful for old machines like CentOS6 or RHEL6 which
                             do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
+                        video:
+                          description: |-
+                            Video configures the video device attached along with the graphics device.
+                            Replaces the default VGA or bochs device when set.
+                          properties:
+                            accel3D:
+                              description: |-
+                                Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                                Vulkan acceleration (Venus) is not supported.
+                                Requires a render node on the node the VMI is scheduled to.
+                              type: boolean
+                            heads:
+                              description: Heads is the number of displays of the
+                                device. Defaults to 1.
+                              format: int32
+                              type: integer
+                            resolution:
+                              description: Resolution the displays are initialized
+                                with.
+                              properties:
+                                height:
+                                  format: int32
+                                  type: integer
+                                width:
+                                  format: int32
+                                  type: integer
+                              required:
+                              - height
+                              - width
+                              type: object
+                            type:
+                              description: |-
+                                Type of the video device.
+                                Supported values: virtio.
+                              type: string
+                          required:
+                          - type
+                          type: object
                         watchdog:
                           description: Watchdog describes a watchdog device which
                             can be added to the vmi.
                           properties:
+                            diag288:
+                              description: diag288 watchdog device, only available
+                                on s390x.
+                              properties:
+                                action:
+                                  description: |-
+                                    The action to take. Valid values are poweroff, reset, shutdown.
+                                    Defaults to reset.
+                                  type: string
+                              type: object
                             i6300esb:
                               description: i6300esb watchdog device.
                               properties:
@@ -6525,6 +6773,16 @@ var CRDsValidation map[string]string = map[string]string{
                                     Defaults to reset.
                                   type: string
                               type: object
+                            itco:
+                              description: itco watchdog device, part of the q35 chipset
+                                on amd64.
+                              properties:
+                                action:
+                                  description: |-
+                                    The action to take. Valid values are poweroff, reset, shutdown.
+                                    Defaults to reset.
+                                  type: string
+                              type: object
                             name:
                               description: Name of the watchdog.
                               type: string
@@ -6819,6 +7077,43 @@ var CRDsValidation map[string]string = map[string]string{
                                     Requires SMM to be enabled.
                                     Defaults to true
                                   type: boolean
+                                secureBootKeys:
+                                  description: |-
+                                    SecureBootKeys references the certificates which are enrolled as
+                                    SecureBoot keys in place of the ones shipped with the OVMF roms.
+                                    Requires SecureBoot to be enabled.
+                                  properties:
+                                    configMap:
+                                      description: ConfigMap references a ConfigMap
+                                        that contains the SecureBoot certificates.
+                                      properties:
+                                        name:
+                                          default: ""
+                                          description: |-
+                                            Name of the referent.
+                                            This field is effectively required, but due to backwards compatibility is
+                                            allowed to be empty. Instances of this type with an empty value here are
+                                            almost certainly wrong.
+                                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          type: string
+                                      type: object
+                                      x-kubernetes-map-type: atomic
+                                    secret:
+                                      description: Secret references a k8s Secret
+                                        that contains the SecureBoot certificates.
+                                      properties:
+                                        name:
+                                          default: ""
+                                          description: |-
+                                            Name of the referent.
+                                            This field is effectively required, but due to backwards compatibility is
+                                            allowed to be empty. Instances of this type with an empty value here are
+                                            almost certainly wrong.
+                                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          type: string
+                                      type: object
+                                      x-kubernetes-map-type: atomic
+                                  type: object
                               type: object
                           type: object
                         kernelBoot:
@@ -6905,6 +7200,25 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Base64 encoded session blob.
                               type: string
                           type: object
+                        snp:
+                          description: AMD Secure Encrypted Virtualization with Secure
+                            Nested Paging (SEV-SNP).
+                          properties:
+                            policy:
+                              description: |-
+                                Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                                Note: due to security reasons it is not allowed to enable guest debugging.
+                              properties:
+                                smt:
+                                  description: |-
+                                    If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                                    Defaults to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
                       type: object
                     machine:
                       description: Machine type.
@@ -6945,6 +7259,22 @@ var CRDsValidation map[string]string = map[string]string{
                             The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                           pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                           x-kubernetes-int-or-string: true
+                        reclaim:
+                          description: |-
+                            Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                            when the node is under memory pressure.
+                            Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                          properties:
+                            floor:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                Floor is the amount of memory which is never taken away from the guest.
+                                Defaults to half of the guest memory.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                          type: object
                       type: object
                     resources:
                       description: Resources describes the Compute Resources required
@@ -7146,6 +7476,26 @@ var CRDsValidation map[string]string = map[string]string{
                               Select the default network and add it to the
                               multus-cni.io/default-network annotation.
                             type: boolean
+                          dhcpServer:
+                            description: |-
+                              DHCPServer enables an in-pod DHCP server for a bridge bound interface
+                              connected to this network, handing out addresses from the given range.
+                              It is meant for networks that have no IPAM of their own.
+                              Requires the DHCPServerIPAM feature gate.
+                            properties:
+                              gateway:
+                                description: |-
+                                  Gateway advertised to the guest. It must be part of the subnet and is
+                                  never handed out as a guest address.
+                                type: string
+                              subnet:
+                                description: |-
+                                  Subnet from which the guest addresses are allocated, in CIDR notation.
+                                  Only IPv4 subnets are supported.
+                                type: string
+                            required:
+                            - subnet
+                            type: object
                           networkName:
                             description: |-
                               References to a NetworkAttachmentDefinition CRD object. Format:
@@ -7916,6 +8266,10 @@ var CRDsValidation map[string]string = map[string]string{
                               claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                             type: string
+                          format:
+                            description: Format is the format the memory is dumped
+                              in, defaults to elf
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -8085,6 +8439,68 @@ var CRDsValidation map[string]string = map[string]string{
             updated through an Update() before ObservedGeneration in Status.
           format: int64
           type: integer
+        hotUnplug:
+          description: HotUnplug reports the progress of a live vCPU or memory scale-down.
+          nullable: true
+          properties:
+            cpu:
+              description: CPU reports the progress of a vCPU hot-unplug, quantities
+                are vCPU counts
+              properties:
+                current:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Current is the amount the guest currently holds
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                requested:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Requested is the amount the guest is being scaled down
+                    to
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                startTimestamp:
+                  description: StartTimestamp is the time the hot-unplug was first
+                    observed
+                  format: date-time
+                  type: string
+              required:
+              - current
+              - requested
+              - startTimestamp
+              type: object
+            memory:
+              description: Memory reports the progress of a memory hot-unplug
+              properties:
+                current:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Current is the amount the guest currently holds
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                requested:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Requested is the amount the guest is being scaled down
+                    to
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                startTimestamp:
+                  description: StartTimestamp is the time the hot-unplug was first
+                    observed
+                  format: date-time
+                  type: string
+              required:
+              - current
+              - requested
+              - startTimestamp
+              type: object
+          type: object
         memoryDumpRequest:
           description: |-
             MemoryDumpRequest tracks memory dump request phase and info of getting a memory
@@ -8102,6 +8518,9 @@ var CRDsValidation map[string]string = map[string]string{
             fileName:
               description: FileName represents the name of the output file
               type: string
+            format:
+              description: Format is the format of the memory dump, defaults to elf
+              type: string
             message:
               description: Message is a detailed message about failure of the memory
                 dump
@@ -8926,6 +9345,25 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Base64 encoded session blob.
                   type: string
               type: object
+            snp:
+              description: AMD Secure Encrypted Virtualization with Secure Nested
+                Paging (SEV-SNP).
+              properties:
+                policy:
+                  description: |-
+                    Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                    Note: due to security reasons it is not allowed to enable guest debugging.
+                  properties:
+                    smt:
+                      description: |-
+                        If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                        Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            tdx:
+              description: Intel Trust Domain Extensions (TDX).
+              type: object
           type: object
         memory:
           description: Required Memory related attributes of the instancetype.
@@ -9287,6 +9725,36 @@ var CRDsValidation map[string]string = map[string]string{
               description: PreferredTPM optionally defines the preferred TPM device
                 to be used.
               properties:
+                ekCertificateAuthority:
+                  description: |-
+                    EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                    with the given certificate authority instead of a self-signed one, so that
+                    guests can take part in measured-boot attestation.
+                    The certificate is only issued when the TPM state is created.
+                  properties:
+                    secretName:
+                      description: |-
+                        SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                        and the private key of the certificate authority, usually distributed from a
+                        cluster wide CA into the namespace of the VirtualMachineInstance.
+                        The private key is never copied to the persistent TPM state.
+                      type: string
+                  required:
+                  - secretName
+                  type: object
+                encryption:
+                  description: |-
+                    Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                    Requires Persistent to be true.
+                  properties:
+                    secretName:
+                      description: |-
+                        SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                        The key must be exactly 32 bytes long.
+                      type: string
+                  required:
+                  - secretName
+                  type: object
                 persistent:
                   description: |-
                     Persistent indicates the state of the TPM device should be kept accross reboots
@@ -9572,6 +10040,43 @@ var CRDsValidation map[string]string = map[string]string{
                     Requires SMM to be enabled.
                     Defaults to true
                   type: boolean
+                secureBootKeys:
+                  description: |-
+                    SecureBootKeys references the certificates which are enrolled as
+                    SecureBoot keys in place of the ones shipped with the OVMF roms.
+                    Requires SecureBoot to be enabled.
+                  properties:
+                    configMap:
+                      description: ConfigMap references a ConfigMap that contains
+                        the SecureBoot certificates.
+                      properties:
+                        name:
+                          default: ""
+                          description: |-
+                            Name of the referent.
+                            This field is effectively required, but due to backwards compatibility is
+                            allowed to be empty. Instances of this type with an empty value here are
+                            almost certainly wrong.
+                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                          type: string
+                      type: object
+                      x-kubernetes-map-type: atomic
+                    secret:
+                      description: Secret references a k8s Secret that contains the
+                        SecureBoot certificates.
+                      properties:
+                        name:
+                          default: ""
+                          description: |-
+                            Name of the referent.
+                            This field is effectively required, but due to backwards compatibility is
+                            allowed to be empty. Instances of this type with an empty value here are
+                            almost certainly wrong.
+                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                          type: string
+                      type: object
+                      x-kubernetes-map-type: atomic
+                  type: object
               type: object
             preferredUseBios:
               description: PreferredUseBios optionally enables BIOS
@@ -11690,9 +12195,54 @@ var CRDsValidation map[string]string = map[string]string{
                     factors of the VirtualMachineInstance, like the number of guest
                     CPUs.
                   type: boolean
+                panic:
+                  description: Panic describes a panic notifier device, which lets
+                    the guest report a kernel panic to the host.
+                  properties:
+                    action:
+                      description: |-
+                        The action to take when the guest panics. Valid values are restart, pause, dump.
+                        Defaults to pause.
+                      type: string
+                    dumpClaimName:
+                      description: |-
+                        DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                        It is handled as a memory dump request of the owning VirtualMachine.
+                      type: string
+                    model:
+                      description: |-
+                        Model of the panic device.
+                        Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                      type: string
+                  type: object
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                serialConsoleLogPersistence:
+                  description: |-
+                    SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                    still be retrieved once the VMI and its pod are gone.
+                    Requires the serial console log to be enabled.
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName of a PVC dedicated to the serial console log of the VM.
+                        When empty, the log is persisted to the backend storage of the VM.
+                      type: string
+                    maxFileSize:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: MaxFileSize is the size of the log file before
+                        it is rotated. Defaults to 1Mi, at most 10Mi.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    maxFiles:
+                      description: MaxFiles is the number of rotated log files kept
+                        next to the current one. Defaults to 3, at most 100.
+                      format: int32
+                      type: integer
+                  type: object
                 sound:
                   description: Whether to emulate a sound device.
                   properties:
@@ -11708,9 +12258,50 @@ var CRDsValidation map[string]string = map[string]string{
                   required:
                   - name
                   type: object
+                spice:
+                  description: |-
+                    Spice attaches a SPICE graphics device next to the VNC one.
+                    Requires the graphics device to be attached.
+                  properties:
+                    streamingMode:
+                      description: |-
+                        StreamingMode selects which screen regions are encoded as a video stream.
+                        Supported values: filter, all, off. Defaults to filter.
+                      type: string
+                  type: object
                 tpm:
                   description: Whether to emulate a TPM device.
                   properties:
+                    ekCertificateAuthority:
+                      description: |-
+                        EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                        with the given certificate authority instead of a self-signed one, so that
+                        guests can take part in measured-boot attestation.
+                        The certificate is only issued when the TPM state is created.
+                      properties:
+                        secretName:
+                          description: |-
+                            SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                            and the private key of the certificate authority, usually distributed from a
+                            cluster wide CA into the namespace of the VirtualMachineInstance.
+                            The private key is never copied to the persistent TPM state.
+                          type: string
+                      required:
+                      - secretName
+                      type: object
+                    encryption:
+                      description: |-
+                        Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                        Requires Persistent to be true.
+                      properties:
+                        secretName:
+                          description: |-
+                            SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                            The key must be exactly 32 bytes long.
+                          type: string
+                      required:
+                      - secretName
+                      type: object
                     persistent:
                       description: |-
                         Persistent indicates the state of the TPM device should be kept accross reboots
@@ -11723,10 +12314,56 @@ var CRDsValidation map[string]string = map[string]string{
                     This is helpful for old machines like CentOS6 or RHEL6 which
                     do not understand virtio_non_transitional (virtio 1.0).
                   type: boolean
+                video:
+                  description: |-
+                    Video configures the video device attached along with the graphics device.
+                    Replaces the default VGA or bochs device when set.
+                  properties:
+                    accel3D:
+                      description: |-
+                        Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                        Vulkan acceleration (Venus) is not supported.
+                        Requires a render node on the node the VMI is scheduled to.
+                      type: boolean
+                    heads:
+                      description: Heads is the number of displays of the device.
+                        Defaults to 1.
+                      format: int32
+                      type: integer
+                    resolution:
+                      description: Resolution the displays are initialized with.
+                      properties:
+                        height:
+                          format: int32
+                          type: integer
+                        width:
+                          format: int32
+                          type: integer
+                      required:
+                      - height
+                      - width
+                      type: object
+                    type:
+                      description: |-
+                        Type of the video device.
+                        Supported values: virtio.
+                      type: string
+                  required:
+                  - type
+                  type: object
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added
                     to the vmi.
                   properties:
+                    diag288:
+                      description: diag288 watchdog device, only available on s390x.
+                      properties:
+                        action:
+                          description: |-
+                            The action to take. Valid values are poweroff, reset, shutdown.
+                            Defaults to reset.
+                          type: string
+                      type: object
                     i6300esb:
                       description: i6300esb watchdog device.
                       properties:
@@ -11736,6 +12373,16 @@ var CRDsValidation map[string]string = map[string]string{
                             Defaults to reset.
                           type: string
                       type: object
+                    itco:
+                      description: itco watchdog device, part of the q35 chipset on
+                        amd64.
+                      properties:
+                        action:
+                          description: |-
+                            The action to take. Valid values are poweroff, reset, shutdown.
+                            Defaults to reset.
+                          type: string
+                      type: object
                     name:
                       description: Name of the watchdog.
                       type: string
@@ -12027,6 +12674,43 @@ var CRDsValidation map[string]string = map[string]string{
                             Requires SMM to be enabled.
                             Defaults to true
                           type: boolean
+                        secureBootKeys:
+                          description: |-
+                            SecureBootKeys references the certificates which are enrolled as
+                            SecureBoot keys in place of the ones shipped with the OVMF roms.
+                            Requires SecureBoot to be enabled.
+                          properties:
+                            configMap:
+                              description: ConfigMap references a ConfigMap that contains
+                                the SecureBoot certificates.
+                              properties:
+                                name:
+                                  default: ""
+                                  description: |-
+                                    Name of the referent.
+                                    This field is effectively required, but due to backwards compatibility is
+                                    allowed to be empty. Instances of this type with an empty value here are
+                                    almost certainly wrong.
+                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  type: string
+                              type: object
+                              x-kubernetes-map-type: atomic
+                            secret:
+                              description: Secret references a k8s Secret that contains
+                                the SecureBoot certificates.
+                              properties:
+                                name:
+                                  default: ""
+                                  description: |-
+                                    Name of the referent.
+                                    This field is effectively required, but due to backwards compatibility is
+                                    allowed to be empty. Instances of this type with an empty value here are
+                                    almost certainly wrong.
+                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  type: string
+                              type: object
+                              x-kubernetes-map-type: atomic
+                          type: object
                       type: object
                   type: object
                 kernelBoot:
@@ -12110,6 +12794,25 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Base64 encoded session blob.
                       type: string
                   type: object
+                snp:
+                  description: AMD Secure Encrypted Virtualization with Secure Nested
+                    Paging (SEV-SNP).
+                  properties:
+                    policy:
+                      description: |-
+                        Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                        Note: due to security reasons it is not allowed to enable guest debugging.
+                      properties:
+                        smt:
+                          description: |-
+                            If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                            Defaults to true.
+                          type: boolean
+                      type: object
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
               type: object
             machine:
               description: Machine type.
@@ -12149,6 +12852,22 @@ var CRDsValidation map[string]string = map[string]string{
                     The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                   pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                   x-kubernetes-int-or-string: true
+                reclaim:
+                  description: |-
+                    Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                    when the node is under memory pressure.
+                    Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                  properties:
+                    floor:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: |-
+                        Floor is the amount of memory which is never taken away from the guest.
+                        Defaults to half of the guest memory.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                  type: object
               type: object
             resources:
               description: Resources describes the Compute Resources required by this
@@ -12349,6 +13068,26 @@ var CRDsValidation map[string]string = map[string]string{
                       Select the default network and add it to the
                       multus-cni.io/default-network annotation.
                     type: boolean
+                  dhcpServer:
+                    description: |-
+                      DHCPServer enables an in-pod DHCP server for a bridge bound interface
+                      connected to this network, handing out addresses from the given range.
+                      It is meant for networks that have no IPAM of their own.
+                      Requires the DHCPServerIPAM feature gate.
+                    properties:
+                      gateway:
+                        description: |-
+                          Gateway advertised to the guest. It must be part of the subnet and is
+                          never handed out as a guest address.
+                        type: string
+                      subnet:
+                        description: |-
+                          Subnet from which the guest addresses are allocated, in CIDR notation.
+                          Only IPv4 subnets are supported.
+                        type: string
+                    required:
+                    - subnet
+                    type: object
                   networkName:
                     description: |-
                       References to a NetworkAttachmentDefinition CRD object. Format:
@@ -13109,6 +13848,10 @@ var CRDsValidation map[string]string = map[string]string{
                       claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                       More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                     type: string
+                  format:
+                    description: Format is the format the memory is dumped in, defaults
+                      to elf
+                    type: string
                   hotpluggable:
                     description: Hotpluggable indicates whether the volume can be
                       hotplugged and hotunplugged.
@@ -13302,6 +14045,12 @@ var CRDsValidation map[string]string = map[string]string{
             FSFreezeStatus is the state of the fs of the guest
             it can be either frozen or thawed
           type: string
+        guestCurrentVCPUs:
+          description: |-
+            GuestCurrentVCPUs specifies how many vCPUs are currently enabled in the guest.
+            It lags behind the current topology while the guest releases hot-unplugged vCPUs.
+          format: int32
+          type: integer
         guestOSInfo:
           description: Guest OS Information
           properties:
@@ -13405,6 +14154,15 @@ var CRDsValidation map[string]string = map[string]string{
           description: Memory shows various informations about the VirtualMachine
             memory.
           properties:
+            balloonTarget:
+              anyOf:
+              - type: integer
+              - type: string
+              description: |-
+                BalloonTarget specifies down to how much memory the memory balloon shrinks the guest
+                while the node is under memory pressure.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
             guestAtBoot:
               anyOf:
               - type: integer
@@ -14864,9 +15622,54 @@ var CRDsValidation map[string]string = map[string]string{
                     factors of the VirtualMachineInstance, like the number of guest
                     CPUs.
                   type: boolean
+                panic:
+                  description: Panic describes a panic notifier device, which lets
+                    the guest report a kernel panic to the host.
+                  properties:
+                    action:
+                      description: |-
+                        The action to take when the guest panics. Valid values are restart, pause, dump.
+                        Defaults to pause.
+                      type: string
+                    dumpClaimName:
+                      description: |-
+                        DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                        It is handled as a memory dump request of the owning VirtualMachine.
+                      type: string
+                    model:
+                      description: |-
+                        Model of the panic device.
+                        Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                      type: string
+                  type: object
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                serialConsoleLogPersistence:
+                  description: |-
+                    SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                    still be retrieved once the VMI and its pod are gone.
+                    Requires the serial console log to be enabled.
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName of a PVC dedicated to the serial console log of the VM.
+                        When empty, the log is persisted to the backend storage of the VM.
+                      type: string
+                    maxFileSize:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: MaxFileSize is the size of the log file before
+                        it is rotated. Defaults to 1Mi, at most 10Mi.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    maxFiles:
+                      description: MaxFiles is the number of rotated log files kept
+                        next to the current one. Defaults to 3, at most 100.
+                      format: int32
+                      type: integer
+                  type: object
                 sound:
                   description: Whether to emulate a sound device.
                   properties:
@@ -14882,9 +15685,50 @@ var CRDsValidation map[string]string = map[string]string{
                   required:
                   - name
                   type: object
+                spice:
+                  description: |-
+                    Spice attaches a SPICE graphics device next to the VNC one.
+                    Requires the graphics device to be attached.
+                  properties:
+                    streamingMode:
+                      description: |-
+                        StreamingMode selects which screen regions are encoded as a video stream.
+                        Supported values: filter, all, off. Defaults to filter.
+                      type: string
+                  type: object
                 tpm:
                   description: Whether to emulate a TPM device.
                   properties:
+                    ekCertificateAuthority:
+                      description: |-
+                        EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                        with the given certificate authority instead of a self-signed one, so that
+                        guests can take part in measured-boot attestation.
+                        The certificate is only issued when the TPM state is created.
+                      properties:
+                        secretName:
+                          description: |-
+                            SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                            and the private key of the certificate authority, usually distributed from a
+                            cluster wide CA into the namespace of the VirtualMachineInstance.
+                            The private key is never copied to the persistent TPM state.
+                          type: string
+                      required:
+                      - secretName
+                      type: object
+                    encryption:
+                      description: |-
+                        Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                        Requires Persistent to be true.
+                      properties:
+                        secretName:
+                          description: |-
+                            SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                            The key must be exactly 32 bytes long.
+                          type: string
+                      required:
+                      - secretName
+                      type: object
                     persistent:
                       description: |-
                         Persistent indicates the state of the TPM device should be kept accross reboots
@@ -14897,10 +15741,56 @@ var CRDsValidation map[string]string = map[string]string{
                     This is helpful for old machines like CentOS6 or RHEL6 which
                     do not understand virtio_non_transitional (virtio 1.0).
                   type: boolean
+                video:
+                  description: |-
+                    Video configures the video device attached along with the graphics device.
+                    Replaces the default VGA or bochs device when set.
+                  properties:
+                    accel3D:
+                      description: |-
+                        Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                        Vulkan acceleration (Venus) is not supported.
+                        Requires a render node on the node the VMI is scheduled to.
+                      type: boolean
+                    heads:
+                      description: Heads is the number of displays of the device.
+                        Defaults to 1.
+                      format: int32
+                      type: integer
+                    resolution:
+                      description: Resolution the displays are initialized with.
+                      properties:
+                        height:
+                          format: int32
+                          type: integer
+                        width:
+                          format: int32
+                          type: integer
+                      required:
+                      - height
+                      - width
+                      type: object
+                    type:
+                      description: |-
+                        Type of the video device.
+                        Supported values: virtio.
+                      type: string
+                  required:
+                  - type
+                  type: object
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added
                     to the vmi.
                   properties:
+                    diag288:
+                      description: diag288 watchdog device, only available on s390x.
+                      properties:
+                        action:
+                          description: |-
+                            The action to take. Valid values are poweroff, reset, shutdown.
+                            Defaults to reset.
+                          type: string
+                      type: object
                     i6300esb:
                       description: i6300esb watchdog device.
                       properties:
@@ -14910,6 +15800,16 @@ var CRDsValidation map[string]string = map[string]string{
                             Defaults to reset.
                           type: string
                       type: object
+                    itco:
+                      description: itco watchdog device, part of the q35 chipset on
+                        amd64.
+                      properties:
+                        action:
+                          description: |-
+                            The action to take. Valid values are poweroff, reset, shutdown.
+                            Defaults to reset.
+                          type: string
+                      type: object
                     name:
                       description: Name of the watchdog.
                       type: string
@@ -15201,6 +16101,43 @@ var CRDsValidation map[string]string = map[string]string{
                             Requires SMM to be enabled.
                             Defaults to true
                           type: boolean
+                        secureBootKeys:
+                          description: |-
+                            SecureBootKeys references the certificates which are enrolled as
+                            SecureBoot keys in place of the ones shipped with the OVMF roms.
+                            Requires SecureBoot to be enabled.
+                          properties:
+                            configMap:
+                              description: ConfigMap references a ConfigMap that contains
+                                the SecureBoot certificates.
+                              properties:
+                                name:
+                                  default: ""
+                                  description: |-
+                                    Name of the referent.
+                                    This field is effectively required, but due to backwards compatibility is
+                                    allowed to be empty. Instances of this type with an empty value here are
+                                    almost certainly wrong.
+                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  type: string
+                              type: object
+                              x-kubernetes-map-type: atomic
+                            secret:
+                              description: Secret references a k8s Secret that contains
+                                the SecureBoot certificates.
+                              properties:
+                                name:
+                                  default: ""
+                                  description: |-
+                                    Name of the referent.
+                                    This field is effectively required, but due to backwards compatibility is
+                                    allowed to be empty. Instances of this type with an empty value here are
+                                    almost certainly wrong.
+                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  type: string
+                              type: object
+                              x-kubernetes-map-type: atomic
+                          type: object
                       type: object
                   type: object
                 kernelBoot:
@@ -15284,6 +16221,25 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Base64 encoded session blob.
                       type: string
                   type: object
+                snp:
+                  description: AMD Secure Encrypted Virtualization with Secure Nested
+                    Paging (SEV-SNP).
+                  properties:
+                    policy:
+                      description: |-
+                        Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                        Note: due to security reasons it is not allowed to enable guest debugging.
+                      properties:
+                        smt:
+                          description: |-
+                            If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                            Defaults to true.
+                          type: boolean
+                      type: object
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
               type: object
             machine:
               description: Machine type.
@@ -15323,6 +16279,22 @@ var CRDsValidation map[string]string = map[string]string{
                     The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                   pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                   x-kubernetes-int-or-string: true
+                reclaim:
+                  description: |-
+                    Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                    when the node is under memory pressure.
+                    Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                  properties:
+                    floor:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: |-
+                        Floor is the amount of memory which is never taken away from the guest.
+                        Defaults to half of the guest memory.
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                  type: object
               type: object
             resources:
               description: Resources describes the Compute Resources required by this
@@ -17270,10 +18242,56 @@ var CRDsValidation map[string]string = map[string]string{
                             depends on additional factors of the VirtualMachineInstance,
                             like the number of guest CPUs.
                           type: boolean
+                        panic:
+                          description: Panic describes a panic notifier device, which
+                            lets the guest report a kernel panic to the host.
+                          properties:
+                            action:
+                              description: |-
+                                The action to take when the guest panics. Valid values are restart, pause, dump.
+                                Defaults to pause.
+                              type: string
+                            dumpClaimName:
+                              description: |-
+                                DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                                It is handled as a memory dump request of the owning VirtualMachine.
+                              type: string
+                            model:
+                              description: |-
+                                Model of the panic device.
+                                Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                              type: string
+                          type: object
                         rng:
                           description: Whether to have random number generator from
                             host
                           type: object
+                        serialConsoleLogPersistence:
+                          description: |-
+                            SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                            still be retrieved once the VMI and its pod are gone.
+                            Requires the serial console log to be enabled.
+                          properties:
+                            claimName:
+                              description: |-
+                                ClaimName of a PVC dedicated to the serial console log of the VM.
+                                When empty, the log is persisted to the backend storage of the VM.
+                              type: string
+                            maxFileSize:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: MaxFileSize is the size of the log file
+                                before it is rotated. Defaults to 1Mi, at most 10Mi.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            maxFiles:
+                              description: MaxFiles is the number of rotated log files
+                                kept next to the current one. Defaults to 3, at most
+                                100.
+                              format: int32
+                              type: integer
+                          type: object
                         sound:
                           description: Whether to emulate a sound device.
                           properties:
@@ -17289,9 +18307,50 @@ var CRDsValidation map[string]string = map[string]string{
                           required:
                           - name
                           type: object
+                        spice:
+                          description: |-
+                            Spice attaches a SPICE graphics device next to the VNC one.
+                            Requires the graphics device to be attached.
+                          properties:
+                            streamingMode:
+                              description: |-
+                                StreamingMode selects which screen regions are encoded as a video stream.
+                                Supported values: filter, all, off. Defaults to filter.
+                              type: string
+                          type: object
                         tpm:
                           description: Whether to emulate a TPM device.
                           properties:
+                            ekCertificateAuthority:
+                              description: |-
+                                EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                                with the given certificate authority instead of a self-signed one, so that
+                                guests can take part in measured-boot attestation.
+                                The certificate is only issued when the TPM state is created.
+                              properties:
+                                secretName:
+                                  description: |-
+                                    SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                                    and the private key of the certificate authority, usually distributed from a
+                                    cluster wide CA into the namespace of the VirtualMachineInstance.
+                                    The private key is never copied to the persistent TPM state.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
+                            encryption:
+                              description: |-
+                                Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                                Requires Persistent to be true.
+                              properties:
+                                secretName:
+                                  description: |-
+                                    SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                                    The key must be exactly 32 bytes long.
+                                  type: string
+                              required:
+                              - secretName
+                              type: object
                             persistent:
                               description: |-
                                 Persistent indicates the state of the TPM device should be kept accross reboots
@@ -17304,10 +18363,58 @@ var CRDsValidation map[string]string = map[string]string{
                             This is helpful for old machines like CentOS6 or RHEL6 which
                             do not understand virtio_non_transitional (virtio 1.0).
                           type: boolean
+                        video:
+                          description: |-
+                            Video configures the video device attached along with the graphics device.
+                            Replaces the default VGA or bochs device when set.
+                          properties:
+                            accel3D:
+                              description: |-
+                                Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                                Vulkan acceleration (Venus) is not supported.
+                                Requires a render node on the node the VMI is scheduled to.
+                              type: boolean
+                            heads:
+                              description: Heads is the number of displays of the
+                                device. Defaults to 1.
+                              format: int32
+                              type: integer
+                            resolution:
+                              description: Resolution the displays are initialized
+                                with.
+                              properties:
+                                height:
+                                  format: int32
+                                  type: integer
+                                width:
+                                  format: int32
+                                  type: integer
+                              required:
+                              - height
+                              - width
+                              type: object
+                            type:
+                              description: |-
+                                Type of the video device.
+                                Supported values: virtio.
+                              type: string
+                          required:
+                          - type
+                          type: object
                         watchdog:
                           description: Watchdog describes a watchdog device which
                             can be added to the vmi.
                           properties:
+                            diag288:
+                              description: diag288 watchdog device, only available
+                                on s390x.
+                              properties:
+                                action:
+                                  description: |-
+                                    The action to take. Valid values are poweroff, reset, shutdown.
+                                    Defaults to reset.
+                                  type: string
+                              type: object
                             i6300esb:
                               description: i6300esb watchdog device.
                               properties:
@@ -17317,6 +18424,16 @@ var CRDsValidation map[string]string = map[string]string{
                                     Defaults to reset.
                                   type: string
                               type: object
+                            itco:
+                              description: itco watchdog device, part of the q35 chipset
+                                on amd64.
+                              properties:
+                                action:
+                                  description: |-
+                                    The action to take. Valid values are poweroff, reset, shutdown.
+                                    Defaults to reset.
+                                  type: string
+                              type: object
                             name:
                               description: Name of the watchdog.
                               type: string
@@ -17611,6 +18728,43 @@ var CRDsValidation map[string]string = map[string]string{
                                     Requires SMM to be enabled.
                                     Defaults to true
                                   type: boolean
+                                secureBootKeys:
+                                  description: |-
+                                    SecureBootKeys references the certificates which are enrolled as
+                                    SecureBoot keys in place of the ones shipped with the OVMF roms.
+                                    Requires SecureBoot to be enabled.
+                                  properties:
+                                    configMap:
+                                      description: ConfigMap references a ConfigMap
+                                        that contains the SecureBoot certificates.
+                                      properties:
+                                        name:
+                                          default: ""
+                                          description: |-
+                                            Name of the referent.
+                                            This field is effectively required, but due to backwards compatibility is
+                                            allowed to be empty. Instances of this type with an empty value here are
+                                            almost certainly wrong.
+                                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          type: string
+                                      type: object
+                                      x-kubernetes-map-type: atomic
+                                    secret:
+                                      description: Secret references a k8s Secret
+                                        that contains the SecureBoot certificates.
+                                      properties:
+                                        name:
+                                          default: ""
+                                          description: |-
+                                            Name of the referent.
+                                            This field is effectively required, but due to backwards compatibility is
+                                            allowed to be empty. Instances of this type with an empty value here are
+                                            almost certainly wrong.
+                                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          type: string
+                                      type: object
+                                      x-kubernetes-map-type: atomic
+                                  type: object
                               type: object
                           type: object
                         kernelBoot:
@@ -17697,6 +18851,25 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Base64 encoded session blob.
                               type: string
                           type: object
+                        snp:
+                          description: AMD Secure Encrypted Virtualization with Secure
+                            Nested Paging (SEV-SNP).
+                          properties:
+                            policy:
+                              description: |-
+                                Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                                Note: due to security reasons it is not allowed to enable guest debugging.
+                              properties:
+                                smt:
+                                  description: |-
+                                    If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                                    Defaults to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
                       type: object
                     machine:
                       description: Machine type.
@@ -17737,6 +18910,22 @@ var CRDsValidation map[string]string = map[string]string{
                             The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                           pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                           x-kubernetes-int-or-string: true
+                        reclaim:
+                          description: |-
+                            Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                            when the node is under memory pressure.
+                            Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                          properties:
+                            floor:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                Floor is the amount of memory which is never taken away from the guest.
+                                Defaults to half of the guest memory.
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                          type: object
                       type: object
                     resources:
                       description: Resources describes the Compute Resources required
@@ -17938,6 +19127,26 @@ var CRDsValidation map[string]string = map[string]string{
                               Select the default network and add it to the
                               multus-cni.io/default-network annotation.
                             type: boolean
+                          dhcpServer:
+                            description: |-
+                              DHCPServer enables an in-pod DHCP server for a bridge bound interface
+                              connected to this network, handing out addresses from the given range.
+                              It is meant for networks that have no IPAM of their own.
+                              Requires the DHCPServerIPAM feature gate.
+                            properties:
+                              gateway:
+                                description: |-
+                                  Gateway advertised to the guest. It must be part of the subnet and is
+                                  never handed out as a guest address.
+                                type: string
+                              subnet:
+                                description: |-
+                                  Subnet from which the guest addresses are allocated, in CIDR notation.
+                                  Only IPv4 subnets are supported.
+                                type: string
+                            required:
+                            - subnet
+                            type: object
                           networkName:
                             description: |-
                               References to a NetworkAttachmentDefinition CRD object. Format:
@@ -18708,6 +19917,10 @@ var CRDsValidation map[string]string = map[string]string{
                               claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                             type: string
+                          format:
+                            description: Format is the format the memory is dumped
+                              in, defaults to elf
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -19055,6 +20268,25 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Base64 encoded session blob.
                   type: string
               type: object
+            snp:
+              description: AMD Secure Encrypted Virtualization with Secure Nested
+                Paging (SEV-SNP).
+              properties:
+                policy:
+                  description: |-
+                    Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                    Note: due to security reasons it is not allowed to enable guest debugging.
+                  properties:
+                    smt:
+                      description: |-
+                        If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                        Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            tdx:
+              description: Intel Trust Domain Extensions (TDX).
+              type: object
           type: object
         memory:
           description: Required Memory related attributes of the instancetype.
@@ -21781,10 +23013,58 @@ var CRDsValidation map[string]string = map[string]string{
                                     factors of the VirtualMachineInstance, like the
                                     number of guest CPUs.
                                   type: boolean
+                                panic:
+                                  description: Panic describes a panic notifier device,
+                                    which lets the guest report a kernel panic to
+                                    the host.
+                                  properties:
+                                    action:
+                                      description: |-
+                                        The action to take when the guest panics. Valid values are restart, pause, dump.
+                                        Defaults to pause.
+                                      type: string
+                                    dumpClaimName:
+                                      description: |-
+                                        DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                                        It is handled as a memory dump request of the owning VirtualMachine.
+                                      type: string
+                                    model:
+                                      description: |-
+                                        Model of the panic device.
+                                        Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                                      type: string
+                                  type: object
                                 rng:
                                   description: Whether to have random number generator
                                     from host
                                   type: object
+                                serialConsoleLogPersistence:
+                                  description: |-
+                                    SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                                    still be retrieved once the VMI and its pod are gone.
+                                    Requires the serial console log to be enabled.
+                                  properties:
+                                    claimName:
+                                      description: |-
+                                        ClaimName of a PVC dedicated to the serial console log of the VM.
+                                        When empty, the log is persisted to the backend storage of the VM.
+                                      type: string
+                                    maxFileSize:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: MaxFileSize is the size of the
+                                        log file before it is rotated. Defaults to
+                                        1Mi, at most 10Mi.
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    maxFiles:
+                                      description: MaxFiles is the number of rotated
+                                        log files kept next to the current one. Defaults
+                                        to 3, at most 100.
+                                      format: int32
+                                      type: integer
+                                  type: object
                                 sound:
                                   description: Whether to emulate a sound device.
                                   properties:
@@ -21801,9 +23081,50 @@ var CRDsValidation map[string]string = map[string]string{
                                   required:
                                   - name
                                   type: object
+                                spice:
+                                  description: |-
+                                    Spice attaches a SPICE graphics device next to the VNC one.
+                                    Requires the graphics device to be attached.
+                                  properties:
+                                    streamingMode:
+                                      description: |-
+                                        StreamingMode selects which screen regions are encoded as a video stream.
+                                        Supported values: filter, all, off. Defaults to filter.
+                                      type: string
+                                  type: object
                                 tpm:
                                   description: Whether to emulate a TPM device.
                                   properties:
+                                    ekCertificateAuthority:
+                                      description: |-
+                                        EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                                        with the given certificate authority instead of a self-signed one, so that
+                                        guests can take part in measured-boot attestation.
+                                        The certificate is only issued when the TPM state is created.
+                                      properties:
+                                        secretName:
+                                          description: |-
+                                            SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                                            and the private key of the certificate authority, usually distributed from a
+                                            cluster wide CA into the namespace of the VirtualMachineInstance.
+                                            The private key is never copied to the persistent TPM state.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
+                                    encryption:
+                                      description: |-
+                                        Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                                        Requires Persistent to be true.
+                                      properties:
+                                        secretName:
+                                          description: |-
+                                            SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                                            The key must be exactly 32 bytes long.
+                                          type: string
+                                      required:
+                                      - secretName
+                                      type: object
                                     persistent:
                                       description: |-
                                         Persistent indicates the state of the TPM device should be kept accross reboots
@@ -21816,10 +23137,58 @@ var CRDsValidation map[string]string = map[string]string{
                                     This is helpful for old machines like CentOS6 or RHEL6 which
                                     do not understand virtio_non_transitional (virtio 1.0).
                                   type: boolean
+                                video:
+                                  description: |-
+                                    Video configures the video device attached along with the graphics device.
+                                    Replaces the default VGA or bochs device when set.
+                                  properties:
+                                    accel3D:
+                                      description: |-
+                                        Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                                        Vulkan acceleration (Venus) is not supported.
+                                        Requires a render node on the node the VMI is scheduled to.
+                                      type: boolean
+                                    heads:
+                                      description: Heads is the number of displays
+                                        of the device. Defaults to 1.
+                                      format: int32
+                                      type: integer
+                                    resolution:
+                                      description: Resolution the displays are initialized
+                                        with.
+                                      properties:
+                                        height:
+                                          format: int32
+                                          type: integer
+                                        width:
+                                          format: int32
+                                          type: integer
+                                      required:
+                                      - height
+                                      - width
+                                      type: object
+                                    type:
+                                      description: |-
+                                        Type of the video device.
+                                        Supported values: virtio.
+                                      type: string
+                                  required:
+                                  - type
+                                  type: object
                                 watchdog:
                                   description: Watchdog describes a watchdog device
                                     which can be added to the vmi.
                                   properties:
+                                    diag288:
+                                      description: diag288 watchdog device, only available
+                                        on s390x.
+                                      properties:
+                                        action:
+                                          description: |-
+                                            The action to take. Valid values are poweroff, reset, shutdown.
+                                            Defaults to reset.
+                                          type: string
+                                      type: object
                                     i6300esb:
                                       description: i6300esb watchdog device.
                                       properties:
@@ -21829,6 +23198,16 @@ var CRDsValidation map[string]string = map[string]string{
                                             Defaults to reset.
                                           type: string
                                       type: object
+                                    itco:
+                                      description: itco watchdog device, part of the
+                                        q35 chipset on amd64.
+                                      properties:
+                                        action:
+                                          description: |-
+                                            The action to take. Valid values are poweroff, reset, shutdown.
+                                            Defaults to reset.
+                                          type: string
+                                      type: object
                                     name:
                                       description: Name of the watchdog.
                                       type: string
@@ -22126,6 +23505,45 @@ var CRDsValidation map[string]string = map[string]string{
                                             Requires SMM to be enabled.
                                             Defaults to true
                                           type: boolean
+                                        secureBootKeys:
+                                          description: |-
+                                            SecureBootKeys references the certificates which are enrolled as
+                                            SecureBoot keys in place of the ones shipped with the OVMF roms.
+                                            Requires SecureBoot to be enabled.
+                                          properties:
+                                            configMap:
+                                              description: ConfigMap references a
+                                                ConfigMap that contains the SecureBoot
+                                                certificates.
+                                              properties:
+                                                name:
+                                                  default: ""
+                                                  description: |-
+                                                    Name of the referent.
+                                                    This field is effectively required, but due to backwards compatibility is
+                                                    allowed to be empty. Instances of this type with an empty value here are
+                                                    almost certainly wrong.
+                                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                  type: string
+                                              type: object
+                                              x-kubernetes-map-type: atomic
+                                            secret:
+                                              description: Secret references a k8s
+                                                Secret that contains the SecureBoot
+                                                certificates.
+                                              properties:
+                                                name:
+                                                  default: ""
+                                                  description: |-
+                                                    Name of the referent.
+                                                    This field is effectively required, but due to backwards compatibility is
+                                                    allowed to be empty. Instances of this type with an empty value here are
+                                                    almost certainly wrong.
+                                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                  type: string
+                                              type: object
+                                              x-kubernetes-map-type: atomic
+                                          type: object
                                       type: object
                                   type: object
                                 kernelBoot:
@@ -22214,6 +23632,25 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: Base64 encoded session blob.
                                       type: string
                                   type: object
+                                snp:
+                                  description: AMD Secure Encrypted Virtualization
+                                    with Secure Nested Paging (SEV-SNP).
+                                  properties:
+                                    policy:
+                                      description: |-
+                                        Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                                        Note: due to security reasons it is not allowed to enable guest debugging.
+                                      properties:
+                                        smt:
+                                          description: |-
+                                            If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                tdx:
+                                  description: Intel Trust Domain Extensions (TDX).
+                                  type: object
                               type: object
                             machine:
                               description: Machine type.
@@ -22257,6 +23694,22 @@ var CRDsValidation map[string]string = map[string]string{
                                     The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                                   pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                   x-kubernetes-int-or-string: true
+                                reclaim:
+                                  description: |-
+                                    Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                                    when the node is under memory pressure.
+                                    Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                                  properties:
+                                    floor:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: |-
+                                        Floor is the amount of memory which is never taken away from the guest.
+                                        Defaults to half of the guest memory.
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                  type: object
                               type: object
                             resources:
                               description: Resources describes the Compute Resources
@@ -22458,6 +23911,26 @@ var CRDsValidation map[string]string = map[string]string{
                                       Select the default network and add it to the
                                       multus-cni.io/default-network annotation.
                                     type: boolean
+                                  dhcpServer:
+                                    description: |-
+                                      DHCPServer enables an in-pod DHCP server for a bridge bound interface
+                                      connected to this network, handing out addresses from the given range.
+                                      It is meant for networks that have no IPAM of their own.
+                                      Requires the DHCPServerIPAM feature gate.
+                                    properties:
+                                      gateway:
+                                        description: |-
+                                          Gateway advertised to the guest. It must be part of the subnet and is
+                                          never handed out as a guest address.
+                                        type: string
+                                      subnet:
+                                        description: |-
+                                          Subnet from which the guest addresses are allocated, in CIDR notation.
+                                          Only IPv4 subnets are supported.
+                                        type: string
+                                    required:
+                                    - subnet
+                                    type: object
                                   networkName:
                                     description: |-
                                       References to a NetworkAttachmentDefinition CRD object. Format:
@@ -23237,6 +24710,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                                       More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                                     type: string
+                                  format:
+                                    description: Format is the format the memory is
+                                      dumped in, defaults to elf
+                                    type: string
                                   hotpluggable:
                                     description: Hotpluggable indicates whether the
                                       volume can be hotplugged and hotunplugged.
@@ -23703,6 +25180,36 @@ var CRDsValidation map[string]string = map[string]string{
               description: PreferredTPM optionally defines the preferred TPM device
                 to be used.
               properties:
+                ekCertificateAuthority:
+                  description: |-
+                    EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                    with the given certificate authority instead of a self-signed one, so that
+                    guests can take part in measured-boot attestation.
+                    The certificate is only issued when the TPM state is created.
+                  properties:
+                    secretName:
+                      description: |-
+                        SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                        and the private key of the certificate authority, usually distributed from a
+                        cluster wide CA into the namespace of the VirtualMachineInstance.
+                        The private key is never copied to the persistent TPM state.
+                      type: string
+                  required:
+                  - secretName
+                  type: object
+                encryption:
+                  description: |-
+                    Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                    Requires Persistent to be true.
+                  properties:
+                    secretName:
+                      description: |-
+                        SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                        The key must be exactly 32 bytes long.
+                      type: string
+                  required:
+                  - secretName
+                  type: object
                 persistent:
                   description: |-
                     Persistent indicates the state of the TPM device should be kept accross reboots
@@ -23988,6 +25495,43 @@ var CRDsValidation map[string]string = map[string]string{
                     Requires SMM to be enabled.
                     Defaults to true
                   type: boolean
+                secureBootKeys:
+                  description: |-
+                    SecureBootKeys references the certificates which are enrolled as
+                    SecureBoot keys in place of the ones shipped with the OVMF roms.
+                    Requires SecureBoot to be enabled.
+                  properties:
+                    configMap:
+                      description: ConfigMap references a ConfigMap that contains
+                        the SecureBoot certificates.
+                      properties:
+                        name:
+                          default: ""
+                          description: |-
+                            Name of the referent.
+                            This field is effectively required, but due to backwards compatibility is
+                            allowed to be empty. Instances of this type with an empty value here are
+                            almost certainly wrong.
+                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                          type: string
+                      type: object
+                      x-kubernetes-map-type: atomic
+                    secret:
+                      description: Secret references a k8s Secret that contains the
+                        SecureBoot certificates.
+                      properties:
+                        name:
+                          default: ""
+                          description: |-
+                            Name of the referent.
+                            This field is effectively required, but due to backwards compatibility is
+                            allowed to be empty. Instances of this type with an empty value here are
+                            almost certainly wrong.
+                            More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                          type: string
+                      type: object
+                      x-kubernetes-map-type: atomic
+                  type: object
               type: object
             preferredUseBios:
               description: PreferredUseBios optionally enables BIOS
@@ -26991,10 +28535,58 @@ var CRDsValidation map[string]string = map[string]string{
                                         factors of the VirtualMachineInstance, like
                                         the number of guest CPUs.
                                       type: boolean
+                                    panic:
+                                      description: Panic describes a panic notifier
+                                        device, which lets the guest report a kernel
+                                        panic to the host.
+                                      properties:
+                                        action:
+                                          description: |-
+                                            The action to take when the guest panics. Valid values are restart, pause, dump.
+                                            Defaults to pause.
+                                          type: string
+                                        dumpClaimName:
+                                          description: |-
+                                            DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+                                            It is handled as a memory dump request of the owning VirtualMachine.
+                                          type: string
+                                        model:
+                                          description: |-
+                                            Model of the panic device.
+                                            Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+                                          type: string
+                                      type: object
                                     rng:
                                       description: Whether to have random number generator
                                         from host
                                       type: object
+                                    serialConsoleLogPersistence:
+                                      description: |-
+                                        SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+                                        still be retrieved once the VMI and its pod are gone.
+                                        Requires the serial console log to be enabled.
+                                      properties:
+                                        claimName:
+                                          description: |-
+                                            ClaimName of a PVC dedicated to the serial console log of the VM.
+                                            When empty, the log is persisted to the backend storage of the VM.
+                                          type: string
+                                        maxFileSize:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          description: MaxFileSize is the size of
+                                            the log file before it is rotated. Defaults
+                                            to 1Mi, at most 10Mi.
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                        maxFiles:
+                                          description: MaxFiles is the number of rotated
+                                            log files kept next to the current one.
+                                            Defaults to 3, at most 100.
+                                          format: int32
+                                          type: integer
+                                      type: object
                                     sound:
                                       description: Whether to emulate a sound device.
                                       properties:
@@ -27011,9 +28603,50 @@ var CRDsValidation map[string]string = map[string]string{
                                       required:
                                       - name
                                       type: object
+                                    spice:
+                                      description: |-
+                                        Spice attaches a SPICE graphics device next to the VNC one.
+                                        Requires the graphics device to be attached.
+                                      properties:
+                                        streamingMode:
+                                          description: |-
+                                            StreamingMode selects which screen regions are encoded as a video stream.
+                                            Supported values: filter, all, off. Defaults to filter.
+                                          type: string
+                                      type: object
                                     tpm:
                                       description: Whether to emulate a TPM device.
                                       properties:
+                                        ekCertificateAuthority:
+                                          description: |-
+                                            EKCertificateAuthority signs the endorsement key certificate of the TPM device
+                                            with the given certificate authority instead of a self-signed one, so that
+                                            guests can take part in measured-boot attestation.
+                                            The certificate is only issued when the TPM state is created.
+                                          properties:
+                                            secretName:
+                                              description: |-
+                                                SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+                                                and the private key of the certificate authority, usually distributed from a
+                                                cluster wide CA into the namespace of the VirtualMachineInstance.
+                                                The private key is never copied to the persistent TPM state.
+                                              type: string
+                                          required:
+                                          - secretName
+                                          type: object
+                                        encryption:
+                                          description: |-
+                                            Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+                                            Requires Persistent to be true.
+                                          properties:
+                                            secretName:
+                                              description: |-
+                                                SecretName is the name of the Secret holding the encryption key in its "key" entry.
+                                                The key must be exactly 32 bytes long.
+                                              type: string
+                                          required:
+                                          - secretName
+                                          type: object
                                         persistent:
                                           description: |-
                                             Persistent indicates the state of the TPM device should be kept accross reboots
@@ -27026,10 +28659,58 @@ var CRDsValidation map[string]string = map[string]string{
                                         This is helpful for old machines like CentOS6 or RHEL6 which
                                         do not understand virtio_non_transitional (virtio 1.0).
                                       type: boolean
+                                    video:
+                                      description: |-
+                                        Video configures the video device attached along with the graphics device.
+                                        Replaces the default VGA or bochs device when set.
+                                      properties:
+                                        accel3D:
+                                          description: |-
+                                            Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+                                            Vulkan acceleration (Venus) is not supported.
+                                            Requires a render node on the node the VMI is scheduled to.
+                                          type: boolean
+                                        heads:
+                                          description: Heads is the number of displays
+                                            of the device. Defaults to 1.
+                                          format: int32
+                                          type: integer
+                                        resolution:
+                                          description: Resolution the displays are
+                                            initialized with.
+                                          properties:
+                                            height:
+                                              format: int32
+                                              type: integer
+                                            width:
+                                              format: int32
+                                              type: integer
+                                          required:
+                                          - height
+                                          - width
+                                          type: object
+                                        type:
+                                          description: |-
+                                            Type of the video device.
+                                            Supported values: virtio.
+                                          type: string
+                                      required:
+                                      - type
+                                      type: object
                                     watchdog:
                                       description: Watchdog describes a watchdog device
                                         which can be added to the vmi.
                                       properties:
+                                        diag288:
+                                          description: diag288 watchdog device, only
+                                            available on s390x.
+                                          properties:
+                                            action:
+                                              description: |-
+                                                The action to take. Valid values are poweroff, reset, shutdown.
+                                                Defaults to reset.
+                                              type: string
+                                          type: object
                                         i6300esb:
                                           description: i6300esb watchdog device.
                                           properties:
@@ -27039,6 +28720,16 @@ var CRDsValidation map[string]string = map[string]string{
                                                 Defaults to reset.
                                               type: string
                                           type: object
+                                        itco:
+                                          description: itco watchdog device, part
+                                            of the q35 chipset on amd64.
+                                          properties:
+                                            action:
+                                              description: |-
+                                                The action to take. Valid values are poweroff, reset, shutdown.
+                                                Defaults to reset.
+                                              type: string
+                                          type: object
                                         name:
                                           description: Name of the watchdog.
                                           type: string
@@ -27336,6 +29027,45 @@ var CRDsValidation map[string]string = map[string]string{
                                                 Requires SMM to be enabled.
                                                 Defaults to true
                                               type: boolean
+                                            secureBootKeys:
+                                              description: |-
+                                                SecureBootKeys references the certificates which are enrolled as
+                                                SecureBoot keys in place of the ones shipped with the OVMF roms.
+                                                Requires SecureBoot to be enabled.
+                                              properties:
+                                                configMap:
+                                                  description: ConfigMap references
+                                                    a ConfigMap that contains the
+                                                    SecureBoot certificates.
+                                                  properties:
+                                                    name:
+                                                      default: ""
+                                                      description: |-
+                                                        Name of the referent.
+                                                        This field is effectively required, but due to backwards compatibility is
+                                                        allowed to be empty. Instances of this type with an empty value here are
+                                                        almost certainly wrong.
+                                                        More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                      type: string
+                                                  type: object
+                                                  x-kubernetes-map-type: atomic
+                                                secret:
+                                                  description: Secret references a
+                                                    k8s Secret that contains the SecureBoot
+                                                    certificates.
+                                                  properties:
+                                                    name:
+                                                      default: ""
+                                                      description: |-
+                                                        Name of the referent.
+                                                        This field is effectively required, but due to backwards compatibility is
+                                                        allowed to be empty. Instances of this type with an empty value here are
+                                                        almost certainly wrong.
+                                                        More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                      type: string
+                                                  type: object
+                                                  x-kubernetes-map-type: atomic
+                                              type: object
                                           type: object
                                       type: object
                                     kernelBoot:
@@ -27425,6 +29155,25 @@ var CRDsValidation map[string]string = map[string]string{
                                           description: Base64 encoded session blob.
                                           type: string
                                       type: object
+                                    snp:
+                                      description: AMD Secure Encrypted Virtualization
+                                        with Secure Nested Paging (SEV-SNP).
+                                      properties:
+                                        policy:
+                                          description: |-
+                                            Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+                                            Note: due to security reasons it is not allowed to enable guest debugging.
+                                          properties:
+                                            smt:
+                                              description: |-
+                                                If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+                                                Defaults to true.
+                                              type: boolean
+                                          type: object
+                                      type: object
+                                    tdx:
+                                      description: Intel Trust Domain Extensions (TDX).
+                                      type: object
                                   type: object
                                 machine:
                                   description: Machine type.
@@ -27468,6 +29217,22 @@ var CRDsValidation map[string]string = map[string]string{
                                         The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
                                       pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                       x-kubernetes-int-or-string: true
+                                    reclaim:
+                                      description: |-
+                                        Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+                                        when the node is under memory pressure.
+                                        Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+                                      properties:
+                                        floor:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          description: |-
+                                            Floor is the amount of memory which is never taken away from the guest.
+                                            Defaults to half of the guest memory.
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                      type: object
                                   type: object
                                 resources:
                                   description: Resources describes the Compute Resources
@@ -27670,6 +29435,26 @@ var CRDsValidation map[string]string = map[string]string{
                                           Select the default network and add it to the
                                           multus-cni.io/default-network annotation.
                                         type: boolean
+                                      dhcpServer:
+                                        description: |-
+                                          DHCPServer enables an in-pod DHCP server for a bridge bound interface
+                                          connected to this network, handing out addresses from the given range.
+                                          It is meant for networks that have no IPAM of their own.
+                                          Requires the DHCPServerIPAM feature gate.
+                                        properties:
+                                          gateway:
+                                            description: |-
+                                              Gateway advertised to the guest. It must be part of the subnet and is
+                                              never handed out as a guest address.
+                                            type: string
+                                          subnet:
+                                            description: |-
+                                              Subnet from which the guest addresses are allocated, in CIDR notation.
+                                              Only IPv4 subnets are supported.
+                                            type: string
+                                        required:
+                                        - subnet
+                                        type: object
                                       networkName:
                                         description: |-
                                           References to a NetworkAttachmentDefinition CRD object. Format:
@@ -28459,6 +30244,10 @@ var CRDsValidation map[string]string = map[string]string{
                                           claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                                           More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                                         type: string
+                                      format:
+                                        description: Format is the format the memory
+                                          is dumped in, defaults to elf
+                                        type: string
                                       hotpluggable:
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
@@ -28633,6 +30422,71 @@ var CRDsValidation map[string]string = map[string]string{
                         updated through an Update() before ObservedGeneration in Status.
                       format: int64
                       type: integer
+                    hotUnplug:
+                      description: HotUnplug reports the progress of a live vCPU or
+                        memory scale-down.
+                      nullable: true
+                      properties:
+                        cpu:
+                          description: CPU reports the progress of a vCPU hot-unplug,
+                            quantities are vCPU counts
+                          properties:
+                            current:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: Current is the amount the guest currently
+                                holds
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            requested:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: Requested is the amount the guest is being
+                                scaled down to
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            startTimestamp:
+                              description: StartTimestamp is the time the hot-unplug
+                                was first observed
+                              format: date-time
+                              type: string
+                          required:
+                          - current
+                          - requested
+                          - startTimestamp
+                          type: object
+                        memory:
+                          description: Memory reports the progress of a memory hot-unplug
+                          properties:
+                            current:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: Current is the amount the guest currently
+                                holds
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            requested:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: Requested is the amount the guest is being
+                                scaled down to
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            startTimestamp:
+                              description: StartTimestamp is the time the hot-unplug
+                                was first observed
+                              format: date-time
+                              type: string
+                          required:
+                          - current
+                          - requested
+                          - startTimestamp
+                          type: object
+                      type: object
                     memoryDumpRequest:
                       description: |-
                         MemoryDumpRequest tracks memory dump request phase and info of getting a memory
@@ -28652,6 +30506,10 @@ var CRDsValidation map[string]string = map[string]string{
                           description: FileName represents the name of the output
                             file
                           type: string
+                        format:
+                          description: Format is the format of the memory dump, defaults
+                            to elf
+                          type: string
                         message:
                           description: Message is a detailed message about failure
                             of the memory dump
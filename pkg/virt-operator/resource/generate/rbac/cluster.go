@@ -68,6 +68,7 @@ const (
 	apiVMInstancesConsole                   = "virtualmachineinstances/console"
 	apiVMInstancesVNC                       = "virtualmachineinstances/vnc"
 	apiVMInstancesVNCScreenshot             = "virtualmachineinstances/vnc/screenshot"
+	apiVMInstancesSpice                     = "virtualmachineinstances/spice"
 	apiVMInstancesPortForward               = "virtualmachineinstances/portforward"
 	apiVMInstancesPause                     = "virtualmachineinstances/pause"
 	apiVMInstancesUnpause                   = "virtualmachineinstances/unpause"
@@ -83,6 +84,8 @@ const (
 	apiVMInstancesSEVQueryLaunchMeasurement = "virtualmachineinstances/sev/querylaunchmeasurement"
 	apiVMInstancesSEVSetupSession           = "virtualmachineinstances/sev/setupsession"
 	apiVMInstancesSEVInjectLaunchSecret     = "virtualmachineinstances/sev/injectlaunchsecret"
+	apiVMInstancesLaunchSecurityReport      = "virtualmachineinstances/launchsecurity/report"
+	apiVMInstancesConsoleLog                = "virtualmachineinstances/consolelog"
 	apiVMInstancesUSBRedir                  = "virtualmachineinstances/usbredir"
 )
 
@@ -194,12 +197,15 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesConsole,
 					apiVMInstancesVNC,
 					apiVMInstancesVNCScreenshot,
+					apiVMInstancesSpice,
 					apiVMInstancesPortForward,
 					apiVMInstancesGuestOSInfo,
 					apiVMInstancesFileSysList,
 					apiVMInstancesUserList,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
+					apiVMInstancesLaunchSecurityReport,
+					apiVMInstancesConsoleLog,
 					apiVMInstancesUSBRedir,
 				},
 				Verbs: []string{
@@ -377,12 +383,15 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesConsole,
 					apiVMInstancesVNC,
 					apiVMInstancesVNCScreenshot,
+					apiVMInstancesSpice,
 					apiVMInstancesPortForward,
 					apiVMInstancesGuestOSInfo,
 					apiVMInstancesFileSysList,
 					apiVMInstancesUserList,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
+					apiVMInstancesLaunchSecurityReport,
+					apiVMInstancesConsoleLog,
 					apiVMInstancesUSBRedir,
 				},
 				Verbs: []string{
@@ -585,6 +594,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesUserList,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
+					apiVMInstancesLaunchSecurityReport,
+					apiVMInstancesConsoleLog,
 				},
 				Verbs: []string{
 					"get",
@@ -58,5 +58,17 @@ var _ = Describe("RBAC", func() {
 			Entry("for vms", "kubevirt.io", "virtualmachines"),
 			Entry("for vmis", "kubevirt.io", "virtualmachineinstances"),
 		)
+
+		It("should allow managing secondary network endpointslices", func() {
+			clusterRole := getObject(forController, reflect.TypeOf(&rbacv1.ClusterRole{}), components.ControllerServiceAccountName).(*rbacv1.ClusterRole)
+			Expect(clusterRole).ToNot(BeNil())
+			Expect(clusterRole.Rules).To(
+				ContainElement(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"APIGroups": ContainElement("discovery.k8s.io"),
+					"Resources": ContainElement("endpointslices"),
+					"Verbs":     ContainElements("create", "update", "delete"),
+				})),
+			)
+		})
 	})
 })
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strconv"
 
 	kvtls "kubevirt.io/kubevirt/pkg/util/tls"
@@ -111,6 +112,11 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ctx context.Context, ar *admission
 		results = append(results, validateInfraReplicas(newKV.Spec.Infra.Replicas)...)
 	}
 
+	if networkConfig := newKV.Spec.Configuration.NetworkConfiguration; networkConfig != nil {
+		results = append(results,
+			validateServiceMeshConfiguration(field.NewPath("spec").Child("configuration", "network", "serviceMesh"), networkConfig.ServiceMesh)...)
+	}
+
 	response := validating_webhooks.NewAdmissionResponse(results)
 
 	if featureGatesChanged(&currKV.Spec, &newKV.Spec) {
@@ -309,6 +315,44 @@ func validateSeccompConfiguration(field *field.Path, seccompConf *v1.SeccompConf
 
 }
 
+func validateServiceMeshConfiguration(field *field.Path, meshConf *v1.ServiceMeshConfiguration) []metav1.StatusCause {
+	var statuses []metav1.StatusCause
+	if meshConf == nil {
+		return statuses
+	}
+
+	for i, port := range meshConf.ReservedPorts {
+		if port < 1 || port > 65535 {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field.Child("reservedPorts").Index(i).String(),
+				Message: fmt.Sprintf("port %d is out of range 1-65535", port),
+			})
+		}
+	}
+	for i, port := range meshConf.NonProxiedPorts {
+		if port < 1 || port > 65535 {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field.Child("nonProxiedPorts").Index(i).String(),
+				Message: fmt.Sprintf("port %d is out of range 1-65535", port),
+			})
+		}
+	}
+
+	if meshConf.ProxySourceAddress != "" {
+		ip := net.ParseIP(meshConf.ProxySourceAddress)
+		if ip == nil || ip.To4() == nil || !ip.IsLoopback() {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field.Child("proxySourceAddress").String(),
+				Message: fmt.Sprintf("%q is not an IPv4 loopback address", meshConf.ProxySourceAddress),
+			})
+		}
+	}
+	return statuses
+}
+
 func validateWorkloadPlacement(ctx context.Context, namespace string, placementConfig *v1.NodePlacement, client kubecli.KubevirtClient) []metav1.StatusCause {
 	statuses := []metav1.StatusCause{}
 
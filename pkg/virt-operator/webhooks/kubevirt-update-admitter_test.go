@@ -69,6 +69,28 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 		}, []string{vmProfileField.Child("customProfile", "runtimeDefaultProfile").String(), vmProfileField.Child("customProfile", "localhostProfile").String()}),
 	)
 
+	DescribeTable("validateServiceMeshConfiguration", func(meshConfiguration *v1.ServiceMeshConfiguration, expectedFields []string) {
+		causes := validateServiceMeshConfiguration(test, meshConfiguration)
+		Expect(causes).To(HaveLen(len(expectedFields)))
+		for _, cause := range causes {
+			Expect(cause.Field).To(BeElementOf(expectedFields))
+		}
+	},
+		Entry("not specified", nil, nil),
+		Entry("with valid ports and proxy source address", &v1.ServiceMeshConfiguration{
+			ReservedPorts:      []int32{15001, 15006},
+			NonProxiedPorts:    []int32{22},
+			ProxySourceAddress: "127.0.0.6",
+		}, nil),
+		Entry("with out of range ports", &v1.ServiceMeshConfiguration{
+			ReservedPorts:   []int32{0},
+			NonProxiedPorts: []int32{22, 65536},
+		}, []string{test.Child("reservedPorts").Index(0).String(), test.Child("nonProxiedPorts").Index(1).String()}),
+		Entry("with a non loopback proxy source address", &v1.ServiceMeshConfiguration{
+			ProxySourceAddress: "10.0.0.6",
+		}, []string{test.Child("proxySourceAddress").String()}),
+	)
+
 	DescribeTable("test validateCustomizeComponents", func(cc v1.CustomizeComponents, expectedCauses int) {
 		causes := validateCustomizeComponents(cc)
 		Expect(causes).To(HaveLen(expectedCauses))
@@ -0,0 +1,408 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package mustgather
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	kutil "kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	computeContainer = "compute"
+	metricsPortName  = "metrics"
+	metricsPath      = "metrics"
+	nonRootVirshURI  = "qemu+unix:///session?socket=/var/run/libvirt/virtqemud-sock"
+	redacted         = "REDACTED"
+)
+
+// components are the values of the kubevirt.io label of the KubeVirt component pods
+var components = []string{
+	"virt-operator",
+	"virt-api",
+	"virt-controller",
+	"virt-handler",
+	"virt-exportproxy",
+}
+
+// ExecFn runs the command in the container of the pod and returns its stdout,
+// it can be overridden for unit testing
+var ExecFn = execInPod
+
+type gatherer struct {
+	clientConfig clientcmd.ClientConfig
+	virtClient   kubecli.KubevirtClient
+	bundle       *bundle
+	since        time.Duration
+	redact       bool
+}
+
+// nodeLabels holds the labels and annotations the node-labeller sets on a node
+type nodeLabels struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// gather writes the bundle. Only failures to write the bundle or to detect the
+// KubeVirt installation are returned, all other failures are recorded in the bundle.
+func (g *gatherer) gather(namespaces []string, domainXML, metrics bool) error {
+	installNamespaces, err := g.gatherKubeVirt()
+	if err != nil {
+		return err
+	}
+	for _, namespace := range installNamespaces {
+		if err := g.gatherComponents(namespace, metrics); err != nil {
+			return err
+		}
+	}
+	if err := g.gatherNodes(); err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if err := g.gatherWorkloads(namespace, domainXML); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatherKubeVirt writes the KubeVirt CRs and returns the namespaces KubeVirt is installed in
+func (g *gatherer) gatherKubeVirt() ([]string, error) {
+	kvs, err := g.virtClient.KubeVirt(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list KubeVirt CRs across all namespaces: %v", err)
+	}
+	if len(kvs.Items) == 0 {
+		return nil, errors.New("could not detect a KubeVirt installation")
+	}
+
+	var namespaces []string
+	for i := range kvs.Items {
+		kv := &kvs.Items[i]
+		kv.SetGroupVersionKind(v1.KubeVirtGroupVersionKind)
+		if err := g.bundle.writeObject(path.Join("kubevirt", kv.Namespace, kv.Name+".yaml"), kv); err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, kv.Namespace)
+	}
+	return namespaces, nil
+}
+
+func (g *gatherer) gatherComponents(namespace string, metrics bool) error {
+	selector := fmt.Sprintf("%s in (%s)", v1.AppLabel, strings.Join(components, ","))
+	pods, err := g.virtClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		g.bundle.fail("could not list the KubeVirt component pods in namespace %s: %v", namespace, err)
+		return nil
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		dir := path.Join("components", pod.Namespace, pod.Name)
+		pod.SetGroupVersionKind(k8sv1.SchemeGroupVersion.WithKind("Pod"))
+		if err := g.bundle.writeObject(path.Join(dir, "pod.yaml"), pod); err != nil {
+			return err
+		}
+		// The init containers of virt-handler run the node-labeller
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if err := g.gatherLogs(pod, container.Name, dir); err != nil {
+				return err
+			}
+		}
+		if metrics && pod.Status.Phase == k8sv1.PodRunning {
+			if err := g.gatherMetrics(pod, dir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *gatherer) gatherLogs(pod *k8sv1.Pod, container, dir string) error {
+	opts := &k8sv1.PodLogOptions{Container: container}
+	if g.since > 0 {
+		opts.SinceSeconds = pointer.P(int64(g.since.Seconds()))
+	}
+	logs, err := g.virtClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(context.Background())
+	if err != nil {
+		g.bundle.fail("could not get the logs of container %s of pod %s/%s: %v", container, pod.Namespace, pod.Name, err)
+		return nil
+	}
+	return g.bundle.writeFile(path.Join(dir, container+".log"), logs)
+}
+
+// gatherMetrics scrapes the metrics endpoints of the pod through the pod proxy of the API server
+func (g *gatherer) gatherMetrics(pod *k8sv1.Pod, dir string) error {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name != metricsPortName {
+				continue
+			}
+			metrics, err := g.virtClient.CoreV1().Pods(pod.Namespace).ProxyGet(
+				"https", pod.Name, strconv.Itoa(int(port.ContainerPort)), metricsPath, nil,
+			).DoRaw(context.Background())
+			if err != nil {
+				g.bundle.fail("could not get the metrics of container %s of pod %s/%s: %v", container.Name, pod.Namespace, pod.Name, err)
+				continue
+			}
+			if err := g.bundle.writeFile(path.Join(dir, container.Name+"-metrics.txt"), metrics); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *gatherer) gatherNodes() error {
+	nodes, err := g.virtClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		g.bundle.fail("could not list the nodes: %v", err)
+		return nil
+	}
+
+	for _, node := range nodes.Items {
+		labels := nodeLabels{
+			Name:        node.Name,
+			Labels:      filterKubeVirtKeys(node.Labels),
+			Annotations: filterKubeVirtKeys(node.Annotations),
+		}
+		if err := g.bundle.writeObject(path.Join("nodes", node.Name+".yaml"), labels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterKubeVirtKeys(m map[string]string) map[string]string {
+	filtered := map[string]string{}
+	for key, value := range m {
+		if strings.Contains(key, v1.AppLabel) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// gatherWorkloads writes the VMs, VMIs and migrations of the namespace, all namespaces if it is empty
+func (g *gatherer) gatherWorkloads(namespace string, domainXML bool) error {
+	namespaceName := namespace
+	if namespaceName == metav1.NamespaceAll {
+		namespaceName = "all namespaces"
+	}
+
+	vms, err := g.virtClient.VirtualMachine(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		g.bundle.fail("could not list the VMs in %s: %v", namespaceName, err)
+	} else {
+		for i := range vms.Items {
+			vm := &vms.Items[i]
+			vm.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+			if g.redact {
+				redactObjectMeta(&vm.ObjectMeta)
+				if vm.Spec.Template != nil {
+					redactVolumes(vm.Spec.Template.Spec.Volumes)
+				}
+			}
+			if err := g.bundle.writeObject(workloadPath(vm.Namespace, "virtualmachines", vm.Name+".yaml"), vm); err != nil {
+				return err
+			}
+		}
+	}
+
+	migrations, err := g.virtClient.VirtualMachineInstanceMigration(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		g.bundle.fail("could not list the migrations in %s: %v", namespaceName, err)
+	} else {
+		for i := range migrations.Items {
+			migration := &migrations.Items[i]
+			migration.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+			if err := g.bundle.writeObject(workloadPath(migration.Namespace, "virtualmachineinstancemigrations", migration.Name+".yaml"), migration); err != nil {
+				return err
+			}
+		}
+	}
+
+	vmis, err := g.virtClient.VirtualMachineInstance(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		g.bundle.fail("could not list the VMIs in %s: %v", namespaceName, err)
+		return nil
+	}
+	for i := range vmis.Items {
+		vmi := &vmis.Items[i]
+		vmi.SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+		if g.redact {
+			redactObjectMeta(&vmi.ObjectMeta)
+			redactVolumes(vmi.Spec.Volumes)
+		}
+		if err := g.bundle.writeObject(workloadPath(vmi.Namespace, "virtualmachineinstances", vmi.Name+".yaml"), vmi); err != nil {
+			return err
+		}
+		if err := g.gatherLauncherLogs(vmi); err != nil {
+			return err
+		}
+		if domainXML && vmi.Status.Phase == v1.Running {
+			if err := g.gatherDomainXML(vmi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func workloadPath(namespace, resource, name string) string {
+	return path.Join("namespaces", namespace, resource, name)
+}
+
+// redactObjectMeta drops the last applied configuration, it contains the unredacted spec
+func redactObjectMeta(meta *metav1.ObjectMeta) {
+	delete(meta.Annotations, k8sv1.LastAppliedConfigAnnotation)
+}
+
+// redactVolumes replaces the inline cloud-init user and network data, they commonly carry
+// passwords, keys and tokens. Data referenced from secrets is not gathered.
+func redactVolumes(volumes []v1.Volume) {
+	redact := func(data ...*string) {
+		for _, d := range data {
+			if *d != "" {
+				*d = redacted
+			}
+		}
+	}
+	for i := range volumes {
+		if noCloud := volumes[i].CloudInitNoCloud; noCloud != nil {
+			redact(&noCloud.UserData, &noCloud.UserDataBase64, &noCloud.NetworkData, &noCloud.NetworkDataBase64)
+		}
+		if configDrive := volumes[i].CloudInitConfigDrive; configDrive != nil {
+			redact(&configDrive.UserData, &configDrive.UserDataBase64, &configDrive.NetworkData, &configDrive.NetworkDataBase64)
+		}
+	}
+}
+
+// gatherLauncherLogs writes the compute container logs of all virt-launcher pods of the VMI,
+// during a migration these are the pods on the source and the target node
+func (g *gatherer) gatherLauncherLogs(vmi *v1.VirtualMachineInstance) error {
+	if vmi.UID == "" {
+		return nil
+	}
+	selector := fmt.Sprintf("%s=%s", v1.CreatedByLabel, vmi.UID)
+	pods, err := g.virtClient.CoreV1().Pods(vmi.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		g.bundle.fail("could not list the virt-launcher pods of VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+		return nil
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == k8sv1.PodPending {
+			continue
+		}
+		if err := g.gatherLogs(pod, computeContainer, workloadPath(pod.Namespace, "pods", pod.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatherDomainXML dumps the domain XML of the VMI with virsh in its virt-launcher pod
+func (g *gatherer) gatherDomainXML(vmi *v1.VirtualMachineInstance) error {
+	pod, err := g.launcherPod(vmi)
+	if err != nil {
+		g.bundle.fail("could not find the virt-launcher pod of VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+		return nil
+	}
+
+	command := []string{"virsh"}
+	if kutil.IsNonRootVMI(vmi) {
+		command = append(command, "-c", nonRootVirshURI)
+	}
+	command = append(command, "dumpxml", vmi.Namespace+"_"+vmi.Name)
+
+	domainXML, err := ExecFn(g.clientConfig, g.virtClient, pod, computeContainer, command)
+	if err != nil {
+		g.bundle.fail("could not dump the domain XML of VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+		return nil
+	}
+	return g.bundle.writeFile(workloadPath(vmi.Namespace, "domains", vmi.Name+".xml"), domainXML)
+}
+
+// launcherPod returns the running virt-launcher pod of the VMI on the node the VMI runs on
+func (g *gatherer) launcherPod(vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
+	selector := fmt.Sprintf("%s=%s", v1.CreatedByLabel, vmi.UID)
+	pods, err := g.virtClient.CoreV1().Pods(vmi.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == k8sv1.PodRunning && pod.Spec.NodeName == vmi.Status.NodeName {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("no running pod on node %s", vmi.Status.NodeName)
+}
+
+func execInPod(clientConfig clientcmd.ClientConfig, virtClient kubecli.KubevirtClient, pod *k8sv1.Pod, container string, command []string) ([]byte, error) {
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	req := virtClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec")
+	req.VersionedParams(&k8sv1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(context.Background(), remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
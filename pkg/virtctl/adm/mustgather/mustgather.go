@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package mustgather
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_MUST_GATHER = "must-gather"
+
+	outputFlag     = "output"
+	namespacesFlag = "namespaces"
+	sinceFlag      = "since"
+	domainXMLFlag  = "domain-xml"
+	metricsFlag    = "metrics"
+	redactFlag     = "redact"
+
+	// bundleDir is the directory all files of the bundle are placed in
+	bundleDir  = "must-gather"
+	errorsFile = "errors.txt"
+)
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+	cmd          *cobra.Command
+
+	output     string
+	namespaces []string
+	since      time.Duration
+	domainXML  bool
+	metrics    bool
+	redact     bool
+}
+
+// bundle writes the gathered files into a gzipped tarball. Failures to gather single
+// items do not abort the gathering, they are reported and recorded in the bundle.
+type bundle struct {
+	cmd      *cobra.Command
+	tar      *tar.Writer
+	modTime  time.Time
+	failures []string
+}
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := command{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   COMMAND_MUST_GATHER,
+		Short: "Gather a diagnostics bundle of the KubeVirt installation and its workloads.",
+		Long: `Gather a diagnostics bundle of the KubeVirt installation and its workloads into a tarball.
+The bundle contains the KubeVirt CR, the pods, logs and metrics of the KubeVirt components,
+the KubeVirt labels and annotations of the nodes as set by the node-labeller, the VMs, VMIs
+and migrations of the selected namespaces, the virt-launcher logs and the domain XMLs of their VMIs.
+Inline cloud-init user and network data is redacted unless --redact=false is passed.`,
+		Example: usage(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c.cmd = cmd
+			return c.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&c.output, outputFlag, "", "Path of the tarball to write the bundle to, defaults to must-gather-<timestamp>.tar.gz")
+	cmd.Flags().StringSliceVar(&c.namespaces, namespacesFlag, nil, "Namespaces to gather VMs, VMIs and migrations from, defaults to all namespaces")
+	cmd.Flags().DurationVar(&c.since, sinceFlag, 0, "Only gather component logs newer than the duration, e.g. 1h; all logs are gathered by default")
+	cmd.Flags().BoolVar(&c.domainXML, domainXMLFlag, true, "Gather the domain XMLs of running VMIs from their virt-launcher pods")
+	cmd.Flags().BoolVar(&c.metrics, metricsFlag, true, "Gather a snapshot of the metrics of the KubeVirt components")
+	cmd.Flags().BoolVar(&c.redact, redactFlag, true, "Redact the inline cloud-init user and network data of the gathered VMs and VMIs")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+
+	return cmd
+}
+
+func usage() string {
+	return `  # Gather a bundle of the KubeVirt installation and the workloads of all namespaces:
+  {{ProgramName}} adm must-gather
+
+  # Gather the workloads of the namespaces ns1 and ns2 and component logs of the last hour only:
+  {{ProgramName}} adm must-gather --namespaces=ns1,ns2 --since=1h --output=bundle.tar.gz`
+}
+
+func (c *command) run() error {
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
+	}
+
+	now := time.Now()
+	if c.output == "" {
+		c.output = fmt.Sprintf("must-gather-%s.tar.gz", now.Format("20060102-150405"))
+	}
+	namespaces := c.namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	file, err := os.Create(c.output)
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(file)
+	b := &bundle{cmd: c.cmd, tar: tar.NewWriter(gzipWriter), modTime: now}
+
+	g := &gatherer{
+		clientConfig: c.clientConfig,
+		virtClient:   virtClient,
+		bundle:       b,
+		since:        c.since,
+		redact:       c.redact,
+	}
+	err = g.gather(namespaces, c.domainXML, c.metrics)
+	if err == nil {
+		err = b.writeFailures()
+	}
+	for _, closer := range []io.Closer{b.tar, gzipWriter, file} {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		_ = os.Remove(c.output)
+		return err
+	}
+
+	c.cmd.Printf("Wrote must-gather bundle to %s\n", c.output)
+	if len(b.failures) > 0 {
+		c.cmd.Printf("%d items could not be gathered, see %s in the bundle\n", len(b.failures), errorsFile)
+	}
+	return nil
+}
+
+// writeFile adds a file with the content at the path below the bundle directory
+func (b *bundle) writeFile(name string, content []byte) error {
+	err := b.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(bundleDir, name),
+		Size:     int64(len(content)),
+		Mode:     0644,
+		ModTime:  b.modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = b.tar.Write(content)
+	return err
+}
+
+// writeObject adds the object as YAML file at the path below the bundle directory
+func (b *bundle) writeObject(name string, obj interface{}) error {
+	content, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return b.writeFile(name, content)
+}
+
+// fail records an item which could not be gathered
+func (b *bundle) fail(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	b.cmd.PrintErrln("Warning: " + msg)
+	b.failures = append(b.failures, msg)
+}
+
+func (b *bundle) writeFailures() error {
+	if len(b.failures) == 0 {
+		return nil
+	}
+	return b.writeFile(errorsFile, []byte(strings.Join(b.failures, "\n")+"\n"))
+}
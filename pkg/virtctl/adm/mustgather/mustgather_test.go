@@ -0,0 +1,320 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package mustgather_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/adm"
+	"kubevirt.io/kubevirt/pkg/virtctl/adm/mustgather"
+	testsclientcmd "kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+type fakeResponse []byte
+
+func (r fakeResponse) DoRaw(context.Context) ([]byte, error) {
+	return r, nil
+}
+
+func (r fakeResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r)), nil
+}
+
+var _ = Describe("Must-gather command", func() {
+	const (
+		installNamespace = "kubevirt"
+		nodeName         = "node01"
+		metricsContent   = "kubevirt_info 1\n"
+		domainXML        = "<domain type='kvm'/>"
+		userData         = "#cloud-config\npassword: secret\n"
+	)
+
+	var (
+		kubeClient *k8sfake.Clientset
+		virtClient *kubevirtfake.Clientset
+		output     string
+		execs      [][]string
+	)
+
+	newPod := func(namespace, name string, labels map[string]string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec:       k8sv1.PodSpec{NodeName: nodeName},
+			Status:     k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+	}
+
+	BeforeEach(func() {
+		handler := newPod(installNamespace, "virt-handler-abcde", map[string]string{v1.AppLabel: "virt-handler"})
+		handler.Spec.InitContainers = []k8sv1.Container{{Name: "virt-launcher"}}
+		handler.Spec.Containers = []k8sv1.Container{{
+			Name:  "virt-handler",
+			Ports: []k8sv1.ContainerPort{{Name: "metrics", ContainerPort: 8443}},
+		}}
+		launcher := newPod("ns1", "virt-launcher-testvmi-xyz", map[string]string{
+			v1.AppLabel:       "virt-launcher",
+			v1.CreatedByLabel: "vmi-uid",
+		})
+		launcher.Spec.Containers = []k8sv1.Container{{Name: "compute"}}
+		unrelated := newPod(installNamespace, "cdi-operator", map[string]string{v1.AppLabel: "cdi"})
+		node := &k8sv1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Labels: map[string]string{
+				"cpu-model.node.kubevirt.io/Skylake": "true",
+				"kubernetes.io/hostname":             nodeName,
+			},
+			Annotations: map[string]string{
+				"node.kubevirt.io/heartbeat":                             "2024-01-01T00:00:00Z",
+				"volumes.kubernetes.io/controller-managed-attach-detach": "true",
+			},
+		}}
+		kubeClient = k8sfake.NewSimpleClientset(handler, launcher, unrelated, node)
+		kubeClient.PrependProxyReactor("pods", func(action testing.Action) (bool, restclient.ResponseWrapper, error) {
+			proxy := action.(testing.ProxyGetAction)
+			Expect(proxy.GetScheme()).To(Equal("https"))
+			Expect(proxy.GetName()).To(Equal(handler.Name))
+			Expect(proxy.GetPort()).To(Equal("8443"))
+			Expect(proxy.GetPath()).To(Equal("metrics"))
+			return true, fakeResponse(metricsContent), nil
+		})
+
+		cloudInitVolumes := []v1.Volume{{
+			Name: "cloudinit",
+			VolumeSource: v1.VolumeSource{
+				CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: userData, NetworkDataBase64: "bmV0d29yazogc2VjcmV0"},
+			},
+		}, {
+			Name: "configdrive",
+			VolumeSource: v1.VolumeSource{
+				CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserDataSecretRef: &k8sv1.LocalObjectReference{Name: "userdata"}},
+			},
+		}}
+		lastApplied := map[string]string{k8sv1.LastAppliedConfigAnnotation: userData}
+
+		virtClient = kubevirtfake.NewSimpleClientset(
+			&v1.KubeVirt{ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: installNamespace}},
+			&v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvm", Namespace: "ns1", Annotations: lastApplied},
+				Spec: v1.VirtualMachineSpec{Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{Volumes: cloudInitVolumes},
+				}},
+			},
+			&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "othervm", Namespace: "ns2"}},
+			&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "ns1", UID: "vmi-uid", Annotations: lastApplied},
+				Spec:       v1.VirtualMachineInstanceSpec{Volumes: cloudInitVolumes},
+				Status:     v1.VirtualMachineInstanceStatus{Phase: v1.Running, NodeName: nodeName},
+			},
+			&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "pendingvmi", Namespace: "ns1"},
+				Status:     v1.VirtualMachineInstanceStatus{Phase: v1.Pending},
+			},
+			&v1.VirtualMachineInstanceMigration{ObjectMeta: metav1.ObjectMeta{Name: "testmigration", Namespace: "ns1"}},
+		)
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().KubeVirt(gomock.Any()).DoAndReturn(func(namespace string) kubecli.KubeVirtInterface {
+			return virtClient.KubevirtV1().KubeVirts(namespace)
+		}).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInterface {
+			return virtClient.KubevirtV1().VirtualMachines(namespace)
+		}).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInstanceInterface {
+			return virtClient.KubevirtV1().VirtualMachineInstances(namespace)
+		}).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInstanceMigrationInterface {
+			return virtClient.KubevirtV1().VirtualMachineInstanceMigrations(namespace)
+		}).AnyTimes()
+
+		execs = nil
+		origExecFn := mustgather.ExecFn
+		mustgather.ExecFn = func(_ clientcmd.ClientConfig, _ kubecli.KubevirtClient, pod *k8sv1.Pod, container string, command []string) ([]byte, error) {
+			Expect(pod.Name).To(Equal(launcher.Name))
+			Expect(container).To(Equal("compute"))
+			execs = append(execs, command)
+			return []byte(domainXML), nil
+		}
+		DeferCleanup(func() {
+			mustgather.ExecFn = origExecFn
+		})
+
+		output = filepath.Join(GinkgoT().TempDir(), "bundle.tar.gz")
+	})
+
+	runMustGather := func(args ...string) error {
+		args = append([]string{adm.ADM, mustgather.COMMAND_MUST_GATHER, "--output", output}, args...)
+		return testsclientcmd.NewRepeatableVirtctlCommand(args...)()
+	}
+
+	readBundle := func() map[string]string {
+		file, err := os.Open(output)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+		gzipReader, err := gzip.NewReader(file)
+		Expect(err).ToNot(HaveOccurred())
+
+		files := map[string]string{}
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			header, err := tarReader.Next()
+			if errors.Is(err, io.EOF) {
+				return files
+			}
+			Expect(err).ToNot(HaveOccurred())
+			content, err := io.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+			files[header.Name] = string(content)
+		}
+	}
+
+	logOptions := func() []*k8sv1.PodLogOptions {
+		var opts []*k8sv1.PodLogOptions
+		for _, action := range kubeClient.Actions() {
+			if action.GetSubresource() == "log" {
+				opts = append(opts, action.(testing.GenericAction).GetValue().(*k8sv1.PodLogOptions))
+			}
+		}
+		return opts
+	}
+
+	It("should fail without a KubeVirt installation", func() {
+		Expect(virtClient.KubevirtV1().KubeVirts(installNamespace).Delete(context.Background(), "kubevirt", metav1.DeleteOptions{})).To(Succeed())
+		Expect(runMustGather()).To(MatchError("could not detect a KubeVirt installation"))
+		Expect(output).ToNot(BeAnExistingFile())
+	})
+
+	It("should gather the installation and the workloads of all namespaces", func() {
+		Expect(runMustGather()).To(Succeed())
+
+		files := readBundle()
+		Expect(files).To(HaveKey("must-gather/kubevirt/kubevirt/kubevirt.yaml"))
+		Expect(files["must-gather/kubevirt/kubevirt/kubevirt.yaml"]).To(ContainSubstring("kind: KubeVirt"))
+
+		By("gathering the component pods, logs and metrics")
+		Expect(files).To(HaveKey("must-gather/components/kubevirt/virt-handler-abcde/pod.yaml"))
+		Expect(files).To(HaveKeyWithValue("must-gather/components/kubevirt/virt-handler-abcde/virt-launcher.log", "fake logs"))
+		Expect(files).To(HaveKeyWithValue("must-gather/components/kubevirt/virt-handler-abcde/virt-handler.log", "fake logs"))
+		Expect(files).To(HaveKeyWithValue("must-gather/components/kubevirt/virt-handler-abcde/virt-handler-metrics.txt", metricsContent))
+		Expect(files).ToNot(HaveKey("must-gather/components/kubevirt/cdi-operator/pod.yaml"))
+		for _, opts := range logOptions() {
+			Expect(opts.SinceSeconds).To(BeNil())
+		}
+
+		By("gathering the node-labeller output")
+		Expect(files).To(HaveKey("must-gather/nodes/node01.yaml"))
+		Expect(files["must-gather/nodes/node01.yaml"]).To(ContainSubstring("cpu-model.node.kubevirt.io/Skylake"))
+		Expect(files["must-gather/nodes/node01.yaml"]).To(ContainSubstring("node.kubevirt.io/heartbeat"))
+		Expect(files["must-gather/nodes/node01.yaml"]).ToNot(ContainSubstring("kubernetes.io/hostname"))
+
+		By("gathering the workloads and domain XMLs")
+		Expect(files).To(HaveKey("must-gather/namespaces/ns1/virtualmachines/testvm.yaml"))
+		Expect(files).To(HaveKey("must-gather/namespaces/ns2/virtualmachines/othervm.yaml"))
+		Expect(files).To(HaveKey("must-gather/namespaces/ns1/virtualmachineinstances/testvmi.yaml"))
+		Expect(files).To(HaveKey("must-gather/namespaces/ns1/virtualmachineinstances/pendingvmi.yaml"))
+		Expect(files).To(HaveKey("must-gather/namespaces/ns1/virtualmachineinstancemigrations/testmigration.yaml"))
+		Expect(files).To(HaveKeyWithValue("must-gather/namespaces/ns1/domains/testvmi.xml", domainXML))
+		Expect(files).ToNot(HaveKey("must-gather/namespaces/ns1/domains/pendingvmi.xml"))
+		Expect(execs).To(Equal([][]string{{"virsh", "dumpxml", "ns1_testvmi"}}))
+
+		By("gathering the virt-launcher logs")
+		Expect(files).To(HaveKeyWithValue("must-gather/namespaces/ns1/pods/virt-launcher-testvmi-xyz/compute.log", "fake logs"))
+
+		By("redacting the inline cloud-init data")
+		for _, name := range []string{
+			"must-gather/namespaces/ns1/virtualmachines/testvm.yaml",
+			"must-gather/namespaces/ns1/virtualmachineinstances/testvmi.yaml",
+		} {
+			Expect(files[name]).To(ContainSubstring("userData: REDACTED"))
+			Expect(files[name]).To(ContainSubstring("networkDataBase64: REDACTED"))
+			Expect(files[name]).To(ContainSubstring("name: userdata"))
+			Expect(files[name]).ToNot(ContainSubstring("password: secret"))
+			Expect(files[name]).ToNot(ContainSubstring("bmV0d29yazogc2VjcmV0"))
+			Expect(files[name]).ToNot(ContainSubstring(k8sv1.LastAppliedConfigAnnotation))
+		}
+
+		Expect(files).ToNot(HaveKey("must-gather/errors.txt"))
+	})
+
+	It("should only gather the selected namespaces and recent logs", func() {
+		Expect(runMustGather("--namespaces", "ns2", "--since", "1h", "--domain-xml=false", "--metrics=false")).To(Succeed())
+
+		files := readBundle()
+		Expect(files).To(HaveKey("must-gather/namespaces/ns2/virtualmachines/othervm.yaml"))
+		Expect(files).ToNot(HaveKey("must-gather/namespaces/ns1/virtualmachines/testvm.yaml"))
+		Expect(files).ToNot(HaveKey("must-gather/components/kubevirt/virt-handler-abcde/virt-handler-metrics.txt"))
+		Expect(execs).To(BeEmpty())
+
+		Expect(logOptions()).ToNot(BeEmpty())
+		for _, opts := range logOptions() {
+			Expect(opts.SinceSeconds).To(HaveValue(BeEquivalentTo(3600)))
+		}
+	})
+
+	It("should not redact the inline cloud-init data when disabled", func() {
+		Expect(runMustGather("--namespaces", "ns1", "--redact=false")).To(Succeed())
+
+		files := readBundle()
+		Expect(files["must-gather/namespaces/ns1/virtualmachineinstances/testvmi.yaml"]).To(ContainSubstring("password: secret"))
+		Expect(files["must-gather/namespaces/ns1/virtualmachineinstances/testvmi.yaml"]).To(ContainSubstring(k8sv1.LastAppliedConfigAnnotation))
+		Expect(files["must-gather/namespaces/ns1/virtualmachines/testvm.yaml"]).To(ContainSubstring("bmV0d29yazogc2VjcmV0"))
+	})
+
+	It("should record items which could not be gathered in the bundle", func() {
+		mustgather.ExecFn = func(clientcmd.ClientConfig, kubecli.KubevirtClient, *k8sv1.Pod, string, []string) ([]byte, error) {
+			return nil, errors.New("virsh not found")
+		}
+		virtClient.PrependReactor("list", "virtualmachineinstancemigrations", func(testing.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+
+		Expect(runMustGather("--namespaces", "ns1")).To(Succeed())
+
+		files := readBundle()
+		Expect(files).To(HaveKey("must-gather/namespaces/ns1/virtualmachineinstances/testvmi.yaml"))
+		Expect(files).To(HaveKeyWithValue("must-gather/errors.txt",
+			"could not list the migrations in ns1: forbidden\n"+
+				"could not dump the domain XML of VMI ns1/testvmi: virsh not found\n"))
+	})
+})
@@ -0,0 +1,183 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	selectorArg      = "selector"
+	allNamespacesArg = "all-namespaces"
+	concurrencyArg   = "concurrency"
+	waitArg          = "wait"
+	timeoutArg       = "timeout"
+
+	defaultConcurrency = 5
+	defaultTimeout     = 5 * time.Minute
+)
+
+// PollInterval is how often the state of a VM is checked while waiting for it
+var PollInterval = 2 * time.Second
+
+// Options select the VMs a command acts on in batch mode, and control how it is run
+type Options struct {
+	Selector      string
+	AllNamespaces bool
+	Concurrency   int
+	Wait          bool
+	Timeout       time.Duration
+}
+
+// Condition returns whether the VMI of a VM, nil if it does not exist, reached the target state
+type Condition func(vmi *v1.VirtualMachineInstance) (bool, error)
+
+// Action runs a command on a single VM. It returns the condition to wait for, if any.
+type Action func(vm *v1.VirtualMachine) (Condition, error)
+
+// Command describes how a batch of VMs is reported on
+type Command struct {
+	// Verb is the action run on the VMs, e.g. "stop"
+	Verb string
+	// Done is reported once a VM reached the target state, e.g. "stopped"
+	Done string
+}
+
+// AddFlags adds the batch mode flags to a command
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Selector, selectorArg, "l", "", "Label selector choosing the VMs to act on instead of a single named VM.")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, allNamespacesArg, "A", false, "Select VMs in all namespaces. Requires --selector.")
+	cmd.Flags().IntVar(&o.Concurrency, concurrencyArg, defaultConcurrency, "How many selected VMs are acted on at the same time. Requires --selector.")
+	cmd.Flags().BoolVar(&o.Wait, waitArg, false, "Wait for each selected VM to reach the target state. Requires --selector.")
+	cmd.Flags().DurationVar(&o.Timeout, timeoutArg, defaultTimeout, "How long to wait for each selected VM to reach the target state. Requires --wait.")
+}
+
+// Enabled returns whether VMs are selected by label instead of by name
+func (o *Options) Enabled() bool {
+	return o.Selector != ""
+}
+
+// ExactArgs accepts n positional arguments, or n-1 in batch mode where the VM name is omitted
+func (o *Options) ExactArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if o.Enabled() {
+			if len(args) != n-1 {
+				return fmt.Errorf("accepts %d arg(s) with --%s, received %d", n-1, selectorArg, len(args))
+			}
+			return nil
+		}
+		if cmd.Flags().Changed(allNamespacesArg) || cmd.Flags().Changed(concurrencyArg) || cmd.Flags().Changed(waitArg) || cmd.Flags().Changed(timeoutArg) {
+			return fmt.Errorf("--%s, --%s, --%s and --%s require --%s", allNamespacesArg, concurrencyArg, waitArg, timeoutArg, selectorArg)
+		}
+		return cobra.ExactArgs(n)(cmd, args)
+	}
+}
+
+// Run lists the VMs matching the selector and runs the action on them, reporting the progress of
+// each VM. It returns an error if the action failed or timed out on any of them.
+func (o *Options) Run(virtClient kubecli.KubevirtClient, namespace string, c Command, out io.Writer, action Action) error {
+	if o.Concurrency < 1 {
+		return fmt.Errorf("--%s must be greater than 0", concurrencyArg)
+	}
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	vms, err := virtClient.VirtualMachine(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return fmt.Errorf("error listing VirtualMachines: %v", err)
+	}
+	total := len(vms.Items)
+	if total == 0 {
+		fmt.Fprintf(out, "No VMs match the selector %s\n", o.Selector)
+		return nil
+	}
+
+	var (
+		lock      sync.Mutex
+		completed int
+		failed    int
+	)
+	report := func(vm *v1.VirtualMachine, err error, format string, a ...any) {
+		lock.Lock()
+		defer lock.Unlock()
+		completed++
+		if err != nil {
+			failed++
+			fmt.Fprintf(out, "[%d/%d] VM %s/%s failed to %s: %v\n", completed, total, vm.Namespace, vm.Name, c.Verb, err)
+			return
+		}
+		fmt.Fprintf(out, "[%d/%d] VM %s/%s %s\n", completed, total, vm.Namespace, vm.Name, fmt.Sprintf(format, a...))
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, o.Concurrency)
+	for i := range vms.Items {
+		vm := &vms.Items[i]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			condition, err := action(vm)
+			if err != nil || !o.Wait || condition == nil {
+				report(vm, err, "was scheduled to %s", c.Verb)
+				return
+			}
+			err = o.waitFor(virtClient, vm, condition)
+			report(vm, err, "was %s", c.Done)
+		}()
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d VMs", c.Verb, failed, total)
+	}
+	return nil
+}
+
+func (o *Options) waitFor(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine, condition Condition) error {
+	err := wait.PollUntilContextTimeout(context.Background(), PollInterval, o.Timeout, true, func(ctx context.Context) (bool, error) {
+		vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			vmi = nil
+		} else if err != nil {
+			return false, err
+		}
+		return condition(vmi)
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out after %s", o.Timeout)
+	}
+	return err
+}
@@ -0,0 +1,223 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package batch_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
+)
+
+var _ = Describe("Batch", func() {
+	const otherNamespace = "other"
+
+	var (
+		virtClient *kubevirtfake.Clientset
+		client     *kubecli.MockKubevirtClient
+		out        *bytes.Buffer
+		command    = batch.Command{Verb: "start", Done: "started"}
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		client = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient = kubevirtfake.NewSimpleClientset()
+		out = &bytes.Buffer{}
+
+		client.EXPECT().VirtualMachine(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInterface {
+			return virtClient.KubevirtV1().VirtualMachines(namespace)
+		}).AnyTimes()
+		client.EXPECT().VirtualMachineInstance(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInstanceInterface {
+			return virtClient.KubevirtV1().VirtualMachineInstances(namespace)
+		}).AnyTimes()
+
+		pollInterval := batch.PollInterval
+		batch.PollInterval = time.Millisecond
+		DeferCleanup(func() { batch.PollInterval = pollInterval })
+	})
+
+	createVM := func(namespace, name string, labels map[string]string) {
+		_, err := virtClient.KubevirtV1().VirtualMachines(namespace).Create(context.Background(), &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	noWait := func(*v1.VirtualMachine) (batch.Condition, error) { return nil, nil }
+
+	Context("args", func() {
+		run := func(args ...string) error {
+			opts := &batch.Options{}
+			cmd := &cobra.Command{
+				Use:  "test",
+				Args: opts.ExactArgs(2),
+				RunE: func(*cobra.Command, []string) error { return nil },
+			}
+			opts.AddFlags(cmd)
+			cmd.SetArgs(args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			return cmd.Execute()
+		}
+
+		DescribeTable("should be validated", func(expected string, args ...string) {
+			err := run(args...)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+			Entry("with a name", "", "vm", "myvm"),
+			Entry("with a selector", "", "vm", "-l", "app=web", "-A", "--wait", "--timeout=1m", "--concurrency=2"),
+			Entry("with a selector and a name", "accepts 1 arg(s) with --selector, received 2", "vm", "myvm", "-l", "app=web"),
+			Entry("without a name", "accepts 2 arg(s), received 1", "vm"),
+			Entry("with --wait but no selector", "require --selector", "vm", "myvm", "--wait"),
+			Entry("with --all-namespaces but no selector", "require --selector", "vm", "myvm", "-A"),
+		)
+	})
+
+	It("should act on the VMs matching the selector in the namespace", func() {
+		createVM(metav1.NamespaceDefault, "web1", map[string]string{"app": "web"})
+		createVM(metav1.NamespaceDefault, "web2", map[string]string{"app": "web"})
+		createVM(metav1.NamespaceDefault, "db", map[string]string{"app": "db"})
+		createVM(otherNamespace, "web3", map[string]string{"app": "web"})
+
+		var names []string
+		opts := &batch.Options{Selector: "app=web", Concurrency: 1}
+		err := opts.Run(client, metav1.NamespaceDefault, command, out, func(vm *v1.VirtualMachine) (batch.Condition, error) {
+			names = append(names, vm.Name)
+			return nil, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(ConsistOf("web1", "web2"))
+		Expect(out.String()).To(ContainSubstring("[1/2] VM default/"))
+		Expect(out.String()).To(ContainSubstring("[2/2] VM default/"))
+		Expect(out.String()).To(ContainSubstring("was scheduled to start"))
+	})
+
+	It("should act on the VMs matching the selector in all namespaces", func() {
+		createVM(metav1.NamespaceDefault, "web1", map[string]string{"app": "web"})
+		createVM(otherNamespace, "web2", map[string]string{"app": "web"})
+
+		opts := &batch.Options{Selector: "app=web", AllNamespaces: true, Concurrency: 1}
+		Expect(opts.Run(client, metav1.NamespaceDefault, command, out, noWait)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("VM default/web1 was scheduled to start"))
+		Expect(out.String()).To(ContainSubstring("VM other/web2 was scheduled to start"))
+	})
+
+	It("should report when no VM matches the selector", func() {
+		createVM(metav1.NamespaceDefault, "db", map[string]string{"app": "db"})
+
+		opts := &batch.Options{Selector: "app=web", Concurrency: 1}
+		Expect(opts.Run(client, metav1.NamespaceDefault, command, out, noWait)).To(Succeed())
+		Expect(out.String()).To(Equal("No VMs match the selector app=web\n"))
+	})
+
+	It("should refuse a concurrency below one", func() {
+		opts := &batch.Options{Selector: "app=web"}
+		Expect(opts.Run(client, metav1.NamespaceDefault, command, out, noWait)).To(MatchError("--concurrency must be greater than 0"))
+	})
+
+	It("should act on at most the given number of VMs at the same time", func() {
+		for i := range 6 {
+			createVM(metav1.NamespaceDefault, fmt.Sprintf("web%d", i), map[string]string{"app": "web"})
+		}
+
+		var active, maxActive atomic.Int32
+		opts := &batch.Options{Selector: "app=web", Concurrency: 2}
+		err := opts.Run(client, metav1.NamespaceDefault, command, out, func(*v1.VirtualMachine) (batch.Condition, error) {
+			current := active.Add(1)
+			defer active.Add(-1)
+			for {
+				previous := maxActive.Load()
+				if current <= previous || maxActive.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(maxActive.Load()).To(BeNumerically("<=", 2))
+		Expect(out.String()).To(ContainSubstring("[6/6]"))
+	})
+
+	It("should report the VMs the action failed on", func() {
+		createVM(metav1.NamespaceDefault, "web1", map[string]string{"app": "web"})
+		createVM(metav1.NamespaceDefault, "web2", map[string]string{"app": "web"})
+
+		opts := &batch.Options{Selector: "app=web", Concurrency: 1}
+		err := opts.Run(client, metav1.NamespaceDefault, command, out, func(vm *v1.VirtualMachine) (batch.Condition, error) {
+			if vm.Name == "web2" {
+				return nil, fmt.Errorf("boom")
+			}
+			return nil, nil
+		})
+		Expect(err).To(MatchError("failed to start 1 of 2 VMs"))
+		Expect(out.String()).To(ContainSubstring("VM default/web1 was scheduled to start"))
+		Expect(out.String()).To(ContainSubstring("VM default/web2 failed to start: boom"))
+	})
+
+	Context("when waiting", func() {
+		isRunning := func(vmi *v1.VirtualMachineInstance) (bool, error) {
+			return vmi != nil, nil
+		}
+
+		It("should report the VMs reaching the target state", func() {
+			createVM(metav1.NamespaceDefault, "web1", map[string]string{"app": "web"})
+
+			opts := &batch.Options{Selector: "app=web", Concurrency: 1, Wait: true, Timeout: time.Minute}
+			err := opts.Run(client, metav1.NamespaceDefault, command, out, func(vm *v1.VirtualMachine) (batch.Condition, error) {
+				_, err := virtClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: vm.Name, Namespace: vm.Namespace},
+				}, metav1.CreateOptions{})
+				return isRunning, err
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.String()).To(Equal("[1/1] VM default/web1 was started\n"))
+		})
+
+		It("should report the VMs not reaching the target state in time", func() {
+			createVM(metav1.NamespaceDefault, "web1", map[string]string{"app": "web"})
+
+			opts := &batch.Options{Selector: "app=web", Concurrency: 1, Wait: true, Timeout: 10 * time.Millisecond}
+			err := opts.Run(client, metav1.NamespaceDefault, command, out, func(*v1.VirtualMachine) (batch.Condition, error) {
+				return isRunning, nil
+			})
+			Expect(err).To(MatchError("failed to start 1 of 1 VMs"))
+			Expect(out.String()).To(ContainSubstring("VM default/web1 failed to start: timed out after 10ms"))
+		})
+	})
+})
@@ -20,8 +20,10 @@
 package console
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"time"
@@ -37,7 +39,22 @@ import (
 	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 )
 
-var timeout int
+const (
+	readOnlyFlag = "read-only"
+	takeOverFlag = "take-over"
+	logsFlag     = "logs"
+	sinceFlag    = "since"
+	tailFlag     = "tail"
+)
+
+var (
+	timeout  int
+	readOnly bool
+	takeOver bool
+	logs     bool
+	since    time.Duration
+	tail     int
+)
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -46,25 +63,42 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		Example: usage(),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c := Console{clientConfig: clientConfig}
+			c := Console{clientConfig: clientConfig, out: cmd.OutOrStdout()}
 			return c.Run(args)
 		},
 	}
 
 	cmd.Flags().IntVar(&timeout, "timeout", 5, "The number of minutes to wait for the virtual machine instance to be ready.")
+	cmd.Flags().BoolVar(&readOnly, readOnlyFlag, false, "Attach to the console without sending input, alongside the user currently connected to it.")
+	cmd.Flags().BoolVar(&takeOver, takeOverFlag, false, "Disconnect the user currently connected to the console and take it over.")
+	cmd.Flags().BoolVar(&logs, logsFlag, false, "Print the persisted serial console log instead of connecting to the console, also after the VM stopped.")
+	cmd.Flags().DurationVar(&since, sinceFlag, 0, "Only print the lines of the persisted serial console log newer than a relative duration like 5s, 2m, or 3h. Requires --logs.")
+	cmd.Flags().IntVar(&tail, tailFlag, 0, "Only print this number of the newest lines of the persisted serial console log. The log of a running VMI is limited to 10000 lines. Requires --logs.")
+	cmd.MarkFlagsMutuallyExclusive(readOnlyFlag, takeOverFlag)
+	cmd.MarkFlagsMutuallyExclusive(logsFlag, readOnlyFlag)
+	cmd.MarkFlagsMutuallyExclusive(logsFlag, takeOverFlag)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 type Console struct {
 	clientConfig clientcmd.ClientConfig
+	out          io.Writer
 }
 
 func usage() string {
 	usage := `  # Connect to the console on VirtualMachineInstance 'myvmi':
   {{ProgramName}} console myvmi
   # Configure one minute timeout (default 5 minutes)
-  {{ProgramName}} console --timeout=1 myvmi`
+  {{ProgramName}} console --timeout=1 myvmi
+  # Watch the console while another user is connected to it
+  {{ProgramName}} console --read-only myvmi
+  # Disconnect the user currently connected to the console and take it over
+  {{ProgramName}} console --take-over myvmi
+  # Print the persisted serial console log of the last hour
+  {{ProgramName}} console --logs --since=1h myvmi
+  # Print the last 100 lines of the persisted serial console log
+  {{ProgramName}} console --logs --tail=100 myvmi`
 
 	return usage
 }
@@ -77,11 +111,25 @@ func (c *Console) Run(args []string) error {
 
 	vmi := args[0]
 
+	if since != 0 && !logs {
+		return fmt.Errorf("--%s requires --%s", sinceFlag, logsFlag)
+	}
+	if tail != 0 && !logs {
+		return fmt.Errorf("--%s requires --%s", tailFlag, logsFlag)
+	}
+	if tail < 0 {
+		return fmt.Errorf("--%s must be greater than 0", tailFlag)
+	}
+
 	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
 	if err != nil {
 		return err
 	}
 
+	if logs {
+		return printLog(virtCli, namespace, vmi, since, tail, c.out)
+	}
+
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
 
@@ -94,7 +142,11 @@ func (c *Console) Run(args []string) error {
 	signal.Notify(waitInterrupt, os.Interrupt)
 
 	go func() {
-		con, err := virtCli.VirtualMachineInstance(namespace).SerialConsole(vmi, &kvcorev1.SerialConsoleOptions{ConnectionTimeout: time.Duration(timeout) * time.Minute})
+		con, err := virtCli.VirtualMachineInstance(namespace).SerialConsole(vmi, &kvcorev1.SerialConsoleOptions{
+			ConnectionTimeout: time.Duration(timeout) * time.Minute,
+			ReadOnly:          readOnly,
+			TakeOver:          takeOver,
+		})
 		runningChan <- err
 
 		if err != nil {
@@ -113,18 +165,24 @@ func (c *Console) Run(args []string) error {
 		fmt.Println()
 		return nil
 	case err = <-runningChan:
+		var asyncErr *kvcorev1.AsyncSubresourceError
+		if errors.As(err, &asyncErr) && asyncErr.GetStatusCode() == http.StatusConflict {
+			return fmt.Errorf("%v\nUse --%s to watch the console or --%s to disconnect the other user", err, readOnlyFlag, takeOverFlag)
+		}
 		if err != nil {
 			return err
 		}
 	}
-	err = utils.AttachConsole(stdinReader, stdoutReader, stdinWriter, stdoutWriter,
-		fmt.Sprint("Successfully connected to ", vmi, " console. The escape sequence is ^]\n"),
-		resChan)
+	message := fmt.Sprint("Successfully connected to ", vmi, " console. The escape sequence is ^]\n")
+	if readOnly {
+		message = fmt.Sprint("Successfully connected to ", vmi, " console in read-only mode. The escape sequence is ^]\n")
+	}
+	err = utils.AttachConsole(stdinReader, stdoutReader, stdinWriter, stdoutWriter, message, resChan)
 
 	if err != nil {
 		if e, ok := err.(*websocket.CloseError); ok && e.Code == websocket.CloseAbnormalClosure {
 			fmt.Fprint(os.Stderr, "\nYou were disconnected from the console. This has one of the following reasons:"+
-				"\n - another user connected to the console of the target vm"+
+				"\n - another user took over the console of the target vm"+
 				"\n - network issues\n")
 		}
 		return err
@@ -0,0 +1,11 @@
+package console_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestConsole(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,205 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/consolelog"
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+)
+
+const (
+	logReaderPodPrefix  = "virtctl-consolelog-"
+	logReaderPodTimeout = 2 * time.Minute
+)
+
+// printLog prints the persisted serial console log of a VM. The log of a running VMI is
+// served by its consolelog subresource, otherwise it is read from the claim holding it.
+// A tail greater than 0 only prints that number of the newest lines.
+func printLog(virtCli kubecli.KubevirtClient, namespace, name string, since time.Duration, tail int, out io.Writer) error {
+	var sinceTime time.Time
+	if since > 0 {
+		sinceTime = time.Now().Add(-since)
+	}
+
+	vmi, err := virtCli.VirtualMachineInstance(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && vmi.Status.Phase == v1.Running {
+		options := &v1.SerialConsoleLogOptions{}
+		if !sinceTime.IsZero() {
+			options.SinceTime = &metav1.Time{Time: sinceTime}
+		}
+		if tail > 0 {
+			options.TailLines = pointer.P(int64(tail))
+		}
+		serialConsoleLog, err := virtCli.VirtualMachineInstance(namespace).SerialConsoleLog(context.Background(), name, options)
+		if err != nil {
+			return err
+		}
+		for _, line := range serialConsoleLog.Lines {
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+
+	vm, err := virtCli.VirtualMachine(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("the VMI %s is not running and its VM cannot be retrieved: %v", name, err)
+	}
+	claimName, subPath, err := persistedLogClaim(virtCli, vm)
+	if err != nil {
+		return err
+	}
+	persisted, err := readPersistedLog(virtCli, namespace, claimName, subPath)
+	if err != nil {
+		return err
+	}
+	return consolelog.Filter(persisted, sinceTime, tail, out)
+}
+
+// persistedLogClaim returns the claim and the directory in it holding the persisted log of a VM
+func persistedLogClaim(virtCli kubecli.KubevirtClient, vm *v1.VirtualMachine) (string, string, error) {
+	if vm.Spec.Template == nil || vm.Spec.Template.Spec.Domain.Devices.SerialConsoleLogPersistence == nil {
+		return "", "", fmt.Errorf("the serial console log of the VM %s is not persisted", vm.Name)
+	}
+	if claimName := vm.Spec.Template.Spec.Domain.Devices.SerialConsoleLogPersistence.ClaimName; claimName != "" {
+		return claimName, "", nil
+	}
+
+	pvcs, err := virtCli.CoreV1().PersistentVolumeClaims(vm.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", backendstorage.PVCPrefix, vm.Name),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if len(pvcs.Items) == 0 {
+		return "", "", fmt.Errorf("the backend storage of the VM %s does not exist", vm.Name)
+	}
+	return pvcs.Items[0].Name, consolelog.BackendStorageSubPath, nil
+}
+
+// readPersistedLog prints the persisted log from a pod mounting the claim, and returns its output
+func readPersistedLog(virtCli kubecli.KubevirtClient, namespace, claimName, subPath string) (io.Reader, error) {
+	image, err := guestfs.SetImage(virtCli)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := virtCli.CoreV1().Pods(namespace).Create(context.Background(), logReaderPod(claimName, subPath, image), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := virtCli.CoreV1().Pods(namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to delete the pod %s: %v\n", pod.Name, err)
+		}
+	}()
+
+	err = wait.PollUntilContextTimeout(context.Background(), time.Second, logReaderPodTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := virtCli.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		switch pod.Status.Phase {
+		case k8sv1.PodSucceeded:
+			return true, nil
+		case k8sv1.PodFailed:
+			return false, fmt.Errorf("the pod %s reading the serial console log failed", pod.Name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the whole output before the pod gets deleted
+	output, err := virtCli.CoreV1().Pods(namespace).GetLogs(pod.Name, &k8sv1.PodLogOptions{}).DoRaw(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(output), nil
+}
+
+func logReaderPod(claimName, subPath, image string) *k8sv1.Pod {
+	// Print the rotated files from the oldest to the newest, followed by the current file
+	script := fmt.Sprintf("for i in $(seq %d -1 1); do cat %s.$i 2>/dev/null; done; cat %s 2>/dev/null; true",
+		consolelog.MaxRotatedFiles, consolelog.FileName, consolelog.FileName)
+	allowPrivilegeEscalation := false
+	runAsUser := int64(util.NonRootUID)
+	return &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: logReaderPodPrefix,
+		},
+		Spec: k8sv1.PodSpec{
+			RestartPolicy: k8sv1.RestartPolicyNever,
+			SecurityContext: &k8sv1.PodSecurityContext{
+				RunAsUser: &runAsUser,
+				SeccompProfile: &k8sv1.SeccompProfile{
+					Type: k8sv1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []k8sv1.Container{{
+				Name:       "consolelog",
+				Image:      image,
+				Command:    []string{"/bin/sh", "-c", script},
+				WorkingDir: consolelog.MountPath,
+				SecurityContext: &k8sv1.SecurityContext{
+					AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+					Capabilities: &k8sv1.Capabilities{
+						Drop: []k8sv1.Capability{"ALL"},
+					},
+				},
+				VolumeMounts: []k8sv1.VolumeMount{{
+					Name:      "consolelog",
+					MountPath: consolelog.MountPath,
+					SubPath:   subPath,
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: []k8sv1.Volume{{
+				Name: "consolelog",
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+						ReadOnly:  true,
+					},
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package console_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Console log", func() {
+	const (
+		commandConsole = "console"
+		vmiName        = "testvmi"
+	)
+
+	var (
+		vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		vmInterface  *kubecli.MockVirtualMachineInterface
+		kubeClient   *k8sfake.Clientset
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubeClient = k8sfake.NewSimpleClientset()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	runningVMI := func() *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: vmiName, Namespace: k8smetav1.NamespaceDefault},
+			Status:     v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+		}
+	}
+
+	DescribeTable("should refuse invalid flag combinations", func(expectedErr string, args ...string) {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{commandConsole, vmiName}, args...)...)
+		Expect(cmd()).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("--logs with --read-only", "[logs read-only] were all set", "--logs", "--read-only"),
+		Entry("--logs with --take-over", "[logs take-over] were all set", "--logs", "--take-over"),
+		Entry("--since without --logs", "--since requires --logs", "--since=1h"),
+		Entry("--tail without --logs", "--tail requires --logs", "--tail=10"),
+		Entry("a negative --tail", "--tail must be greater than 0", "--logs", "--tail=-1"),
+		Entry("an invalid --since", `invalid argument "yesterday" for "--since" flag`, "--logs", "--since=yesterday"),
+	)
+
+	It("should print the log of a running VMI from the consolelog subresource", func() {
+		vmiInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(runningVMI(), nil)
+		vmiInterface.EXPECT().SerialConsoleLog(gomock.Any(), vmiName, &v1.SerialConsoleLogOptions{}).
+			Return(v1.SerialConsoleLog{Lines: []string{"booting", "login:"}}, nil)
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(commandConsole, vmiName, "--logs")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("booting\nlogin:\n"))
+	})
+
+	It("should pass --since and --tail to the consolelog subresource", func() {
+		vmiInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(runningVMI(), nil)
+		vmiInterface.EXPECT().SerialConsoleLog(gomock.Any(), vmiName, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, options *v1.SerialConsoleLogOptions) (v1.SerialConsoleLog, error) {
+				Expect(options.SinceTime).ToNot(BeNil())
+				Expect(options.SinceTime.Time).To(BeTemporally("~", time.Now().Add(-90*time.Minute), time.Minute))
+				Expect(options.TailLines).To(Equal(pointer.P(int64(20))))
+				return v1.SerialConsoleLog{}, nil
+			})
+
+		Expect(clientcmd.NewRepeatableVirtctlCommand(commandConsole, vmiName, "--logs", "--since=1h30m", "--tail=20")()).To(Succeed())
+	})
+
+	It("should return the error of the consolelog subresource", func() {
+		vmiInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(runningVMI(), nil)
+		vmiInterface.EXPECT().SerialConsoleLog(gomock.Any(), vmiName, gomock.Any()).
+			Return(v1.SerialConsoleLog{}, errors.NewBadRequest("the serial console log of VMI default/testvmi is not persisted"))
+
+		err := clientcmd.NewRepeatableVirtctlCommand(commandConsole, vmiName, "--logs")()
+		Expect(err).To(MatchError("the serial console log of VMI default/testvmi is not persisted"))
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+	})
+
+	Context("when the VMI is not running", func() {
+		BeforeEach(func() {
+			vmiInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).
+				Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vmiName))
+		})
+
+		It("should fail when the VM does not exist", func() {
+			vmInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).
+				Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), vmiName))
+
+			err := clientcmd.NewRepeatableVirtctlCommand(commandConsole, vmiName, "--logs")()
+			Expect(err).To(MatchError(ContainSubstring("the VMI testvmi is not running and its VM cannot be retrieved")))
+		})
+
+		It("should fail when the log of the VM is not persisted", func() {
+			vmInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(&v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: vmiName, Namespace: k8smetav1.NamespaceDefault},
+				Spec:       v1.VirtualMachineSpec{Template: &v1.VirtualMachineInstanceTemplateSpec{}},
+			}, nil)
+
+			err := clientcmd.NewRepeatableVirtctlCommand(commandConsole, vmiName, "--logs")()
+			Expect(err).To(MatchError("the serial console log of the VM testvmi is not persisted"))
+		})
+
+		It("should read the log from the claim of the VM", func() {
+			origImageInfoGetFunc := guestfs.ImageInfoGetFunc
+			guestfs.ImageInfoGetFunc = func(kubecli.KubevirtClient) (*kubecli.GuestfsInfo, error) {
+				return &kubecli.GuestfsInfo{GsImage: "libguestfs-tools:test"}, nil
+			}
+			DeferCleanup(func() {
+				guestfs.ImageInfoGetFunc = origImageInfoGetFunc
+			})
+			vm := &v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: vmiName, Namespace: k8smetav1.NamespaceDefault},
+				Spec:       v1.VirtualMachineSpec{Template: &v1.VirtualMachineInstanceTemplateSpec{}},
+			}
+			vm.Spec.Template.Spec.Domain.Devices.SerialConsoleLogPersistence = &v1.SerialConsoleLogPersistence{ClaimName: "consolelog"}
+			vmInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(vm, nil)
+			kubeClient.PrependReactor("get", "pods", func(testing.Action) (bool, runtime.Object, error) {
+				return true, &k8sv1.Pod{Status: k8sv1.PodStatus{Phase: k8sv1.PodSucceeded}}, nil
+			})
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(commandConsole, vmiName, "--logs")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("fake logs\n"))
+
+			By("removing the pod reading the log")
+			pods, err := kubeClient.CoreV1().Pods(k8smetav1.NamespaceDefault).List(context.Background(), k8smetav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+	})
+})
@@ -120,3 +120,30 @@ type dataVolumeSourceVDDK struct {
 	Name         string             `param:"name"`
 	BootOrder    *uint              `param:"bootorder"`
 }
+
+type network struct {
+	Name       string `param:"name"`
+	Source     string `param:"src"`
+	Binding    string `param:"binding"`
+	Model      string `param:"model"`
+	MacAddress string `param:"macaddress"`
+}
+
+type hostDevice struct {
+	Name       string `param:"name"`
+	DeviceName string `param:"devicename"`
+	Tag        string `param:"tag"`
+}
+
+type nodeSelector struct {
+	Key   string `param:"key"`
+	Value string `param:"value"`
+}
+
+type toleration struct {
+	Key               string `param:"key"`
+	Operator          string `param:"operator"`
+	Value             string `param:"value"`
+	Effect            string `param:"effect"`
+	TolerationSeconds *uint  `param:"seconds"`
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
@@ -72,6 +73,12 @@ const (
 	CloudInitUserDataFlag    = "cloud-init-user-data"
 	CloudInitNetworkDataFlag = "cloud-init-network-data"
 
+	NetworkFlag      = "network"
+	GPUFlag          = "gpu"
+	HostDeviceFlag   = "host-device"
+	NodeSelectorFlag = "node-selector"
+	TolerationFlag   = "toleration"
+
 	// Deprecated flags
 	DataSourceVolumeFlag = "volume-datasource"
 	ClonePvcVolumeFlag   = "volume-clone-pvc"
@@ -91,6 +98,11 @@ const (
 	accessCredTypePassword = "password"
 	accessCredMethodGA     = "ga"
 
+	podNetwork        = "default"
+	bindingBridge     = "bridge"
+	bindingMasquerade = "masquerade"
+	bindingSRIOV      = "sriov"
+
 	blank    = "blank"
 	gcs      = "gcs"
 	http     = "http"
@@ -105,6 +117,7 @@ const (
 	volumeExistsErrorFmt                 = "there is already a volume with name \"%s\""
 	accessCredUserInvalidError           = "user cannot be specified with selected access credential type and method"
 	accessCredMethodFlagMismatchErrorFmt = "method param and value passed to --%s have to match: %s vs %s"
+	networkExistsErrorFmt                = "there is already a network with name \"%s\""
 )
 
 type createVM struct {
@@ -136,6 +149,12 @@ type createVM struct {
 	cloudInitUserData    string
 	cloudInitNetworkData string
 
+	networks      []string
+	gpus          []string
+	hostDevices   []string
+	nodeSelectors []string
+	tolerations   []string
+
 	// Deprecated fields
 	dataSourceVolumes []string
 	clonePvcVolumes   []string
@@ -169,6 +188,11 @@ var flags = []string{
 	VolumeImportFlag,
 	SysprepVolumeFlag,
 	AccessCredFlag,
+	NetworkFlag,
+	GPUFlag,
+	HostDeviceFlag,
+	NodeSelectorFlag,
+	TolerationFlag,
 }
 
 var volumeImportOptions = map[string]func(string) (*cdiv1.DataVolumeSpec, *uint, error){
@@ -247,6 +271,12 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, SSHKeyFlag)
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, GAManageSSHFlag)
 
+	cmd.Flags().StringArrayVar(&c.networks, NetworkFlag, c.networks, fmt.Sprintf("Specify a network and the interface connecting the VM to it. Can be provided multiple times.\nWithout src the pod network is used, otherwise src references a NetworkAttachmentDefinition.\nOnce provided, only the specified networks are attached to the VM.\nSupported binding values: %s, %s, %s or the name of a network binding plugin.\nSupported parameters: %s", bindingMasquerade, bindingBridge, bindingSRIOV, params.Supported(network{})))
+	cmd.Flags().StringArrayVar(&c.gpus, GPUFlag, c.gpus, fmt.Sprintf("Specify a GPU to be passed through to the VM. Can be provided multiple times.\nSupported parameters: %s", params.Supported(hostDevice{})))
+	cmd.Flags().StringArrayVar(&c.hostDevices, HostDeviceFlag, c.hostDevices, fmt.Sprintf("Specify a host device to be passed through to the VM. Can be provided multiple times.\nSupported parameters: %s", params.Supported(hostDevice{})))
+	cmd.Flags().StringArrayVar(&c.nodeSelectors, NodeSelectorFlag, c.nodeSelectors, fmt.Sprintf("Specify a label the node running the VM must have. Can be provided multiple times.\nSupported parameters: %s", params.Supported(nodeSelector{})))
+	cmd.Flags().StringArrayVar(&c.tolerations, TolerationFlag, c.tolerations, fmt.Sprintf("Specify a toleration of the VM for node taints. Can be provided multiple times.\nSupported parameters: %s", params.Supported(toleration{})))
+
 	// Deprecated flags
 	cmd.Flags().StringArrayVar(&c.dataSourceVolumes, DataSourceVolumeFlag, c.dataSourceVolumes, "Specify a DataSource to be cloned by the VM. Can be provided multiple times.\nSupported parameters: name:string,src:string,bootorder:uint,size:resource.Quantity\nDEPRECATED: Use --volume-import with type:ds and same params instead.")
 	cmd.Flags().StringArrayVar(&c.clonePvcVolumes, ClonePvcVolumeFlag, c.clonePvcVolumes, "Specify a PVC to be cloned by the VM. Can be provided multiple times.\nSupported parameters: name:string,src:string,bootorder:uint,size:resource.Quantity\nDEPRECATED: Use --volume-import with type:pvc and same params instead.")
@@ -405,6 +435,11 @@ func (c *createVM) optFns() map[string]func(*v1.VirtualMachine) error {
 		VolumeImportFlag:        c.withImportedVolume,
 		SysprepVolumeFlag:       c.withSysprepVolume,
 		AccessCredFlag:          c.withAccessCredential,
+		NetworkFlag:             c.withNetwork,
+		GPUFlag:                 c.withGPU,
+		HostDeviceFlag:          c.withHostDevice,
+		NodeSelectorFlag:        c.withNodeSelector,
+		TolerationFlag:          c.withToleration,
 	}
 }
 
@@ -479,7 +514,19 @@ func (c *createVM) usage() string {
   {{ProgramName}} create vm --access-cred=type:password,src:my-pws
 
   # Create a manifest for a VirtualMachine with a Containerdisk and a Sysprep volume (source ConfigMap needs to exist)
-  {{ProgramName}} create vm --memory=1Gi --volume-containerdisk=src:my.registry/my-image:my-tag --sysprep=src:my-cm`
+  {{ProgramName}} create vm --memory=1Gi --volume-containerdisk=src:my.registry/my-image:my-tag --sysprep=src:my-cm
+
+  # Create a manifest for a VirtualMachine connected to the pod network and to a secondary bridged network (NetworkAttachmentDefinition needs to exist)
+  {{ProgramName}} create vm --network=binding:masquerade --network=name:secondary,src:my-ns/my-nad,binding:bridge
+
+  # Create a manifest for a VirtualMachine with a SR-IOV interface only, without the pod network
+  {{ProgramName}} create vm --network=name:sriov,src:my-sriov-nad,binding:sriov
+
+  # Create a manifest for a VirtualMachine with a passed through GPU and host device
+  {{ProgramName}} create vm --gpu=devicename:nvidia.com/GA102GL_A10 --host-device=name:nic,devicename:intel.com/qat
+
+  # Create a manifest for a VirtualMachine scheduled on dedicated GPU nodes
+  {{ProgramName}} create vm --node-selector=key:node-role.kubernetes.io/gpu --toleration=key:nvidia.com/gpu,operator:Exists,effect:NoSchedule`
 }
 
 func (c *createVM) newVM() (*v1.VirtualMachine, error) {
@@ -1474,6 +1521,235 @@ func (c *createVM) withAccessCredentialPassword(src *accessCredential) (*v1.Acce
 	}, nil
 }
 
+func (c *createVM) withNetwork(vm *v1.VirtualMachine) error {
+	hasPodNetwork := false
+	for i, networkParams := range c.networks {
+		src := network{}
+		if err := params.Map(NetworkFlag, networkParams, &src); err != nil {
+			return err
+		}
+
+		networkSource := v1.NetworkSource{}
+		if src.Source == "" {
+			if hasPodNetwork {
+				return params.FlagErr(NetworkFlag, "the pod network can only be specified once")
+			}
+			hasPodNetwork = true
+			networkSource.Pod = &v1.PodNetwork{}
+			if src.Name == "" {
+				src.Name = podNetwork
+			}
+		} else {
+			if _, _, err := params.SplitPrefixedName(src.Source); err != nil {
+				return params.FlagErr(NetworkFlag, "src invalid: %w", err)
+			}
+			networkSource.Multus = &v1.MultusNetwork{
+				NetworkName: src.Source,
+			}
+			if src.Name == "" {
+				src.Name = fmt.Sprintf("%s-network-%d", vm.Name, i)
+			}
+		}
+
+		for _, net := range vm.Spec.Template.Spec.Networks {
+			if net.Name == src.Name {
+				return params.FlagErr(NetworkFlag, networkExistsErrorFmt, src.Name)
+			}
+		}
+
+		iface, err := networkInterface(&src, networkSource.Pod != nil)
+		if err != nil {
+			return err
+		}
+
+		vm.Spec.Template.Spec.Networks = append(vm.Spec.Template.Spec.Networks, v1.Network{
+			Name:          src.Name,
+			NetworkSource: networkSource,
+		})
+		vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces, *iface)
+	}
+
+	// The specified networks are all networks of the VM, do not let the pod network be added implicitly
+	if !hasPodNetwork {
+		vm.Spec.Template.Spec.Domain.Devices.AutoattachPodInterface = pointer.P(false)
+	}
+
+	return nil
+}
+
+func networkInterface(src *network, isPodNetwork bool) (*v1.Interface, error) {
+	iface := &v1.Interface{
+		Name:       src.Name,
+		Model:      src.Model,
+		MacAddress: src.MacAddress,
+	}
+
+	binding := strings.ToLower(src.Binding)
+	if binding == "" {
+		binding = bindingBridge
+		if isPodNetwork {
+			binding = bindingMasquerade
+		}
+	}
+
+	switch binding {
+	case bindingMasquerade:
+		if !isPodNetwork {
+			return nil, params.FlagErr(NetworkFlag, "binding %s can only be used with the pod network", bindingMasquerade)
+		}
+		iface.Masquerade = &v1.InterfaceMasquerade{}
+	case bindingBridge:
+		iface.Bridge = &v1.InterfaceBridge{}
+	case bindingSRIOV:
+		if isPodNetwork {
+			return nil, params.FlagErr(NetworkFlag, "binding %s can not be used with the pod network", bindingSRIOV)
+		}
+		if src.Model != "" {
+			return nil, params.FlagErr(NetworkFlag, "model can not be specified with binding %s", bindingSRIOV)
+		}
+		iface.SRIOV = &v1.InterfaceSRIOV{}
+	default:
+		iface.Binding = &v1.PluginBinding{Name: src.Binding}
+	}
+
+	return iface, nil
+}
+
+func (c *createVM) withGPU(vm *v1.VirtualMachine) error {
+	for i, gpuParams := range c.gpus {
+		src := hostDevice{}
+		if err := params.Map(GPUFlag, gpuParams, &src); err != nil {
+			return err
+		}
+
+		if src.DeviceName == "" {
+			return params.FlagErr(GPUFlag, "devicename must be specified")
+		}
+
+		if src.Name == "" {
+			src.Name = fmt.Sprintf("gpu-%d", i)
+		}
+
+		for _, gpu := range vm.Spec.Template.Spec.Domain.Devices.GPUs {
+			if gpu.Name == src.Name {
+				return params.FlagErr(GPUFlag, "there is already a gpu with name \"%s\"", src.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.GPUs = append(vm.Spec.Template.Spec.Domain.Devices.GPUs, v1.GPU{
+			Name:       src.Name,
+			DeviceName: src.DeviceName,
+			Tag:        src.Tag,
+		})
+	}
+
+	return nil
+}
+
+func (c *createVM) withHostDevice(vm *v1.VirtualMachine) error {
+	for i, hostDeviceParams := range c.hostDevices {
+		src := hostDevice{}
+		if err := params.Map(HostDeviceFlag, hostDeviceParams, &src); err != nil {
+			return err
+		}
+
+		if src.DeviceName == "" {
+			return params.FlagErr(HostDeviceFlag, "devicename must be specified")
+		}
+
+		if src.Name == "" {
+			src.Name = fmt.Sprintf("hostdevice-%d", i)
+		}
+
+		for _, hostDevice := range vm.Spec.Template.Spec.Domain.Devices.HostDevices {
+			if hostDevice.Name == src.Name {
+				return params.FlagErr(HostDeviceFlag, "there is already a host device with name \"%s\"", src.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.HostDevices = append(vm.Spec.Template.Spec.Domain.Devices.HostDevices, v1.HostDevice{
+			Name:       src.Name,
+			DeviceName: src.DeviceName,
+			Tag:        src.Tag,
+		})
+	}
+
+	return nil
+}
+
+func (c *createVM) withNodeSelector(vm *v1.VirtualMachine) error {
+	for _, nodeSelectorParams := range c.nodeSelectors {
+		src := nodeSelector{}
+		if err := params.Map(NodeSelectorFlag, nodeSelectorParams, &src); err != nil {
+			return err
+		}
+
+		if src.Key == "" {
+			return params.FlagErr(NodeSelectorFlag, "key must be specified")
+		}
+
+		if vm.Spec.Template.Spec.NodeSelector == nil {
+			vm.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		if _, exists := vm.Spec.Template.Spec.NodeSelector[src.Key]; exists {
+			return params.FlagErr(NodeSelectorFlag, "key \"%s\" was specified multiple times", src.Key)
+		}
+		vm.Spec.Template.Spec.NodeSelector[src.Key] = src.Value
+	}
+
+	return nil
+}
+
+func (c *createVM) withToleration(vm *v1.VirtualMachine) error {
+	operators := []string{string(k8sv1.TolerationOpEqual), string(k8sv1.TolerationOpExists)}
+	effects := []string{string(k8sv1.TaintEffectNoSchedule), string(k8sv1.TaintEffectPreferNoSchedule), string(k8sv1.TaintEffectNoExecute)}
+
+	for _, tolerationParams := range c.tolerations {
+		src := toleration{}
+		if err := params.Map(TolerationFlag, tolerationParams, &src); err != nil {
+			return err
+		}
+
+		toleration := k8sv1.Toleration{
+			Key:   src.Key,
+			Value: src.Value,
+		}
+
+		switch {
+		case src.Operator == "":
+			if src.Key == "" {
+				return params.FlagErr(TolerationFlag, "key must be specified unless operator is %s", k8sv1.TolerationOpExists)
+			}
+		case slices.Contains(operators, src.Operator):
+			toleration.Operator = k8sv1.TolerationOperator(src.Operator)
+		default:
+			return params.FlagErr(TolerationFlag, "invalid operator \"%s\", supported values are: %s", src.Operator, strings.Join(operators, ", "))
+		}
+
+		if toleration.Operator == k8sv1.TolerationOpExists && src.Value != "" {
+			return params.FlagErr(TolerationFlag, "value can not be specified with operator %s", k8sv1.TolerationOpExists)
+		}
+
+		if src.Effect != "" {
+			if !slices.Contains(effects, src.Effect) {
+				return params.FlagErr(TolerationFlag, "invalid effect \"%s\", supported values are: %s", src.Effect, strings.Join(effects, ", "))
+			}
+			toleration.Effect = k8sv1.TaintEffect(src.Effect)
+		}
+
+		if src.TolerationSeconds != nil {
+			if toleration.Effect != k8sv1.TaintEffectNoExecute {
+				return params.FlagErr(TolerationFlag, "seconds can only be specified with effect %s", k8sv1.TaintEffectNoExecute)
+			}
+			toleration.TolerationSeconds = pointer.P(int64(*src.TolerationSeconds))
+		}
+
+		vm.Spec.Template.Spec.Tolerations = append(vm.Spec.Template.Spec.Tolerations, toleration)
+	}
+
+	return nil
+}
+
 // Deprecated optFns
 
 func (c *createVM) withDataSourceVolume(_ *v1.VirtualMachine) error {
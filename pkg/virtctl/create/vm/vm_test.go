@@ -1182,6 +1182,124 @@ chpasswd: { expire: False }`
 			Entry("explicit type and method", "type:password,src:my-pws,method:ga"),
 		)
 
+		DescribeTable("VM with specified network", func(params string, network v1.Network, iface v1.Interface, autoattachPodInterface *bool) {
+			out, err := runCmd(setFlag(NetworkFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(network))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(ConsistOf(iface))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.AutoattachPodInterface).To(Equal(autoattachPodInterface))
+		},
+			Entry("pod network with default binding", "binding:masquerade",
+				v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+				nil,
+			),
+			Entry("pod network with bridge binding, model and mac address", "name:pod,binding:bridge,model:e1000e,macaddress:02:00:00:00:00:01",
+				v1.Network{Name: "pod", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				v1.Interface{Name: "pod", Model: "e1000e", MacAddress: "02:00:00:00:00:01", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				nil,
+			),
+			Entry("multus network with default binding", "name:secondary,src:my-ns/my-nad",
+				v1.Network{Name: "secondary", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-ns/my-nad"}}},
+				v1.Interface{Name: "secondary", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				pointer.P(false),
+			),
+			Entry("multus network with sriov binding", "name:sriov,src:my-nad,binding:sriov",
+				v1.Network{Name: "sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-nad"}}},
+				v1.Interface{Name: "sriov", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}},
+				pointer.P(false),
+			),
+			Entry("multus network with plugin binding", "name:passt,src:my-nad,binding:passt",
+				v1.Network{Name: "passt", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-nad"}}},
+				v1.Interface{Name: "passt", Binding: &v1.PluginBinding{Name: "passt"}},
+				pointer.P(false),
+			),
+		)
+
+		It("VM with pod network and multiple secondary networks", func() {
+			out, err := runCmd(
+				setFlag(NameFlag, "my-vm"),
+				setFlag(NetworkFlag, "binding:masquerade"),
+				setFlag(NetworkFlag, "src:my-nad"),
+				setFlag(NetworkFlag, "src:my-other-nad"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Networks).To(HaveExactElements(
+				HaveField("Name", "default"),
+				HaveField("Name", "my-vm-network-1"),
+				HaveField("Name", "my-vm-network-2"),
+			))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveExactElements(
+				HaveField("Name", "default"),
+				HaveField("Name", "my-vm-network-1"),
+				HaveField("Name", "my-vm-network-2"),
+			))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.AutoattachPodInterface).To(BeNil())
+		})
+
+		DescribeTable("VM with specified GPU", func(params string, gpu v1.GPU) {
+			out, err := runCmd(setFlag(GPUFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.GPUs).To(ConsistOf(gpu))
+		},
+			Entry("with default name", "devicename:nvidia.com/GA102GL_A10", v1.GPU{Name: "gpu-0", DeviceName: "nvidia.com/GA102GL_A10"}),
+			Entry("with name and tag", "name:my-gpu,devicename:nvidia.com/GA102GL_A10,tag:compute", v1.GPU{Name: "my-gpu", DeviceName: "nvidia.com/GA102GL_A10", Tag: "compute"}),
+		)
+
+		DescribeTable("VM with specified host device", func(params string, hostDevice v1.HostDevice) {
+			out, err := runCmd(setFlag(HostDeviceFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.HostDevices).To(ConsistOf(hostDevice))
+		},
+			Entry("with default name", "devicename:intel.com/qat", v1.HostDevice{Name: "hostdevice-0", DeviceName: "intel.com/qat"}),
+			Entry("with name and tag", "name:my-qat,devicename:intel.com/qat,tag:crypto", v1.HostDevice{Name: "my-qat", DeviceName: "intel.com/qat", Tag: "crypto"}),
+		)
+
+		It("VM with specified node selectors", func() {
+			out, err := runCmd(
+				setFlag(NodeSelectorFlag, "key:kubernetes.io/arch,value:amd64"),
+				setFlag(NodeSelectorFlag, "key:node-role.kubernetes.io/gpu"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{
+				"kubernetes.io/arch":          "amd64",
+				"node-role.kubernetes.io/gpu": "",
+			}))
+		})
+
+		DescribeTable("VM with specified toleration", func(params string, toleration k8sv1.Toleration) {
+			out, err := runCmd(setFlag(TolerationFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Tolerations).To(ConsistOf(toleration))
+		},
+			Entry("with key and value", "key:dedicated,value:vms,effect:NoSchedule",
+				k8sv1.Toleration{Key: "dedicated", Value: "vms", Effect: k8sv1.TaintEffectNoSchedule}),
+			Entry("with operator Exists", "key:nvidia.com/gpu,operator:Exists",
+				k8sv1.Toleration{Key: "nvidia.com/gpu", Operator: k8sv1.TolerationOpExists}),
+			Entry("tolerating all taints", "operator:Exists",
+				k8sv1.Toleration{Operator: k8sv1.TolerationOpExists}),
+			Entry("with seconds", "key:node.kubernetes.io/unreachable,operator:Exists,effect:NoExecute,seconds:300",
+				k8sv1.Toleration{Key: "node.kubernetes.io/unreachable", Operator: k8sv1.TolerationOpExists, Effect: k8sv1.TaintEffectNoExecute, TolerationSeconds: pointer.P(int64(300))}),
+		)
+
 		It("Complex example", func() {
 			const (
 				vmName                       = "my-vm"
@@ -1804,6 +1922,78 @@ chpasswd: { expire: False }`
 			Entry("User with type password and method ga (explicit)", "type:password,src:my-src,method:ga,user:myuser", userNotAllowedError),
 		)
 
+		DescribeTable("Invalid parameters to NetworkFlag", func(errMsg string, networks ...string) {
+			var args []string
+			for _, network := range networks {
+				args = append(args, setFlag(NetworkFlag, network))
+			}
+			out, err := runCmd(args...)
+			Expect(err).To(MatchError("failed to parse \"--network\" flag: " + errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Empty params", paramsEmptyError, ""),
+			Entry("Invalid param", paramsInvalidError, "test=test"),
+			Entry("Unknown param", paramsUnknownError, "test:test"),
+			Entry("Invalid slashes count in src", srcInvalidSlashCountError, "src:my-ns/my-src/madethisup"),
+			Entry("Pod network specified twice", "the pod network can only be specified once", "binding:masquerade", "name:other"),
+			Entry("Duplicate network name", "there is already a network with name \"my-net\"", "name:my-net,src:my-nad", "name:my-net,src:my-other-nad"),
+			Entry("Masquerade binding with secondary network", "binding masquerade can only be used with the pod network", "src:my-nad,binding:masquerade"),
+			Entry("SR-IOV binding with pod network", "binding sriov can not be used with the pod network", "binding:sriov"),
+			Entry("Model with SR-IOV binding", "model can not be specified with binding sriov", "src:my-nad,binding:sriov,model:virtio"),
+		)
+
+		DescribeTable("Invalid parameters to GPUFlag and HostDeviceFlag", func(flag, params, errMsg string) {
+			out, err := runCmd(setFlag(flag, params))
+			Expect(err).To(MatchError(fmt.Sprintf("failed to parse \"--%s\" flag: %s", flag, errMsg)))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Empty params to GPUFlag", GPUFlag, "", paramsEmptyError),
+			Entry("Unknown param to GPUFlag", GPUFlag, "test:test", paramsUnknownError),
+			Entry("Missing devicename to GPUFlag", GPUFlag, "name:my-gpu", "devicename must be specified"),
+			Entry("Empty params to HostDeviceFlag", HostDeviceFlag, "", paramsEmptyError),
+			Entry("Unknown param to HostDeviceFlag", HostDeviceFlag, "test:test", paramsUnknownError),
+			Entry("Missing devicename to HostDeviceFlag", HostDeviceFlag, "name:my-device", "devicename must be specified"),
+		)
+
+		It("Duplicate GPU names are not allowed", func() {
+			out, err := runCmd(
+				setFlag(GPUFlag, "name:my-gpu,devicename:nvidia.com/GA102GL_A10"),
+				setFlag(GPUFlag, "name:my-gpu,devicename:nvidia.com/GA102GL_A10"),
+			)
+			Expect(err).To(MatchError("failed to parse \"--gpu\" flag: there is already a gpu with name \"my-gpu\""))
+			Expect(out).To(BeEmpty())
+		})
+
+		DescribeTable("Invalid parameters to NodeSelectorFlag", func(errMsg string, nodeSelectors ...string) {
+			var args []string
+			for _, nodeSelector := range nodeSelectors {
+				args = append(args, setFlag(NodeSelectorFlag, nodeSelector))
+			}
+			out, err := runCmd(args...)
+			Expect(err).To(MatchError("failed to parse \"--node-selector\" flag: " + errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Empty params", paramsEmptyError, ""),
+			Entry("Unknown param", paramsUnknownError, "test:test"),
+			Entry("Missing key", "key must be specified", "value:amd64"),
+			Entry("Duplicate key", "key \"kubernetes.io/arch\" was specified multiple times", "key:kubernetes.io/arch,value:amd64", "key:kubernetes.io/arch,value:arm64"),
+		)
+
+		DescribeTable("Invalid parameters to TolerationFlag", func(params, errMsg string) {
+			out, err := runCmd(setFlag(TolerationFlag, params))
+			Expect(err).To(MatchError("failed to parse \"--toleration\" flag: " + errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Empty params", "", paramsEmptyError),
+			Entry("Unknown param", "test:test", paramsUnknownError),
+			Entry("Missing key without operator", "value:vms", "key must be specified unless operator is Exists"),
+			Entry("Invalid operator", "key:dedicated,operator:In", "invalid operator \"In\", supported values are: Equal, Exists"),
+			Entry("Value with operator Exists", "key:dedicated,operator:Exists,value:vms", "value can not be specified with operator Exists"),
+			Entry("Invalid effect", "key:dedicated,effect:Evict", "invalid effect \"Evict\", supported values are: NoSchedule, PreferNoSchedule, NoExecute"),
+			Entry("Seconds without effect NoExecute", "key:dedicated,effect:NoSchedule,seconds:60", "seconds can only be specified with effect NoExecute"),
+			Entry("Invalid seconds", "key:dedicated,effect:NoExecute,seconds:-1", "failed to parse param \"seconds\": strconv.ParseUint: parsing \"-1\": invalid syntax"),
+		)
+
 		DescribeTable("Cloud-init source type mismatch with AccessCredFlag", func(params, cloudInit, errMsg string) {
 			out, err := runCmd(
 				setFlag(AccessCredFlag, params),
@@ -3,6 +3,7 @@ package expose
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -22,25 +23,33 @@ const (
 )
 
 type command struct {
-	serviceName       string
-	clusterIP         string
-	externalIP        string
-	loadBalancerIP    string
-	port              int32
-	strProtocol       string
-	strTargetPort     string
-	strServiceType    string
-	portName          string
-	strIPFamily       string
-	strIPFamilyPolicy string
+	serviceName              string
+	clusterIP                string
+	externalIP               string
+	loadBalancerIP           string
+	portSpecs                []string
+	strProtocol              string
+	strTargetPort            string
+	strServiceType           string
+	portName                 string
+	strIPFamily              string
+	strIPFamilyPolicy        string
+	strSessionAffinity       string
+	sessionAffinityTimeout   int32
+	strExternalTrafficPolicy string
+	healthCheckNodePort      int32
+	requireGuestAgent        bool
 
 	clientConfig clientcmd.ClientConfig
 
-	targetPort     intstr.IntOrString
-	protocol       k8sv1.Protocol
-	serviceType    k8sv1.ServiceType
-	ipFamilies     []k8sv1.IPFamily
-	ipFamilyPolicy k8sv1.IPFamilyPolicy
+	ports                 []k8sv1.ServicePort
+	targetPort            intstr.IntOrString
+	protocol              k8sv1.Protocol
+	serviceType           k8sv1.ServiceType
+	ipFamilies            []k8sv1.IPFamily
+	ipFamilyPolicy        k8sv1.IPFamilyPolicy
+	sessionAffinity       k8sv1.ServiceAffinity
+	externalTrafficPolicy k8sv1.ServiceExternalTrafficPolicy
 
 	namespace string
 	client    kubecli.KubevirtClient
@@ -70,13 +79,18 @@ virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplica
 	}
 	cmd.Flags().StringVar(&c.clusterIP, "cluster-ip", "", "ClusterIP to be assigned to the service. Leave empty to auto-allocate, or set to 'None' to create a headless service.")
 	cmd.Flags().StringVar(&c.externalIP, "external-ip", "", "Additional external IP address (not managed by the cluster) to accept for the service. If this IP is routed to a node, the service can be accessed by this IP in addition to its generated service IP. Optional.")
-	cmd.Flags().Int32Var(&c.port, "port", 0, "The port that the service should serve on.")
-	cmd.Flags().StringVar(&c.strProtocol, "protocol", "TCP", "The network protocol for the service to be created.")
-	cmd.Flags().StringVar(&c.strTargetPort, "target-port", "", "Name or number for the port on the VM that the service should direct traffic to. Optional.")
+	cmd.Flags().StringArrayVar(&c.portSpecs, "port", nil, "The port that the service should serve on, in the form [name:]port[:targetPort][/protocol]. May be specified multiple times to expose several ports.")
+	cmd.Flags().StringVar(&c.strProtocol, "protocol", "TCP", "The network protocol for ports which do not specify one: TCP or UDP.")
+	cmd.Flags().StringVar(&c.strTargetPort, "target-port", "", "Name or number for the port on the VM that the service should direct traffic to, for ports which do not specify one. Optional.")
 	cmd.Flags().StringVar(&c.strServiceType, "type", "ClusterIP", "Type for this service: ClusterIP, NodePort, or LoadBalancer.")
-	cmd.Flags().StringVar(&c.portName, "port-name", "", "Name of the port. Optional.")
+	cmd.Flags().StringVar(&c.portName, "port-name", "", "Name of the port when a single --port is specified. Optional.")
 	cmd.Flags().StringVar(&c.strIPFamily, "ip-family", "", "IP family over which the service will be exposed. Valid values are 'IPv4', 'IPv6', 'IPv4,IPv6' or 'IPv6,IPv4'")
 	cmd.Flags().StringVar(&c.strIPFamilyPolicy, "ip-family-policy", "", "IP family policy defines whether the service can use IPv4, IPv6, or both. Valid values are 'SingleStack', 'PreferDualStack' or 'RequireDualStack'")
+	cmd.Flags().StringVar(&c.strSessionAffinity, "session-affinity", "", "Session affinity of the service. Valid values are 'None' or 'ClientIP'. Optional.")
+	cmd.Flags().Int32Var(&c.sessionAffinityTimeout, "session-affinity-timeout", 0, "Seconds to keep the ClientIP session affinity. Requires --session-affinity=ClientIP. Optional.")
+	cmd.Flags().StringVar(&c.strExternalTrafficPolicy, "external-traffic-policy", "", "How external traffic is routed to the VMs. Valid values are 'Cluster' or 'Local'. Only for NodePort and LoadBalancer services.")
+	cmd.Flags().Int32Var(&c.healthCheckNodePort, "health-check-node-port", 0, "Node port used by load balancers to health check the nodes. Only for LoadBalancer services with --external-traffic-policy=Local. Optional.")
+	cmd.Flags().BoolVar(&c.requireGuestAgent, "require-guest-agent", false, "Only direct traffic to VMs whose guest agent is connected, requires the GuestAgentConnectedLabel feature gate.")
 
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
@@ -91,7 +105,13 @@ func usage() string {
   {{ProgramName}} expose vmirs myvmirs --name=vmirs-service
 
   # Expose port 8080 as port 80 from a virtual machine instance replicaset on a service:
-  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service`
+  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service
+
+  # Expose DNS over TCP and UDP of a virtual machine on a LoadBalancer service, keeping the client source IP:
+  {{ProgramName}} expose vm myvm --name=myvm-dns --type=LoadBalancer --port=dns-tcp:53/TCP --port=dns-udp:53/UDP --external-traffic-policy=Local
+
+  # Expose a web server of a virtual machine only while its guest agent is connected, with sticky sessions:
+  {{ProgramName}} expose vm myvm --name=myvm-web --port=http:80:8080 --port=https:443:8443 --require-guest-agent --session-affinity=ClientIP`
 }
 
 func (c *command) run(cmd *cobra.Command, args []string) error {
@@ -126,12 +146,17 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 }
 
 func (c *command) parseFlags() error {
-	c.targetPort = intstr.Parse(c.strTargetPort)
+	if c.strTargetPort != "" {
+		c.targetPort = intstr.Parse(c.strTargetPort)
+	}
 
 	var err error
 	if c.protocol, err = convertProtocol(c.strProtocol); err != nil {
 		return err
 	}
+	if c.ports, err = c.convertPorts(); err != nil {
+		return err
+	}
 	if c.serviceType, err = convertServiceType(c.strServiceType); err != nil {
 		return err
 	}
@@ -141,10 +166,95 @@ func (c *command) parseFlags() error {
 	if c.ipFamilyPolicy, err = convertIPFamilyPolicy(c.strIPFamilyPolicy, c.ipFamilies); err != nil {
 		return err
 	}
+	if c.sessionAffinity, err = convertSessionAffinity(c.strSessionAffinity); err != nil {
+		return err
+	}
+	if c.sessionAffinityTimeout != 0 && c.sessionAffinity != k8sv1.ServiceAffinityClientIP {
+		return fmt.Errorf("--session-affinity-timeout requires --session-affinity=ClientIP")
+	}
+	if c.externalTrafficPolicy, err = convertExternalTrafficPolicy(c.strExternalTrafficPolicy); err != nil {
+		return err
+	}
+	if c.externalTrafficPolicy != "" && c.serviceType == k8sv1.ServiceTypeClusterIP {
+		return fmt.Errorf("--external-traffic-policy is only supported with NodePort and LoadBalancer services")
+	}
+	if c.healthCheckNodePort != 0 &&
+		(c.serviceType != k8sv1.ServiceTypeLoadBalancer || c.externalTrafficPolicy != k8sv1.ServiceExternalTrafficPolicyLocal) {
+		return fmt.Errorf("--health-check-node-port requires --type=LoadBalancer and --external-traffic-policy=Local")
+	}
 
 	return nil
 }
 
+// convertPorts converts the --port specs into service ports.
+// The --protocol, --target-port and --port-name flags are used for the parts a spec does not set.
+func (c *command) convertPorts() ([]k8sv1.ServicePort, error) {
+	if c.portName != "" && len(c.portSpecs) > 1 {
+		return nil, fmt.Errorf("--port-name cannot be used with multiple --port flags, name the ports in the --port specs instead")
+	}
+
+	ports := []k8sv1.ServicePort{}
+	names := map[string]struct{}{}
+	for i, spec := range c.portSpecs {
+		port, err := parsePortSpec(spec, c.protocol, c.targetPort)
+		if err != nil {
+			return nil, err
+		}
+		if port.Name == "" {
+			port.Name = c.portName
+		}
+		if port.Name == "" && len(c.portSpecs) > 1 {
+			port.Name = fmt.Sprintf("port-%d", i+1)
+		}
+		if _, exists := names[port.Name]; exists {
+			return nil, fmt.Errorf("duplicate port name: %s", port.Name)
+		}
+		names[port.Name] = struct{}{}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// parsePortSpec parses a port spec in the form [name:]port[:targetPort][/protocol].
+func parsePortSpec(spec string, defaultProtocol k8sv1.Protocol, defaultTargetPort intstr.IntOrString) (k8sv1.ServicePort, error) {
+	servicePort := k8sv1.ServicePort{Protocol: defaultProtocol, TargetPort: defaultTargetPort}
+
+	portSpec, strProtocol, hasProtocol := strings.Cut(spec, "/")
+	if hasProtocol {
+		protocol, err := convertProtocol(strProtocol)
+		if err != nil {
+			return servicePort, err
+		}
+		servicePort.Protocol = protocol
+	}
+
+	parts := strings.Split(portSpec, ":")
+	if len(parts) > 1 {
+		if _, err := strconv.Atoi(parts[0]); err != nil {
+			servicePort.Name = parts[0]
+			parts = parts[1:]
+		}
+	}
+	if len(parts) == 0 || len(parts) > 2 {
+		return servicePort, fmt.Errorf("invalid port: %s", spec)
+	}
+
+	port, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return servicePort, fmt.Errorf("invalid port: %s", spec)
+	}
+	servicePort.Port = int32(port)
+
+	if len(parts) == 2 {
+		if parts[1] == "" {
+			return servicePort, fmt.Errorf("invalid port: %s", spec)
+		}
+		servicePort.TargetPort = intstr.Parse(parts[1])
+	}
+
+	return servicePort, nil
+}
+
 func (c *command) getServiceSelectorAndPorts(vmType, vmName string) (map[string]string, []k8sv1.ServicePort, error) {
 	var serviceSelector map[string]string
 	var ports []k8sv1.ServicePort
@@ -158,6 +268,8 @@ func (c *command) getServiceSelectorAndPorts(vmType, vmName string) (map[string]
 		ports = podNetworkPorts(&vmi.Spec)
 		serviceSelector = vmi.ObjectMeta.Labels
 		delete(serviceSelector, v1.NodeNameLabel)
+		delete(serviceSelector, v1.GuestAgentConnectedLabel)
+		delete(serviceSelector, v1.OutdatedLauncherImageLabel)
 		delete(serviceSelector, v1.VirtualMachinePoolRevisionName)
 		delete(serviceSelector, v1.MigrationTargetNodeNameLabel)
 	case "vm", "vms", "virtualmachine", "virtualmachines":
@@ -188,13 +300,16 @@ func (c *command) getServiceSelectorAndPorts(vmType, vmName string) (map[string]
 		return nil, nil, fmt.Errorf("unsupported resource type: %s", vmType)
 	}
 
-	if c.port != 0 {
-		ports = []k8sv1.ServicePort{{Name: c.portName, Protocol: c.protocol, Port: c.port, TargetPort: c.targetPort}}
+	if len(c.ports) > 0 {
+		ports = c.ports
 	}
 
 	if len(serviceSelector) == 0 {
 		return nil, nil, fmt.Errorf("cannot expose %s without any label: %s", vmType, vmName)
 	}
+	if c.requireGuestAgent {
+		serviceSelector[v1.GuestAgentConnectedLabel] = "true"
+	}
 	if len(ports) == 0 {
 		return nil, nil, fmt.Errorf("couldn't find port via --port flag or introspection")
 	}
@@ -222,6 +337,20 @@ func (c *command) createService(serviceSelector map[string]string, ports []k8sv1
 	if c.ipFamilyPolicy != "" {
 		service.Spec.IPFamilyPolicy = &c.ipFamilyPolicy
 	}
+	if c.sessionAffinity != "" {
+		service.Spec.SessionAffinity = c.sessionAffinity
+	}
+	if c.sessionAffinityTimeout != 0 {
+		service.Spec.SessionAffinityConfig = &k8sv1.SessionAffinityConfig{
+			ClientIP: &k8sv1.ClientIPConfig{TimeoutSeconds: &c.sessionAffinityTimeout},
+		}
+	}
+	if c.externalTrafficPolicy != "" {
+		service.Spec.ExternalTrafficPolicy = c.externalTrafficPolicy
+	}
+	if c.healthCheckNodePort != 0 {
+		service.Spec.HealthCheckNodePort = c.healthCheckNodePort
+	}
 	if _, err := c.client.CoreV1().Services(c.namespace).Create(context.Background(), service, metav1.CreateOptions{}); err != nil {
 		return fmt.Errorf("service creation failed: %v", err)
 	}
@@ -290,6 +419,32 @@ func convertIPFamilyPolicy(strIPFamilyPolicy string, ipFamilies []k8sv1.IPFamily
 	}
 }
 
+func convertSessionAffinity(strSessionAffinity string) (k8sv1.ServiceAffinity, error) {
+	switch strings.ToLower(strSessionAffinity) {
+	case "":
+		return "", nil
+	case strings.ToLower(string(k8sv1.ServiceAffinityNone)):
+		return k8sv1.ServiceAffinityNone, nil
+	case strings.ToLower(string(k8sv1.ServiceAffinityClientIP)):
+		return k8sv1.ServiceAffinityClientIP, nil
+	default:
+		return "", fmt.Errorf("unknown session affinity: %s", strSessionAffinity)
+	}
+}
+
+func convertExternalTrafficPolicy(strExternalTrafficPolicy string) (k8sv1.ServiceExternalTrafficPolicy, error) {
+	switch strings.ToLower(strExternalTrafficPolicy) {
+	case "":
+		return "", nil
+	case strings.ToLower(string(k8sv1.ServiceExternalTrafficPolicyCluster)):
+		return k8sv1.ServiceExternalTrafficPolicyCluster, nil
+	case strings.ToLower(string(k8sv1.ServiceExternalTrafficPolicyLocal)):
+		return k8sv1.ServiceExternalTrafficPolicyLocal, nil
+	default:
+		return "", fmt.Errorf("unknown external traffic policy: %s", strExternalTrafficPolicy)
+	}
+}
+
 func podNetworkPorts(vmiSpec *v1.VirtualMachineInstanceSpec) []k8sv1.ServicePort {
 	podNetworkName := ""
 	for _, network := range vmiSpec.Networks {
@@ -78,8 +78,28 @@ var _ = Describe("Expose", func() {
 			Entry("service type externalname", "--type=externalname", "type: externalname not supported"),
 			Entry("invalid ip family", "--ip-family=madeup", "unknown IPFamily/s: madeup"),
 			Entry("invalid ip family policy", "--ip-family-policy=madeup", "unknown IPFamilyPolicy/s: madeup"),
+			Entry("invalid port", "--port=madeup", "invalid port: madeup"),
+			Entry("port out of range", "--port=70000", "invalid port: 70000"),
+			Entry("port with too many parts", "--port=http:80:8080:90", "invalid port: http:80:8080:90"),
+			Entry("port with empty target port", "--port=80:", "invalid port: 80:"),
+			Entry("port with invalid protocol", "--port=80/madeup", "unknown protocol: madeup"),
+			Entry("invalid session affinity", "--session-affinity=madeup", "unknown session affinity: madeup"),
+			Entry("session affinity timeout without ClientIP", "--session-affinity-timeout=60", "--session-affinity-timeout requires --session-affinity=ClientIP"),
+			Entry("invalid external traffic policy", "--external-traffic-policy=madeup", "unknown external traffic policy: madeup"),
+			Entry("external traffic policy with ClusterIP", "--external-traffic-policy=Local", "--external-traffic-policy is only supported with NodePort and LoadBalancer services"),
+			Entry("health check node port without LoadBalancer", "--health-check-node-port=30000", "--health-check-node-port requires --type=LoadBalancer and --external-traffic-policy=Local"),
 		)
 
+		It("with --port-name and multiple ports", func() {
+			err := runCommand("vmi", "my-vm", "--name", "my-service", "--port=80", "--port=443", "--port-name=web")
+			Expect(err).To(MatchError("--port-name cannot be used with multiple --port flags, name the ports in the --port specs instead"))
+		})
+
+		It("with duplicate port names", func() {
+			err := runCommand("vmi", "my-vm", "--name", "my-service", "--port=web:80", "--port=web:443")
+			Expect(err).To(MatchError("duplicate port name: web"))
+		})
+
 		It("when client has an error", func() {
 			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetInvalidKubevirtClientFromClientConfig
 			err := runCommand("vmi", "my-vm", "--name", "my-service")
@@ -384,6 +404,88 @@ var _ = Describe("Expose", func() {
 			Entry("with VirtualMachineInstanceReplicaSet", "vmirs"),
 		)
 
+		DescribeTable("creating a service with multiple ports", func(resType string) {
+			err := runCommand(resType, getResName(resType), "--name", serviceName,
+				"--port", "dns-tcp:53/TCP", "--port", "dns-udp:53/udp", "--port", "http:80:8080", "--port", "443:https")
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(HaveLen(1))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(labelKey, labelValue))
+			Expect(service.Spec.Ports).To(ConsistOf(
+				k8sv1.ServicePort{Name: "dns-tcp", Protocol: k8sv1.ProtocolTCP, Port: 53},
+				k8sv1.ServicePort{Name: "dns-udp", Protocol: k8sv1.ProtocolUDP, Port: 53},
+				k8sv1.ServicePort{Name: "http", Protocol: k8sv1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080)},
+				k8sv1.ServicePort{Name: "port-4", Protocol: k8sv1.ProtocolTCP, Port: 443, TargetPort: intstr.FromString("https")},
+			))
+		},
+			Entry("with VirtualMachineInstance", "vmi"),
+			Entry("with VirtualMachine", "vm"),
+			Entry("with VirtualMachineInstanceReplicaSet", "vmirs"),
+		)
+
+		DescribeTable("creating a service with session affinity", func(resType string) {
+			err := runCommand(resType, getResName(resType), "--name", serviceName, "--port", servicePortStr,
+				"--session-affinity", "clientip", "--session-affinity-timeout", "600")
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.SessionAffinity).To(Equal(k8sv1.ServiceAffinityClientIP))
+			Expect(service.Spec.SessionAffinityConfig).ToNot(BeNil())
+			Expect(*service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(Equal(int32(600)))
+		},
+			Entry("with VirtualMachineInstance", "vmi"),
+			Entry("with VirtualMachine", "vm"),
+			Entry("with VirtualMachineInstanceReplicaSet", "vmirs"),
+		)
+
+		DescribeTable("creating a LoadBalancer service with local external traffic policy", func(resType string) {
+			err := runCommand(resType, getResName(resType), "--name", serviceName, "--port", servicePortStr,
+				"--type", "LoadBalancer", "--external-traffic-policy", "local", "--health-check-node-port", "30123")
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Type).To(Equal(k8sv1.ServiceTypeLoadBalancer))
+			Expect(service.Spec.ExternalTrafficPolicy).To(Equal(k8sv1.ServiceExternalTrafficPolicyLocal))
+			Expect(service.Spec.HealthCheckNodePort).To(Equal(int32(30123)))
+		},
+			Entry("with VirtualMachineInstance", "vmi"),
+			Entry("with VirtualMachine", "vm"),
+			Entry("with VirtualMachineInstanceReplicaSet", "vmirs"),
+		)
+
+		DescribeTable("creating a service requiring a connected guest agent", func(resType string) {
+			err := runCommand(resType, getResName(resType), "--name", serviceName, "--port", servicePortStr, "--require-guest-agent")
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(HaveLen(2))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(labelKey, labelValue))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(v1.GuestAgentConnectedLabel, "true"))
+		},
+			Entry("with VirtualMachineInstance", "vmi"),
+			Entry("with VirtualMachine", "vm"),
+			Entry("with VirtualMachineInstanceReplicaSet", "vmirs"),
+		)
+
+		It("creating a service for a VirtualMachineInstance should not select on its dynamic labels", func() {
+			vmi.Labels[v1.GuestAgentConnectedLabel] = "true"
+			vmi.Labels[v1.OutdatedLauncherImageLabel] = ""
+			vmi, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Update(context.Background(), vmi, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runCommand("vmi", vmi.Name, "--name", serviceName, "--port", servicePortStr)
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{labelKey: labelValue}))
+		})
+
 		DescribeTable("creating a service selecting a suitable default IPFamilyPolicy", func(resType, ipFamily string, ipFamilyPolicy *k8sv1.IPFamilyPolicy, expected ...k8sv1.IPFamily) {
 			err := runCommand(resType, getResName(resType), "--name", serviceName, "--port", servicePortStr, "--ip-family", ipFamily)
 			Expect(err).ToNot(HaveOccurred())
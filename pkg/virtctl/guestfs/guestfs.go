@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -45,6 +46,15 @@ const (
 	tmpDirPath        = "/tmp/guestfs"
 	pullPolicyDefault = corev1.PullIfNotPresent
 	timeout           = 500 * time.Second
+
+	diskImage          = "disk.img"
+	scriptVolume       = "guestfs-script"
+	scriptDir          = "/guestfs-script"
+	scriptKey          = "script"
+	scriptPath         = scriptDir + "/" + scriptKey
+	scriptPollInterval = 2 * time.Second
+	toolGuestfish      = "guestfish"
+	toolVirtCustomize  = "virt-customize"
 )
 
 type guestfsCommand struct {
@@ -57,6 +67,8 @@ type guestfsCommand struct {
 	uid          string
 	gid          string
 	pullPolicy   string
+	script       string
+	scriptTool   string
 }
 
 // Following variables allow overriding the default functions (useful for unit testing)
@@ -84,17 +96,25 @@ func NewGuestfsShellCommand(clientConfig clientcmd.ClientConfig) *cobra.Command
 	cmd.PersistentFlags().StringVar(&c.gid, "gid", "", "Set gid for the libguestfs-tool container. This works only combined when the uid is manually set")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.PersistentFlags().StringVar(&c.fsGroup, "fsGroup", "", "Set the fsgroup for the libguestfs-tool container")
+	cmd.PersistentFlags().StringVar(&c.script, "script", "", "Run the local script against the disk of the pvc instead of attaching a shell, and exit with its exit code")
+	cmd.PersistentFlags().StringVar(&c.scriptTool, "script-tool", toolGuestfish, fmt.Sprintf("The tool running the script, either %s or %s", toolGuestfish, toolVirtCustomize))
 
 	return cmd
 }
 
 func usage() string {
 	usage := `  # Create a pod with libguestfs-tools, mount the pvc and attach a shell to it:
-  {{ProgramName}} guestfs <pvc-name>`
+  {{ProgramName}} guestfs <pvc-name>
+
+  # Run a guestfish script against the disk of the pvc and exit with its exit code:
+  {{ProgramName}} guestfs <pvc-name> --script=customize.gf
+
+  # Run virt-customize commands against the disk of the pvc:
+  {{ProgramName}} guestfs <pvc-name> --script=customize.txt --script-tool=virt-customize`
 	return usage
 }
 
-func (c *guestfsCommand) run(_ *cobra.Command, args []string) error {
+func (c *guestfsCommand) run(cmd *cobra.Command, args []string) error {
 	c.pvc = args[0]
 	namespace, _, err := c.clientConfig.Namespace()
 	if err != nil {
@@ -106,6 +126,17 @@ func (c *guestfsCommand) run(_ *cobra.Command, args []string) error {
 		c.pullPolicy != string(corev1.PullIfNotPresent) {
 		return fmt.Errorf("Invalid pull policy: %s", c.pullPolicy)
 	}
+	if c.scriptTool != toolGuestfish && c.scriptTool != toolVirtCustomize {
+		return fmt.Errorf("Invalid script tool: %s, supported are %s and %s", c.scriptTool, toolGuestfish, toolVirtCustomize)
+	}
+	var script []byte
+	if c.script != "" {
+		// #nosec G304 No risk for path injection as the script is read with the privileges of the virtctl user
+		script, err = os.ReadFile(c.script)
+		if err != nil {
+			return err
+		}
+	}
 	var inUse bool
 	conf, err := c.clientConfig.ClientConfig()
 	if err != nil {
@@ -133,11 +164,21 @@ func (c *guestfsCommand) run(_ *cobra.Command, args []string) error {
 	if inUse {
 		return fmt.Errorf("PVC %s is used by another pod", c.pvc)
 	}
+	vmi, err := client.getRunningVMIForPVC(c.pvc, namespace)
+	if err != nil {
+		return err
+	}
+	if vmi != "" {
+		return fmt.Errorf("PVC %s is used by the running VMI %s", c.pvc, vmi)
+	}
 	isBlock, err := client.isPVCVolumeBlock(c.pvc, namespace)
 	if err != nil {
 		return err
 	}
 	defer client.removePod(namespace, genPodName(c.pvc))
+	if c.script != "" {
+		return c.runScriptInPodWithPVC(client, namespace, string(script), isBlock, cmd.OutOrStdout())
+	}
 	return c.createInteractivePodWithPVC(client, namespace, "/entrypoint.sh", []string{}, isBlock)
 }
 
@@ -249,6 +290,26 @@ func (client *K8sClient) isPVCinUse(pvc, ns string) (bool, error) {
 	return false, nil
 }
 
+// getRunningVMIForPVC returns the name of a VMI using the PVC, which did not finish yet
+func (client *K8sClient) getRunningVMIForPVC(pvc, ns string) (string, error) {
+	vmis, err := client.VirtClient.VirtualMachineInstance(ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, vmi := range vmis.Items {
+		if vmi.IsFinal() {
+			continue
+		}
+		for _, volume := range vmi.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc ||
+				volume.DataVolume != nil && volume.DataVolume.Name == pvc {
+				return vmi.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
 func (client *K8sClient) waitForContainerRunning(podName, ns string, timeout time.Duration) error {
 	terminated := "Terminated"
 	chTerm := make(chan os.Signal, 1)
@@ -561,6 +622,137 @@ func (c *guestfsCommand) createInteractivePodWithPVC(client *K8sClient, ns, comm
 	return CreateAttacherFunc(client, p, command)
 }
 
+// scriptCommand returns the command running the script mounted from the secret with the tool against the disk
+func (c *guestfsCommand) scriptCommand(isBlock bool) (string, []string) {
+	disk := diskPath
+	if !isBlock {
+		disk = diskDir + "/" + diskImage
+	}
+	if c.scriptTool == toolVirtCustomize {
+		return toolVirtCustomize, []string{"--format", "raw", "-a", disk, "--commands-from-file", scriptPath}
+	}
+	return toolGuestfish, []string{"--rw", "--format=raw", "-a", disk, "-i", "-f", scriptPath}
+}
+
+// createScriptSecret stores the script in a secret which lives only as long as the script runs,
+// this keeps its content out of the pod spec
+func (client *K8sClient) createScriptSecret(ns, name, script string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: map[string][]byte{
+			scriptKey: []byte(script),
+		},
+	}
+	_, err := client.Client.CoreV1().Secrets(ns).Create(context.TODO(), secret, metav1.CreateOptions{})
+	return err
+}
+
+func (client *K8sClient) removeSecret(ns, name string) error {
+	return client.Client.CoreV1().Secrets(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// runScriptInPodWithPVC runs the script in a libguestfs-tools pod, streams its output and returns
+// an ExitError carrying the exit code when the script failed
+func (c *guestfsCommand) runScriptInPodWithPVC(client *K8sClient, ns, script string, isBlock bool, out io.Writer) error {
+	tool, args := c.scriptCommand(isBlock)
+	pod, err := c.createLibguestfsPod(tool, args, isBlock)
+	if err != nil {
+		return err
+	}
+	secretName := genScriptSecretName(c.pvc)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: scriptVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	container := &pod.Spec.Containers[0]
+	container.Stdin = false
+	container.TTY = false
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      scriptVolume,
+		ReadOnly:  true,
+		MountPath: scriptDir,
+	})
+
+	if err := client.createScriptSecret(ns, secretName, script); err != nil {
+		return err
+	}
+	defer client.removeSecret(ns, secretName)
+	if _, err := client.Client.CoreV1().Pods(ns).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	// if the user killed the guestfs command, the libguestfs-tools pod and the script secret are also removed
+	chTerm := make(chan os.Signal, 1)
+	signal.Notify(chTerm, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(chTerm)
+	go func() {
+		if _, received := <-chTerm; received {
+			client.removePod(ns, pod.Name)
+			client.removeSecret(ns, secretName)
+		}
+	}()
+
+	if _, err := client.waitForScript(pod.Name, ns, timeout, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	}); err != nil {
+		return fmt.Errorf("timeout in waiting for the containers to be started in pod %s: %w", pod.Name, err)
+	}
+
+	logs, err := client.Client.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{Container: contName, Follow: true}).Stream(context.TODO())
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+	if _, err := io.Copy(out, logs); err != nil {
+		return err
+	}
+
+	finished, err := client.waitForScript(pod.Name, ns, 0, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return err
+	}
+	for _, status := range finished.Status.ContainerStatuses {
+		if status.Name == contName && status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return &utils.ExitError{
+				Code: int(status.State.Terminated.ExitCode),
+				Err:  fmt.Errorf("%s script failed with exit code %d", c.scriptTool, status.State.Terminated.ExitCode),
+			}
+		}
+	}
+	if finished.Status.Phase == corev1.PodFailed {
+		return fmt.Errorf("%s script failed: %s", c.scriptTool, finished.Status.Message)
+	}
+	return nil
+}
+
+// waitForScript polls the pod until the condition is met, a zero timeout waits forever
+func (client *K8sClient) waitForScript(podName, ns string, timeout time.Duration, condition func(*corev1.Pod) bool) (*corev1.Pod, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var pod *corev1.Pod
+	err := wait.PollUntilContextCancel(ctx, scriptPollInterval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		pod, err = client.Client.CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return condition(pod), nil
+	})
+	return pod, err
+}
+
 func (client *K8sClient) removePod(ns, podName string) error {
 	return client.Client.CoreV1().Pods(ns).Delete(context.TODO(), podName, metav1.DeleteOptions{})
 }
@@ -568,3 +760,7 @@ func (client *K8sClient) removePod(ns, podName string) error {
 func genPodName(pvc string) string {
 	return fmt.Sprintf("%s-%s", podNamePrefix, pvc)
 }
+
+func genScriptSecretName(pvc string) string {
+	return fmt.Sprintf("%s-script", genPodName(pvc))
+}
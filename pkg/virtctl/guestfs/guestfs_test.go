@@ -1,8 +1,13 @@
 package guestfs_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -15,9 +20,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/testing"
 
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 
 	virtctlcmd "kubevirt.io/kubevirt/tests/clientcmd"
 )
@@ -40,7 +48,14 @@ var _ = Describe("Guestfs shell", func() {
 	var (
 		kubeClient     *fake.Clientset
 		kubevirtClient *kubecli.MockKubevirtClient
+		virtClient     *kubevirtfake.Clientset
 	)
+
+	BeforeEach(func() {
+		kubevirtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient = kubevirtfake.NewSimpleClientset()
+		kubevirtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(virtClient.KubevirtV1().VirtualMachineInstances(testNamespace)).AnyTimes()
+	})
 	mode := v1.PersistentVolumeFilesystem
 	pvc := &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -153,6 +168,148 @@ var _ = Describe("Guestfs shell", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(Equal(fmt.Sprintf("gid requires the uid to be set")))
 		})
+
+		DescribeTable("PVC in use by a VMI", func(phase kubevirtv1.VirtualMachineInstancePhase, volume kubevirtv1.VolumeSource, expectedErr string) {
+			_, err := virtClient.KubevirtV1().VirtualMachineInstances(testNamespace).Create(context.Background(), &kubevirtv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: testNamespace},
+				Spec: kubevirtv1.VirtualMachineInstanceSpec{
+					Volumes: []kubevirtv1.Volume{{Name: "disk", VolumeSource: volume}},
+				},
+				Status: kubevirtv1.VirtualMachineInstanceStatus{Phase: phase},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			guestfs.CreateClientFunc = fakeCreateClientPVC
+			err = virtctlcmd.NewRepeatableVirtctlCommand(commandName, pvcName)()
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expectedErr))
+			}
+		},
+			Entry("with a running VMI using the PVC", kubevirtv1.Running, kubevirtv1.VolumeSource{
+				PersistentVolumeClaim: &kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}, fmt.Sprintf("PVC %s is used by the running VMI testvmi", pvcName)),
+			Entry("with a running VMI using the DataVolume", kubevirtv1.Scheduling, kubevirtv1.VolumeSource{
+				DataVolume: &kubevirtv1.DataVolumeSource{Name: pvcName},
+			}, fmt.Sprintf("PVC %s is used by the running VMI testvmi", pvcName)),
+			Entry("with a stopped VMI using the PVC", kubevirtv1.Succeeded, kubevirtv1.VolumeSource{
+				DataVolume: &kubevirtv1.DataVolumeSource{Name: pvcName},
+			}, ""),
+			Entry("with a running VMI using another PVC", kubevirtv1.Running, kubevirtv1.VolumeSource{
+				DataVolume: &kubevirtv1.DataVolumeSource{Name: "other"},
+			}, ""),
+		)
+	})
+
+	Context("run a script", func() {
+		var scriptPath string
+
+		fakeCreateClientScript := func(exitCode int32) func(*rest.Config, clientcmd.ClientConfig) (*guestfs.K8sClient, error) {
+			return func(config *rest.Config, virtClientConfig clientcmd.ClientConfig) (*guestfs.K8sClient, error) {
+				kubeClient = fake.NewSimpleClientset(pvc)
+				kubeClient.Fake.PrependReactor("get", "pods", func(action testing.Action) (bool, runtime.Object, error) {
+					phase := v1.PodSucceeded
+					if exitCode != 0 {
+						phase = v1.PodFailed
+					}
+					return true, &v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "libguestfs-tools", Namespace: testNamespace},
+						Status: v1.PodStatus{
+							Phase: phase,
+							ContainerStatuses: []v1.ContainerStatus{{
+								Name:  "libguestfs",
+								State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode}},
+							}},
+						},
+					}, nil
+				})
+				return &guestfs.K8sClient{Client: kubeClient, VirtClient: kubevirtClient}, nil
+			}
+		}
+
+		createdPod := func() *v1.Pod {
+			for _, action := range kubeClient.Actions() {
+				if create, ok := action.(testing.CreateAction); ok && action.GetResource().Resource == "pods" {
+					return create.GetObject().(*v1.Pod)
+				}
+			}
+			Fail("no pod created")
+			return nil
+		}
+
+		BeforeEach(func() {
+			guestfs.ImageSetFunc = fakeSetImage
+			scriptPath = filepath.Join(GinkgoT().TempDir(), "script")
+			Expect(os.WriteFile(scriptPath, []byte("run-command touch /etc/customized\n"), 0600)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			guestfs.ImageSetFunc = guestfs.SetImage
+			guestfs.CreateClientFunc = guestfs.CreateClient
+		})
+
+		DescribeTable("should run the script with the tool and stream its output", func(tool string, expectedArgs []string) {
+			guestfs.CreateClientFunc = fakeCreateClientScript(0)
+			out, err := virtctlcmd.NewRepeatableVirtctlCommandWithOut(commandName, pvcName, "--script", scriptPath, "--script-tool", tool)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("fake logs"))
+
+			pod := createdPod()
+			container := pod.Spec.Containers[0]
+			Expect(container.Command).To(Equal([]string{tool}))
+			Expect(container.Args).To(Equal(expectedArgs))
+			Expect(container.Env).ToNot(ContainElement(HaveField("Value", ContainSubstring("customized"))))
+			Expect(container.TTY).To(BeFalse())
+			Expect(container.Stdin).To(BeFalse())
+
+			By("mounting the script from a secret")
+			secretName := "libguestfs-tools-" + pvcName + "-script"
+			Expect(pod.Spec.Volumes).To(ContainElement(v1.Volume{
+				Name:         "guestfs-script",
+				VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: secretName}},
+			}))
+			Expect(container.VolumeMounts).To(ContainElement(v1.VolumeMount{Name: "guestfs-script", ReadOnly: true, MountPath: "/guestfs-script"}))
+			var secret *v1.Secret
+			for _, action := range kubeClient.Actions() {
+				if create, ok := action.(testing.CreateAction); ok && action.GetResource().Resource == "secrets" {
+					secret = create.GetObject().(*v1.Secret)
+				}
+			}
+			Expect(secret).ToNot(BeNil())
+			Expect(secret.Name).To(Equal(secretName))
+			Expect(secret.Data).To(HaveKeyWithValue("script", []byte("run-command touch /etc/customized\n")))
+
+			By("removing the pod and the secret afterwards")
+			_, err = kubeClient.Tracker().Get(v1.SchemeGroupVersion.WithResource("pods"), testNamespace, "libguestfs-tools-"+pvcName)
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+			_, err = kubeClient.Tracker().Get(v1.SchemeGroupVersion.WithResource("secrets"), testNamespace, secretName)
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		},
+			Entry("guestfish", "guestfish", []string{"--rw", "--format=raw", "-a", "/disk/disk.img", "-i", "-f", "/guestfs-script/script"}),
+			Entry("virt-customize", "virt-customize", []string{"--format", "raw", "-a", "/disk/disk.img", "--commands-from-file", "/guestfs-script/script"}),
+		)
+
+		It("should return the exit code of a failed script", func() {
+			guestfs.CreateClientFunc = fakeCreateClientScript(3)
+			err := virtctlcmd.NewRepeatableVirtctlCommand(commandName, pvcName, "--script", scriptPath)()
+			Expect(err).To(MatchError("guestfish script failed with exit code 3"))
+			var exitErr *utils.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.Code).To(Equal(3))
+		})
+
+		It("should refuse an unknown tool", func() {
+			err := virtctlcmd.NewRepeatableVirtctlCommand(commandName, pvcName, "--script", scriptPath, "--script-tool", "virt-sysprep")()
+			Expect(err).To(MatchError("Invalid script tool: virt-sysprep, supported are guestfish and virt-customize"))
+		})
+
+		It("should fail when the script does not exist", func() {
+			err := virtctlcmd.NewRepeatableVirtctlCommand(commandName, pvcName, "--script", filepath.Join(GinkgoT().TempDir(), "missing"))()
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
 	})
 
 	Context("URL authenticity", func() {
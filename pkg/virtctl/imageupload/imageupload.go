@@ -138,6 +138,8 @@ func NewImageUploadCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.Flags().StringVar(&c.volumeMode, "volume-mode", "", "Specify the VolumeMode (block/filesystem) used to create the PVC. Default is the storageProfile default. For archive upload default is filesystem.")
 	cmd.Flags().StringVar(&c.imagePath, "image-path", "", "Path to the local VM image.")
 	cmd.Flags().StringVar(&c.archivePath, "archive-path", "", "Path to the local archive.")
+	cmd.Flags().StringVar(&c.imageURL, "image-url", "", "URL of the VM image, either http(s):// or docker:// for a containerdisk. The image is decompressed, raw and qcow2 images are streamed to the upload, other formats are converted to raw before it.")
+	cmd.Flags().StringVar(&c.imageArch, "image-arch", defaultPlatformArch, "Architecture of the containerdisk to pull when the docker:// image-url refers to a multi-platform image.")
 	cmd.Flags().BoolVar(&c.noCreate, "no-create", false, "Don't attempt to create a new DataVolume/PVC.")
 	cmd.Flags().UintVar(&c.uploadPodWaitSecs, "wait-secs", 300, "Seconds to wait for upload pod to start.")
 	cmd.Flags().UintVar(&c.uploadRetries, "retry", 5, "When upload server returns a transient error, we retry this number of times before giving up")
@@ -174,7 +176,13 @@ func usage() string {
   {{ProgramName}} image-upload dv fedora-dv --uploadproxy-url=https://cdi-uploadproxy.mycluster.com --image-path=/images/fedora30.qcow2
 
   # Upload a local disk archive to a newly created DataVolume:
-  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --archive-path=/images/fedora30.tar`
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --archive-path=/images/fedora30.tar
+
+  # Upload a disk image downloaded from an HTTP server to a newly created DataVolume:
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-url=https://download.example.com/images/fedora30.qcow2.xz
+
+  # Upload the disk image of a containerdisk to a newly created DataVolume:
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-url=docker://quay.io/containerdisks/fedora:latest`
 	return usage
 }
 
@@ -189,6 +197,8 @@ type command struct {
 	pvcSize                 string
 	storageClass            string
 	imagePath               string
+	imageURL                string
+	imageArch               string
 	volumeMode              string
 	archivePath             string
 	accessMode              string
@@ -239,10 +249,17 @@ func (c *command) parseArgs(args []string) error {
 	}
 
 	c.archiveUpload = false
-	if c.imagePath == "" && c.archivePath == "" {
-		return fmt.Errorf("either image-path or archive-path must be provided")
+	if c.imagePath == "" && c.archivePath == "" && c.imageURL == "" {
+		return fmt.Errorf("either image-path, archive-path or image-url must be provided")
 	} else if c.imagePath != "" && c.archivePath != "" {
 		return fmt.Errorf("cannot handle both image-path and archive-path, provide only one")
+	} else if c.imageURL != "" {
+		if c.imagePath != "" || c.archivePath != "" {
+			return fmt.Errorf("cannot handle image-url together with image-path or archive-path, provide only one")
+		}
+		if err := validateImageURL(c.imageURL); err != nil {
+			return err
+		}
 	} else if c.archivePath != "" {
 		c.archiveUpload = true
 		c.imagePath = c.archivePath
@@ -291,24 +308,36 @@ func (c *command) run(args []string) error {
 		return err
 	}
 
-	// #nosec G304 No risk for path injection as this function executes with
-	// the same privileges as those of virtctl user who supplies imagePath
-	file, err := os.Open(c.imagePath)
-	if err != nil {
-		return err
-	}
-	defer util.CloseIOAndCheckErr(file, nil)
-
-	pvc, err := c.getAndValidateUploadPVC()
-	if err != nil {
-		if !(k8serrors.IsNotFound(err) && !c.noCreate) {
+	var file *os.File
+	if c.imageURL == "" {
+		// #nosec G304 No risk for path injection as this function executes with
+		// the same privileges as those of virtctl user who supplies imagePath
+		imageFile, err := os.Open(c.imagePath)
+		if err != nil {
 			return err
 		}
+		defer util.CloseIOAndCheckErr(imageFile, nil)
+		file = imageFile
+	}
 
-		if !c.noCreate && len(c.size) == 0 {
-			return fmt.Errorf("when creating a resource, the size must be specified")
+	pvc, err := c.getAndValidateUploadPVC()
+	if err != nil && !(k8serrors.IsNotFound(err) && !c.noCreate) {
+		return err
+	}
+	if err != nil && len(c.size) == 0 {
+		return fmt.Errorf("when creating a resource, the size must be specified")
+	}
+	if c.imageURL != "" {
+		// The image is checked once the target was validated, and before it is created
+		stagedFile, cleanup, stageErr := c.prepareImage()
+		if stageErr != nil {
+			return stageErr
 		}
+		defer cleanup()
+		file = stagedFile
+	}
 
+	if err != nil {
 		var obj metav1.Object
 
 		if c.createPVC {
@@ -368,7 +397,12 @@ func (c *command) run(args []string) error {
 		return err
 	}
 
-	if err := c.uploadData(token, file); err != nil {
+	if file != nil {
+		err = c.uploadData(token, file)
+	} else {
+		err = c.uploadImage(token)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -382,6 +416,8 @@ func (c *command) run(args []string) error {
 	err = UploadProcessingCompleteFunc(c.client, c.cmd, c.namespace, c.name, processingWaitInterval, processingWaitTotal)
 	if err != nil {
 		c.cmd.Printf("Timed out waiting for post upload processing to complete, please check upload pod status for progress\n")
+	} else if c.imageURL != "" {
+		c.cmd.Printf("Uploading %s completed successfully\n", c.imageURL)
 	} else {
 		c.cmd.Printf("Uploading %s completed successfully\n", c.imagePath)
 	}
@@ -442,31 +478,56 @@ func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool)
 }
 
 func (c *command) uploadData(token string, file *os.File) error {
-	uploadURL, err := ConstructUploadProxyPathAsync(c.uploadProxyURL, token, c.insecure)
+	fi, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return c.upload(token, file, fi.Size(), c.uploadRetries)
+}
+
+// uploadImage streams the image at the image URL to the upload proxy. The download can not be
+// repeated, so the upload is not retried.
+func (c *command) uploadImage(token string) error {
+	image, err := c.openImage()
 	if err != nil {
 		return err
 	}
+	defer util.CloseIOAndCheckErr(image, nil)
 
-	fi, err := file.Stat()
+	if err := c.upload(token, image, -1, 1); err != nil {
+		return err
+	}
+	if err := image.wait(); err != nil {
+		return fmt.Errorf("failed to download %s: %w", c.imageURL, err)
+	}
+	return nil
+}
+
+// upload posts the data of the given size, -1 if unknown, to the upload proxy. Retrying
+// requires the data to be seekable.
+func (c *command) upload(token string, data io.Reader, size int64, retries uint) error {
+	uploadURL, err := ConstructUploadProxyPathAsync(c.uploadProxyURL, token, c.insecure)
 	if err != nil {
 		return err
 	}
 
-	bar := pb.Full.Start64(fi.Size())
+	bar := pb.Full.Start64(size)
 	bar.SetWriter(os.Stdout)
 	bar.Set(pb.Bytes, true)
-	reader := bar.NewProxyReader(file)
+	reader := bar.NewProxyReader(data)
 
 	client := GetHTTPClientFn(c.insecure)
 	req, _ := http.NewRequest("POST", uploadURL, io.NopCloser(reader))
 
 	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/octet-stream")
-	req.ContentLength = fi.Size()
+	req.ContentLength = size
 
 	clientDo := func() error {
-		if _, err := file.Seek(0, io.SeekStart); err != nil {
-			return err
+		if seeker, ok := data.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
 		}
 		resp, err := client.Do(req)
 		if err != nil {
@@ -487,12 +548,12 @@ func (c *command) uploadData(token string, file *os.File) error {
 	bar.Start()
 
 	retry := uint(0)
-	for retry < c.uploadRetries {
+	for retry < retries {
 		if err = clientDo(); err == nil {
 			break
 		}
 		retry++
-		if retry < c.uploadRetries {
+		if retry < retries {
 			time.Sleep(time.Duration(retry*rand.UintN(50)) * time.Millisecond)
 		}
 	}
@@ -500,8 +561,8 @@ func (c *command) uploadData(token string, file *os.File) error {
 	bar.Finish()
 	c.cmd.Println()
 
-	if err != nil && retry == c.uploadRetries {
-		return fmt.Errorf("error uploading image after %d retries: %w", c.uploadRetries, err)
+	if err != nil && retry == retries {
+		return fmt.Errorf("error uploading image after %d retries: %w", retries, err)
 	}
 
 	return nil
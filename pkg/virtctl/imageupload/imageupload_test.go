@@ -1,8 +1,12 @@
 package imageupload_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -806,6 +810,171 @@ var _ = Describe("ImageUpload", func() {
 		})
 	})
 
+	Context("Upload from an image URL", func() {
+		const (
+			rawImage   = "raw image content"
+			qcow2Image = "QFI\xfbqcow2 image content"
+			vmdkImage  = "KDMVvmdk image content"
+			layerPath  = "/v2/containerdisks/fedora/blobs/sha256:disk"
+			token      = "anonymous"
+		)
+
+		var (
+			uploaded      []byte
+			imageServer   *httptest.Server
+			imageRequests int
+		)
+
+		gzipped := func(data []byte) []byte {
+			buf := &bytes.Buffer{}
+			writer := gzip.NewWriter(buf)
+			_, err := writer.Write(data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+			return buf.Bytes()
+		}
+
+		containerDiskLayer := func(name, content string) []byte {
+			buf := &bytes.Buffer{}
+			writer := tar.NewWriter(buf)
+			Expect(writer.WriteHeader(&tar.Header{Name: name, Mode: 0444, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+			_, err := writer.Write([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+			return gzipped(buf.Bytes())
+		}
+
+		serveImages := func(files map[string][]byte) {
+			imageServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				imageRequests++
+				if r.URL.Path == "/token" {
+					fmt.Fprintf(w, `{"token": "%s"}`, token)
+					return
+				}
+				if strings.HasPrefix(r.URL.Path, "/v2/") && r.Header.Get("Authorization") != "Bearer "+token {
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry"`, r.Host))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				content, ok := files[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(content)
+			}))
+		}
+
+		BeforeEach(func() {
+			testInit(http.StatusOK)
+			uploaded = nil
+			imageRequests = 0
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					body, err := io.ReadAll(r.Body)
+					Expect(err).ToNot(HaveOccurred())
+					uploaded = body
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			imageupload.ConvertToRawFn = func(format, src, dst string) error {
+				Expect(format).To(Equal("vmdk"))
+				Expect(os.ReadFile(src)).To(BeEquivalentTo(vmdkImage))
+				return os.WriteFile(dst, []byte(rawImage), 0600)
+			}
+		})
+
+		AfterEach(func() {
+			imageServer.Close()
+			imageupload.ConvertToRawFn = func(string, string, string) error {
+				return fmt.Errorf("unexpected conversion")
+			}
+			testDone()
+		})
+
+		runUpload := func(imageURL string, extraArgs ...string) error {
+			return clientcmd.NewRepeatableVirtctlCommand(append([]string{commandName, "dv", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-url", imageURL}, extraArgs...)...)()
+		}
+
+		DescribeTable("should upload the image from an HTTP server", func(content []byte, expected string) {
+			serveImages(map[string][]byte{"/disk.img": content})
+			Expect(runUpload(imageServer.URL + "/disk.img")).To(Succeed())
+			Expect(dvCreateCalled.IsTrue()).To(BeTrue())
+			Expect(string(uploaded)).To(Equal(expected))
+		},
+			Entry("with a raw image", []byte(rawImage), rawImage),
+			Entry("with a gzip compressed raw image", gzipped([]byte(rawImage)), rawImage),
+			Entry("streaming a qcow2 image", []byte(qcow2Image), qcow2Image),
+			Entry("streaming a gzip compressed qcow2 image", gzipped([]byte(qcow2Image)), qcow2Image),
+			Entry("converting a vmdk image to raw", []byte(vmdkImage), rawImage),
+			Entry("converting a gzip compressed vmdk image to raw", gzipped([]byte(vmdkImage)), rawImage),
+		)
+
+		It("should validate the target before downloading the image", func() {
+			serveImages(map[string][]byte{"/disk.img": []byte(rawImage)})
+			err := clientcmd.NewRepeatableVirtctlCommand(commandName, "dv", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-url", imageServer.URL+"/disk.img")()
+			Expect(err).To(MatchError("when creating a resource, the size must be specified"))
+			Expect(imageRequests).To(BeZero())
+			Expect(dvCreateCalled.IsTrue()).To(BeFalse())
+		})
+
+		It("should fail when the image can not be downloaded", func() {
+			serveImages(nil)
+			Expect(runUpload(imageServer.URL + "/disk.img")).To(MatchError(ContainSubstring("unexpected return value 404 downloading")))
+			Expect(dvCreateCalled.IsTrue()).To(BeFalse())
+		})
+
+		DescribeTable("should upload the disk of a containerdisk", func(index bool) {
+			files := map[string][]byte{
+				"/v2/containerdisks/fedora/manifests/sha256:amd64": []byte(`{"layers": [{"digest": "sha256:disk"}, {"digest": "sha256:empty"}]}`),
+				layerPath: containerDiskLayer("./disk/fedora.vmdk", vmdkImage),
+				"/v2/containerdisks/fedora/blobs/sha256:empty": containerDiskLayer("etc/motd", "hello"),
+			}
+			if index {
+				files["/v2/containerdisks/fedora/manifests/40"] = []byte(`{"manifests": [
+					{"digest": "sha256:arm64", "platform": {"os": "linux", "architecture": "arm64"}},
+					{"digest": "sha256:amd64", "platform": {"os": "linux", "architecture": "amd64"}}
+				]}`)
+			} else {
+				files["/v2/containerdisks/fedora/manifests/40"] = files["/v2/containerdisks/fedora/manifests/sha256:amd64"]
+			}
+			serveImages(files)
+
+			Expect(runUpload("docker://" + strings.TrimPrefix(imageServer.URL, "https://") + "/containerdisks/fedora:40")).To(Succeed())
+			Expect(string(uploaded)).To(Equal(rawImage))
+		},
+			Entry("with an image manifest", false),
+			Entry("with an image index", true),
+		)
+
+		It("should upload the containerdisk of the requested architecture", func() {
+			serveImages(map[string][]byte{
+				"/v2/containerdisks/fedora/manifests/40": []byte(`{"manifests": [
+					{"digest": "sha256:arm64", "platform": {"os": "linux", "architecture": "arm64"}},
+					{"digest": "sha256:amd64", "platform": {"os": "linux", "architecture": "amd64"}}
+				]}`),
+				"/v2/containerdisks/fedora/manifests/sha256:arm64": []byte(`{"layers": [{"digest": "sha256:disk"}]}`),
+				layerPath: containerDiskLayer("disk/fedora.qcow2", qcow2Image),
+			})
+
+			Expect(runUpload("docker://"+strings.TrimPrefix(imageServer.URL, "https://")+"/containerdisks/fedora:40", "--image-arch=arm64")).To(Succeed())
+			Expect(string(uploaded)).To(Equal(qcow2Image))
+		})
+
+		It("should fail when the containerdisk contains no disk", func() {
+			serveImages(map[string][]byte{
+				"/v2/containerdisks/fedora/manifests/latest": []byte(`{"layers": [{"digest": "sha256:disk"}]}`),
+				layerPath: containerDiskLayer("etc/motd", "hello"),
+			})
+
+			imageURL := "docker://" + strings.TrimPrefix(imageServer.URL, "https://") + "/containerdisks/fedora"
+			Expect(runUpload(imageURL)).To(MatchError("no disk image found in /disk of " + imageURL))
+		})
+	})
+
 	Context("Upload fails", func() {
 		It("DV already uploaded and garbagecollected", func() {
 			testInit(http.StatusOK, pvcSpecWithGarbageCollection())
@@ -967,7 +1136,7 @@ var _ = Describe("ImageUpload", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(Equal(errString))
 		},
-			Entry("No args", "either image-path or archive-path must be provided", []string{}),
+			Entry("No args", "either image-path, archive-path or image-url must be provided", []string{}),
 			Entry("Missing arg", "expecting two args",
 				[]string{"targetName", "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
 			Entry("No name", "expecting two args",
@@ -976,10 +1145,14 @@ var _ = Describe("ImageUpload", func() {
 				[]string{"dv", targetName, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
 			Entry("Size invalid", "validation failed for size=500Zb: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
 				[]string{"dv", targetName, "--size", "500Zb", "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
-			Entry("No image path nor archive-path", "either image-path or archive-path must be provided",
+			Entry("No image path nor archive-path", "either image-path, archive-path or image-url must be provided",
 				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure"}),
 			Entry("Image path and archive path provided", "cannot handle both image-path and archive-path, provide only one",
 				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null", "--archive-path", "/dev/null.tar"}),
+			Entry("Image URL and image path provided", "cannot handle image-url together with image-path or archive-path, provide only one",
+				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null", "--image-url", "https://example.com/disk.img"}),
+			Entry("Image URL with unsupported scheme", "unsupported scheme 'ftp' in image-url, supported are http, https and docker",
+				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-url", "ftp://example.com/disk.img"}),
 			Entry("Archive path and block volume true provided", "In archive upload the volume mode should always be filesystem",
 				[]string{"dv", targetName, "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--archive-path", "/dev/null.tar", "--block-volume"}),
 			Entry("BlockVolume true provided with different volume-mode", "incompatible --volume-mode 'filesystem' and --block-volume",
@@ -0,0 +1,305 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package imageupload
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	dockerHubHost     = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+
+	// containerDiskDir is the directory containerdisks keep their disk image in
+	containerDiskDir = "disk"
+
+	platformOS = "linux"
+	// defaultPlatformArch is the architecture of the containerdisk pulled from an image index by default
+	defaultPlatformArch = "amd64"
+)
+
+var (
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+
+	authParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+type imageReference struct {
+	registry   string
+	repository string
+	reference  string
+}
+
+type descriptor struct {
+	Digest   string `json:"digest"`
+	Platform *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+// imageManifest holds the fields shared by image indexes and image manifests
+type imageManifest struct {
+	Manifests []descriptor `json:"manifests"`
+	Layers    []descriptor `json:"layers"`
+}
+
+type registryClient struct {
+	client *http.Client
+	image  imageReference
+	token  string
+}
+
+// layerFile is a file in a layer of an image, closing it closes the layer
+type layerFile struct {
+	io.Reader
+	layer io.Closer
+}
+
+func (f *layerFile) Close() error {
+	return f.layer.Close()
+}
+
+// parseImageReference parses references like docker://quay.io/containerdisks/fedora:latest,
+// image names without registry refer to Docker Hub
+func parseImageReference(imageURL string) (imageReference, error) {
+	name := strings.TrimPrefix(imageURL, schemeDocker+"://")
+	ref := imageReference{reference: defaultTag}
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.reference = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.reference = name[:i], name[i+1:]
+	}
+	if name == "" || ref.reference == "" {
+		return imageReference{}, fmt.Errorf("invalid image reference %s", imageURL)
+	}
+
+	ref.registry, ref.repository = dockerHubHost, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		if host := name[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.registry, ref.repository = host, name[i+1:]
+		}
+	}
+	if ref.registry == dockerHubHost {
+		ref.registry = dockerHubRegistry
+		if !strings.Contains(ref.repository, "/") {
+			ref.repository = "library/" + ref.repository
+		}
+	}
+	return ref, nil
+}
+
+// openContainerDisk pulls the containerdisk behind the reference from its registry and returns the
+// content of its disk image for the architecture. Only registries allowing anonymous pulls are supported.
+func openContainerDisk(client *http.Client, imageURL, arch string) (io.ReadCloser, error) {
+	image, err := parseImageReference(imageURL)
+	if err != nil {
+		return nil, err
+	}
+	r := &registryClient{client: client, image: image}
+
+	manifest, err := r.getManifest(image.reference)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Manifests) > 0 {
+		digest, err := selectPlatform(manifest.Manifests, arch)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", imageURL, err)
+		}
+		if manifest, err = r.getManifest(digest); err != nil {
+			return nil, err
+		}
+	}
+
+	// The top-most layer containing a disk wins
+	for i := len(manifest.Layers) - 1; i >= 0; i-- {
+		file, err := r.openDisk(manifest.Layers[i].Digest)
+		if err != nil {
+			return nil, err
+		}
+		if file != nil {
+			return file, nil
+		}
+	}
+	return nil, fmt.Errorf("no disk image found in /%s of %s", containerDiskDir, imageURL)
+}
+
+func selectPlatform(manifests []descriptor, arch string) (string, error) {
+	for _, m := range manifests {
+		if m.Platform != nil && m.Platform.OS == platformOS && m.Platform.Architecture == arch {
+			return m.Digest, nil
+		}
+	}
+	if len(manifests) == 1 {
+		return manifests[0].Digest, nil
+	}
+	return "", fmt.Errorf("no image for %s/%s found, reference the image of the platform by its digest", platformOS, arch)
+}
+
+func (r *registryClient) getManifest(reference string) (*imageManifest, error) {
+	resp, err := r.get("manifests/"+reference, manifestMediaTypes...)
+	if err != nil {
+		return nil, err
+	}
+	defer util.CloseIOAndCheckErr(resp.Body, nil)
+
+	manifest := &imageManifest{}
+	if err := json.NewDecoder(resp.Body).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %w", reference, err)
+	}
+	return manifest, nil
+}
+
+// openDisk returns the disk image in the layer, or nil if the layer does not contain one
+func (r *registryClient) openDisk(digest string) (io.ReadCloser, error) {
+	resp, err := r.get("blobs/" + digest)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(resp.Body)
+	var layer io.Reader = buffered
+	if hasMagic(buffered, gzipMagic) {
+		if layer, err = gzip.NewReader(buffered); err != nil {
+			util.CloseIOAndCheckErr(resp.Body, nil)
+			return nil, err
+		}
+	}
+
+	tarReader := tar.NewReader(layer)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			util.CloseIOAndCheckErr(resp.Body, nil)
+			return nil, nil
+		}
+		if err != nil {
+			util.CloseIOAndCheckErr(resp.Body, nil)
+			return nil, fmt.Errorf("failed to read layer %s: %w", digest, err)
+		}
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if header.Typeflag == tar.TypeReg && path.Dir(name) == containerDiskDir {
+			return &layerFile{Reader: tarReader, layer: resp.Body}, nil
+		}
+	}
+}
+
+// get requests the path below the repository, authenticating with an anonymous token when the
+// registry asks for one
+func (r *registryClient) get(subPath string, accept ...string) (*http.Response, error) {
+	u := url.URL{Scheme: schemeHTTPS, Host: r.image.registry, Path: path.Join("/v2", r.image.repository, subPath)}
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, mediaType := range accept {
+			req.Header.Add("Accept", mediaType)
+		}
+		if r.token != "" {
+			req.Header.Add("Authorization", "Bearer "+r.token)
+		}
+		return r.client.Do(req)
+	}
+
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		util.CloseIOAndCheckErr(resp.Body, nil)
+		if err := r.authenticate(resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+		if resp, err = do(); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		util.CloseIOAndCheckErr(resp.Body, nil)
+		return nil, fmt.Errorf("unexpected return value %d getting %s", resp.StatusCode, u.String())
+	}
+	return resp, nil
+}
+
+// authenticate requests an anonymous token as described by the Bearer challenge of the registry
+func (r *registryClient) authenticate(challenge string) error {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return fmt.Errorf("registry %s requires unsupported authentication '%s'", r.image.registry, challenge)
+	}
+	params := map[string]string{}
+	for _, match := range authParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("registry %s sent invalid authentication realm '%s'", r.image.registry, params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	resp, err := r.client.Get(realm.String())
+	if err != nil {
+		return err
+	}
+	defer util.CloseIOAndCheckErr(resp.Body, nil)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected return value %d authenticating to registry %s", resp.StatusCode, r.image.registry)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	return nil
+}
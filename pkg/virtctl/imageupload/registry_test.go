@@ -0,0 +1,47 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package imageupload
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image reference", func() {
+	DescribeTable("should be parsed", func(imageURL string, expected imageReference) {
+		Expect(parseImageReference(imageURL)).To(Equal(expected))
+	},
+		Entry("with registry, repository and tag", "docker://quay.io/containerdisks/fedora:40",
+			imageReference{registry: "quay.io", repository: "containerdisks/fedora", reference: "40"}),
+		Entry("with registry port and without tag", "docker://registry.local:5000/fedora",
+			imageReference{registry: "registry.local:5000", repository: "fedora", reference: "latest"}),
+		Entry("with digest", "docker://quay.io/containerdisks/fedora@sha256:1234",
+			imageReference{registry: "quay.io", repository: "containerdisks/fedora", reference: "sha256:1234"}),
+		Entry("with Docker Hub user image", "docker://kubevirt/fedora-cloud-container-disk-demo:latest",
+			imageReference{registry: "registry-1.docker.io", repository: "kubevirt/fedora-cloud-container-disk-demo", reference: "latest"}),
+		Entry("with Docker Hub official image", "docker://busybox",
+			imageReference{registry: "registry-1.docker.io", repository: "library/busybox", reference: "latest"}),
+	)
+
+	It("should fail without repository", func() {
+		_, err := parseImageReference("docker://")
+		Expect(err).To(MatchError("invalid image reference docker://"))
+	})
+})
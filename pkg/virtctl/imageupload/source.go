@@ -0,0 +1,286 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package imageupload
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	schemeHTTP   = "http"
+	schemeHTTPS  = "https"
+	schemeDocker = "docker"
+
+	formatRaw   = "raw"
+	formatQcow2 = "qcow2"
+	formatVmdk  = "vmdk"
+	formatVhdx  = "vhdx"
+
+	qemuImg = "qemu-img"
+	xz      = "xz"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	xzMagic    = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	qcow2Magic = []byte{'Q', 'F', 'I', 0xfb}
+	vmdkMagic  = []byte("KDMV")
+	vhdxMagic  = []byte("vhdxfile")
+)
+
+// ConvertToRawFn converts the image at src in the given format to a raw image at dst,
+// it can be overridden for unit testing
+var ConvertToRawFn = convertToRaw
+
+func validateImageURL(imageURL string) error {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case schemeHTTP, schemeHTTPS, schemeDocker:
+		return nil
+	default:
+		return fmt.Errorf("unsupported scheme '%s' in image-url, supported are %s, %s and %s", u.Scheme, schemeHTTP, schemeHTTPS, schemeDocker)
+	}
+}
+
+// probeImage opens the image at the image URL to detect its format and closes it again,
+// so an unreachable image is reported before the upload target is created
+func (c *command) probeImage() (string, error) {
+	image, err := c.openImage()
+	if err != nil {
+		return "", err
+	}
+	defer util.CloseIOAndCheckErr(image, nil)
+	return image.format, nil
+}
+
+// prepareImage checks the image at the image URL. Raw and qcow2 images are streamed to the upload
+// later on, for them no file is returned. Other formats are staged to a raw image file, which is
+// removed again by the returned function.
+func (c *command) prepareImage() (*os.File, func(), error) {
+	noCleanup := func() {}
+	format, err := c.probeImage()
+	if err != nil {
+		return nil, nil, err
+	}
+	if streamsImage(format) {
+		return nil, noCleanup, nil
+	}
+
+	rawPath, cleanup, err := c.stageImage()
+	if err != nil {
+		return nil, nil, err
+	}
+	// #nosec G304 the raw image was written by virtctl itself
+	file, err := os.Open(rawPath)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return file, func() {
+		util.CloseIOAndCheckErr(file, nil)
+		cleanup()
+	}, nil
+}
+
+// streamsImage returns whether an image of the format is uploaded as it is downloaded.
+// CDI converts qcow2 images itself, other formats are converted to raw locally.
+func streamsImage(format string) bool {
+	return format == formatRaw || format == formatQcow2
+}
+
+// stageImage downloads the image at the image URL to a temporary file and converts it to raw.
+// It returns the path of the raw image and a function removing it again.
+func (c *command) stageImage() (string, func(), error) {
+	image, err := c.openImage()
+	if err != nil {
+		return "", nil, err
+	}
+	defer util.CloseIOAndCheckErr(image, nil)
+
+	file, err := os.CreateTemp("", "virtctl-image-upload-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { removeFile(file.Name()) }
+	_, err = io.Copy(file, image)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if waitErr := image.wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to download %s: %w", c.imageURL, err)
+	}
+
+	c.cmd.Printf("Converting %s image to raw\n", image.format)
+	rawPath := file.Name() + "." + formatRaw
+	err = ConvertToRawFn(image.format, file.Name(), rawPath)
+	cleanup()
+	if err != nil {
+		removeFile(rawPath)
+		return "", nil, err
+	}
+	return rawPath, func() { removeFile(rawPath) }, nil
+}
+
+// image is the decompressed content of the image at the image URL
+type image struct {
+	*bufio.Reader
+	format string
+	source io.Closer
+	// wait returns the result of the decompression once the image was read or closed
+	wait func() error
+}
+
+// Close stops the download. An error of the decompression caused by it is expected and ignored.
+func (i *image) Close() error {
+	err := i.source.Close()
+	_ = i.wait()
+	return err
+}
+
+// openImage downloads the image at the image URL, decompressing it on the way
+func (c *command) openImage() (*image, error) {
+	source, err := c.openImageURL()
+	if err != nil {
+		return nil, err
+	}
+	reader, wait, err := decompress(bufio.NewReader(source))
+	if err != nil {
+		util.CloseIOAndCheckErr(source, nil)
+		return nil, err
+	}
+	buffered := bufio.NewReader(reader)
+	return &image{Reader: buffered, format: detectFormat(buffered), source: source, wait: sync.OnceValue(wait)}, nil
+}
+
+// openImageURL returns the content of the image behind an http(s) URL, or of the disk in the
+// containerdisk behind a docker reference
+func (c *command) openImageURL() (io.ReadCloser, error) {
+	u, err := url.Parse(c.imageURL)
+	if err != nil {
+		return nil, err
+	}
+	client := GetHTTPClientFn(false)
+	if u.Scheme == schemeDocker {
+		c.cmd.Printf("Pulling containerdisk %s\n", c.imageURL)
+		return openContainerDisk(client, c.imageURL, c.imageArch)
+	}
+
+	c.cmd.Printf("Downloading %s\n", c.imageURL)
+	resp, err := client.Get(c.imageURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		util.CloseIOAndCheckErr(resp.Body, nil)
+		return nil, fmt.Errorf("unexpected return value %d downloading %s", resp.StatusCode, c.imageURL)
+	}
+	return resp.Body, nil
+}
+
+// decompress returns a reader of the decompressed content if it is gzip or xz compressed.
+// The returned function waits for the decompression to finish after the reader was consumed.
+func decompress(reader *bufio.Reader) (io.Reader, func() error, error) {
+	noWait := func() error { return nil }
+	switch {
+	case hasMagic(reader, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gzipReader, noWait, nil
+	case hasMagic(reader, xzMagic):
+		if _, err := exec.LookPath(xz); err != nil {
+			return nil, nil, fmt.Errorf("%s is required to decompress xz images: %w", xz, err)
+		}
+		cmd := exec.Command(xz, "--decompress", "--stdout")
+		cmd.Stdin = reader
+		cmd.Stderr = os.Stderr
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, err
+		}
+		// Closing the output first stops xz in case the reader was not consumed completely
+		return out, func() error {
+			util.CloseIOAndCheckErr(out, nil)
+			return cmd.Wait()
+		}, nil
+	default:
+		return reader, noWait, nil
+	}
+}
+
+func detectFormat(reader *bufio.Reader) string {
+	switch {
+	case hasMagic(reader, qcow2Magic):
+		return formatQcow2
+	case hasMagic(reader, vmdkMagic):
+		return formatVmdk
+	case hasMagic(reader, vhdxMagic):
+		return formatVhdx
+	default:
+		return formatRaw
+	}
+}
+
+func hasMagic(reader *bufio.Reader, magic []byte) bool {
+	header, err := reader.Peek(len(magic))
+	return err == nil && bytes.Equal(header, magic)
+}
+
+func convertToRaw(format, src, dst string) error {
+	if _, err := exec.LookPath(qemuImg); err != nil {
+		return fmt.Errorf("%s is required to convert %s images to raw: %w", qemuImg, format, err)
+	}
+	// #nosec G204 No risk for command injection as the paths are temporary files created by virtctl
+	out, err := exec.Command(qemuImg, "convert", "-f", format, "-O", formatRaw, src, dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to convert %s image to raw: %v: %s", format, err, out)
+	}
+	return nil
+}
+
+func removeFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Log.Reason(err).Errorf("failed to remove %s", path)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -52,6 +53,7 @@ const (
 	FormatFlag       = "format"
 	LocalPortFlag    = "local-port"
 	OutputFileFlag   = "output"
+	DumpFormatFlag   = "dump-format"
 
 	configName         = "config"
 	filesystemOverhead = v1.Percent("0.055")
@@ -70,6 +72,7 @@ var (
 	storageClass string
 	accessMode   string
 	outputFile   string
+	dumpFormat   string
 )
 
 type command struct {
@@ -89,6 +92,10 @@ func usageMemoryDump() string {
   #Create and download memory dump to the given output file.
   {{ProgramName}} memory-dump get myvm --claim-name=memoryvolume --create-claim --output=memoryDump.dump.gz
 
+  #Dump memory in the kdump-compressed format crash and volatility can open. The downloaded archive contains
+  #the domain XML and the guest kernel version next to the dump.
+  {{ProgramName}} memory-dump get myvm --claim-name=memoryvolume --create-claim --dump-format=kdump-zlib --output=memoryDump.tar.gz
+
   #Dump memory again to the same virtual machine with an already associated pvc(existing memory dump on vm status).
   {{ProgramName}} memory-dump get myvm
 
@@ -122,6 +129,7 @@ func NewMemoryDumpCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.Flags().StringVar(&storageClass, StorageClassFlag, "", "The storage class for the PVC.")
 	cmd.Flags().StringVar(&accessMode, AccessModeFlag, "", "The access mode for the PVC.")
 	cmd.Flags().StringVar(&outputFile, OutputFileFlag, "", "Specifies the output path of the memory dump to be downloaded.")
+	cmd.Flags().StringVar(&dumpFormat, DumpFormatFlag, "", fmt.Sprintf("The format to dump the memory in (%s). The kdump formats compress the memory pages, defaults to %s.", supportedDumpFormats(), v1.MemoryDumpFormatELF))
 
 	return cmd
 }
@@ -271,9 +279,28 @@ func createPVCforMemoryDump(namespace, vmName, claimName string, virtClient kube
 	return nil
 }
 
+func supportedDumpFormats() string {
+	return strings.Join([]string{
+		string(v1.MemoryDumpFormatELF),
+		string(v1.MemoryDumpFormatKdumpZlib),
+		string(v1.MemoryDumpFormatKdumpLzo),
+		string(v1.MemoryDumpFormatKdumpSnappy),
+	}, ", ")
+}
+
+func validateDumpFormat() error {
+	switch v1.MemoryDumpFormat(dumpFormat) {
+	case "", v1.MemoryDumpFormatELF, v1.MemoryDumpFormatKdumpZlib, v1.MemoryDumpFormatKdumpLzo, v1.MemoryDumpFormatKdumpSnappy:
+		return nil
+	default:
+		return fmt.Errorf("invalid dump format %s, supported are %s", dumpFormat, supportedDumpFormats())
+	}
+}
+
 func createMemoryDump(namespace, vmName, claimName string, virtClient kubecli.KubevirtClient) error {
 	memoryDumpRequest := &v1.VirtualMachineMemoryDumpRequest{
 		ClaimName: claimName,
+		Format:    v1.MemoryDumpFormat(dumpFormat),
 	}
 
 	err := virtClient.VirtualMachine(namespace).MemoryDump(context.Background(), vmName, memoryDumpRequest)
@@ -285,6 +312,9 @@ func createMemoryDump(namespace, vmName, claimName string, virtClient kubecli.Ku
 }
 
 func getMemoryDump(namespace, vmName string, virtClient kubecli.KubevirtClient) error {
+	if err := validateDumpFormat(); err != nil {
+		return err
+	}
 	if createClaim {
 		if claimName == "" {
 			return fmt.Errorf("missing claim name")
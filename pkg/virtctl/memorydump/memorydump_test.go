@@ -111,7 +111,7 @@ var _ = Describe("MemoryDump", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
-	expectVMEndpointMemoryDump := func(claimName string) {
+	expectVMEndpointMemoryDumpWithFormat := func(claimName string, format v1.MemoryDumpFormat) {
 		virtClient.PrependReactor("put", "virtualmachines/memorydump", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
 			switch action := action.(type) {
 			case kvtesting.PutAction[*v1.VirtualMachineMemoryDumpRequest]:
@@ -119,6 +119,7 @@ var _ = Describe("MemoryDump", func() {
 				request := action.GetOptions()
 				Expect(request).ToNot(BeNil())
 				Expect(request.ClaimName).To(Equal(claimName))
+				Expect(request.Format).To(Equal(format))
 				return true, nil, nil
 			default:
 				Fail("unexpected action type on memorydump")
@@ -127,6 +128,10 @@ var _ = Describe("MemoryDump", func() {
 		})
 	}
 
+	expectVMEndpointMemoryDump := func(claimName string) {
+		expectVMEndpointMemoryDumpWithFormat(claimName, "")
+	}
+
 	DescribeTable("should fail with missing required or invalid parameters", func(errorString string, args ...string) {
 		Expect(runCmd(args...)).To(MatchError(ContainSubstring(errorString)))
 	},
@@ -136,6 +141,7 @@ var _ = Describe("MemoryDump", func() {
 		Entry("memorydump wrong action arg", "invalid action type create", "create", vmName),
 		Entry("memorydump name, invalid extra parameter", "unknown flag", "testvm", setFlag(memorydump.ClaimNameFlag, pvcName), "--invalid=test"),
 		Entry("memorydump download missing outputFile", "missing outputFile", "download", "testvm", setFlag(memorydump.ClaimNameFlag, pvcName)),
+		Entry("memorydump invalid dump format", "invalid dump format kdump-gzip, supported are elf, kdump-zlib, kdump-lzo, kdump-snappy", "get", "testvm", setFlag(memorydump.DumpFormatFlag, "kdump-gzip")),
 	)
 
 	It("should call memory dump subresource", func() {
@@ -147,6 +153,16 @@ var _ = Describe("MemoryDump", func() {
 		Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "memorydump")).To(HaveLen(1))
 	})
 
+	It("should call memory dump subresource with dump format", func() {
+		expectVMEndpointMemoryDumpWithFormat(pvcName, v1.MemoryDumpFormatKdumpZlib)
+		err := runGetCmd(
+			setFlag(memorydump.ClaimNameFlag, pvcName),
+			setFlag(memorydump.DumpFormatFlag, string(v1.MemoryDumpFormatKdumpZlib)),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "memorydump")).To(HaveLen(1))
+	})
+
 	It("should call memory dump subresource without claim-name no create", func() {
 		expectVMEndpointMemoryDump("")
 		Expect(runGetCmd()).To(Succeed())
@@ -26,6 +26,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
@@ -34,12 +35,14 @@ import (
 
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 type virtCommand struct {
 	clientConfig clientcmd.ClientConfig
 	dryRun       bool
+	batchOpts    batch.Options
 }
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -51,22 +54,30 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		Short: "Pause a virtual machine",
 		Long: `Pauses a virtual machine by freezing it. Machine state is kept in memory.
 First argument is the resource type, possible types are (case insensitive, both singular and plural forms) virtualmachineinstance (vmi) or virtualmachine (vm).
-Second argument is the name of the resource.`,
-		Args:    cobra.ExactArgs(2),
+Second argument is the name of the resource, or omitted when virtual machines are selected by label.`,
+		Args:    c.batchOpts.ExactArgs(2),
 		Example: usage(),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if c.batchOpts.Enabled() {
+				return c.runBatch(cmd, args)
+			}
 			return c.Run(args)
 		},
 	}
 
 	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "--dry-run=false: Flag used to set whether to perform a dry run or not. If true the command will be executed without performing any changes.")
+	c.batchOpts.AddFlags(cmd)
 
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func usage() string {
-	return "  # Pause a virtualmachine called 'myvm':\n  {{ProgramName}} pause vm myvm"
+	return `  # Pause a virtualmachine called 'myvm':
+  {{ProgramName}} pause vm myvm
+
+  # Pause all virtualmachines labeled 'app=web', and wait for each of them:
+  {{ProgramName}} pause vm -l app=web --wait`
 }
 
 func (vc *virtCommand) Run(args []string) error {
@@ -91,6 +102,48 @@ func (vc *virtCommand) Run(args []string) error {
 	return executePauseCMD(virtClient, namespace, resourceType, resourceName, dryRunOption)
 }
 
+// runBatch pauses the VMIs of all VMs matching the selector
+func (vc *virtCommand) runBatch(cmd *cobra.Command, args []string) error {
+	if resourceType := strings.ToLower(args[0]); resourceType != "virtualmachine" && resourceType != "vm" {
+		return fmt.Errorf("only VirtualMachines can be selected by label, not %s", args[0])
+	}
+	namespace, _, err := vc.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(vc.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	var dryRunOption []string
+	if vc.dryRun {
+		fmt.Println("Dry Run execution")
+		dryRunOption = []string{v1.DryRunAll}
+		vc.batchOpts.Wait = false
+	}
+
+	return vc.batchOpts.Run(virtClient, namespace, batch.Command{Verb: "pause", Done: "paused"}, cmd.OutOrStdout(), func(vm *kubevirtV1.VirtualMachine) (batch.Condition, error) {
+		err := virtClient.VirtualMachineInstance(vm.Namespace).Pause(context.Background(), vm.Name, &kubevirtV1.PauseOptions{DryRun: dryRunOption})
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("the VMI %s is not running", vm.Name)
+		} else if err != nil {
+			return nil, err
+		}
+		return func(vmi *kubevirtV1.VirtualMachineInstance) (bool, error) {
+			if vmi == nil {
+				return false, fmt.Errorf("the VMI %s is gone", vm.Name)
+			}
+			for _, condition := range vmi.Status.Conditions {
+				if condition.Type == kubevirtV1.VirtualMachineInstancePaused && condition.Status == k8sv1.ConditionTrue {
+					return true, nil
+				}
+			}
+			return false, nil
+		}, nil
+	})
+}
+
 func executePauseCMD(client kubecli.KubevirtClient, namespace, resourceType, resourceName string, dryRunOption []string) error {
 	switch resourceType {
 	case "virtualmachine", "vm":
@@ -88,4 +88,29 @@ var _ = Describe("Pausing", func() {
 		Entry("", &v1.PauseOptions{}),
 		Entry("with dry-run option", &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}),
 	)
+	Context("with a selector", func() {
+		It("should pause the VMIs of the matching VMs", func() {
+			vm1 := kubecli.NewMinimalVM("web1")
+			vm1.Namespace = k8smetav1.NamespaceDefault
+			vm2 := kubecli.NewMinimalVM("web2")
+			vm2.Namespace = k8smetav1.NamespaceDefault
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(2)
+			vmInterface.EXPECT().List(context.Background(), k8smetav1.ListOptions{LabelSelector: "app=web"}).
+				Return(kubecli.NewVMList(*vm1, *vm2), nil).Times(1)
+			vmiInterface.EXPECT().Pause(context.Background(), vm1.Name, &v1.PauseOptions{}).Return(nil).Times(1)
+			vmiInterface.EXPECT().Pause(context.Background(), vm2.Name, &v1.PauseOptions{}).Return(nil).Times(1)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(COMMAND_PAUSE, "vm", "-l", "app=web")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VM default/web1 was scheduled to pause"))
+			Expect(string(out)).To(ContainSubstring("VM default/web2 was scheduled to pause"))
+		})
+
+		It("should refuse to select VMIs", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand(COMMAND_PAUSE, "vmi", "-l", "app=web")
+			Expect(cmd()).To(MatchError("only VirtualMachines can be selected by label, not vmi"))
+		})
+	})
 })
@@ -29,21 +29,26 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 )
 
 const (
 	forwardToStdioFlag = "stdio"
 	addressFlag        = "address"
+	vsockFlag          = "vsock"
 )
 
 var (
 	forwardToStdio bool
 	address        string = "127.0.0.1"
+	vsock          bool
 )
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -72,6 +77,8 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		fmt.Sprintf("--%s=true: Set this to true to forward the tunnel to stdout/stdin; Only works with a single port", forwardToStdioFlag))
 	cmd.Flags().StringVar(&address, addressFlag, address,
 		fmt.Sprintf("--%s=: Set this to the address the local ports should be opened on", addressFlag))
+	cmd.Flags().BoolVar(&vsock, vsockFlag, vsock,
+		fmt.Sprintf("--%s=true: Set this to true to connect to a VSOCK port of the VMI instead of a port on the pod network; Only works together with --%s; Requires get on virtualmachineinstances/vsock, which the admin and edit roles do not grant and needs an explicit Role and RoleBinding", vsockFlag, forwardToStdioFlag))
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -89,6 +96,10 @@ func (o *PortForward) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if vsock && !forwardToStdio {
+		return fmt.Errorf("--%s is only supported together with --%s", vsockFlag, forwardToStdioFlag)
+	}
+
 	if err := o.setResource(kind, namespace); err != nil {
 		return err
 	}
@@ -97,6 +108,9 @@ func (o *PortForward) Run(cmd *cobra.Command, args []string) error {
 		if len(ports) != 1 {
 			return errors.New("only one port supported when forwarding to stdout")
 		}
+		if vsock {
+			return o.startVSOCKStdoutStream(namespace, name, ports[0])
+		}
 		return o.startStdoutStream(namespace, name, ports[0])
 	}
 
@@ -161,14 +175,37 @@ func (o *PortForward) startStdoutStream(namespace, name string, port forwardedPo
 	}
 
 	log.Log.V(3).Infof("forwarding to %s/%s:%d", namespace, name, port.remote)
-	if err := streamer.Stream(kvcorev1.StreamOptions{
-		In:  os.Stdin,
-		Out: os.Stdout,
-	}); err != nil {
+	return streamToStdio(streamer)
+}
+
+// startVSOCKStdoutStream connects stdin/stdout to a VSOCK port of the VMI. The VMI of a VM
+// shares its name, so both kinds are reached the same way.
+func (o *PortForward) startVSOCKStdoutStream(namespace, name string, port forwardedPort) error {
+	if port.protocol != protocolTCP {
+		return fmt.Errorf("only %s is supported with --%s", protocolTCP, vsockFlag)
+	}
+
+	client, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
 		return err
 	}
+	streamer, err := client.VirtualMachineInstance(namespace).VSOCK(name, &v1.VSOCKOptions{
+		TargetPort: uint32(port.remote),
+		UseTLS:     pointer.P(false),
+	})
+	if err != nil {
+		return utils.VSOCKError(name, err)
+	}
 
-	return nil
+	log.Log.V(3).Infof("forwarding to VSOCK %s/%s:%d", namespace, name, port.remote)
+	return streamToStdio(streamer)
+}
+
+func streamToStdio(streamer kvcorev1.StreamInterface) error {
+	return streamer.Stream(kvcorev1.StreamOptions{
+		In:  os.Stdin,
+		Out: os.Stdout,
+	})
 }
 
 func (o *PortForward) startPortForwards(kind, namespace, name string, ports []forwardedPort) error {
@@ -234,5 +271,8 @@ func examples() string {
   ssh -o 'ProxyCommand={{ProgramName}} port-forward --stdio=true testvmi.mynamespace 22' user@testvmi.mynamespace
 
   # Use as SCP ProxyCommand:
-  scp -o 'ProxyCommand={{ProgramName}} port-forward --stdio=true testvmi.mynamespace 22' local.file user@testvmi.mynamespace`
+  scp -o 'ProxyCommand={{ProgramName}} port-forward --stdio=true testvmi.mynamespace 22' local.file user@testvmi.mynamespace
+
+  # Open an SSH connection to an sshd listening on VSOCK port 22 in the guest:
+  ssh -o 'ProxyCommand={{ProgramName}} port-forward --stdio=true --vsock=true testvmi.mynamespace 22' user@testvmi.mynamespace`
 }
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package portforward
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+type fakeStream struct{}
+
+func (fakeStream) Stream(kvcorev1.StreamOptions) error { return nil }
+func (fakeStream) AsConn() net.Conn                    { return nil }
+
+var _ = Describe("PortForward", func() {
+	Context("over VSOCK", func() {
+		var (
+			vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+			portForward  PortForward
+		)
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+			kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance("default").Return(vmiInterface).AnyTimes()
+			portForward = PortForward{}
+		})
+
+		It("should stream the VSOCK port of the VMI without TLS", func() {
+			vmiInterface.EXPECT().VSOCK("testvmi", &v1.VSOCKOptions{TargetPort: 22, UseTLS: pointer.P(false)}).Return(fakeStream{}, nil)
+			Expect(portForward.startVSOCKStdoutStream("default", "testvmi", forwardedPort{local: 22, remote: 22, protocol: protocolTCP})).To(Succeed())
+		})
+
+		It("should fail when the VSOCK subresource is not available", func() {
+			vmiInterface.EXPECT().VSOCK("testvmi", gomock.Any()).Return(nil, errors.New("VSOCK is not attached"))
+			Expect(portForward.startVSOCKStdoutStream("default", "testvmi", forwardedPort{local: 22, remote: 22, protocol: protocolTCP})).
+				To(MatchError("can't access VSOCK of VMI testvmi, it requires the VSOCK feature gate and autoattachVSOCK: VSOCK is not attached"))
+		})
+
+		It("should explain how to grant access to the VSOCK subresource when it is forbidden", func() {
+			vmiInterface.EXPECT().VSOCK("testvmi", gomock.Any()).Return(nil, &kvcorev1.AsyncSubresourceError{StatusCode: http.StatusForbidden})
+			err := portForward.startVSOCKStdoutStream("default", "testvmi", forwardedPort{local: 22, remote: 22, protocol: protocolTCP})
+			Expect(err).To(MatchError(ContainSubstring("access to VSOCK of VMI testvmi is forbidden, grant get on virtualmachineinstances/vsock " +
+				"in the subresources.kubevirt.io API group with a Role and RoleBinding")))
+			var asyncErr *kvcorev1.AsyncSubresourceError
+			Expect(errors.As(err, &asyncErr)).To(BeTrue())
+		})
+
+		It("should refuse UDP", func() {
+			Expect(portForward.startVSOCKStdoutStream("default", "testvmi", forwardedPort{local: 22, remote: 22, protocol: protocolUDP})).
+				To(MatchError("only tcp is supported with --vsock"))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package virtctl
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -26,14 +27,17 @@ import (
 	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
 	"kubevirt.io/kubevirt/pkg/virtctl/scp"
 	"kubevirt.io/kubevirt/pkg/virtctl/softreboot"
+	"kubevirt.io/kubevirt/pkg/virtctl/spice"
 	"kubevirt.io/kubevirt/pkg/virtctl/ssh"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 	"kubevirt.io/kubevirt/pkg/virtctl/unpause"
 	"kubevirt.io/kubevirt/pkg/virtctl/usbredir"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 	"kubevirt.io/kubevirt/pkg/virtctl/version"
 	"kubevirt.io/kubevirt/pkg/virtctl/vm"
 	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 	"kubevirt.io/kubevirt/pkg/virtctl/vnc"
+	"kubevirt.io/kubevirt/pkg/virtctl/wait"
 )
 
 var programName string
@@ -85,6 +89,7 @@ func NewVirtctlCommand() (*cobra.Command, clientcmd.ClientConfig) {
 		console.NewCommand(clientConfig),
 		usbredir.NewCommand(clientConfig),
 		vnc.NewCommand(clientConfig),
+		spice.NewCommand(clientConfig),
 		scp.NewCommand(clientConfig),
 		ssh.NewCommand(clientConfig),
 		portforward.NewCommand(clientConfig),
@@ -99,6 +104,7 @@ func NewVirtctlCommand() (*cobra.Command, clientcmd.ClientConfig) {
 		vm.NewAddVolumeCommand(clientConfig),
 		vm.NewRemoveVolumeCommand(clientConfig),
 		vm.NewExpandCommand(clientConfig),
+		vm.NewCommand(clientConfig),
 		memorydump.NewMemoryDumpCommand(clientConfig),
 		pause.NewCommand(clientConfig),
 		unpause.NewCommand(clientConfig),
@@ -111,6 +117,7 @@ func NewVirtctlCommand() (*cobra.Command, clientcmd.ClientConfig) {
 		create.NewCommand(clientConfig),
 		credentials.NewCommand(clientConfig),
 		adm.NewCommand(clientConfig),
+		wait.NewCommand(clientConfig),
 		optionsCmd,
 	)
 	return rootCmd, clientConfig
@@ -135,6 +142,10 @@ func Execute() {
 	if err := cmd.Execute(); err != nil {
 		version.CheckClientServerVersion(&clientConfig, cmd)
 		fmt.Fprintln(cmd.Root().ErrOrStderr(), strings.TrimSpace(err.Error()))
+		var exitErr *utils.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -72,6 +72,14 @@ func (o *SCP) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if o.options.EphemeralKey {
+		cleanup, err := ssh.SetupEphemeralKey(o.clientConfig, remote.Kind, remote.Namespace, remote.Name, &o.options)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
 	if o.options.WrapLocalSSH {
 		clientArgs := o.buildSCPTarget(local, remote, toRemote)
 		return ssh.RunLocalClient(remote.Kind, remote.Namespace, remote.Name, &o.options, clientArgs)
@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package spice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	listenTimeout = 60 * time.Second
+
+	remoteViewer = "remote-viewer"
+)
+
+type Spice struct {
+	clientConfig  clientcmd.ClientConfig
+	listenAddress string
+	proxyOnly     bool
+	customPort    int
+}
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	log.InitializeLogging("spice")
+	c := Spice{
+		clientConfig:  clientConfig,
+		listenAddress: "127.0.0.1",
+	}
+	cmd := &cobra.Command{
+		Use:     "spice (VMI)",
+		Short:   "Open a SPICE connection to a virtual machine instance.",
+		Example: usage(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.Run,
+	}
+	cmd.Flags().StringVar(&c.listenAddress, "address", c.listenAddress, "--address=127.0.0.1: Setting this will change the listening address of the SPICE proxy. Only used together with --proxy-only.")
+	cmd.Flags().BoolVar(&c.proxyOnly, "proxy-only", c.proxyOnly, "--proxy-only=false: Setting this true will run only the virtctl SPICE proxy and show the port where SPICE clients can connect")
+	cmd.Flags().IntVar(&c.customPort, "port", c.customPort,
+		"--port=0: Assigning a port value to this will try to run the proxy on the given port if the port is accessible; If unassigned, the proxy will run on a random port")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func (c *Spice) Run(cmd *cobra.Command, args []string) error {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	vmi := args[0]
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return err
+	}
+	vmiClient := virtCli.VirtualMachineInstance(namespace)
+
+	// Open the stream of the first channel upfront to fail early if the VMI can not be reached
+	firstStream, err := vmiClient.Spice(vmi)
+	if err != nil {
+		return fmt.Errorf("Can't access VMI %s: %s", vmi, err.Error())
+	}
+
+	listenAddress := c.listenAddress
+	if !c.proxyOnly {
+		listenAddress = "127.0.0.1"
+	}
+	lnAddr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(listenAddress, fmt.Sprint(c.customPort)))
+	if err != nil {
+		return fmt.Errorf("Can't resolve the address: %s", err.Error())
+	}
+	ln, err := net.ListenTCP("tcp", lnAddr)
+	if err != nil {
+		return fmt.Errorf("Can't listen on %s: %s", lnAddr, err.Error())
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	viewResChan := make(chan error, 1)
+	if c.proxyOnly {
+		optionString, err := json.Marshal(struct {
+			Port int `json:"port"`
+		}{port})
+		if err != nil {
+			return fmt.Errorf("Error encountered: %s", err.Error())
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(optionString))
+	} else {
+		// exit early if spawning the SPICE client fails
+		if err := ln.SetDeadline(time.Now().Add(listenTimeout)); err != nil {
+			return err
+		}
+		go runRemoteViewer(viewResChan, port)
+	}
+
+	listenResChan := make(chan error, 1)
+	go func() {
+		// A SPICE client opens one connection per channel, each one is forwarded through its own stream
+		for first := true; ; first = false {
+			fd, err := ln.Accept()
+			if err != nil {
+				listenResChan <- err
+				return
+			}
+			stream := firstStream
+			if first {
+				if err := ln.SetDeadline(time.Time{}); err != nil {
+					listenResChan <- err
+					return
+				}
+				templates.PrintWarningForPausedVMI(virtCli, vmi, namespace)
+			} else if stream, err = vmiClient.Spice(vmi); err != nil {
+				log.Log.Reason(err).Errorf("Can't open a SPICE channel to VMI %s", vmi)
+				fd.Close()
+				continue
+			}
+			go forward(fd, stream)
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	select {
+	case <-interrupt:
+	case err = <-viewResChan:
+	case err = <-listenResChan:
+	}
+	if err != nil {
+		return fmt.Errorf("Error encountered: %s", err.Error())
+	}
+	return nil
+}
+
+// forward copies data between a local SPICE channel connection and its stream to the VMI
+func forward(local net.Conn, stream kvcorev1.StreamInterface) {
+	remote := stream.AsConn()
+	errs := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(remote, local)
+		errs <- err
+	}()
+	go func() {
+		_, err := io.Copy(local, remote)
+		errs <- err
+	}()
+
+	if err := <-errs; err != nil {
+		log.Log.V(2).Infof("SPICE channel closed: %v", err)
+	}
+	local.Close()
+	remote.Close()
+}
+
+func runRemoteViewer(viewResChan chan error, port int) {
+	if _, err := exec.LookPath(remoteViewer); err != nil {
+		viewResChan <- fmt.Errorf("could not find %s binary in $PATH", remoteViewer)
+		return
+	}
+
+	args := remoteViewerArgs(port)
+	log.Log.V(4).Infof("Executing commandline: '%s %v'", remoteViewer, args)
+	// #nosec No risk for attacker injection. args include predefined strings
+	output, err := exec.Command(remoteViewer, args...).CombinedOutput()
+	if err != nil {
+		log.Log.Errorf("%s execution failed: %v, output: %v", remoteViewer, err, string(output))
+	} else {
+		log.Log.V(2).Infof("%v output: %v", remoteViewer, string(output))
+	}
+	viewResChan <- err
+}
+
+func remoteViewerArgs(port int) (args []string) {
+	args = append(args, fmt.Sprintf("spice://127.0.0.1:%d", port))
+	if log.Log.Verbosity(4) {
+		args = append(args, "--debug")
+	}
+	return
+}
+
+func usage() string {
+	return `  # Connect to 'testvmi' via remote-viewer:
+  {{ProgramName}} spice testvmi
+
+  # Only run the proxy and connect a SPICE client manually to the printed port:
+  {{ProgramName}} spice testvmi --proxy-only`
+}
@@ -0,0 +1,11 @@
+package spice_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestSpice(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
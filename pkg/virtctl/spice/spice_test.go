@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package spice_test
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+type fakeStream struct{}
+
+func (fakeStream) Stream(kvcorev1.StreamOptions) error { return nil }
+func (fakeStream) AsConn() net.Conn                    { return nil }
+
+var _ = Describe("SPICE", func() {
+	const (
+		commandSpice = "spice"
+		vmiName      = "testvmi"
+	)
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	It("should fail without a VMI name", func() {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(commandSpice)
+		Expect(cmd()).To(HaveOccurred())
+	})
+
+	It("should fail early when the SPICE subresource rejects the request", func() {
+		vmiInterface.EXPECT().Spice(vmiName).Return(nil, errors.NewBadRequest("'Spice' feature gate is not enabled"))
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand(commandSpice, vmiName)
+		Expect(cmd()).To(MatchError("Can't access VMI testvmi: 'Spice' feature gate is not enabled"))
+	})
+
+	It("should fail when the proxy port is not available", func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer ln.Close()
+		port := ln.Addr().(*net.TCPAddr).Port
+
+		vmiInterface.EXPECT().Spice(vmiName).Return(fakeStream{}, nil)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand(commandSpice, vmiName, "--proxy-only", "--port", fmt.Sprint(port))
+		Expect(cmd()).To(MatchError(ContainSubstring(fmt.Sprintf("Can't listen on 127.0.0.1:%d", port))))
+	})
+})
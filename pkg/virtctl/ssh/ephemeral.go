@@ -0,0 +1,311 @@
+//go:build !excludenative
+
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/virtctl/credentials/common"
+)
+
+const (
+	ephemeralKeyPrefix       = "virtctl-ephemeral-"
+	ephemeralKeyFileName     = "id_ed25519"
+	ephemeralKeyTimeout      = 2 * time.Minute
+	ephemeralKeyPollInterval = 2 * time.Second
+	// ephemeralKeyLifetime is how long a key is kept in the secret when virtctl could not remove it on exit
+	ephemeralKeyLifetime = time.Hour
+	// ephemeralKeyExpiryAnnotationPrefix is followed by the data key of an ephemeral key, the value is its expiry
+	ephemeralKeyExpiryAnnotationPrefix = "virtctl.kubevirt.io/"
+)
+
+// ephemeralKey is a short-lived SSH key, propagated to the guest through a secret of the
+// qemu-guest-agent access credentials of the VMI
+type ephemeralKey struct {
+	virtClient kubecli.KubevirtClient
+	namespace  string
+	secretName string
+	dataKey    string
+
+	signer  ssh.Signer
+	keyDir  string
+	keyFile string
+}
+
+// SetupEphemeralKey generates a short-lived key, adds it to the secret the VMI propagates the SSH keys
+// of the user from, and waits until the guest accepts it. The options are changed to authenticate
+// with this key only. The returned function removes the key again, it is also run on interrupt.
+// Keys which could not be removed, e.g. because virtctl was killed, are removed by the next
+// SetupEphemeralKey on the same secret once they expired.
+func SetupEphemeralKey(clientConfig clientcmd.ClientConfig, kind, namespace, name string, opts *SSHOptions) (func(), error) {
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't access VMI %s: %w", name, err)
+	}
+
+	key, err := injectEphemeralKey(virtClient, vmi, opts.SSHUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session may switch the terminal to raw mode, which os.Exit would leave behind
+	restoreTerminal := func() {}
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		if state, err := term.GetState(fd); err == nil {
+			restoreTerminal = func() { term.Restore(fd, state) }
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	var once sync.Once
+	cleanup := func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			if err := key.remove(); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove the ephemeral key from secret %s: %v\n", key.secretName, err)
+			}
+		})
+	}
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		if _, received := <-signals; received {
+			cleanup()
+			restoreTerminal()
+			os.Exit(1)
+		}
+	}()
+
+	opts.IdentityFilePath = key.keyFile
+	opts.IdentityFilePathProvided = true
+	conn := NativeSSHConnection{ClientConfig: clientConfig, Options: *opts}
+
+	fmt.Fprintln(os.Stderr, "Waiting for the guest agent to propagate the ephemeral key")
+	err = wait.PollUntilContextTimeout(context.Background(), ephemeralKeyPollInterval, ephemeralKeyTimeout, true, func(_ context.Context) (bool, error) {
+		return conn.acceptsKey(kind, namespace, name, key.signer)
+	})
+	if err != nil {
+		cleanup()
+		if wait.Interrupted(err) {
+			return nil, fmt.Errorf("the guest did not accept the ephemeral key within %s, is the qemu-guest-agent running?", ephemeralKeyTimeout)
+		}
+		return nil, err
+	}
+	return cleanup, nil
+}
+
+// injectEphemeralKey generates a key, writes its private part to a temporary file and adds its
+// public part to the first secret propagating the SSH keys of the user to the VMI. The expired
+// keys left behind in the secret are removed at the same time.
+func injectEphemeralKey(virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, user string) (*ephemeralKey, error) {
+	secrets := common.GetSSHSecretsForUser(vmi.Spec.AccessCredentials, user)
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("VMI %s does not propagate SSH keys of user %s through the qemu-guest-agent, "+
+			"add them with 'credentials add-ssh-key --create-secret' and restart the VM", vmi.Name, user)
+	}
+
+	key := &ephemeralKey{
+		virtClient: virtClient,
+		namespace:  vmi.Namespace,
+		secretName: secrets[0],
+		dataKey:    common.RandomWithPrefix(ephemeralKeyPrefix),
+	}
+	authorizedKey, err := key.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := virtClient.CoreV1().Secrets(key.namespace).Get(context.Background(), key.secretName, metav1.GetOptions{})
+	if err != nil {
+		key.removeFile()
+		return nil, fmt.Errorf("error getting secret %s: %w", key.secretName, err)
+	}
+	patchSet := patch.New(removeExpiredKeys(secret, time.Now())...)
+	if secret.Data == nil {
+		patchSet.AddOption(patch.WithTest("/data", nil), patch.WithAdd("/data", map[string][]byte{}))
+	}
+	if secret.Annotations == nil {
+		patchSet.AddOption(patch.WithTest("/metadata/annotations", nil), patch.WithAdd("/metadata/annotations", map[string]string{}))
+	}
+	patchSet.AddOption(
+		patch.WithAdd("/data/"+key.dataKey, authorizedKey),
+		patch.WithAdd(expiryAnnotationPath(key.dataKey), time.Now().Add(ephemeralKeyLifetime).UTC().Format(time.RFC3339)),
+	)
+	if err := key.patchSecret(patchSet); err != nil {
+		key.removeFile()
+		return nil, err
+	}
+	return key, nil
+}
+
+// generate creates the key and writes it to a new temporary directory. It returns the public key
+// in the authorized_keys format.
+func (k *ephemeralKey) generate() ([]byte, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	k.signer, err = ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, k.dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k.keyDir, err = os.MkdirTemp("", ephemeralKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	k.keyFile = filepath.Join(k.keyDir, ephemeralKeyFileName)
+	if err := os.WriteFile(k.keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		k.removeFile()
+		return nil, err
+	}
+
+	// The comment makes the key recognizable in the authorized_keys of the guest
+	authorizedKey := ssh.MarshalAuthorizedKey(sshPublicKey)
+	return append(authorizedKey[:len(authorizedKey)-1], []byte(" "+k.dataKey+"\n")...), nil
+}
+
+// removeExpiredKeys returns the patch options removing the ephemeral keys of a secret which expired
+func removeExpiredKeys(secret *k8sv1.Secret, now time.Time) []patch.PatchOption {
+	var options []patch.PatchOption
+	for annotation, expiry := range secret.Annotations {
+		dataKey, found := strings.CutPrefix(annotation, ephemeralKeyExpiryAnnotationPrefix)
+		if !found || !strings.HasPrefix(dataKey, ephemeralKeyPrefix) {
+			continue
+		}
+		if expiresAt, err := time.Parse(time.RFC3339, expiry); err != nil || expiresAt.After(now) {
+			continue
+		}
+		options = append(options, patch.WithRemove(expiryAnnotationPath(dataKey)))
+		if _, exists := secret.Data[dataKey]; exists {
+			options = append(options, patch.WithRemove("/data/"+dataKey))
+		}
+	}
+	return options
+}
+
+func expiryAnnotationPath(dataKey string) string {
+	return "/metadata/annotations/" + patch.EscapeJSONPointer(ephemeralKeyExpiryAnnotationPrefix+dataKey)
+}
+
+// remove deletes the key and its expiry from the secret and its private part from the disk.
+// Either may already be gone when the key expired in the meantime.
+func (k *ephemeralKey) remove() error {
+	defer k.removeFile()
+	secret, err := k.virtClient.CoreV1().Secrets(k.namespace).Get(context.Background(), k.secretName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	patchSet := patch.New()
+	if _, exists := secret.Data[k.dataKey]; exists {
+		patchSet.AddOption(patch.WithRemove("/data/" + k.dataKey))
+	}
+	if _, exists := secret.Annotations[ephemeralKeyExpiryAnnotationPrefix+k.dataKey]; exists {
+		patchSet.AddOption(patch.WithRemove(expiryAnnotationPath(k.dataKey)))
+	}
+	if patchSet.IsEmpty() {
+		return nil
+	}
+	err = k.patchSecret(patchSet)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (k *ephemeralKey) removeFile() {
+	if k.keyDir == "" {
+		return
+	}
+	if err := os.RemoveAll(k.keyDir); err != nil {
+		log.Log.Reason(err).Errorf("failed to remove the ephemeral key at %s", k.keyDir)
+	}
+}
+
+func (k *ephemeralKey) patchSecret(patchSet *patch.PatchSet) error {
+	payload, err := patchSet.GeneratePayload()
+	if err != nil {
+		return err
+	}
+	_, err = k.virtClient.CoreV1().Secrets(k.namespace).Patch(context.Background(), k.secretName, types.JSONPatchType, payload, metav1.PatchOptions{})
+	return err
+}
+
+// acceptsKey returns whether the guest accepts the signer of the user. The host key is not verified,
+// only the one of the connection opened afterwards is.
+func (o *NativeSSHConnection) acceptsKey(kind, namespace, name string, signer ssh.Signer) (bool, error) {
+	stream, err := o.prepareSSHTunnel(kind, namespace, name)
+	if err != nil {
+		return false, err
+	}
+	addr := fmt.Sprintf("%s/%s.%s:%d", kind, name, namespace, o.Options.SSHPort)
+	sshConn, chans, reqs, err := ssh.NewClientConn(stream.AsConn(), addr, &ssh.ClientConfig{
+		// #nosec G106 -- the connection only checks whether the key is accepted
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		User:            o.Options.SSHUsername,
+	})
+	if err != nil {
+		log.Log.V(3).Infof("ephemeral key not accepted yet: %v", err)
+		return false, nil
+	}
+	return true, ssh.NewClient(sshConn, chans, reqs).Close()
+}
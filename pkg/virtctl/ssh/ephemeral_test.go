@@ -0,0 +1,206 @@
+//go:build !excludenative
+
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package ssh
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Ephemeral key", func() {
+	const (
+		user       = "jdoe"
+		secretName = "my-keys"
+	)
+
+	var (
+		coreClient *k8sfake.Clientset
+		virtClient *kubecli.MockKubevirtClient
+	)
+
+	BeforeEach(func() {
+		coreClient = k8sfake.NewSimpleClientset()
+		virtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().CoreV1().Return(coreClient.CoreV1()).AnyTimes()
+	})
+
+	newVMI := func(users ...string) *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: metav1.NamespaceDefault},
+			Spec: v1.VirtualMachineInstanceSpec{
+				AccessCredentials: []v1.AccessCredential{{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: v1.SSHPublicKeyAccessCredentialSource{
+							Secret: &v1.AccessCredentialSecretSource{SecretName: secretName},
+						},
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: users},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	createSecret := func(data map[string][]byte) {
+		_, err := coreClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: metav1.NamespaceDefault},
+			Data:       data,
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getSecret := func() *k8sv1.Secret {
+		secret, err := coreClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(context.Background(), secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return secret
+	}
+
+	getSecretData := func() map[string][]byte {
+		return getSecret().Data
+	}
+
+	It("should fail when the keys of the user are not propagated through the guest agent", func() {
+		_, err := injectEphemeralKey(virtClient, newVMI("other"), user)
+		Expect(err).To(MatchError(ContainSubstring("does not propagate SSH keys of user jdoe")))
+	})
+
+	DescribeTable("should be added to the secret of the user and removed again", func(data map[string][]byte) {
+		createSecret(data)
+
+		key, err := injectEphemeralKey(virtClient, newVMI(user), user)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("adding the public key next to the existing keys")
+		secretData := getSecretData()
+		Expect(secretData).To(HaveLen(len(data) + 1))
+		Expect(secretData).To(HaveKey(key.dataKey))
+		Expect(strings.HasPrefix(key.dataKey, ephemeralKeyPrefix)).To(BeTrue())
+		publicKey, comment, _, _, err := ssh.ParseAuthorizedKey(secretData[key.dataKey])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comment).To(Equal(key.dataKey))
+		Expect(publicKey.Marshal()).To(Equal(key.signer.PublicKey().Marshal()))
+
+		By("recording when the key expires")
+		expiry, err := time.Parse(time.RFC3339, getSecret().Annotations[ephemeralKeyExpiryAnnotationPrefix+key.dataKey])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expiry).To(BeTemporally("~", time.Now().Add(ephemeralKeyLifetime), time.Minute))
+
+		By("writing the private key only readable by the user")
+		info, err := os.Stat(key.keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		privateKey, err := os.ReadFile(key.keyFile)
+		Expect(err).ToNot(HaveOccurred())
+		signer, err := ssh.ParsePrivateKey(privateKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signer.PublicKey().Marshal()).To(Equal(publicKey.Marshal()))
+
+		By("removing the key again")
+		Expect(key.remove()).To(Succeed())
+		Expect(getSecretData()).ToNot(HaveKey(key.dataKey))
+		Expect(getSecretData()).To(HaveLen(len(data)))
+		Expect(getSecret().Annotations).To(BeEmpty())
+		Expect(key.keyDir).ToNot(BeADirectory())
+	},
+		Entry("with existing keys", map[string][]byte{"key1": []byte("ssh-ed25519 AAAA jdoe@host")}),
+		Entry("without data", nil),
+	)
+
+	It("should remove the private key when the secret is gone", func() {
+		createSecret(nil)
+		key, err := injectEphemeralKey(virtClient, newVMI(user), user)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(coreClient.CoreV1().Secrets(metav1.NamespaceDefault).Delete(context.Background(), secretName, metav1.DeleteOptions{})).To(Succeed())
+		Expect(key.remove()).To(Succeed())
+		Expect(key.keyDir).ToNot(BeADirectory())
+	})
+
+	It("should remove the expired keys left behind when adding a key", func() {
+		const (
+			expiredKey = ephemeralKeyPrefix + "expired"
+			activeKey  = ephemeralKeyPrefix + "active"
+		)
+		_, err := coreClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: metav1.NamespaceDefault,
+				Annotations: map[string]string{
+					ephemeralKeyExpiryAnnotationPrefix + expiredKey: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+					ephemeralKeyExpiryAnnotationPrefix + activeKey:  time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+				},
+			},
+			Data: map[string][]byte{
+				"key1":     []byte("ssh-ed25519 AAAA jdoe@host"),
+				expiredKey: []byte("ssh-ed25519 BBBB " + expiredKey),
+				activeKey:  []byte("ssh-ed25519 CCCC " + activeKey),
+			},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		key, err := injectEphemeralKey(virtClient, newVMI(user), user)
+		Expect(err).ToNot(HaveOccurred())
+		defer key.remove()
+
+		secret := getSecret()
+		Expect(secret.Data).To(HaveKey("key1"))
+		Expect(secret.Data).To(HaveKey(activeKey))
+		Expect(secret.Data).To(HaveKey(key.dataKey))
+		Expect(secret.Data).ToNot(HaveKey(expiredKey))
+		Expect(secret.Annotations).To(HaveKey(ephemeralKeyExpiryAnnotationPrefix + activeKey))
+		Expect(secret.Annotations).ToNot(HaveKey(ephemeralKeyExpiryAnnotationPrefix + expiredKey))
+	})
+
+	It("should succeed to remove a key which expired in the meantime", func() {
+		createSecret(nil)
+		key, err := injectEphemeralKey(virtClient, newVMI(user), user)
+		Expect(err).ToNot(HaveOccurred())
+
+		secret := getSecret()
+		delete(secret.Data, key.dataKey)
+		delete(secret.Annotations, ephemeralKeyExpiryAnnotationPrefix+key.dataKey)
+		_, err = coreClient.CoreV1().Secrets(metav1.NamespaceDefault).Update(context.Background(), secret, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(key.remove()).To(Succeed())
+		Expect(key.keyDir).ToNot(BeADirectory())
+	})
+
+	It("should only authenticate with the ephemeral key", func() {
+		conn := NativeSSHConnection{Options: SSHOptions{EphemeralKey: true, IdentityFilePath: "/nonexistent", IdentityFilePathProvided: true}}
+		Expect(conn.getAuthMethods("vmi", metav1.NamespaceDefault, "testvmi")).To(HaveLen(1))
+	})
+})
@@ -16,8 +16,12 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 )
 
 const (
@@ -94,6 +98,11 @@ func (o *NativeSSHConnection) PrepareSSHClient(kind, namespace, name string) (*s
 func (o *NativeSSHConnection) getAuthMethods(kind, namespace, name string) []ssh.AuthMethod {
 	var methods []ssh.AuthMethod
 
+	// An ephemeral key is the only way to authenticate
+	if o.Options.EphemeralKey {
+		return o.tryPrivateKey(methods)
+	}
+
 	methods = o.trySSHAgent(methods)
 	methods = o.tryPrivateKey(methods)
 
@@ -211,7 +220,16 @@ func (o *NativeSSHConnection) prepareSSHTunnel(kind, namespace, name string) (kv
 	}
 
 	var stream kvcorev1.StreamInterface
-	if kind == "vmi" {
+	if o.Options.VSOCK {
+		// The VMI of a VM shares its name, both are reached through the VSOCK subresource of the VMI
+		stream, err = virtCli.VirtualMachineInstance(namespace).VSOCK(name, &v1.VSOCKOptions{
+			TargetPort: uint32(o.Options.SSHPort),
+			UseTLS:     pointer.P(false),
+		})
+		if err != nil {
+			return nil, utils.VSOCKError(name, err)
+		}
+	} else if kind == "vmi" {
 		stream, err = virtCli.VirtualMachineInstance(namespace).PortForward(name, o.Options.SSHPort, "tcp")
 		if err != nil {
 			return nil, fmt.Errorf("can't access VMI %s: %w", name, err)
@@ -0,0 +1,79 @@
+//go:build !excludenative
+
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package ssh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+var _ = Describe("Native SSH", func() {
+	var (
+		vmInterface  *kubecli.MockVirtualMachineInterface
+		vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		conn         NativeSSHConnection
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine("default").Return(vmInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance("default").Return(vmiInterface).AnyTimes()
+		conn = NativeSSHConnection{Options: SSHOptions{SSHPort: 2222}}
+	})
+
+	It("should tunnel through the port forward of the VM", func() {
+		vmInterface.EXPECT().PortForward("testvm", 2222, "tcp").Return(nil, errors.New("no port forward"))
+		_, err := conn.prepareSSHTunnel("vm", "default", "testvm")
+		Expect(err).To(MatchError("can't access VM testvm: no port forward"))
+	})
+
+	DescribeTable("should tunnel through the VSOCK of the VMI", func(kind string) {
+		conn.Options.VSOCK = true
+		vmiInterface.EXPECT().VSOCK("testvm", &v1.VSOCKOptions{TargetPort: 2222, UseTLS: pointer.P(false)}).Return(nil, errors.New("no vsock"))
+		_, err := conn.prepareSSHTunnel(kind, "default", "testvm")
+		Expect(err).To(MatchError("can't access VSOCK of VMI testvm, it requires the VSOCK feature gate and autoattachVSOCK: no vsock"))
+	},
+		Entry("with a VM", "vm"),
+		Entry("with a VMI", "vmi"),
+	)
+
+	It("should explain how to grant access to the VSOCK of the VMI when it is forbidden", func() {
+		conn.Options.VSOCK = true
+		vmiInterface.EXPECT().VSOCK("testvm", gomock.Any()).Return(nil, &kvcorev1.AsyncSubresourceError{StatusCode: http.StatusForbidden})
+		_, err := conn.prepareSSHTunnel("vmi", "default", "testvm")
+		Expect(err).To(MatchError(ContainSubstring("access to VSOCK of VMI testvm is forbidden, grant get on virtualmachineinstances/vsock")))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 const (
@@ -21,6 +22,10 @@ func addAdditionalCommandlineArgs(flagset *pflag.FlagSet, opts *SSHOptions) {
 		fmt.Sprintf(`--%s="-o StrictHostKeyChecking=no" : Additional options to be passed to the local ssh`, additionalOpts))
 }
 
+func SetupEphemeralKey(_ clientcmd.ClientConfig, _, _, _ string, _ *SSHOptions) (func(), error) {
+	return nil, fmt.Errorf("--%s is unsupported in this build", ephemeralKeyFlag)
+}
+
 func (o *SSH) nativeSSH(_, _, _ string) error {
 	panic("Native SSH is unsupported in this build!")
 }
@@ -42,6 +42,8 @@ const (
 	knownHostsFilePathFlag                          = "known-hosts"
 	commandToExecute, commandToExecuteShort         = "command", "c"
 	additionalOpts, additionalOptsShort             = "local-ssh-opts", "t"
+	ephemeralKeyFlag                                = "ephemeral-key"
+	vsockFlag                                       = "vsock"
 )
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -77,6 +79,10 @@ func AddCommandlineArgs(flagset *pflag.FlagSet, opts *SSHOptions) {
 		fmt.Sprintf("--%s=/home/jdoe/.ssh/kubevirt_known_hosts: Set the path to the known_hosts file.", knownHostsFilePathFlag))
 	flagset.IntVarP(&opts.SSHPort, portFlag, portFlagShort, opts.SSHPort,
 		fmt.Sprintf(`--%s=22: Specify a port on the VM to send SSH traffic to`, portFlag))
+	flagset.BoolVar(&opts.EphemeralKey, ephemeralKeyFlag, opts.EphemeralKey,
+		fmt.Sprintf("--%s=true: Authenticate with a short-lived key, which is propagated to the guest through the qemu-guest-agent access credentials of the user and removed again on exit. Keys left behind are removed by a later use once they are older than an hour", ephemeralKeyFlag))
+	flagset.BoolVar(&opts.VSOCK, vsockFlag, opts.VSOCK,
+		fmt.Sprintf("--%s=true: Connect to an sshd listening on VSOCK in the guest instead of going through the pod network; Requires the VSOCK feature gate, autoattachVSOCK on the VMI and get on virtualmachineinstances/vsock, which the admin and edit roles do not grant and needs an explicit Role and RoleBinding", vsockFlag))
 
 	addAdditionalCommandlineArgs(flagset, opts)
 }
@@ -120,6 +126,8 @@ type SSHOptions struct {
 	AdditionalSSHLocalOptions []string
 	WrapLocalSSH              bool
 	LocalClientName           string
+	EphemeralKey              bool
+	VSOCK                     bool
 }
 
 func (o *SSH) Run(cmd *cobra.Command, args []string) error {
@@ -128,6 +136,14 @@ func (o *SSH) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if o.options.EphemeralKey {
+		cleanup, err := SetupEphemeralKey(o.clientConfig, kind, namespace, name, &o.options)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
 	if o.options.WrapLocalSSH {
 		clientArgs := o.buildSSHTarget(kind, namespace, name)
 		return RunLocalClient(kind, namespace, name, &o.options, clientArgs)
@@ -165,10 +181,18 @@ func usage() string {
   {{ProgramName}} ssh jdoe@vm/testvm.mynamespace [--%s]
 
   # Specify a username and namespace:
-  {{ProgramName}} ssh --namespace=mynamespace --%s=jdoe testvmi`,
+  {{ProgramName}} ssh --namespace=mynamespace --%s=jdoe testvmi
+
+  # Connect to 'testvm' with a key only valid for this connection:
+  {{ProgramName}} ssh jdoe@vm/testvm --%s
+
+  # Connect to an sshd listening on VSOCK in 'testvm', which needs no guest networking:
+  {{ProgramName}} ssh jdoe@vm/testvm --%s`,
 		IdentityFilePathFlag,
 		IdentityFilePathFlag,
 		usernameFlag,
+		ephemeralKeyFlag,
+		vsockFlag,
 	) + additionalUsage()
 }
 
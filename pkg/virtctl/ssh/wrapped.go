@@ -16,7 +16,7 @@ var runCommand = func(cmd *exec.Cmd) error {
 
 func RunLocalClient(kind, namespace, name string, options *SSHOptions, clientArgs []string) error {
 	args := []string{"-o"}
-	args = append(args, buildProxyCommandOption(kind, namespace, name, options.SSHPort))
+	args = append(args, buildProxyCommandOption(kind, namespace, name, options.SSHPort, options.VSOCK))
 
 	if len(options.AdditionalSSHLocalOptions) > 0 {
 		args = append(args, options.AdditionalSSHLocalOptions...)
@@ -24,6 +24,9 @@ func RunLocalClient(kind, namespace, name string, options *SSHOptions, clientArg
 	if options.IdentityFilePathProvided {
 		args = append(args, "-i", options.IdentityFilePath)
 	}
+	if options.EphemeralKey {
+		args = append(args, "-o", "IdentitiesOnly=yes")
+	}
 
 	args = append(args, clientArgs...)
 
@@ -36,11 +39,14 @@ func RunLocalClient(kind, namespace, name string, options *SSHOptions, clientArg
 	return runCommand(cmd)
 }
 
-func buildProxyCommandOption(kind, namespace, name string, port int) string {
+func buildProxyCommandOption(kind, namespace, name string, port int, vsock bool) string {
 	proxyCommand := strings.Builder{}
 	proxyCommand.WriteString("ProxyCommand=")
 	proxyCommand.WriteString(os.Args[0])
 	proxyCommand.WriteString(" port-forward --stdio=true ")
+	if vsock {
+		proxyCommand.WriteString("--vsock=true ")
+	}
 	proxyCommand.WriteString(fmt.Sprintf("%s/%s.%s", kind, name, namespace))
 	proxyCommand.WriteString(" ")
 
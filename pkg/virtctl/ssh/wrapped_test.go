@@ -38,17 +38,24 @@ var _ = Describe("Wrapped SSH", func() {
 
 	It("buildProxyCommandOption", func() {
 		const sshPort = 12345
-		proxyCommand := buildProxyCommandOption(fakeKind, fakeNamespace, fakeName, sshPort)
+		proxyCommand := buildProxyCommandOption(fakeKind, fakeNamespace, fakeName, sshPort, false)
 		expected := fmt.Sprintf("port-forward --stdio=true fake-kind/fake-name.fake-ns %d", sshPort)
 		Expect(proxyCommand).To(ContainSubstring(expected))
 	})
 
+	It("buildProxyCommandOption with VSOCK", func() {
+		const sshPort = 12345
+		proxyCommand := buildProxyCommandOption(fakeKind, fakeNamespace, fakeName, sshPort, true)
+		expected := fmt.Sprintf("port-forward --stdio=true --vsock=true fake-kind/fake-name.fake-ns %d", sshPort)
+		Expect(proxyCommand).To(ContainSubstring(expected))
+	})
+
 	It("RunLocalClient", func() {
 		runCommand = func(cmd *exec.Cmd) error {
 			Expect(cmd).ToNot(BeNil())
 			Expect(cmd.Args).To(HaveLen(4))
 			Expect(cmd.Args[0]).To(Equal("ssh"))
-			Expect(cmd.Args[2]).To(Equal(buildProxyCommandOption(fakeKind, fakeNamespace, fakeName, ssh.options.SSHPort)))
+			Expect(cmd.Args[2]).To(Equal(buildProxyCommandOption(fakeKind, fakeNamespace, fakeName, ssh.options.SSHPort, ssh.options.VSOCK)))
 			Expect(cmd.Args[3]).To(Equal(ssh.buildSSHTarget(fakeKind, fakeNamespace, fakeName)[0]))
 
 			return nil
@@ -60,4 +67,17 @@ var _ = Describe("Wrapped SSH", func() {
 		err := RunLocalClient(fakeKind, fakeNamespace, fakeName, &ssh.options, clientArgs)
 		Expect(err).ShouldNot(HaveOccurred())
 	})
+	It("RunLocalClient with an ephemeral key", func() {
+		runCommand = func(cmd *exec.Cmd) error {
+			Expect(cmd.Args).To(ContainElements("-i", "/tmp/key", "IdentitiesOnly=yes"))
+			return nil
+		}
+
+		ssh.options = DefaultSSHOptions()
+		ssh.options.EphemeralKey = true
+		ssh.options.IdentityFilePath = "/tmp/key"
+		ssh.options.IdentityFilePathProvided = true
+		clientArgs := ssh.buildSSHTarget(fakeKind, fakeNamespace, fakeName)
+		Expect(RunLocalClient(fakeKind, fakeNamespace, fakeName, &ssh.options, clientArgs)).To(Succeed())
+	})
 })
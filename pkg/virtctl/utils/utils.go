@@ -1,14 +1,43 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/signal"
 
 	"golang.org/x/term"
+
+	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 )
 
+// ExitError is returned when a command wants virtctl to exit with a specific code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// VSOCKError explains a failed connection to the VSOCK subresource of a VMI. The subresource is not
+// part of the aggregated admin and edit roles, so access has to be granted explicitly.
+func VSOCKError(name string, err error) error {
+	var asyncErr *kvcorev1.AsyncSubresourceError
+	if errors.As(err, &asyncErr) && asyncErr.GetStatusCode() == http.StatusForbidden {
+		return fmt.Errorf("access to VSOCK of VMI %s is forbidden, grant get on virtualmachineinstances/vsock "+
+			"in the subresources.kubevirt.io API group with a Role and RoleBinding: %w", name, err)
+	}
+	return fmt.Errorf("can't access VSOCK of VMI %s, it requires the VSOCK feature gate and autoattachVSOCK: %w", name, err)
+}
+
 // AttachConsole attaches stdin and stdout to the console
 // in -> stdinWriter | stdinReader -> console
 // out <- stdoutReader | stdoutWriter <- console
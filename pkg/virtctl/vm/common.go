@@ -23,10 +23,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 )
 
 const (
@@ -54,9 +58,15 @@ var (
 type Command struct {
 	clientConfig clientcmd.ClientConfig
 	command      string
+	dryRunOption []string
 }
 
 func usage(cmd string) string {
+	if cmd == COMMAND_START || cmd == COMMAND_STOP || cmd == COMMAND_RESTART || cmd == COMMAND_MIGRATE {
+		return fmt.Sprintf("  # %s a virtual machine called 'myvm':\n  {{ProgramName}} %s myvm\n\n"+
+			"  # %s all virtual machines labeled 'app=web' in all namespaces, and wait for each of them:\n  {{ProgramName}} %s -l app=web -A --wait",
+			strings.Title(cmd), cmd, strings.Title(cmd), cmd)
+	}
 	if cmd == COMMAND_USERLIST || cmd == COMMAND_FSLIST || cmd == COMMAND_GUESTOSINFO {
 		return fmt.Sprintf("  # %s a virtual machine instance called 'myvm':\n  {{ProgramName}} %s myvm", strings.Title(cmd), cmd)
 	}
@@ -86,3 +96,19 @@ func setDryRunOption(dryRun bool) []string {
 
 	return nil
 }
+
+// runBatch runs the action on every VM selected by the batch options
+func (o *Command) runBatch(cmd *cobra.Command, batchOpts *batch.Options, done string, action func(kubecli.KubevirtClient, *v1.VirtualMachine) (batch.Condition, error)) error {
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+	o.dryRunOption = setDryRunOption(dryRun)
+	// Nothing changes in a dry run, so there is nothing to wait for
+	if dryRun {
+		batchOpts.Wait = false
+	}
+	return batchOpts.Run(virtClient, namespace, batch.Command{Verb: o.command, Done: done}, cmd.OutOrStdout(), func(vm *v1.VirtualMachine) (batch.Condition, error) {
+		return action(virtClient, vm)
+	})
+}
@@ -24,26 +24,36 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const COMMAND_MIGRATE = "migrate"
 
 func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	batchOpts := &batch.Options{}
 	cmd := &cobra.Command{
 		Use:     "migrate (VM)",
 		Short:   "Migrate a virtual machine.",
 		Example: usage(COMMAND_MIGRATE),
-		Args:    cobra.ExactArgs(1),
+		Args:    batchOpts.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_MIGRATE, clientConfig: clientConfig}
+			if batchOpts.Enabled() {
+				return c.runBatch(cmd, batchOpts, "migrated", c.migrateVM)
+			}
 			return c.migrateRun(args)
 		},
 	}
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	batchOpts.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -67,3 +77,32 @@ func (o *Command) migrateRun(args []string) error {
 
 	return nil
 }
+
+// migrateVM migrates a selected VM, which is migrated once a new migration of its VMI completed
+func (o *Command) migrateVM(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) (batch.Condition, error) {
+	var oldMigrationUID types.UID
+	vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(context.Background(), vm.Name, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && vmi.Status.MigrationState != nil {
+		oldMigrationUID = vmi.Status.MigrationState.MigrationUID
+	}
+
+	if err := virtClient.VirtualMachine(vm.Namespace).Migrate(context.Background(), vm.Name, &v1.MigrateOptions{DryRun: o.dryRunOption}); err != nil {
+		return nil, err
+	}
+	return func(vmi *v1.VirtualMachineInstance) (bool, error) {
+		if vmi == nil {
+			return false, fmt.Errorf("the VMI is gone")
+		}
+		state := vmi.Status.MigrationState
+		if state == nil || state.MigrationUID == oldMigrationUID {
+			return false, nil
+		}
+		if state.Failed {
+			return false, fmt.Errorf("the migration failed")
+		}
+		return state.Completed, nil
+	}, nil
+}
@@ -24,28 +24,41 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const COMMAND_RESTART = "restart"
 
 func NewRestartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	batchOpts := &batch.Options{}
 	cmd := &cobra.Command{
 		Use:     "restart (VM)",
 		Short:   "Restart a virtual machine.",
 		Example: usage(COMMAND_RESTART),
-		Args:    cobra.ExactArgs(1),
+		Args:    batchOpts.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_RESTART, clientConfig: clientConfig}
+			if batchOpts.Enabled() {
+				if cmd.Flags().Changed(gracePeriodArg) != forceRestart {
+					return fmt.Errorf("Must both use --force=true and set --grace-period.")
+				}
+				return c.runBatch(cmd, batchOpts, "restarted", c.restartVM)
+			}
 			return c.restartRun(args, cmd)
 		},
 	}
 	cmd.Flags().BoolVar(&forceRestart, forceArg, false, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
 	cmd.Flags().Int64Var(&gracePeriod, gracePeriodArg, -1, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	batchOpts.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -81,3 +94,25 @@ func (o *Command) restartRun(args []string, cmd *cobra.Command) error {
 
 	return nil
 }
+
+// restartVM restarts a selected VM, which is restarted once a new VMI is running
+func (o *Command) restartVM(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) (batch.Condition, error) {
+	var oldUID types.UID
+	vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(context.Background(), vm.Name, metav1.GetOptions{})
+	if err == nil {
+		oldUID = vmi.UID
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	restartOpts := &v1.RestartOptions{DryRun: o.dryRunOption}
+	if forceRestart {
+		restartOpts.GracePeriodSeconds = &gracePeriod
+	}
+	if err := virtClient.VirtualMachine(vm.Namespace).Restart(context.Background(), vm.Name, restartOpts); err != nil {
+		return nil, err
+	}
+	return func(vmi *v1.VirtualMachineInstance) (bool, error) {
+		return vmi != nil && vmi.UID != oldUID && vmi.Status.Phase == v1.Running, nil
+	}, nil
+}
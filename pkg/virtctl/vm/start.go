@@ -26,7 +26,9 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -40,18 +42,23 @@ var (
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	batchOpts := &batch.Options{}
 	cmd := &cobra.Command{
 		Use:     "start (VM)",
 		Short:   "Start a virtual machine.",
 		Example: usage(COMMAND_START),
-		Args:    cobra.ExactArgs(1),
+		Args:    batchOpts.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_START, clientConfig: clientConfig}
+			if batchOpts.Enabled() {
+				return c.runBatch(cmd, batchOpts, "started", c.startVM)
+			}
 			return c.startRun(args)
 		},
 	}
 	cmd.Flags().BoolVar(&startPaused, pausedArg, false, "--paused=false: If set to true, start virtual machine in paused state")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	batchOpts.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -75,3 +82,17 @@ func (o *Command) startRun(args []string) error {
 
 	return nil
 }
+
+// startVM starts a selected VM, which is started once its VMI is running
+func (o *Command) startVM(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) (batch.Condition, error) {
+	err := virtClient.VirtualMachine(vm.Namespace).Start(context.Background(), vm.Name, &v1.StartOptions{Paused: startPaused, DryRun: o.dryRunOption})
+	if err != nil {
+		return nil, err
+	}
+	return func(vmi *v1.VirtualMachineInstance) (bool, error) {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return false, nil
+		}
+		return !startPaused || hasVMICondition(vmi, v1.VirtualMachineInstancePaused), nil
+	}, nil
+}
@@ -26,20 +26,29 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/batch"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const COMMAND_STOP = "stop"
 
 func NewStopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	batchOpts := &batch.Options{}
 	cmd := &cobra.Command{
 		Use:     "stop (VM)",
 		Short:   "Stop a virtual machine.",
 		Example: usage(COMMAND_STOP),
-		Args:    cobra.ExactArgs(1),
+		Args:    batchOpts.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_STOP, clientConfig: clientConfig}
+			if batchOpts.Enabled() {
+				if cmd.Flags().Changed(gracePeriodArg) != forceRestart {
+					return fmt.Errorf("Must both use --force=true and set --grace-period.")
+				}
+				return c.runBatch(cmd, batchOpts, "stopped", c.stopVM)
+			}
 			return c.stopRun(args, cmd)
 		},
 	}
@@ -47,6 +56,7 @@ func NewStopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.Flags().BoolVar(&forceRestart, forceArg, false, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
 	cmd.Flags().Int64Var(&gracePeriod, gracePeriodArg, -1, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	batchOpts.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -82,3 +92,17 @@ func (o *Command) stopRun(args []string, cmd *cobra.Command) error {
 
 	return nil
 }
+
+// stopVM stops a selected VM, which is stopped once its VMI is gone
+func (o *Command) stopVM(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) (batch.Condition, error) {
+	stopOpts := &v1.StopOptions{DryRun: o.dryRunOption}
+	if forceRestart {
+		stopOpts.GracePeriod = &gracePeriod
+	}
+	if err := virtClient.VirtualMachine(vm.Namespace).Stop(context.Background(), vm.Name, stopOpts); err != nil {
+		return nil, err
+	}
+	return func(vmi *v1.VirtualMachineInstance) (bool, error) {
+		return vmi == nil, nil
+	}, nil
+}
@@ -21,14 +21,18 @@ package vm_test
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/tests/clientcmd"
@@ -112,4 +116,46 @@ var _ = Describe("Stop command", func() {
 			},
 			"stop", vmName),
 	)
+	Context("with a selector", func() {
+		It("should stop the matching VMs", func() {
+			vm1 := kubecli.NewMinimalVM("web1")
+			vm1.Namespace = k8smetav1.NamespaceDefault
+			vm2 := kubecli.NewMinimalVM("web2")
+			vm2.Namespace = k8smetav1.NamespaceDefault
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(3)
+			vmInterface.EXPECT().List(context.Background(), k8smetav1.ListOptions{LabelSelector: "app=web"}).
+				Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*vm1, *vm2}}, nil).Times(1)
+			vmInterface.EXPECT().Stop(context.Background(), vm1.Name, &v1.StopOptions{}).Return(nil).Times(1)
+			vmInterface.EXPECT().Stop(context.Background(), vm2.Name, &v1.StopOptions{}).Return(fmt.Errorf("boom")).Times(1)
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("stop", "-l", "app=web", "--concurrency=1")()
+			Expect(err).To(MatchError("failed to stop 1 of 2 VMs"))
+			Expect(string(out)).To(ContainSubstring("[1/2] VM default/web1 was scheduled to stop"))
+			Expect(string(out)).To(ContainSubstring("[2/2] VM default/web2 failed to stop: boom"))
+		})
+
+		It("should wait for the matching VMs to be stopped", func() {
+			vm := kubecli.NewMinimalVM("web1")
+			vm.Namespace = k8smetav1.NamespaceDefault
+			vm.Labels = map[string]string{"app": "web"}
+			virtClient := kubevirtfake.NewSimpleClientset(vm)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachines(k8smetav1.NamespaceDefault)).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)).AnyTimes()
+			virtClient.PrependReactor("put", "virtualmachines/stop", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("stop", "-l", "app=web", "--wait", "--grace-period=0", "--force")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("[1/1] VM default/web1 was stopped\n"))
+		})
+
+		It("should refuse a VM name", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand("stop", vmName, "-l", "app=web")
+			Expect(cmd()).To(MatchError("accepts 0 arg(s) with --selector, received 1"))
+		})
+	})
 })
@@ -0,0 +1,407 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	cpuSocketsArg = "cpu-sockets"
+	memoryArg     = "memory"
+	addDiskArg    = "add-disk"
+	removeDiskArg = "remove-disk"
+	timeoutArg    = "timeout"
+
+	defaultDiskBus = v1.DiskBusSCSI
+	pollInterval   = time.Second
+)
+
+type diskParams struct {
+	Name   string `param:"name"`
+	Source string `param:"src"`
+	Bus    string `param:"bus"`
+	Serial string `param:"serial"`
+}
+
+type update struct {
+	clientConfig clientcmd.ClientConfig
+	cmd          *cobra.Command
+
+	cpuSockets  uint32
+	memory      string
+	addDisks    []string
+	removeDisks []string
+	timeout     time.Duration
+
+	// the names of the disks added to or removed from the VM
+	addedDisks   []string
+	removedDisks []string
+}
+
+// NewCommand returns the command grouping operations on a virtual machine
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vm",
+		Short: "Operate on a virtual machine.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Print(cmd.UsageString())
+		},
+	}
+
+	cmd.AddCommand(
+		NewUpdateCommand(clientConfig),
+	)
+
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewUpdateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	u := update{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   "update (VM)",
+		Short: "Update the CPU sockets, memory or disks of a virtual machine, live if possible.",
+		Long: `Update the CPU sockets, memory or disks of a virtual machine, live if possible.
+
+The changes are applied to the VM spec. If the VM is running, the command waits for them
+to be propagated to the running instance, and reports whether a restart is needed to apply them.
+Live updates require the LiveUpdate VM rollout strategy, and CPU sockets and memory can only be
+increased up to the maximum set by the VM or derived from the LiveUpdateConfiguration of the cluster.`,
+		Example: usageUpdate(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    u.run,
+	}
+
+	cmd.Flags().Uint32Var(&u.cpuSockets, cpuSocketsArg, 0, "The number of CPU sockets of the VM.")
+	cmd.Flags().StringVar(&u.memory, memoryArg, "", "The amount of guest memory of the VM.")
+	cmd.Flags().StringArrayVar(&u.addDisks, addDiskArg, nil, fmt.Sprintf("Add a disk backed by a DataVolume or PersistentVolumeClaim to the VM. Can be provided multiple times.\nSupported parameters: %s", params.Supported(diskParams{})))
+	cmd.Flags().StringArrayVar(&u.removeDisks, removeDiskArg, nil, "Remove the disk with the given name and its volume from the VM. Can be provided multiple times.")
+	cmd.Flags().DurationVar(&u.timeout, timeoutArg, 2*time.Minute, "How long to wait for the changes to be applied to the running VM. Zero does not wait.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageUpdate() string {
+	return `  # Increase the CPU sockets and memory of the VM 'myvm':
+  {{ProgramName}} vm update myvm --cpu-sockets=4 --memory=8Gi
+
+  # Hotplug the PersistentVolumeClaim 'mypvc' as disk 'data' and remove the disk 'scratch':
+  {{ProgramName}} vm update myvm --add-disk=name:data,src:mypvc --remove-disk=scratch
+
+  # Update the VM without waiting for the changes to be applied:
+  {{ProgramName}} vm update myvm --memory=4Gi --timeout=0`
+}
+
+func (u *update) run(cmd *cobra.Command, args []string) error {
+	u.cmd = cmd
+	vmName := args[0]
+
+	if !cmd.Flags().Changed(cpuSocketsArg) && !cmd.Flags().Changed(memoryArg) && len(u.addDisks) == 0 && len(u.removeDisks) == 0 {
+		return fmt.Errorf("at least one of --%s, --%s, --%s or --%s must be specified", cpuSocketsArg, memoryArg, addDiskArg, removeDiskArg)
+	}
+
+	virtClient, namespace, err := GetNamespaceAndClient(u.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(context.Background(), vmName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VM %s: %v", vmName, err)
+	}
+
+	desired, err := u.desiredVM(virtClient, vm)
+	if err != nil {
+		return err
+	}
+
+	patchBytes, err := updatePatch(vm, desired)
+	if err != nil {
+		return err
+	}
+	vm, err = virtClient.VirtualMachine(namespace).Patch(context.Background(), vmName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating VM %s: %v", vmName, err)
+	}
+	cmd.Printf("Successfully updated VM %s\n", vmName)
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(context.Background(), vmName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		cmd.Printf("VM %s is not running, the changes apply on its next start\n", vmName)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting VMI %s: %v", vmName, err)
+	}
+
+	if reasons := exceededLiveUpdateLimits(vmi, desired); len(reasons) > 0 {
+		for _, reason := range reasons {
+			cmd.Printf("%s, a restart is required to apply it\n", reason)
+		}
+	}
+
+	if u.timeout == 0 {
+		return nil
+	}
+	return u.waitForLiveUpdate(virtClient, vm, desired)
+}
+
+// desiredVM returns a copy of the VM with the requested changes applied
+func (u *update) desiredVM(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+	if vm.Spec.Template == nil {
+		return nil, fmt.Errorf("VM %s has no template", vm.Name)
+	}
+	desired := vm.DeepCopy()
+	domain := &desired.Spec.Template.Spec.Domain
+
+	if u.cmd.Flags().Changed(cpuSocketsArg) || u.cmd.Flags().Changed(memoryArg) {
+		if vm.Spec.Instancetype != nil {
+			return nil, fmt.Errorf("the CPU and memory of VM %s are defined by its instancetype", vm.Name)
+		}
+	}
+
+	if u.cmd.Flags().Changed(cpuSocketsArg) {
+		if u.cpuSockets == 0 {
+			return nil, fmt.Errorf("--%s must be greater than 0", cpuSocketsArg)
+		}
+		if domain.CPU == nil {
+			domain.CPU = &v1.CPU{}
+		}
+		domain.CPU.Sockets = u.cpuSockets
+	}
+
+	if u.cmd.Flags().Changed(memoryArg) {
+		memory, err := resource.ParseQuantity(u.memory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %v", memoryArg, err)
+		}
+		if memory.Sign() <= 0 {
+			return nil, fmt.Errorf("--%s must be greater than 0", memoryArg)
+		}
+		if domain.Memory == nil {
+			domain.Memory = &v1.Memory{}
+		}
+		domain.Memory.Guest = &memory
+	}
+
+	for _, name := range u.removeDisks {
+		volumes := desired.Spec.Template.Spec.Volumes
+		index := slices.IndexFunc(volumes, func(volume v1.Volume) bool { return volume.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("VM %s has no volume named %s", vm.Name, name)
+		}
+		desired.Spec.Template.Spec.Volumes = slices.Delete(volumes, index, index+1)
+		domain.Devices.Disks = slices.DeleteFunc(domain.Devices.Disks, func(disk v1.Disk) bool { return disk.Name == name })
+		u.removedDisks = append(u.removedDisks, name)
+	}
+
+	for _, addDisk := range u.addDisks {
+		disk := diskParams{}
+		if err := params.Map(addDiskArg, addDisk, &disk); err != nil {
+			return nil, err
+		}
+		if disk.Source == "" {
+			return nil, params.FlagErr(addDiskArg, "src must be specified")
+		}
+		if disk.Name == "" {
+			disk.Name = disk.Source
+		}
+		if disk.Bus == "" {
+			disk.Bus = string(defaultDiskBus)
+		}
+		if disk.Serial == "" {
+			disk.Serial = disk.Name
+		}
+		if slices.ContainsFunc(desired.Spec.Template.Spec.Volumes, func(volume v1.Volume) bool { return volume.Name == disk.Name }) {
+			return nil, params.FlagErr(addDiskArg, "VM %s already has a volume named %s", vm.Name, disk.Name)
+		}
+
+		hotplugSource, err := getVolumeSourceFromVolume(disk.Source, vm.Namespace, virtClient)
+		if err != nil {
+			return nil, params.FlagErr(addDiskArg, "%v", err)
+		}
+		desired.Spec.Template.Spec.Volumes = append(desired.Spec.Template.Spec.Volumes, v1.Volume{
+			Name: disk.Name,
+			VolumeSource: v1.VolumeSource{
+				DataVolume:            hotplugSource.DataVolume,
+				PersistentVolumeClaim: hotplugSource.PersistentVolumeClaim,
+			},
+		})
+		domain.Devices.Disks = append(domain.Devices.Disks, v1.Disk{
+			Name:   disk.Name,
+			Serial: disk.Serial,
+			DiskDevice: v1.DiskDevice{
+				Disk: &v1.DiskTarget{Bus: v1.DiskBus(disk.Bus)},
+			},
+		})
+		u.addedDisks = append(u.addedDisks, disk.Name)
+	}
+
+	return desired, nil
+}
+
+// updatePatch returns the JSON patch turning the VM into the desired one. Every changed
+// field is tested first, so the patch fails if the VM was changed in the meantime.
+func updatePatch(vm, desired *v1.VirtualMachine) ([]byte, error) {
+	const templateSpec = "/spec/template/spec"
+	patchSet := patch.New()
+
+	addOrReplace := func(path string, old, new any, oldIsSet bool) {
+		if oldIsSet {
+			patchSet.AddOption(patch.WithTest(path, old), patch.WithReplace(path, new))
+		} else {
+			patchSet.AddOption(patch.WithAdd(path, new))
+		}
+	}
+
+	oldDomain, newDomain := vm.Spec.Template.Spec.Domain, desired.Spec.Template.Spec.Domain
+	if !equality.Semantic.DeepEqual(oldDomain.CPU, newDomain.CPU) {
+		addOrReplace(templateSpec+"/domain/cpu", oldDomain.CPU, newDomain.CPU, oldDomain.CPU != nil)
+	}
+	if !equality.Semantic.DeepEqual(oldDomain.Memory, newDomain.Memory) {
+		addOrReplace(templateSpec+"/domain/memory", oldDomain.Memory, newDomain.Memory, oldDomain.Memory != nil)
+	}
+	if !equality.Semantic.DeepEqual(vm.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes) {
+		addOrReplace(templateSpec+"/volumes", vm.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes, vm.Spec.Template.Spec.Volumes != nil)
+	}
+	if !equality.Semantic.DeepEqual(oldDomain.Devices.Disks, newDomain.Devices.Disks) {
+		addOrReplace(templateSpec+"/domain/devices/disks", oldDomain.Devices.Disks, newDomain.Devices.Disks, oldDomain.Devices.Disks != nil)
+	}
+
+	if patchSet.IsEmpty() {
+		return nil, fmt.Errorf("VM %s already matches the requested changes", vm.Name)
+	}
+	return patchSet.GeneratePayload()
+}
+
+// exceededLiveUpdateLimits returns why the desired CPU sockets or memory can not be hotplugged
+// into the running VMI, whose maximums were derived from the LiveUpdateConfiguration when it started
+func exceededLiveUpdateLimits(vmi *v1.VirtualMachineInstance, desired *v1.VirtualMachine) []string {
+	var reasons []string
+	domain := desired.Spec.Template.Spec.Domain
+	if domain.CPU != nil && vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.MaxSockets > 0 &&
+		domain.CPU.Sockets > vmi.Spec.Domain.CPU.MaxSockets {
+		reasons = append(reasons, fmt.Sprintf("%d CPU sockets exceed the maximum of %d", domain.CPU.Sockets, vmi.Spec.Domain.CPU.MaxSockets))
+	}
+	if domain.Memory != nil && domain.Memory.Guest != nil && vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil &&
+		domain.Memory.Guest.Cmp(*vmi.Spec.Domain.Memory.MaxGuest) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%s of memory exceed the maximum of %s", domain.Memory.Guest.String(), vmi.Spec.Domain.Memory.MaxGuest.String()))
+	}
+	return reasons
+}
+
+// waitForLiveUpdate waits until the changes are applied to the running VMI, or until the VM
+// reports that a restart is required to apply them. The status of the VM is only trusted once
+// the controller synced the generation of the patched VM, so a RestartRequired condition
+// which predates the patch is not reported.
+func (u *update) waitForLiveUpdate(virtClient kubecli.KubevirtClient, vm, desired *v1.VirtualMachine) error {
+	u.cmd.Printf("Waiting for the changes to be applied to the running VM %s\n", vm.Name)
+
+	patchedGeneration := vm.Generation
+	var restartRequired bool
+	err := wait.PollUntilContextTimeout(context.Background(), pollInterval, u.timeout, true, func(ctx context.Context) (bool, error) {
+		vm, err := virtClient.VirtualMachine(vm.Namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if vm.Status.DesiredGeneration < patchedGeneration {
+			return false, nil
+		}
+		if hasVMCondition(vm, v1.VirtualMachineRestartRequired) {
+			restartRequired = true
+			return true, nil
+		}
+		vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return u.isLiveUpdated(vmi, desired), nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out waiting for the changes to be applied to the running VM %s", vm.Name)
+	} else if err != nil {
+		return err
+	}
+
+	if restartRequired {
+		u.cmd.Printf("VM %s needs to be restarted to apply all changes\n", vm.Name)
+	} else {
+		u.cmd.Printf("The changes were applied to the running VM %s, no restart is required\n", vm.Name)
+	}
+	return nil
+}
+
+// isLiveUpdated returns whether the running VMI matches the desired CPU sockets, memory and disks
+func (u *update) isLiveUpdated(vmi *v1.VirtualMachineInstance, desired *v1.VirtualMachine) bool {
+	domain := desired.Spec.Template.Spec.Domain
+	if domain.CPU != nil && (vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.Sockets != domain.CPU.Sockets ||
+		hasVMICondition(vmi, v1.VirtualMachineInstanceVCPUChange)) {
+		return false
+	}
+	if domain.Memory != nil && domain.Memory.Guest != nil && (vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Guest == nil ||
+		!vmi.Spec.Domain.Memory.Guest.Equal(*domain.Memory.Guest) || hasVMICondition(vmi, v1.VirtualMachineInstanceMemoryChange)) {
+		return false
+	}
+
+	for _, name := range u.removedDisks {
+		if slices.ContainsFunc(vmi.Spec.Volumes, func(volume v1.Volume) bool { return volume.Name == name }) {
+			return false
+		}
+	}
+	for _, name := range u.addedDisks {
+		if !slices.ContainsFunc(vmi.Status.VolumeStatus, func(status v1.VolumeStatus) bool {
+			return status.Name == name && status.Phase == v1.VolumeReady
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasVMCondition(vm *v1.VirtualMachine, conditionType v1.VirtualMachineConditionType) bool {
+	return slices.ContainsFunc(vm.Status.Conditions, func(condition v1.VirtualMachineCondition) bool {
+		return condition.Type == conditionType && condition.Status == k8sv1.ConditionTrue
+	})
+}
+
+func hasVMICondition(vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType) bool {
+	return slices.ContainsFunc(vmi.Status.Conditions, func(condition v1.VirtualMachineInstanceCondition) bool {
+		return condition.Type == conditionType && condition.Status == k8sv1.ConditionTrue
+	})
+}
@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("VM update command", func() {
+	const vmName = "testvm"
+
+	var virtClient *kubevirtfake.Clientset
+	var coreClient *k8sfake.Clientset
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient = kubevirtfake.NewSimpleClientset()
+		coreClient = k8sfake.NewSimpleClientset()
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdifake.NewSimpleClientset()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1()).AnyTimes()
+	})
+
+	newVM := func() *v1.VirtualMachine {
+		guest := resource.MustParse("1Gi")
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							CPU:    &v1.CPU{Sockets: 1},
+							Memory: &v1.Memory{Guest: &guest},
+							Devices: v1.Devices{
+								Disks: []v1.Disk{{Name: "scratch"}},
+							},
+						},
+						Volumes: []v1.Volume{{
+							Name: "scratch",
+							VolumeSource: v1.VolumeSource{
+								EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")},
+							},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	createVM := func(vm *v1.VirtualMachine) {
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createVMI := func(vmi *v1.VirtualMachineInstance) {
+		vmi.Name = vmName
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getVM := func() *v1.VirtualMachine {
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), vmName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vm
+	}
+
+	DescribeTable("should fail", func(expected string, args ...string) {
+		createVM(newVM())
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{"vm", "update", vmName}, args...)...)
+		Expect(cmd()).To(MatchError(ContainSubstring(expected)))
+	},
+		Entry("without any change", "at least one of"),
+		Entry("with zero CPU sockets", "--cpu-sockets must be greater than 0", "--cpu-sockets=0"),
+		Entry("with invalid memory", "invalid --memory", "--memory=lots"),
+		Entry("with unchanged values", "already matches", "--cpu-sockets=1", "--memory=1Gi"),
+		Entry("when removing an unknown disk", "has no volume named missing", "--remove-disk=missing"),
+		Entry("when adding a disk without source", "src must be specified", "--add-disk=name:data"),
+		Entry("when adding an existing disk", "already has a volume named scratch", "--add-disk=name:scratch,src:mypvc"),
+		Entry("when adding a disk without a DataVolume or PVC", "is not a DataVolume or PersistentVolumeClaim", "--add-disk=src:missing"),
+	)
+
+	It("should refuse to change the CPU of a VM with an instancetype", func() {
+		vm := newVM()
+		vm.Spec.Instancetype = &v1.InstancetypeMatcher{Name: "u1.small"}
+		createVM(vm)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand("vm", "update", vmName, "--cpu-sockets=2")
+		Expect(cmd()).To(MatchError(ContainSubstring("defined by its instancetype")))
+	})
+
+	It("should update the CPU and memory of a stopped VM", func() {
+		createVM(newVM())
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "update", vmName, "--cpu-sockets=4", "--memory=8Gi")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("is not running, the changes apply on its next start"))
+
+		domain := getVM().Spec.Template.Spec.Domain
+		Expect(domain.CPU.Sockets).To(Equal(uint32(4)))
+		Expect(domain.Memory.Guest.String()).To(Equal("8Gi"))
+	})
+
+	It("should add and remove disks of a stopped VM", func() {
+		createVM(newVM())
+		_, err := coreClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand("vm", "update", vmName, "--add-disk=name:data,src:mypvc", "--remove-disk=scratch")
+		Expect(cmd()).To(Succeed())
+
+		spec := getVM().Spec.Template.Spec
+		Expect(spec.Volumes).To(HaveLen(1))
+		Expect(spec.Volumes[0].Name).To(Equal("data"))
+		Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("mypvc"))
+		Expect(spec.Volumes[0].PersistentVolumeClaim.Hotpluggable).To(BeTrue())
+		Expect(spec.Domain.Devices.Disks).To(ConsistOf(v1.Disk{
+			Name:   "data",
+			Serial: "data",
+			DiskDevice: v1.DiskDevice{
+				Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI},
+			},
+		}))
+	})
+
+	Context("with a running VM", func() {
+		It("should report that the changes were applied live", func() {
+			createVM(newVM())
+			guest := resource.MustParse("2Gi")
+			createVMI(&v1.VirtualMachineInstance{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU:    &v1.CPU{Sockets: 2},
+						Memory: &v1.Memory{Guest: &guest},
+					},
+				},
+			})
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "update", vmName, "--cpu-sockets=2", "--memory=2Gi")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("no restart is required"))
+		})
+
+		It("should report that a restart is required", func() {
+			vm := newVM()
+			vm.Generation = 2
+			vm.Status.DesiredGeneration = 2
+			vm.Status.Conditions = []v1.VirtualMachineCondition{{
+				Type:   v1.VirtualMachineRestartRequired,
+				Status: k8sv1.ConditionTrue,
+			}}
+			createVM(vm)
+			createVMI(&v1.VirtualMachineInstance{})
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "update", vmName, "--cpu-sockets=2")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("needs to be restarted to apply all changes"))
+		})
+
+		It("should ignore a restart required condition until the controller synced the patched generation", func() {
+			vm := newVM()
+			vm.Generation = 2
+			vm.Status.DesiredGeneration = 1
+			vm.Status.Conditions = []v1.VirtualMachineCondition{{
+				Type:   v1.VirtualMachineRestartRequired,
+				Status: k8sv1.ConditionTrue,
+			}}
+			createVM(vm)
+			createVMI(&v1.VirtualMachineInstance{})
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand("vm", "update", vmName, "--cpu-sockets=2", "--timeout=1ms")
+			Expect(cmd()).To(MatchError(ContainSubstring("timed out waiting for the changes")))
+		})
+
+		It("should report exceeded maximums without waiting", func() {
+			createVM(newVM())
+			maxGuest := resource.MustParse("4Gi")
+			createVMI(&v1.VirtualMachineInstance{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU:    &v1.CPU{Sockets: 1, MaxSockets: 4},
+						Memory: &v1.Memory{MaxGuest: &maxGuest},
+					},
+				},
+			})
+
+			out, err := clientcmd.NewRepeatableVirtctlCommandWithOut("vm", "update", vmName, "--cpu-sockets=8", "--memory=8Gi", "--timeout=0")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("8 CPU sockets exceed the maximum of 4, a restart is required to apply it"))
+			Expect(string(out)).To(ContainSubstring("8Gi of memory exceed the maximum of 4Gi, a restart is required to apply it"))
+			Expect(string(out)).ToNot(ContainSubstring("Waiting"))
+		})
+
+		It("should time out when the changes are not applied", func() {
+			createVM(newVM())
+			createVMI(&v1.VirtualMachineInstance{})
+
+			cmd := clientcmd.NewRepeatableVirtctlCommand("vm", "update", vmName, "--cpu-sockets=2", "--timeout=1ms")
+			Expect(cmd()).To(MatchError(ContainSubstring("timed out waiting for the changes")))
+		})
+	})
+})
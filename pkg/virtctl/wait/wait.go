@@ -0,0 +1,295 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	v1 "kubevirt.io/api/core/v1"
+	exportv1 "kubevirt.io/api/export/v1beta1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
+)
+
+const (
+	COMMAND_WAIT = "wait"
+
+	timeoutArg     = "timeout"
+	defaultTimeout = 5 * time.Minute
+)
+
+// Exit codes of virtctl when waiting did not succeed
+const (
+	ExitTimeout     = 2
+	ExitUnreachable = 3
+)
+
+type lister func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error)
+type watcher func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error)
+
+// condition describes how to watch a kind of object and when it met the condition.
+// The check returns an error when the condition can no longer be met.
+type condition struct {
+	description string
+	objType     runtime.Object
+	list        lister
+	watch       watcher
+	check       func(obj runtime.Object) (bool, error)
+}
+
+var conditions = map[string]condition{
+	"vm-ready": {
+		description: "the VirtualMachine is ready",
+		objType:     &v1.VirtualMachine{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachine(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachine(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			return obj.(*v1.VirtualMachine).Status.Ready, nil
+		},
+	},
+	"agent-connected": {
+		description: "the guest agent of the VirtualMachineInstance is connected",
+		objType:     &v1.VirtualMachineInstance{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachineInstance(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachineInstance(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			vmi := obj.(*v1.VirtualMachineInstance)
+			if vmi.IsFinal() {
+				return false, fmt.Errorf("VirtualMachineInstance %s is %s", vmi.Name, vmi.Status.Phase)
+			}
+			return slices.ContainsFunc(vmi.Status.Conditions, func(c v1.VirtualMachineInstanceCondition) bool {
+				return c.Type == v1.VirtualMachineInstanceAgentConnected && c.Status == k8sv1.ConditionTrue
+			}), nil
+		},
+	},
+	"migration-succeeded": {
+		description: "the VirtualMachineInstanceMigration succeeded",
+		objType:     &v1.VirtualMachineInstanceMigration{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachineInstanceMigration(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachineInstanceMigration(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			migration := obj.(*v1.VirtualMachineInstanceMigration)
+			if migration.Status.Phase == v1.MigrationFailed {
+				return false, fmt.Errorf("VirtualMachineInstanceMigration %s failed", migration.Name)
+			}
+			return migration.Status.Phase == v1.MigrationSucceeded, nil
+		},
+	},
+	"export-ready": {
+		description: "the VirtualMachineExport is ready",
+		objType:     &exportv1.VirtualMachineExport{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachineExport(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachineExport(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			export := obj.(*exportv1.VirtualMachineExport)
+			if export.Status == nil {
+				return false, nil
+			}
+			if export.Status.Phase == exportv1.Terminated || export.Status.Phase == exportv1.Skipped {
+				return false, fmt.Errorf("VirtualMachineExport %s is %s", export.Name, export.Status.Phase)
+			}
+			return export.Status.Phase == exportv1.Ready, nil
+		},
+	},
+	"snapshot-ready": {
+		description: "the VirtualMachineSnapshot is ready to use",
+		objType:     &snapshotv1.VirtualMachineSnapshot{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachineSnapshot(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachineSnapshot(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			snapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+			if snapshot.Status == nil {
+				return false, nil
+			}
+			if snapshot.Status.Phase == snapshotv1.Failed {
+				return false, fmt.Errorf("VirtualMachineSnapshot %s failed", snapshot.Name)
+			}
+			return snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse, nil
+		},
+	},
+	"restore-complete": {
+		description: "the VirtualMachineRestore is complete",
+		objType:     &snapshotv1.VirtualMachineRestore{},
+		list: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (runtime.Object, error) {
+			return virtClient.VirtualMachineRestore(namespace).List(ctx, options)
+		},
+		watch: func(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, options metav1.ListOptions) (watch.Interface, error) {
+			return virtClient.VirtualMachineRestore(namespace).Watch(ctx, options)
+		},
+		check: func(obj runtime.Object) (bool, error) {
+			restore := obj.(*snapshotv1.VirtualMachineRestore)
+			if restore.Status == nil {
+				return false, nil
+			}
+			if slices.ContainsFunc(restore.Status.Conditions, func(c snapshotv1.Condition) bool {
+				return c.Type == snapshotv1.ConditionFailure && c.Status == k8sv1.ConditionTrue
+			}) {
+				return false, fmt.Errorf("VirtualMachineRestore %s failed", restore.Name)
+			}
+			return restore.Status.Complete != nil && *restore.Status.Complete, nil
+		},
+	},
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+	timeout      time.Duration
+}
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := command{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   "wait (CONDITION) (NAME)",
+		Short: "Wait for a condition of a KubeVirt resource to be met.",
+		Long: fmt.Sprintf(`Wait for a condition of a KubeVirt resource to be met.
+
+Supported conditions:
+%s
+
+virtctl exits with 0 once the condition is met, with %d if the timeout expired first and with %d
+if the condition can no longer be met, e.g. because the resource failed or was deleted.`, usageConditions(), ExitTimeout, ExitUnreachable),
+		Example: usage(),
+		Args:    cobra.ExactArgs(2),
+		RunE:    c.run,
+	}
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultTimeout, "How long to wait for the condition to be met.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Wait for the VM 'myvm' to be ready:
+  {{ProgramName}} wait vm-ready myvm
+
+  # Wait up to 10 minutes for the migration 'mymigration' to succeed:
+  {{ProgramName}} wait migration-succeeded mymigration --timeout=10m`
+}
+
+func conditionNames() []string {
+	names := make([]string, 0, len(conditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func usageConditions() string {
+	var lines []string
+	for _, name := range conditionNames() {
+		lines = append(lines, fmt.Sprintf("  %-20s %s", name, conditions[name].description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	conditionName, name := args[0], args[1]
+	cond, exists := conditions[conditionName]
+	if !exists {
+		return fmt.Errorf("unknown condition %s, supported conditions are: %s", conditionName, strings.Join(conditionNames(), ", "))
+	}
+	if c.timeout <= 0 {
+		return fmt.Errorf("--%s must be greater than 0", timeoutArg)
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return cond.list(ctx, virtClient, namespace, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return cond.watch(ctx, virtClient, namespace, options)
+		},
+	}
+
+	_, err = watchtools.UntilWithSync(ctx, lw, cond.objType, nil, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Deleted {
+			return false, &utils.ExitError{Code: ExitUnreachable, Err: fmt.Errorf("%s was deleted", name)}
+		}
+		met, err := cond.check(event.Object)
+		if err != nil {
+			return false, &utils.ExitError{Code: ExitUnreachable, Err: err}
+		}
+		return met, nil
+	})
+	var exitErr *utils.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr
+	} else if err != nil && ctx.Err() != nil {
+		return &utils.ExitError{Code: ExitTimeout, Err: fmt.Errorf("timed out after %s waiting for condition %s of %s", c.timeout, conditionName, name)}
+	} else if err != nil {
+		return err
+	}
+
+	cmd.Printf("Condition %s of %s met\n", conditionName, name)
+	return nil
+}
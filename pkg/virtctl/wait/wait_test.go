@@ -0,0 +1,173 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+	exportv1 "kubevirt.io/api/export/v1beta1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/utils"
+	"kubevirt.io/kubevirt/pkg/virtctl/wait"
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Wait command", func() {
+	const name = "test"
+
+	var virtClient *kubevirtfake.Clientset
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ns := metav1.NamespaceDefault
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(ns).Return(virtClient.KubevirtV1().VirtualMachines(ns)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(ns).Return(virtClient.KubevirtV1().VirtualMachineInstances(ns)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(ns).Return(virtClient.KubevirtV1().VirtualMachineInstanceMigrations(ns)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(ns).Return(virtClient.ExportV1beta1().VirtualMachineExports(ns)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineSnapshot(ns).Return(virtClient.SnapshotV1beta1().VirtualMachineSnapshots(ns)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineRestore(ns).Return(virtClient.SnapshotV1beta1().VirtualMachineRestores(ns)).AnyTimes()
+	})
+
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault}
+
+	expectExitCode := func(err error, code int) {
+		var exitErr *utils.ExitError
+		Expect(errors.As(err, &exitErr)).To(BeTrue(), "unexpected error: %v", err)
+		Expect(exitErr.Code).To(Equal(code))
+	}
+
+	DescribeTable("should fail", func(expected string, args ...string) {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{wait.COMMAND_WAIT}, args...)...)
+		Expect(cmd()).To(MatchError(ContainSubstring(expected)))
+	},
+		Entry("without arguments", "accepts 2 arg(s), received 0"),
+		Entry("with an unknown condition", "unknown condition vm-gone, supported conditions are: agent-connected, export-ready", "vm-gone", name),
+		Entry("with a zero timeout", "--timeout must be greater than 0", "vm-ready", name, "--timeout=0"),
+	)
+
+	DescribeTable("should succeed when the condition is met", func(condition string, obj runtime.Object) {
+		Expect(virtClient.Tracker().Add(obj)).To(Succeed())
+
+		out, err := clientcmd.NewRepeatableVirtctlCommandWithOut(wait.COMMAND_WAIT, condition, name, "--timeout=10s")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("Condition " + condition + " of " + name + " met\n"))
+	},
+		Entry("vm-ready", "vm-ready", &v1.VirtualMachine{
+			ObjectMeta: objectMeta,
+			Status:     v1.VirtualMachineStatus{Ready: true},
+		}),
+		Entry("agent-connected", "agent-connected", &v1.VirtualMachineInstance{
+			ObjectMeta: objectMeta,
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+				Conditions: []v1.VirtualMachineInstanceCondition{{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: k8sv1.ConditionTrue,
+				}},
+			},
+		}),
+		Entry("migration-succeeded", "migration-succeeded", &v1.VirtualMachineInstanceMigration{
+			ObjectMeta: objectMeta,
+			Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+		}),
+		Entry("export-ready", "export-ready", &exportv1.VirtualMachineExport{
+			ObjectMeta: objectMeta,
+			Status:     &exportv1.VirtualMachineExportStatus{Phase: exportv1.Ready},
+		}),
+		Entry("snapshot-ready", "snapshot-ready", &snapshotv1.VirtualMachineSnapshot{
+			ObjectMeta: objectMeta,
+			Status:     &snapshotv1.VirtualMachineSnapshotStatus{ReadyToUse: pointer.P(true)},
+		}),
+		Entry("restore-complete", "restore-complete", &snapshotv1.VirtualMachineRestore{
+			ObjectMeta: objectMeta,
+			Status:     &snapshotv1.VirtualMachineRestoreStatus{Complete: pointer.P(true)},
+		}),
+	)
+
+	It("should wait for the condition to be met", func() {
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), &v1.VirtualMachine{ObjectMeta: objectMeta}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(100 * time.Millisecond)
+			vm.Status.Ready = true
+			_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).UpdateStatus(context.Background(), vm, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		cmd := clientcmd.NewRepeatableVirtctlCommand(wait.COMMAND_WAIT, "vm-ready", name, "--timeout=10s")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should exit with the timeout code when the condition is not met in time", func() {
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), &v1.VirtualMachine{ObjectMeta: objectMeta}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = clientcmd.NewRepeatableVirtctlCommand(wait.COMMAND_WAIT, "vm-ready", name, "--timeout=100ms")()
+		Expect(err).To(MatchError("timed out after 100ms waiting for condition vm-ready of test"))
+		expectExitCode(err, wait.ExitTimeout)
+	})
+
+	It("should exit with the unreachable code when the migration failed", func() {
+		_, err := virtClient.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault).Create(context.Background(), &v1.VirtualMachineInstanceMigration{
+			ObjectMeta: objectMeta,
+			Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationFailed},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = clientcmd.NewRepeatableVirtctlCommand(wait.COMMAND_WAIT, "migration-succeeded", name)()
+		Expect(err).To(MatchError("VirtualMachineInstanceMigration test failed"))
+		expectExitCode(err, wait.ExitUnreachable)
+	})
+
+	It("should exit with the unreachable code when the resource is deleted", func() {
+		_, err := virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).Create(context.Background(), &snapshotv1.VirtualMachineSnapshot{ObjectMeta: objectMeta}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(100 * time.Millisecond)
+			Expect(virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).Delete(context.Background(), name, metav1.DeleteOptions{})).To(Succeed())
+		}()
+
+		err = clientcmd.NewRepeatableVirtctlCommand(wait.COMMAND_WAIT, "snapshot-ready", name, "--timeout=10s")()
+		Expect(err).To(MatchError("test was deleted"))
+		expectExitCode(err, wait.ExitUnreachable)
+	})
+})
@@ -798,6 +798,22 @@ func (in *DHCPPrivateOptions) DeepCopy() *DHCPPrivateOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPServerIPAM) DeepCopyInto(out *DHCPServerIPAM) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPServerIPAM.
+func (in *DHCPServerIPAM) DeepCopy() *DHCPServerIPAM {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPServerIPAM)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	*out = *in
@@ -965,6 +981,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(Watchdog)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Panic != nil {
+		in, out := &in.Panic, &out.Panic
+		*out = new(PanicDevice)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Interfaces != nil {
 		in, out := &in.Interfaces, &out.Interfaces
 		*out = make([]Interface, len(*in))
@@ -987,6 +1008,16 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Video != nil {
+		in, out := &in.Video, &out.Video
+		*out = new(VideoDevice)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spice != nil {
+		in, out := &in.Spice, &out.Spice
+		*out = new(Spice)
+		**out = **in
+	}
 	if in.AutoattachSerialConsole != nil {
 		in, out := &in.AutoattachSerialConsole, &out.AutoattachSerialConsole
 		*out = new(bool)
@@ -997,6 +1028,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SerialConsoleLogPersistence != nil {
+		in, out := &in.SerialConsoleLogPersistence, &out.SerialConsoleLogPersistence
+		*out = new(SerialConsoleLogPersistence)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutoattachMemBalloon != nil {
 		in, out := &in.AutoattachMemBalloon, &out.AutoattachMemBalloon
 		*out = new(bool)
@@ -1079,6 +1115,22 @@ func (in *Devices) DeepCopy() *Devices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Diag288Watchdog) DeepCopyInto(out *Diag288Watchdog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Diag288Watchdog.
+func (in *Diag288Watchdog) DeepCopy() *Diag288Watchdog {
+	if in == nil {
+		return nil
+	}
+	out := new(Diag288Watchdog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisableFreePageReporting) DeepCopyInto(out *DisableFreePageReporting) {
 	*out = *in
@@ -1376,6 +1428,11 @@ func (in *EFI) DeepCopyInto(out *EFI) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SecureBootKeys != nil {
+		in, out := &in.SecureBootKeys, &out.SecureBootKeys
+		*out = new(SecureBootKeysSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1955,6 +2012,25 @@ func (in *HostDisk) DeepCopy() *HostDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotUnplugProgress) DeepCopyInto(out *HotUnplugProgress) {
+	*out = *in
+	out.Requested = in.Requested.DeepCopy()
+	out.Current = in.Current.DeepCopy()
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotUnplugProgress.
+func (in *HotUnplugProgress) DeepCopy() *HotUnplugProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(HotUnplugProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HotplugVolumeSource) DeepCopyInto(out *HotplugVolumeSource) {
 	*out = *in
@@ -2071,6 +2147,22 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ITCOWatchdog) DeepCopyInto(out *ITCOWatchdog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ITCOWatchdog.
+func (in *ITCOWatchdog) DeepCopy() *ITCOWatchdog {
+	if in == nil {
+		return nil
+	}
+	out := new(ITCOWatchdog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InitrdInfo) DeepCopyInto(out *InitrdInfo) {
 	*out = *in
@@ -2633,6 +2725,11 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(KSMConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MemoryReclaimConfiguration != nil {
+		in, out := &in.MemoryReclaimConfiguration, &out.MemoryReclaimConfiguration
+		*out = new(MemoryReclaimConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutoCPULimitNamespaceLabelSelector != nil {
 		in, out := &in.AutoCPULimitNamespaceLabelSelector, &out.AutoCPULimitNamespaceLabelSelector
 		*out = new(metav1.LabelSelector)
@@ -2857,6 +2954,16 @@ func (in *LaunchSecurity) DeepCopyInto(out *LaunchSecurity) {
 		*out = new(SEV)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SNP != nil {
+		in, out := &in.SNP, &out.SNP
+		*out = new(SEVSNP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TDX != nil {
+		in, out := &in.TDX, &out.TDX
+		*out = new(TDX)
+		**out = **in
+	}
 	return
 }
 
@@ -2870,6 +2977,31 @@ func (in *LaunchSecurity) DeepCopy() *LaunchSecurity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchSecurityReport) DeepCopyInto(out *LaunchSecurityReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchSecurityReport.
+func (in *LaunchSecurityReport) DeepCopy() *LaunchSecurityReport {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchSecurityReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LaunchSecurityReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LiveUpdateConfiguration) DeepCopyInto(out *LiveUpdateConfiguration) {
 	*out = *in
@@ -2883,6 +3015,11 @@ func (in *LiveUpdateConfiguration) DeepCopyInto(out *LiveUpdateConfiguration) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.HotUnplugTimeout != nil {
+		in, out := &in.HotUnplugTimeout, &out.HotUnplugTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -3018,6 +3155,11 @@ func (in *Memory) DeepCopyInto(out *Memory) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Reclaim != nil {
+		in, out := &in.Reclaim, &out.Reclaim
+		*out = new(MemoryReclaim)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3048,6 +3190,53 @@ func (in *MemoryDumpVolumeSource) DeepCopy() *MemoryDumpVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryReclaim) DeepCopyInto(out *MemoryReclaim) {
+	*out = *in
+	if in.Floor != nil {
+		in, out := &in.Floor, &out.Floor
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryReclaim.
+func (in *MemoryReclaim) DeepCopy() *MemoryReclaim {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryReclaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryReclaimConfiguration) DeepCopyInto(out *MemoryReclaimConfiguration) {
+	*out = *in
+	if in.NodeLabelSelector != nil {
+		in, out := &in.NodeLabelSelector, &out.NodeLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FreeMemoryPercent != nil {
+		in, out := &in.FreeMemoryPercent, &out.FreeMemoryPercent
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryReclaimConfiguration.
+func (in *MemoryReclaimConfiguration) DeepCopy() *MemoryReclaimConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryReclaimConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryStatus) DeepCopyInto(out *MemoryStatus) {
 	*out = *in
@@ -3066,6 +3255,11 @@ func (in *MemoryStatus) DeepCopyInto(out *MemoryStatus) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.BalloonTarget != nil {
+		in, out := &in.BalloonTarget, &out.BalloonTarget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -3180,6 +3374,11 @@ func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
 	*out = *in
+	if in.DHCPServer != nil {
+		in, out := &in.DHCPServer, &out.DHCPServer
+		*out = new(DHCPServerIPAM)
+		**out = **in
+	}
 	return
 }
 
@@ -3267,6 +3466,11 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3291,7 +3495,7 @@ func (in *NetworkSource) DeepCopyInto(out *NetworkSource) {
 	if in.Multus != nil {
 		in, out := &in.Multus, &out.Multus
 		*out = new(MultusNetwork)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -3411,6 +3615,27 @@ func (in *PITTimer) DeepCopy() *PITTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PanicDevice) DeepCopyInto(out *PanicDevice) {
+	*out = *in
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(PanicDeviceModel)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PanicDevice.
+func (in *PanicDevice) DeepCopy() *PanicDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(PanicDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PauseOptions) DeepCopyInto(out *PauseOptions) {
 	*out = *in
@@ -4035,6 +4260,48 @@ func (in *SEVPolicy) DeepCopy() *SEVPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSNP) DeepCopyInto(out *SEVSNP) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(SEVSNPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSNP.
+func (in *SEVSNP) DeepCopy() *SEVSNP {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSNP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSNPPolicy) DeepCopyInto(out *SEVSNPPolicy) {
+	*out = *in
+	if in.SMT != nil {
+		in, out := &in.SMT, &out.SMT
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSNPPolicy.
+func (in *SEVSNPPolicy) DeepCopy() *SEVSNPPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSNPPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SEVSecretOptions) DeepCopyInto(out *SEVSecretOptions) {
 	*out = *in
@@ -4211,6 +4478,113 @@ func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecureBootKeysSource) DeepCopyInto(out *SecureBootKeysSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecureBootKeysSource.
+func (in *SecureBootKeysSource) DeepCopy() *SecureBootKeysSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecureBootKeysSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialConsoleLog) DeepCopyInto(out *SerialConsoleLog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Lines != nil {
+		in, out := &in.Lines, &out.Lines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialConsoleLog.
+func (in *SerialConsoleLog) DeepCopy() *SerialConsoleLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialConsoleLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SerialConsoleLog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialConsoleLogOptions) DeepCopyInto(out *SerialConsoleLogOptions) {
+	*out = *in
+	if in.SinceTime != nil {
+		in, out := &in.SinceTime, &out.SinceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialConsoleLogOptions.
+func (in *SerialConsoleLogOptions) DeepCopy() *SerialConsoleLogOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialConsoleLogOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialConsoleLogPersistence) DeepCopyInto(out *SerialConsoleLogPersistence) {
+	*out = *in
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFiles != nil {
+		in, out := &in.MaxFiles, &out.MaxFiles
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialConsoleLogPersistence.
+func (in *SerialConsoleLogPersistence) DeepCopy() *SerialConsoleLogPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialConsoleLogPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
 	*out = *in
@@ -4227,6 +4601,39 @@ func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshConfiguration) DeepCopyInto(out *ServiceMeshConfiguration) {
+	*out = *in
+	if in.InjectionAnnotations != nil {
+		in, out := &in.InjectionAnnotations, &out.InjectionAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReservedPorts != nil {
+		in, out := &in.ReservedPorts, &out.ReservedPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonProxiedPorts != nil {
+		in, out := &in.NonProxiedPorts, &out.NonProxiedPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshConfiguration.
+func (in *ServiceMeshConfiguration) DeepCopy() *ServiceMeshConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMeshConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SoundDevice) DeepCopyInto(out *SoundDevice) {
 	*out = *in
@@ -4243,6 +4650,22 @@ func (in *SoundDevice) DeepCopy() *SoundDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Spice) DeepCopyInto(out *Spice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spice.
+func (in *Spice) DeepCopy() *Spice {
+	if in == nil {
+		return nil
+	}
+	out := new(Spice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StartOptions) DeepCopyInto(out *StartOptions) {
 	*out = *in
@@ -4387,6 +4810,22 @@ func (in *SysprepSource) DeepCopy() *SysprepSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TDX) DeepCopyInto(out *TDX) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TDX.
+func (in *TDX) DeepCopy() *TDX {
+	if in == nil {
+		return nil
+	}
+	out := new(TDX)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfiguration) DeepCopyInto(out *TLSConfiguration) {
 	*out = *in
@@ -4416,6 +4855,16 @@ func (in *TPMDevice) DeepCopyInto(out *TPMDevice) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(TPMEncryption)
+		**out = **in
+	}
+	if in.EKCertificateAuthority != nil {
+		in, out := &in.EKCertificateAuthority, &out.EKCertificateAuthority
+		*out = new(TPMEKCertificateAuthority)
+		**out = **in
+	}
 	return
 }
 
@@ -4429,6 +4878,38 @@ func (in *TPMDevice) DeepCopy() *TPMDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMEKCertificateAuthority) DeepCopyInto(out *TPMEKCertificateAuthority) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMEKCertificateAuthority.
+func (in *TPMEKCertificateAuthority) DeepCopy() *TPMEKCertificateAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMEKCertificateAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TPMEncryption) DeepCopyInto(out *TPMEncryption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TPMEncryption.
+func (in *TPMEncryption) DeepCopy() *TPMEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(TPMEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -4710,6 +5191,53 @@ func (in *VSOCKOptions) DeepCopy() *VSOCKOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VideoDevice) DeepCopyInto(out *VideoDevice) {
+	*out = *in
+	if in.Heads != nil {
+		in, out := &in.Heads, &out.Heads
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(VideoResolution)
+		**out = **in
+	}
+	if in.Accel3D != nil {
+		in, out := &in.Accel3D, &out.Accel3D
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoDevice.
+func (in *VideoDevice) DeepCopy() *VideoDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VideoDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VideoResolution) DeepCopyInto(out *VideoResolution) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoResolution.
+func (in *VideoResolution) DeepCopy() *VideoResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(VideoResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
 	*out = *in
@@ -4756,6 +5284,32 @@ func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineHotUnplugStatus) DeepCopyInto(out *VirtualMachineHotUnplugStatus) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(HotUnplugProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(HotUnplugProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineHotUnplugStatus.
+func (in *VirtualMachineHotUnplugStatus) DeepCopy() *VirtualMachineHotUnplugStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineHotUnplugStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
 	*out = *in
@@ -5667,6 +6221,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(CPUTopology)
 		**out = **in
 	}
+	if in.GuestCurrentVCPUs != nil {
+		in, out := &in.GuestCurrentVCPUs, &out.GuestCurrentVCPUs
+		*out = new(uint32)
+		**out = **in
+	}
 	if in.Memory != nil {
 		in, out := &in.Memory, &out.Memory
 		*out = new(MemoryStatus)
@@ -5948,6 +6507,11 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(VirtualMachineMemoryDumpRequest)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HotUnplug != nil {
+		in, out := &in.HotUnplug, &out.HotUnplug
+		*out = new(VirtualMachineHotUnplugStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeUpdateState != nil {
 		in, out := &in.VolumeUpdateState, &out.VolumeUpdateState
 		*out = new(VolumeUpdateState)
@@ -6221,6 +6785,16 @@ func (in *WatchdogDevice) DeepCopyInto(out *WatchdogDevice) {
 		*out = new(I6300ESBWatchdog)
 		**out = **in
 	}
+	if in.Diag288 != nil {
+		in, out := &in.Diag288, &out.Diag288
+		*out = new(Diag288Watchdog)
+		**out = **in
+	}
+	if in.ITCO != nil {
+		in, out := &in.ITCO, &out.ITCO
+		*out = new(ITCOWatchdog)
+		**out = **in
+	}
 	return
 }
 
@@ -250,6 +250,23 @@ type EFI struct {
 	// Defaults to false
 	// +optional
 	Persistent *bool `json:"persistent,omitempty"`
+	// SecureBootKeys references the certificates which are enrolled as
+	// SecureBoot keys in place of the ones shipped with the OVMF roms.
+	// Requires SecureBoot to be enabled.
+	// +optional
+	SecureBootKeys *SecureBootKeysSource `json:"secureBootKeys,omitempty"`
+}
+
+// SecureBootKeysSource references a Secret or ConfigMap holding PEM encoded
+// certificates under the keys PK, KEK, db and, optionally, dbx.
+// PK must contain exactly one certificate, the others may hold several.
+type SecureBootKeysSource struct {
+	// Secret references a k8s Secret that contains the SecureBoot certificates.
+	// +optional
+	Secret *v1.LocalObjectReference `json:"secret,omitempty"`
+	// ConfigMap references a ConfigMap that contains the SecureBoot certificates.
+	// +optional
+	ConfigMap *v1.LocalObjectReference `json:"configMap,omitempty"`
 }
 
 // If set, the VM will be booted from the defined kernel / initrd.
@@ -389,6 +406,19 @@ type Memory struct {
 	// MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.
 	// The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
 	MaxGuest *resource.Quantity `json:"maxGuest,omitempty"`
+	// Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance
+	// when the node is under memory pressure.
+	// Requires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.
+	// +optional
+	Reclaim *MemoryReclaim `json:"reclaim,omitempty"`
+}
+
+// MemoryReclaim configures how much guest memory can be reclaimed through the memory balloon.
+type MemoryReclaim struct {
+	// Floor is the amount of memory which is never taken away from the guest.
+	// Defaults to half of the guest memory.
+	// +optional
+	Floor *resource.Quantity `json:"floor,omitempty"`
 }
 
 type MemoryStatus struct {
@@ -401,6 +431,10 @@ type MemoryStatus struct {
 	// GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.
 	// +optional
 	GuestRequested *resource.Quantity `json:"guestRequested,omitempty"`
+	// BalloonTarget specifies down to how much memory the memory balloon shrinks the guest
+	// while the node is under memory pressure.
+	// +optional
+	BalloonTarget *resource.Quantity `json:"balloonTarget,omitempty"`
 }
 
 // Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
@@ -450,6 +484,9 @@ type Devices struct {
 	Disks []Disk `json:"disks,omitempty"`
 	// Watchdog describes a watchdog device which can be added to the vmi.
 	Watchdog *Watchdog `json:"watchdog,omitempty"`
+	// Panic describes a panic notifier device, which lets the guest report a kernel panic to the host.
+	// +optional
+	Panic *PanicDevice `json:"panic,omitempty"`
 	// Interfaces describe network interfaces which are added to the vmi.
 	// +kubebuilder:validation:MaxItems:=256
 	Interfaces []Interface `json:"interfaces,omitempty"`
@@ -460,6 +497,14 @@ type Devices struct {
 	// Whether to attach the default graphics device or not.
 	// VNC will not be available if set to false. Defaults to true.
 	AutoattachGraphicsDevice *bool `json:"autoattachGraphicsDevice,omitempty"`
+	// Video configures the video device attached along with the graphics device.
+	// Replaces the default VGA or bochs device when set.
+	// +optional
+	Video *VideoDevice `json:"video,omitempty"`
+	// Spice attaches a SPICE graphics device next to the VNC one.
+	// Requires the graphics device to be attached.
+	// +optional
+	Spice *Spice `json:"spice,omitempty"`
 	// Whether to attach the default virtio-serial console or not.
 	// Serial console access will not be available if set to false. Defaults to true.
 	AutoattachSerialConsole *bool `json:"autoattachSerialConsole,omitempty"`
@@ -468,6 +513,11 @@ type Devices struct {
 	// Not relevant if autoattachSerialConsole is disabled.
 	// Defaults to cluster wide setting on VirtualMachineOptions.
 	LogSerialConsole *bool `json:"logSerialConsole,omitempty"`
+	// SerialConsoleLogPersistence rotates and persists the serial console log, so it can
+	// still be retrieved once the VMI and its pod are gone.
+	// Requires the serial console log to be enabled.
+	// +optional
+	SerialConsoleLogPersistence *SerialConsoleLogPersistence `json:"serialConsoleLogPersistence,omitempty"`
 	// Whether to attach the Memory balloon device with default period.
 	// Period can be adjusted in virt-config.
 	// Defaults to true.
@@ -546,6 +596,33 @@ type TPMDevice struct {
 	// Persistent indicates the state of the TPM device should be kept accross reboots
 	// Defaults to false
 	Persistent *bool `json:"persistent,omitempty"`
+	// Encryption encrypts the persistent state of the TPM device with a key from a Secret.
+	// Requires Persistent to be true.
+	// +optional
+	Encryption *TPMEncryption `json:"encryption,omitempty"`
+	// EKCertificateAuthority signs the endorsement key certificate of the TPM device
+	// with the given certificate authority instead of a self-signed one, so that
+	// guests can take part in measured-boot attestation.
+	// The certificate is only issued when the TPM state is created.
+	// +optional
+	EKCertificateAuthority *TPMEKCertificateAuthority `json:"ekCertificateAuthority,omitempty"`
+}
+
+// TPMEncryption references the key used to encrypt the TPM state.
+type TPMEncryption struct {
+	// SecretName is the name of the Secret holding the encryption key in its "key" entry.
+	// The key must be exactly 32 bytes long.
+	SecretName string `json:"secretName"`
+}
+
+// TPMEKCertificateAuthority references the certificate authority which signs
+// the endorsement key certificate of the TPM device.
+type TPMEKCertificateAuthority struct {
+	// SecretName is the name of a kubernetes.io/tls Secret holding the certificate
+	// and the private key of the certificate authority, usually distributed from a
+	// cluster wide CA into the namespace of the VirtualMachineInstance.
+	// The private key is never copied to the persistent TPM state.
+	SecretName string `json:"secretName"`
 }
 
 type InputBus string
@@ -573,6 +650,67 @@ type Input struct {
 	Name string `json:"name"`
 }
 
+type VideoType string
+
+const (
+	VideoTypeVirtIO VideoType = "virtio"
+)
+
+// VideoDevice describes a paravirtual video device.
+type VideoDevice struct {
+	// Type of the video device.
+	// Supported values: virtio.
+	Type VideoType `json:"type"`
+	// Heads is the number of displays of the device. Defaults to 1.
+	// +optional
+	Heads *uint32 `json:"heads,omitempty"`
+	// Resolution the displays are initialized with.
+	// +optional
+	Resolution *VideoResolution `json:"resolution,omitempty"`
+	// Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.
+	// Vulkan acceleration (Venus) is not supported.
+	// Requires a render node on the node the VMI is scheduled to.
+	// +optional
+	Accel3D *bool `json:"accel3D,omitempty"`
+}
+
+// VideoResolution is a display resolution in pixels.
+type VideoResolution struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+}
+
+type SpiceStreamingMode string
+
+const (
+	SpiceStreamingModeFilter SpiceStreamingMode = "filter"
+	SpiceStreamingModeAll    SpiceStreamingMode = "all"
+	SpiceStreamingModeOff    SpiceStreamingMode = "off"
+)
+
+// Spice configures the SPICE remote display protocol.
+// Multiple displays are provided by the heads of the video device.
+type Spice struct {
+	// StreamingMode selects which screen regions are encoded as a video stream.
+	// Supported values: filter, all, off. Defaults to filter.
+	// +optional
+	StreamingMode SpiceStreamingMode `json:"streamingMode,omitempty"`
+}
+
+// SerialConsoleLogPersistence configures where and how much of the serial console log is persisted.
+type SerialConsoleLogPersistence struct {
+	// ClaimName of a PVC dedicated to the serial console log of the VM.
+	// When empty, the log is persisted to the backend storage of the VM.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// MaxFileSize is the size of the log file before it is rotated. Defaults to 1Mi, at most 10Mi.
+	// +optional
+	MaxFileSize *resource.Quantity `json:"maxFileSize,omitempty"`
+	// MaxFiles is the number of rotated log files kept next to the current one. Defaults to 3, at most 100.
+	// +optional
+	MaxFiles *uint32 `json:"maxFiles,omitempty"`
+}
+
 type Filesystem struct {
 	// Name is the device name
 	Name string `json:"name"`
@@ -708,6 +846,12 @@ type DiskTarget struct {
 type LaunchSecurity struct {
 	// AMD Secure Encrypted Virtualization (SEV).
 	SEV *SEV `json:"sev,omitempty"`
+	// AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP).
+	// +optional
+	SNP *SEVSNP `json:"snp,omitempty"`
+	// Intel Trust Domain Extensions (TDX).
+	// +optional
+	TDX *TDX `json:"tdx,omitempty"`
 }
 
 type SEV struct {
@@ -733,6 +877,23 @@ type SEVPolicy struct {
 type SEVAttestation struct {
 }
 
+type SEVSNP struct {
+	// Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+	// Note: due to security reasons it is not allowed to enable guest debugging.
+	// +optional
+	Policy *SEVSNPPolicy `json:"policy,omitempty"`
+}
+
+type SEVSNPPolicy struct {
+	// If set to false, the guest must not run on a host with simultaneous multithreading enabled.
+	// Defaults to true.
+	// +optional
+	SMT *bool `json:"smt,omitempty"`
+}
+
+type TDX struct {
+}
+
 type LunTarget struct {
 	// Bus indicates the type of disk device to emulate.
 	// supported values: virtio, sata, scsi.
@@ -880,6 +1041,9 @@ type MemoryDumpVolumeSource struct {
 	// Directly attached to the virt launcher
 	// +optional
 	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// Format is the format the memory is dumped in, defaults to elf
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
 type EphemeralVolumeSource struct {
@@ -1227,6 +1391,12 @@ type WatchdogDevice struct {
 	// i6300esb watchdog device.
 	// +optional
 	I6300ESB *I6300ESBWatchdog `json:"i6300esb,omitempty"`
+	// diag288 watchdog device, only available on s390x.
+	// +optional
+	Diag288 *Diag288Watchdog `json:"diag288,omitempty"`
+	// itco watchdog device, part of the q35 chipset on amd64.
+	// +optional
+	ITCO *ITCOWatchdog `json:"itco,omitempty"`
 }
 
 // i6300esb watchdog device.
@@ -1236,6 +1406,61 @@ type I6300ESBWatchdog struct {
 	Action WatchdogAction `json:"action,omitempty"`
 }
 
+// diag288 watchdog device.
+type Diag288Watchdog struct {
+	// The action to take. Valid values are poweroff, reset, shutdown.
+	// Defaults to reset.
+	Action WatchdogAction `json:"action,omitempty"`
+}
+
+// itco watchdog device.
+type ITCOWatchdog struct {
+	// The action to take. Valid values are poweroff, reset, shutdown.
+	// Defaults to reset.
+	Action WatchdogAction `json:"action,omitempty"`
+}
+
+// PanicDeviceModel is the model of the panic notifier device.
+type PanicDeviceModel string
+
+const (
+	// PanicDeviceModelISA is the ISA pvpanic device, available on amd64.
+	PanicDeviceModelISA PanicDeviceModel = "isa"
+	// PanicDeviceModelPVPanic is the PCI pvpanic device.
+	PanicDeviceModelPVPanic PanicDeviceModel = "pvpanic"
+	// PanicDeviceModelS390 is the s390 panic notifier, only available on s390x.
+	PanicDeviceModelS390 PanicDeviceModel = "s390"
+)
+
+// PanicAction defines the action taken when the guest reports a panic.
+type PanicAction string
+
+const (
+	// PanicActionRestart restarts the guest after a panic.
+	PanicActionRestart PanicAction = "restart"
+	// PanicActionPause keeps the guest paused after a panic, for inspection.
+	PanicActionPause PanicAction = "pause"
+	// PanicActionDump keeps the guest paused after a panic and dumps its memory to the memory dump PVC.
+	// It is only supported on VMIs owned by a VirtualMachine.
+	PanicActionDump PanicAction = "dump"
+)
+
+// PanicDevice lets the guest report a kernel panic to the host.
+type PanicDevice struct {
+	// Model of the panic device.
+	// Defaults to isa on amd64, pvpanic on arm64 and s390 on s390x.
+	// +optional
+	Model *PanicDeviceModel `json:"model,omitempty"`
+	// The action to take when the guest panics. Valid values are restart, pause, dump.
+	// Defaults to pause.
+	// +optional
+	Action PanicAction `json:"action,omitempty"`
+	// DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.
+	// It is handled as a memory dump request of the owning VirtualMachine.
+	// +optional
+	DumpClaimName string `json:"dumpClaimName,omitempty"`
+}
+
 type Interface struct {
 	// Logical name of the interface as well as a reference to the associated networks.
 	// Must match the Name of a Network.
@@ -1571,6 +1796,27 @@ type MultusNetwork struct {
 	// Select the default network and add it to the
 	// multus-cni.io/default-network annotation.
 	Default bool `json:"default,omitempty"`
+
+	// DHCPServer enables an in-pod DHCP server for a bridge bound interface
+	// connected to this network, handing out addresses from the given range.
+	// It is meant for networks that have no IPAM of their own.
+	// Requires the DHCPServerIPAM feature gate.
+	// +optional
+	DHCPServer *DHCPServerIPAM `json:"dhcpServer,omitempty"`
+}
+
+// DHCPServerIPAM represents the address range served by the in-pod DHCP server.
+// Allocations are shared by all the VMIs using the same network and are
+// persisted by KubeVirt.
+type DHCPServerIPAM struct {
+	// Subnet from which the guest addresses are allocated, in CIDR notation.
+	// Only IPv4 subnets are supported.
+	Subnet string `json:"subnet"`
+
+	// Gateway advertised to the guest. It must be part of the subnet and is
+	// never handed out as a guest address.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
 }
 
 // CPUTopology allows specifying the amount of cores, sockets
@@ -121,9 +121,18 @@ func (BIOS) SwaggerDoc() map[string]string {
 
 func (EFI) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":           "If set, EFI will be used instead of BIOS.",
-		"secureBoot": "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
-		"persistent": "If set to true, Persistent will persist the EFI NVRAM across reboots.\nDefaults to false\n+optional",
+		"":               "If set, EFI will be used instead of BIOS.",
+		"secureBoot":     "If set, SecureBoot will be enabled and the OVMF roms will be swapped for\nSecureBoot-enabled ones.\nRequires SMM to be enabled.\nDefaults to true\n+optional",
+		"persistent":     "If set to true, Persistent will persist the EFI NVRAM across reboots.\nDefaults to false\n+optional",
+		"secureBootKeys": "SecureBootKeys references the certificates which are enrolled as\nSecureBoot keys in place of the ones shipped with the OVMF roms.\nRequires SecureBoot to be enabled.\n+optional",
+	}
+}
+
+func (SecureBootKeysSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SecureBootKeysSource references a Secret or ConfigMap holding PEM encoded\ncertificates under the keys PK, KEK, db and, optionally, dbx.\nPK must contain exactly one certificate, the others may hold several.",
+		"secret":    "Secret references a k8s Secret that contains the SecureBoot certificates.\n+optional",
+		"configMap": "ConfigMap references a ConfigMap that contains the SecureBoot certificates.\n+optional",
 	}
 }
 
@@ -203,6 +212,14 @@ func (Memory) SwaggerDoc() map[string]string {
 		"hugepages": "Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.\n+optional",
 		"guest":     "Guest allows to specifying the amount of memory which is visible inside the Guest OS.\nThe Guest must lie between Requests and Limits from the resources section.\nDefaults to the requested memory in the resources section if not specified.\n+ optional",
 		"maxGuest":  "MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.\nThe delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.",
+		"reclaim":   "Reclaim allows virt-handler to inflate the memory balloon of the VirtualMachineInstance\nwhen the node is under memory pressure.\nRequires the memory balloon device and the MemoryReclaimConfiguration in the KubeVirt CR.\n+optional",
+	}
+}
+
+func (MemoryReclaim) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "MemoryReclaim configures how much guest memory can be reclaimed through the memory balloon.",
+		"floor": "Floor is the amount of memory which is never taken away from the guest.\nDefaults to half of the guest memory.\n+optional",
 	}
 }
 
@@ -211,6 +228,7 @@ func (MemoryStatus) SwaggerDoc() map[string]string {
 		"guestAtBoot":    "GuestAtBoot specifies with how much memory the VirtualMachine intiallly booted with.\n+optional",
 		"guestCurrent":   "GuestCurrent specifies how much memory is currently available for the VirtualMachine.\n+optional",
 		"guestRequested": "GuestRequested specifies how much memory was requested (hotplug) for the VirtualMachine.\n+optional",
+		"balloonTarget":  "BalloonTarget specifies down to how much memory the memory balloon shrinks the guest\nwhile the node is under memory pressure.\n+optional",
 	}
 }
 
@@ -245,29 +263,33 @@ func (ACPI) SwaggerDoc() map[string]string {
 
 func (Devices) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"useVirtioTransitional":      "Fall back to legacy virtio 0.9 support if virtio bus is selected on devices.\nThis is helpful for old machines like CentOS6 or RHEL6 which\ndo not understand virtio_non_transitional (virtio 1.0).",
-		"disableHotplug":             "DisableHotplug disabled the ability to hotplug disks.",
-		"disks":                      "Disks describes disks, cdroms and luns which are connected to the vmi.\n+kubebuilder:validation:MaxItems:=256",
-		"watchdog":                   "Watchdog describes a watchdog device which can be added to the vmi.",
-		"interfaces":                 "Interfaces describe network interfaces which are added to the vmi.\n+kubebuilder:validation:MaxItems:=256",
-		"inputs":                     "Inputs describe input devices",
-		"autoattachPodInterface":     "Whether to attach a pod network interface. Defaults to true.",
-		"autoattachGraphicsDevice":   "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
-		"autoattachSerialConsole":    "Whether to attach the default virtio-serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
-		"logSerialConsole":           "Whether to log the auto-attached default serial console or not.\nSerial console logs will be collect to a file and then streamed from a named `guest-console-log`.\nNot relevant if autoattachSerialConsole is disabled.\nDefaults to cluster wide setting on VirtualMachineOptions.",
-		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
-		"autoattachInputDevice":      "Whether to attach an Input Device.\nDefaults to false.\n+optional",
-		"autoattachVSOCK":            "Whether to attach the VSOCK CID to the VM or not.\nVSOCK access will be available if set to true. Defaults to false.",
-		"rng":                        "Whether to have random number generator from host\n+optional",
-		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
-		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
-		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
-		"downwardMetrics":            "DownwardMetrics creates a virtio serials for exposing the downward metrics to the vmi.\n+optional",
-		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
-		"hostDevices":                "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
-		"clientPassthrough":          "To configure and access client devices such as redirecting USB\n+optional",
-		"sound":                      "Whether to emulate a sound device.\n+optional",
-		"tpm":                        "Whether to emulate a TPM device.\n+optional",
+		"useVirtioTransitional":       "Fall back to legacy virtio 0.9 support if virtio bus is selected on devices.\nThis is helpful for old machines like CentOS6 or RHEL6 which\ndo not understand virtio_non_transitional (virtio 1.0).",
+		"disableHotplug":              "DisableHotplug disabled the ability to hotplug disks.",
+		"disks":                       "Disks describes disks, cdroms and luns which are connected to the vmi.\n+kubebuilder:validation:MaxItems:=256",
+		"watchdog":                    "Watchdog describes a watchdog device which can be added to the vmi.",
+		"panic":                       "Panic describes a panic notifier device, which lets the guest report a kernel panic to the host.\n+optional",
+		"interfaces":                  "Interfaces describe network interfaces which are added to the vmi.\n+kubebuilder:validation:MaxItems:=256",
+		"inputs":                      "Inputs describe input devices",
+		"autoattachPodInterface":      "Whether to attach a pod network interface. Defaults to true.",
+		"autoattachGraphicsDevice":    "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
+		"video":                       "Video configures the video device attached along with the graphics device.\nReplaces the default VGA or bochs device when set.\n+optional",
+		"spice":                       "Spice attaches a SPICE graphics device next to the VNC one.\nRequires the graphics device to be attached.\n+optional",
+		"autoattachSerialConsole":     "Whether to attach the default virtio-serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
+		"logSerialConsole":            "Whether to log the auto-attached default serial console or not.\nSerial console logs will be collect to a file and then streamed from a named `guest-console-log`.\nNot relevant if autoattachSerialConsole is disabled.\nDefaults to cluster wide setting on VirtualMachineOptions.",
+		"serialConsoleLogPersistence": "SerialConsoleLogPersistence rotates and persists the serial console log, so it can\nstill be retrieved once the VMI and its pod are gone.\nRequires the serial console log to be enabled.\n+optional",
+		"autoattachMemBalloon":        "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
+		"autoattachInputDevice":       "Whether to attach an Input Device.\nDefaults to false.\n+optional",
+		"autoattachVSOCK":             "Whether to attach the VSOCK CID to the VM or not.\nVSOCK access will be available if set to true. Defaults to false.",
+		"rng":                         "Whether to have random number generator from host\n+optional",
+		"blockMultiQueue":             "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
+		"networkInterfaceMultiqueue":  "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
+		"gpus":                        "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
+		"downwardMetrics":             "DownwardMetrics creates a virtio serials for exposing the downward metrics to the vmi.\n+optional",
+		"filesystems":                 "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
+		"hostDevices":                 "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
+		"clientPassthrough":           "To configure and access client devices such as redirecting USB\n+optional",
+		"sound":                       "Whether to emulate a sound device.\n+optional",
+		"tpm":                         "Whether to emulate a TPM device.\n+optional",
 	}
 }
 
@@ -287,7 +309,23 @@ func (SoundDevice) SwaggerDoc() map[string]string {
 
 func (TPMDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"persistent": "Persistent indicates the state of the TPM device should be kept accross reboots\nDefaults to false",
+		"persistent":             "Persistent indicates the state of the TPM device should be kept accross reboots\nDefaults to false",
+		"encryption":             "Encryption encrypts the persistent state of the TPM device with a key from a Secret.\nRequires Persistent to be true.\n+optional",
+		"ekCertificateAuthority": "EKCertificateAuthority signs the endorsement key certificate of the TPM device\nwith the given certificate authority instead of a self-signed one, so that\nguests can take part in measured-boot attestation.\nThe certificate is only issued when the TPM state is created.\n+optional",
+	}
+}
+
+func (TPMEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "TPMEncryption references the key used to encrypt the TPM state.",
+		"secretName": "SecretName is the name of the Secret holding the encryption key in its \"key\" entry.\nThe key must be exactly 32 bytes long.",
+	}
+}
+
+func (TPMEKCertificateAuthority) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "TPMEKCertificateAuthority references the certificate authority which signs\nthe endorsement key certificate of the TPM device.",
+		"secretName": "SecretName is the name of a kubernetes.io/tls Secret holding the certificate\nand the private key of the certificate authority, usually distributed from a\ncluster wide CA into the namespace of the VirtualMachineInstance.\nThe private key is never copied to the persistent TPM state.",
 	}
 }
 
@@ -299,6 +337,38 @@ func (Input) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VideoDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "VideoDevice describes a paravirtual video device.",
+		"type":       "Type of the video device.\nSupported values: virtio.",
+		"heads":      "Heads is the number of displays of the device. Defaults to 1.\n+optional",
+		"resolution": "Resolution the displays are initialized with.\n+optional",
+		"accel3D":    "Accel3D enables OpenGL 3D acceleration (virgl) rendered on a GPU render node of the host.\nVulkan acceleration (Venus) is not supported.\nRequires a render node on the node the VMI is scheduled to.\n+optional",
+	}
+}
+
+func (VideoResolution) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VideoResolution is a display resolution in pixels.",
+	}
+}
+
+func (Spice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "Spice configures the SPICE remote display protocol.\nMultiple displays are provided by the heads of the video device.",
+		"streamingMode": "StreamingMode selects which screen regions are encoded as a video stream.\nSupported values: filter, all, off. Defaults to filter.\n+optional",
+	}
+}
+
+func (SerialConsoleLogPersistence) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "SerialConsoleLogPersistence configures where and how much of the serial console log is persisted.",
+		"claimName":   "ClaimName of a PVC dedicated to the serial console log of the VM.\nWhen empty, the log is persisted to the backend storage of the VM.\n+optional",
+		"maxFileSize": "MaxFileSize is the size of the log file before it is rotated. Defaults to 1Mi, at most 10Mi.\n+optional",
+		"maxFiles":    "MaxFiles is the number of rotated log files kept next to the current one. Defaults to 3, at most 100.\n+optional",
+	}
+}
+
 func (Filesystem) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"name":     "Name is the device name",
@@ -386,6 +456,8 @@ func (DiskTarget) SwaggerDoc() map[string]string {
 func (LaunchSecurity) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"sev": "AMD Secure Encrypted Virtualization (SEV).",
+		"snp": "AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP).\n+optional",
+		"tdx": "Intel Trust Domain Extensions (TDX).\n+optional",
 	}
 }
 
@@ -408,6 +480,22 @@ func (SEVAttestation) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (SEVSNP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"policy": "Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.\nNote: due to security reasons it is not allowed to enable guest debugging.\n+optional",
+	}
+}
+
+func (SEVSNPPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"smt": "If set to false, the guest must not run on a host with simultaneous multithreading enabled.\nDefaults to true.\n+optional",
+	}
+}
+
+func (TDX) SwaggerDoc() map[string]string {
+	return map[string]string{}
+}
+
 func (LunTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"bus":         "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
@@ -475,7 +563,9 @@ func (PersistentVolumeClaimVolumeSource) SwaggerDoc() map[string]string {
 }
 
 func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
-	return map[string]string{}
+	return map[string]string{
+		"format": "Format is the format the memory is dumped in, defaults to elf\n+optional",
+	}
 }
 
 func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
@@ -655,6 +745,8 @@ func (WatchdogDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Hardware watchdog device.\nExactly one of its members must be set.",
 		"i6300esb": "i6300esb watchdog device.\n+optional",
+		"diag288":  "diag288 watchdog device, only available on s390x.\n+optional",
+		"itco":     "itco watchdog device, part of the q35 chipset on amd64.\n+optional",
 	}
 }
 
@@ -665,6 +757,29 @@ func (I6300ESBWatchdog) SwaggerDoc() map[string]string {
 	}
 }
 
+func (Diag288Watchdog) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "diag288 watchdog device.",
+		"action": "The action to take. Valid values are poweroff, reset, shutdown.\nDefaults to reset.",
+	}
+}
+
+func (ITCOWatchdog) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "itco watchdog device.",
+		"action": "The action to take. Valid values are poweroff, reset, shutdown.\nDefaults to reset.",
+	}
+}
+
+func (PanicDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "PanicDevice lets the guest report a kernel panic to the host.",
+		"model":         "Model of the panic device.\nDefaults to isa on amd64, pvpanic on arm64 and s390 on s390x.\n+optional",
+		"action":        "The action to take when the guest panics. Valid values are restart, pause, dump.\nDefaults to pause.\n+optional",
+		"dumpClaimName": "DumpClaimName is the name of the PVC the memory is dumped to when the action is dump.\nIt is handled as a memory dump request of the owning VirtualMachine.\n+optional",
+	}
+}
+
 func (Interface) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"name":        "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
@@ -877,6 +992,15 @@ func (MultusNetwork) SwaggerDoc() map[string]string {
 		"":            "Represents the multus cni network.",
 		"networkName": "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
 		"default":     "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
+		"dhcpServer":  "DHCPServer enables an in-pod DHCP server for a bridge bound interface\nconnected to this network, handing out addresses from the given range.\nIt is meant for networks that have no IPAM of their own.\nRequires the DHCPServerIPAM feature gate.\n+optional",
+	}
+}
+
+func (DHCPServerIPAM) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "DHCPServerIPAM represents the address range served by the in-pod DHCP server.\nAllocations are shared by all the VMIs using the same network and are\npersisted by KubeVirt.",
+		"subnet":  "Subnet from which the guest addresses are allocated, in CIDR notation.\nOnly IPv4 subnets are supported.",
+		"gateway": "Gateway advertised to the guest. It must be part of the subnet and is\nnever handed out as a guest address.\n+optional",
 	}
 }
 
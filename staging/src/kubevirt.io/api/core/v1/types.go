@@ -291,6 +291,11 @@ type VirtualMachineInstanceStatus struct {
 	// takes place.
 	CurrentCPUTopology *CPUTopology `json:"currentCPUTopology,omitempty"`
 
+	// GuestCurrentVCPUs specifies how many vCPUs are currently enabled in the guest.
+	// It lags behind the current topology while the guest releases hot-unplugged vCPUs.
+	// +optional
+	GuestCurrentVCPUs *uint32 `json:"guestCurrentVCPUs,omitempty"`
+
 	// Memory shows various informations about the VirtualMachine memory.
 	// +optional
 	Memory *MemoryStatus `json:"memory,omitempty"`
@@ -554,6 +559,9 @@ const (
 	// If the VMI was paused by the user, this is reported as true.
 	VirtualMachineInstancePaused VirtualMachineInstanceConditionType = "Paused"
 
+	// Reflects whether the guest reported a panic and is kept in the panicked state by the panic action
+	VirtualMachineInstanceGuestPanicked VirtualMachineInstanceConditionType = "GuestPanicked"
+
 	// Reflects whether the QEMU guest agent is connected through the channel
 	VirtualMachineInstanceAgentConnected VirtualMachineInstanceConditionType = "AgentConnected"
 
@@ -598,6 +606,8 @@ const (
 	VirtualMachineInstanceReasonHostDeviceNotMigratable = "HostDeviceNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses Secure Encrypted Virtualization (SEV)
 	VirtualMachineInstanceReasonSEVNotMigratable = "SEVNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it uses SEV-SNP or TDX
+	VirtualMachineInstanceReasonConfidentialComputingNotMigratable = "ConfidentialComputingNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses HyperV Reenlightenment while TSC Frequency is not available
 	VirtualMachineInstanceReasonNoTSCFrequencyMigratable = "NoTSCFrequencyNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses HyperV Reenlightenment while TSC Frequency is not available
@@ -921,6 +931,10 @@ const (
 	VirtHandlerHeartbeat string = "kubevirt.io/heartbeat"
 	// This label indicates what launcher image a VMI is currently running with.
 	OutdatedLauncherImageLabel string = "kubevirt.io/outdatedLauncherImage"
+	// This label is set to "true" on a VMI and its virt-launcher pod while the guest agent is connected.
+	// It can be used in Service selectors to only target VMIs with a responsive guest agent.
+	// The label is only maintained when the GuestAgentConnectedLabel feature gate is enabled.
+	GuestAgentConnectedLabel string = "kubevirt.io/guest-agent-connected"
 	// Namespace recommended by Kubernetes for commonly recognized labels
 	AppLabelPrefix = "app.kubernetes.io"
 	// This label is commonly used by 3rd party management tools to identify
@@ -1035,6 +1049,12 @@ const (
 	// SEVESLabel marks the node as capable of running workloads with SEV-ES
 	SEVESLabel string = "kubevirt.io/sev-es"
 
+	// SEVSNPLabel marks the node as capable of running workloads with SEV-SNP
+	SEVSNPLabel string = "kubevirt.io/sev-snp"
+
+	// TDXLabel marks the node as capable of running workloads with TDX
+	TDXLabel string = "kubevirt.io/tdx"
+
 	// KSMEnabledLabel marks the node as KSM-handling enabled
 	KSMEnabledLabel string = "kubevirt.io/ksm-enabled"
 
@@ -1092,6 +1112,13 @@ const (
 	// in which freePageReporting is always disabled.
 	FreePageReportingDisabledAnnotation string = "kubevirt.io/free-page-reporting-disabled"
 
+	// EndpointsNetworkLabel on a Service selects the VMI network whose guest reported addresses
+	// are mirrored into the Service EndpointSlices.
+	EndpointsNetworkLabel string = "kubevirt.io/endpoints-network"
+	// EndpointsVMISelectorAnnotation on a Service holds the label selector of the VMIs backing the Service.
+	// It replaces the Service pod selector, which must be left empty.
+	EndpointsVMISelectorAnnotation string = "kubevirt.io/endpoints-vmi-selector"
+
 	// VirtualMachinePodCPULimitsLabel indicates VMI pod CPU resource limits
 	VirtualMachinePodCPULimitsLabel string = "kubevirt.io/vmi-pod-cpu-resource-limits"
 	// VirtualMachinePodMemoryRequestsLabel indicates VMI pod Memory resource requests
@@ -1682,6 +1709,11 @@ type VirtualMachineStatus struct {
 	// +optional
 	MemoryDumpRequest *VirtualMachineMemoryDumpRequest `json:"memoryDumpRequest,omitempty" optional:"true"`
 
+	// HotUnplug reports the progress of a live vCPU or memory scale-down.
+	// +nullable
+	// +optional
+	HotUnplug *VirtualMachineHotUnplugStatus `json:"hotUnplug,omitempty" optional:"true"`
+
 	// ObservedGeneration is the generation observed by the vmi when started.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty" optional:"true"`
@@ -2358,6 +2390,26 @@ type FreezeUnfreezeTimeout struct {
 	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
 }
 
+// VirtualMachineHotUnplugStatus reports the progress of a live scale-down of the guest
+type VirtualMachineHotUnplugStatus struct {
+	// CPU reports the progress of a vCPU hot-unplug, quantities are vCPU counts
+	// +optional
+	CPU *HotUnplugProgress `json:"cpu,omitempty"`
+	// Memory reports the progress of a memory hot-unplug
+	// +optional
+	Memory *HotUnplugProgress `json:"memory,omitempty"`
+}
+
+// HotUnplugProgress represents a resource the guest is releasing
+type HotUnplugProgress struct {
+	// Requested is the amount the guest is being scaled down to
+	Requested resource.Quantity `json:"requested"`
+	// Current is the amount the guest currently holds
+	Current resource.Quantity `json:"current"`
+	// StartTimestamp is the time the hot-unplug was first observed
+	StartTimestamp metav1.Time `json:"startTimestamp"`
+}
+
 // VirtualMachineMemoryDumpRequest represent the memory dump request phase and info
 type VirtualMachineMemoryDumpRequest struct {
 	// ClaimName is the name of the pvc that will contain the memory dump
@@ -2379,6 +2431,9 @@ type VirtualMachineMemoryDumpRequest struct {
 	// Message is a detailed message about failure of the memory dump
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Format is the format of the memory dump, defaults to elf
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
 type MemoryDumpPhase string
@@ -2398,6 +2453,19 @@ const (
 	MemoryDumpFailed MemoryDumpPhase = "Failed"
 )
 
+type MemoryDumpFormat string
+
+const (
+	// The memory is dumped as plain ELF core file
+	MemoryDumpFormatELF MemoryDumpFormat = "elf"
+	// The memory is dumped in the kdump-compressed format with zlib compressed pages
+	MemoryDumpFormatKdumpZlib MemoryDumpFormat = "kdump-zlib"
+	// The memory is dumped in the kdump-compressed format with lzo compressed pages
+	MemoryDumpFormatKdumpLzo MemoryDumpFormat = "kdump-lzo"
+	// The memory is dumped in the kdump-compressed format with snappy compressed pages
+	MemoryDumpFormatKdumpSnappy MemoryDumpFormat = "kdump-snappy"
+)
+
 // AddVolumeOptions is provided when dynamically hot plugging a volume and disk
 type AddVolumeOptions struct {
 	// Name represents the name that will be used to map the
@@ -2422,6 +2490,17 @@ type ScreenshotOptions struct {
 	MoveCursor bool `json:"moveCursor"`
 }
 
+// SerialConsoleLogOptions selects the part of the persisted serial console log to retrieve
+type SerialConsoleLogOptions struct {
+	// SinceTime only returns the lines logged after this time
+	// +optional
+	SinceTime *metav1.Time `json:"sinceTime,omitempty"`
+	// TailLines only returns this number of the newest lines.
+	// A running VMI returns at most 10000 lines.
+	// +optional
+	TailLines *int64 `json:"tailLines,omitempty"`
+}
+
 type VSOCKOptions struct {
 	TargetPort uint32 `json:"targetPort"`
 	UseTLS     *bool  `json:"useTLS,omitempty"`
@@ -2528,6 +2607,10 @@ type KubeVirtConfiguration struct {
 	// KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).
 	KSMConfiguration *KSMConfiguration `json:"ksmConfiguration,omitempty"`
 
+	// MemoryReclaimConfiguration enables reclaiming guest memory through the memory balloon
+	// on nodes under memory pressure.
+	MemoryReclaimConfiguration *MemoryReclaimConfiguration `json:"memoryReclaimConfiguration,omitempty"`
+
 	// When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside
 	// namespaces that match the label selector.
 	// The CPU limit will equal the number of requested vCPUs.
@@ -2886,6 +2969,22 @@ type KSMConfiguration struct {
 	NodeLabelSelector *metav1.LabelSelector `json:"nodeLabelSelector,omitempty"`
 }
 
+// MemoryReclaimConfiguration holds information about memory reclaim through the memory balloon.
+// +k8s:openapi-gen=true
+type MemoryReclaimConfiguration struct {
+	// NodeLabelSelector is a selector that filters on which nodes guest memory will be reclaimed.
+	// Empty or missing NodeLabelSelector will reclaim memory on every node.
+	// +optional
+	NodeLabelSelector *metav1.LabelSelector `json:"nodeLabelSelector,omitempty"`
+	// FreeMemoryPercent is the percentage of the node memory which has to be available,
+	// below it guest memory is reclaimed on the node.
+	// Defaults to 20.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	FreeMemoryPercent *uint32 `json:"freeMemoryPercent,omitempty"`
+}
+
 // NetworkConfiguration holds network options
 type NetworkConfiguration struct {
 	NetworkInterface string `json:"defaultNetworkInterface,omitempty"`
@@ -2894,6 +2993,40 @@ type NetworkConfiguration struct {
 	DeprecatedPermitSlirpInterface    *bool                             `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool                             `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
 	Binding                           map[string]InterfaceBindingPlugin `json:"binding,omitempty"`
+	// ServiceMesh configures how masquerade bound interfaces cooperate with a service mesh data plane.
+	// When unset, the Istio defaults are used.
+	// +optional
+	ServiceMesh *ServiceMeshConfiguration `json:"serviceMesh,omitempty"`
+}
+
+// ServiceMeshConfiguration describes the service mesh data plane running next to the VMI,
+// either as a sidecar proxy in the virt-launcher pod or as a node level proxy (Istio ambient mode).
+type ServiceMeshConfiguration struct {
+	// InjectionAnnotations mark a VMI as part of a sidecar based service mesh, e.g. {"linkerd.io/inject": "enabled"}.
+	// The Istio "sidecar.istio.io/inject": "true" annotation is always honored.
+	// +optional
+	InjectionAnnotations map[string]string `json:"injectionAnnotations,omitempty"`
+	// ReservedPorts are the TCP ports used by the mesh data plane in the virt-launcher pod.
+	// They are excluded from the masquerade NAT and are never forwarded to the guest.
+	// Defaults to the Istio proxy ports.
+	// +optional
+	// +listType=set
+	ReservedPorts []int32 `json:"reservedPorts,omitempty"`
+	// NonProxiedPorts are the TCP ports forwarded to the guest directly, bypassing the mesh proxy.
+	// Defaults to port 22.
+	// +optional
+	// +listType=set
+	NonProxiedPorts []int32 `json:"nonProxiedPorts,omitempty"`
+	// ProxySourceAddress is the IPv4 loopback address the sidecar proxy uses to deliver inbound traffic.
+	// Defaults to 127.0.0.6.
+	// +optional
+	ProxySourceAddress string `json:"proxySourceAddress,omitempty"`
+	// TranslateSourceIP translates the client address of inbound traffic delivered by an ambient mesh proxy
+	// to the guest gateway address, for meshes which do not route the guest replies back through the proxy.
+	// By default the client address is preserved.
+	// Traffic originating from a loopback address is always translated.
+	// +optional
+	TranslateSourceIP bool `json:"translateSourceIP,omitempty"`
 }
 
 type InterfaceBindingPlugin struct {
@@ -2907,7 +3040,7 @@ type InterfaceBindingPlugin struct {
 	// version: 1alphav1
 	NetworkAttachmentDefinition string `json:"networkAttachmentDefinition,omitempty"`
 	// DomainAttachmentType is a standard domain network attachment method kubevirt supports.
-	// Supported values: "tap", "managedTap" (since v1.4).
+	// Supported values: "tap", "managedTap" (since v1.4), "vhostuser" (since v1.5).
 	// The standard domain attachment can be used instead or in addition to the sidecarImage.
 	// version: 1alphav1
 	DomainAttachmentType DomainAttachmentType `json:"domainAttachmentType,omitempty"`
@@ -2950,6 +3083,11 @@ const (
 	// ManagedTap domain attachment type is binding an ethernet connection into guests using a tap device.
 	// The tap device is created (unless already present) on the network pod interface with a Linux bridge.
 	ManagedTap DomainAttachmentType = "managedTap"
+	// VhostUser domain attachment type is binding a userspace datapath (e.g. OVS-DPDK, VPP) into guests
+	// using a vhost-user unix socket, shared with the host through the launcher pod.
+	// It requires the guest memory to be backed by hugepages.
+	// https://libvirt.org/formatdomain.html#vhost-user-connection
+	VhostUser DomainAttachmentType = "vhostuser"
 )
 
 type NetworkBindingDownwardAPIType string
@@ -3107,6 +3245,11 @@ type LiveUpdateConfiguration struct {
 	// MaxGuest defines the maximum amount memory that can be allocated
 	// to the guest using hotplug.
 	MaxGuest *resource.Quantity `json:"maxGuest,omitempty"`
+	// HotUnplugTimeout is how long a vCPU or memory hot-unplug may wait for the guest
+	// to release the resources before the VM falls back to requiring a restart.
+	// defaults to 5m
+	// +optional
+	HotUnplugTimeout *metav1.Duration `json:"hotUnplugTimeout,omitempty"`
 }
 
 // SEVPlatformInfo contains information about the AMD SEV features for the node.
@@ -3120,6 +3263,43 @@ type SEVPlatformInfo struct {
 	CertChain string `json:"certChain,omitempty"`
 }
 
+// LaunchSecurityReport contains the launch parameters of a confidential guest as
+// reported by its host. A guest owner matches them against the attestation report
+// the guest presents.
+// It is not available for TDX guests, whose measurement is only part of the TD quote
+// obtained within the guest.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type LaunchSecurityReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Type of the launch security technology, one of sev or sev-snp.
+	Type LaunchSecurityType `json:"type"`
+	// Policy the guest was launched with.
+	Policy uint64 `json:"policy,omitempty"`
+	// Base64 encoded launch measurement, only reported for SEV guests.
+	Measurement string `json:"measurement,omitempty"`
+	// SHA256 of the loader binary
+	LoaderSHA string `json:"loaderSHA,omitempty"`
+}
+
+// SerialConsoleLog contains the persisted serial console log of a VirtualMachineInstance
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SerialConsoleLog struct {
+	metav1.TypeMeta `json:",inline"`
+	// Lines of the serial console log, from the oldest to the newest one
+	// +listType=atomic
+	Lines []string `json:"lines"`
+}
+
+type LaunchSecurityType string
+
+const (
+	LaunchSecurityTypeSEV    LaunchSecurityType = "sev"
+	LaunchSecurityTypeSEVSNP LaunchSecurityType = "sev-snp"
+	LaunchSecurityTypeTDX    LaunchSecurityType = "tdx"
+)
+
 // SEVMeasurementInfo contains information about the guest launch measurement.
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -81,6 +81,7 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"selinuxContext":                "SELinuxContext is the actual SELinux context of the virt-launcher pod\n+optional",
 		"machine":                       "Machine shows the final resulting qemu machine type. This can be different\nthan the machine type selected in the spec, due to qemus machine type alias mechanism.\n+optional",
 		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.",
+		"guestCurrentVCPUs":             "GuestCurrentVCPUs specifies how many vCPUs are currently enabled in the guest.\nIt lags behind the current topology while the guest releases hot-unplugged vCPUs.\n+optional",
 		"memory":                        "Memory shows various informations about the VirtualMachine memory.\n+optional",
 		"migratedVolumes":               "MigratedVolumes lists the source and destination volumes during the volume migration\n+listType=atomic\n+optional",
 	}
@@ -403,6 +404,7 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
 		"startFailure":           "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
 		"memoryDumpRequest":      "MemoryDumpRequest tracks memory dump request phase and info of getting a memory\ndump to the given pvc\n+nullable\n+optional",
+		"hotUnplug":              "HotUnplug reports the progress of a live vCPU or memory scale-down.\n+nullable\n+optional",
 		"observedGeneration":     "ObservedGeneration is the generation observed by the vmi when started.\n+optional",
 		"desiredGeneration":      "DesiredGeneration is the generation which is desired for the VMI.\nThis will be used in comparisons with ObservedGeneration to understand when\nthe VMI is out of sync. This will be changed at the same time as\nObservedGeneration to remove errors which could occur if Generation is\nupdated through an Update() before ObservedGeneration in Status.\n+optional",
 		"runStrategy":            "RunStrategy tracks the last recorded RunStrategy used by the VM.\nThis is needed to correctly process the next strategy (for now only the RerunOnFailure)",
@@ -691,6 +693,23 @@ func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineHotUnplugStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineHotUnplugStatus reports the progress of a live scale-down of the guest",
+		"cpu":    "CPU reports the progress of a vCPU hot-unplug, quantities are vCPU counts\n+optional",
+		"memory": "Memory reports the progress of a memory hot-unplug\n+optional",
+	}
+}
+
+func (HotUnplugProgress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "HotUnplugProgress represents a resource the guest is releasing",
+		"requested":      "Requested is the amount the guest is being scaled down to",
+		"current":        "Current is the amount the guest currently holds",
+		"startTimestamp": "StartTimestamp is the time the hot-unplug was first observed",
+	}
+}
+
 func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "VirtualMachineMemoryDumpRequest represent the memory dump request phase and info",
@@ -701,6 +720,7 @@ func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 		"endTimestamp":   "EndTimestamp represents the time the memory dump was completed\n+optional",
 		"fileName":       "FileName represents the name of the output file\n+optional",
 		"message":        "Message is a detailed message about failure of the memory dump\n+optional",
+		"format":         "Format is the format of the memory dump, defaults to elf\n+optional",
 	}
 }
 
@@ -718,6 +738,14 @@ func (ScreenshotOptions) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (SerialConsoleLogOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SerialConsoleLogOptions selects the part of the persisted serial console log to retrieve",
+		"sinceTime": "SinceTime only returns the lines logged after this time\n+optional",
+		"tailLines": "TailLines only returns this number of the newest lines.\nA running VMI returns at most 10000 lines.\n+optional",
+	}
+}
+
 func (VSOCKOptions) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
@@ -767,6 +795,7 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"supportedGuestAgentVersions":        "deprecated",
 		"vmStateStorageClass":                "VMStateStorageClass is the name of the storage class to use for the PVCs created to preserve VM state, like TPM.\nThe storage class must support RWX in filesystem mode.",
 		"ksmConfiguration":                   "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).",
+		"memoryReclaimConfiguration":         "MemoryReclaimConfiguration enables reclaiming guest memory through the memory balloon\non nodes under memory pressure.",
 		"autoCPULimitNamespaceLabelSelector": "When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside\nnamespaces that match the label selector.\nThe CPU limit will equal the number of requested vCPUs.\nThis setting does not apply to VMIs with dedicated CPUs.",
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
 		"vmRolloutStrategy":                  "VMRolloutStrategy defines how changes to a VM object propagate to its VMI\n+nullable\n+kubebuilder:validation:Enum=Stage;LiveUpdate",
@@ -954,10 +983,30 @@ func (KSMConfiguration) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MemoryReclaimConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "MemoryReclaimConfiguration holds information about memory reclaim through the memory balloon.\n+k8s:openapi-gen=true",
+		"nodeLabelSelector": "NodeLabelSelector is a selector that filters on which nodes guest memory will be reclaimed.\nEmpty or missing NodeLabelSelector will reclaim memory on every node.\n+optional",
+		"freeMemoryPercent": "FreeMemoryPercent is the percentage of the node memory which has to be available,\nbelow it guest memory is reclaimed on the node.\nDefaults to 20.\n+kubebuilder:validation:Minimum=0\n+kubebuilder:validation:Maximum=100\n+optional",
+	}
+}
+
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                     "NetworkConfiguration holds network options",
 		"permitSlirpInterface": "DeprecatedPermitSlirpInterface is an alias for the deprecated PermitSlirpInterface.\nDeprecated: Removed in v1.3.",
+		"serviceMesh":          "ServiceMesh configures how masquerade bound interfaces cooperate with a service mesh data plane.\nWhen unset, the Istio defaults are used.\n+optional",
+	}
+}
+
+func (ServiceMeshConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "ServiceMeshConfiguration describes the service mesh data plane running next to the VMI,\neither as a sidecar proxy in the virt-launcher pod or as a node level proxy (Istio ambient mode).",
+		"injectionAnnotations": "InjectionAnnotations mark a VMI as part of a sidecar based service mesh, e.g. {\"linkerd.io/inject\": \"enabled\"}.\nThe Istio \"sidecar.istio.io/inject\": \"true\" annotation is always honored.\n+optional",
+		"reservedPorts":        "ReservedPorts are the TCP ports used by the mesh data plane in the virt-launcher pod.\nThey are excluded from the masquerade NAT and are never forwarded to the guest.\nDefaults to the Istio proxy ports.\n+optional\n+listType=set",
+		"nonProxiedPorts":      "NonProxiedPorts are the TCP ports forwarded to the guest directly, bypassing the mesh proxy.\nDefaults to port 22.\n+optional\n+listType=set",
+		"proxySourceAddress":   "ProxySourceAddress is the IPv4 loopback address the sidecar proxy uses to deliver inbound traffic.\nDefaults to 127.0.0.6.\n+optional",
+		"translateSourceIP":    "TranslateSourceIP translates the client address of inbound traffic delivered by an ambient mesh proxy\nto the guest gateway address, for meshes which do not route the guest replies back through the proxy.\nBy default the client address is preserved.\nTraffic originating from a loopback address is always translated.\n+optional",
 	}
 }
 
@@ -965,7 +1014,7 @@ func (InterfaceBindingPlugin) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"sidecarImage":                "SidecarImage references a container image that runs in the virt-launcher pod.\nThe sidecar handles (libvirt) domain configuration and optional services.\nversion: 1alphav1",
 		"networkAttachmentDefinition": "NetworkAttachmentDefinition references to a NetworkAttachmentDefinition CR object.\nFormat: <name>, <namespace>/<name>.\nIf namespace is not specified, VMI namespace is assumed.\nversion: 1alphav1",
-		"domainAttachmentType":        "DomainAttachmentType is a standard domain network attachment method kubevirt supports.\nSupported values: \"tap\", \"managedTap\" (since v1.4).\nThe standard domain attachment can be used instead or in addition to the sidecarImage.\nversion: 1alphav1",
+		"domainAttachmentType":        "DomainAttachmentType is a standard domain network attachment method kubevirt supports.\nSupported values: \"tap\", \"managedTap\" (since v1.4), \"vhostuser\" (since v1.5).\nThe standard domain attachment can be used instead or in addition to the sidecarImage.\nversion: 1alphav1",
 		"migration":                   "Migration means the VM using the plugin can be safely migrated\nversion: 1alphav1",
 		"downwardAPI":                 "DownwardAPI specifies what kind of data should be exposed to the binding plugin sidecar.\nSupported values: \"device-info\"\nversion: v1alphav1\n+optional",
 		"computeResourceOverhead":     "ComputeResourceOverhead specifies the resource overhead that should be added to the compute container when using the binding.\nversion: v1alphav1\n+optional",
@@ -1028,9 +1077,10 @@ func (PreferenceMatcher) SwaggerDoc() map[string]string {
 
 func (LiveUpdateConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"maxHotplugRatio": "MaxHotplugRatio is the ratio used to define the max amount\nof a hotplug resource that can be made available to a VM\nwhen the specific Max* setting is not defined (MaxCpuSockets, MaxGuest)\nExample: VM is configured with 512Mi of guest memory, if MaxGuest is not\ndefined and MaxHotplugRatio is 2 then MaxGuest = 1Gi\ndefaults to 4",
-		"maxCpuSockets":   "MaxCpuSockets provides a MaxSockets value for VMs that do not provide their own.\nFor VMs with more sockets than maximum the MaxSockets will be set to equal number of sockets.",
-		"maxGuest":        "MaxGuest defines the maximum amount memory that can be allocated\nto the guest using hotplug.",
+		"maxHotplugRatio":  "MaxHotplugRatio is the ratio used to define the max amount\nof a hotplug resource that can be made available to a VM\nwhen the specific Max* setting is not defined (MaxCpuSockets, MaxGuest)\nExample: VM is configured with 512Mi of guest memory, if MaxGuest is not\ndefined and MaxHotplugRatio is 2 then MaxGuest = 1Gi\ndefaults to 4",
+		"maxCpuSockets":    "MaxCpuSockets provides a MaxSockets value for VMs that do not provide their own.\nFor VMs with more sockets than maximum the MaxSockets will be set to equal number of sockets.",
+		"maxGuest":         "MaxGuest defines the maximum amount memory that can be allocated\nto the guest using hotplug.",
+		"hotUnplugTimeout": "HotUnplugTimeout is how long a vCPU or memory hot-unplug may wait for the guest\nto release the resources before the VM falls back to requiring a restart.\ndefaults to 5m\n+optional",
 	}
 }
 
@@ -1042,6 +1092,23 @@ func (SEVPlatformInfo) SwaggerDoc() map[string]string {
 	}
 }
 
+func (LaunchSecurityReport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "LaunchSecurityReport contains the launch parameters of a confidential guest as\nreported by its host. A guest owner matches them against the attestation report\nthe guest presents.\nIt is not available for TDX guests, whose measurement is only part of the TD quote\nobtained within the guest.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"type":        "Type of the launch security technology, one of sev or sev-snp.",
+		"policy":      "Policy the guest was launched with.",
+		"measurement": "Base64 encoded launch measurement, only reported for SEV guests.",
+		"loaderSHA":   "SHA256 of the loader binary",
+	}
+}
+
+func (SerialConsoleLog) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "SerialConsoleLog contains the persisted serial console log of a VirtualMachineInstance\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"lines": "Lines of the serial console log, from the oldest to the newest one\n+listType=atomic",
+	}
+}
+
 func (SEVMeasurementInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "SEVMeasurementInfo contains information about the guest launch measurement.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -1155,6 +1155,17 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNC(arg0 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNC", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) Spice(name string) (v122.StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Spice", name)
+	ret0, _ := ret[0].(v122.StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Spice(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Spice", arg0)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) Screenshot(ctx context.Context, name string, options *v121.ScreenshotOptions) ([]byte, error) {
 	ret := _m.ctrl.Call(_m, "Screenshot", ctx, name, options)
 	ret0, _ := ret[0].([]byte)
@@ -1313,6 +1324,28 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SEVQueryLaunchMeasureme
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SEVQueryLaunchMeasurement", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) LaunchSecurityReport(ctx context.Context, name string) (v121.LaunchSecurityReport, error) {
+	ret := _m.ctrl.Call(_m, "LaunchSecurityReport", ctx, name)
+	ret0, _ := ret[0].(v121.LaunchSecurityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) LaunchSecurityReport(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LaunchSecurityReport", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) SerialConsoleLog(ctx context.Context, name string, options *v121.SerialConsoleLogOptions) (v121.SerialConsoleLog, error) {
+	ret := _m.ctrl.Call(_m, "SerialConsoleLog", ctx, name, options)
+	ret0, _ := ret[0].(v121.SerialConsoleLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialConsoleLog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialConsoleLog", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) SEVSetupSession(ctx context.Context, name string, sevSessionOptions *v121.SEVSessionOptions) error {
 	ret := _m.ctrl.Call(_m, "SEVSetupSession", ctx, name, sevSessionOptions)
 	ret0, _ := ret[0].(error)
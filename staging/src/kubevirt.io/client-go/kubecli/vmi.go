@@ -67,6 +67,10 @@ func (v *vmis) VNC(name string) (kvcorev1.StreamInterface, error) {
 	return kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "vnc", url.Values{})
 }
 
+func (v *vmis) Spice(name string) (kvcorev1.StreamInterface, error) {
+	return kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "spice", url.Values{})
+}
+
 func (v *vmis) PortForward(name string, port int, protocol string) (kvcorev1.StreamInterface, error) {
 	return kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, buildPortForwardResourcePath(port, protocol), url.Values{})
 }
@@ -90,6 +94,13 @@ type connectionStruct struct {
 }
 
 func (v *vmis) SerialConsole(name string, options *kvcorev1.SerialConsoleOptions) (kvcorev1.StreamInterface, error) {
+	queryParams := url.Values{}
+	if options != nil && options.ReadOnly {
+		queryParams.Set("readOnly", "true")
+	}
+	if options != nil && options.TakeOver {
+		queryParams.Set("takeOver", "true")
+	}
 
 	if options != nil && options.ConnectionTimeout != 0 {
 		timeoutChan := time.Tick(options.ConnectionTimeout)
@@ -108,7 +119,7 @@ func (v *vmis) SerialConsole(name string, options *kvcorev1.SerialConsoleOptions
 				default:
 				}
 
-				con, err := kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "console", url.Values{})
+				con, err := kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "console", queryParams)
 				if err != nil {
 					asyncSubresourceError, ok := err.(*kvcorev1.AsyncSubresourceError)
 					// return if response status code does not equal to 400
@@ -128,7 +139,7 @@ func (v *vmis) SerialConsole(name string, options *kvcorev1.SerialConsoleOptions
 		conStruct := <-connectionChan
 		return conStruct.con, conStruct.err
 	} else {
-		return kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "console", url.Values{})
+		return kvcorev1.AsyncSubresourceHelper(v.config, v.resource, v.namespace, name, "console", queryParams)
 	}
 }
 
@@ -479,6 +479,29 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should get the launch security report via subresource", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		launchSecurityReport := v1.LaunchSecurityReport{
+			Type:      v1.LaunchSecurityTypeSEVSNP,
+			Policy:    0x30000,
+			LoaderSHA: "AAABBB",
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMIPath, "launchsecurity/report")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, launchSecurityReport),
+		))
+		fetchedReport, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).LaunchSecurityReport(context.Background(), "testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch the report normally")
+		Expect(fetchedReport).To(Equal(launchSecurityReport), "fetched report should be the same as passed in")
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	DescribeTable("should setup SEV session for a VirtualMachineInstance", func(proxyPath string) {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
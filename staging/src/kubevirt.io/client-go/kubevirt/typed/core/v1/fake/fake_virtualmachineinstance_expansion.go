@@ -42,6 +42,10 @@ func (c *FakeVirtualMachineInstances) VNC(name string) (kvcorev1.StreamInterface
 	return nil, nil
 }
 
+func (c *FakeVirtualMachineInstances) Spice(name string) (kvcorev1.StreamInterface, error) {
+	return nil, nil
+}
+
 func (c *FakeVirtualMachineInstances) Screenshot(ctx context.Context, name string, options *v1.ScreenshotOptions) ([]byte, error) {
 	return nil, nil
 }
@@ -152,3 +156,20 @@ func (c *FakeVirtualMachineInstances) SEVInjectLaunchSecret(ctx context.Context,
 
 	return err
 }
+
+func (c *FakeVirtualMachineInstances) LaunchSecurityReport(ctx context.Context, name string) (v1.LaunchSecurityReport, error) {
+	_, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(virtualmachineinstancesResource, c.ns, "launchsecurity/report", name), &v1.LaunchSecurityReport{})
+
+	return v1.LaunchSecurityReport{}, err
+}
+
+func (c *FakeVirtualMachineInstances) SerialConsoleLog(ctx context.Context, name string, options *v1.SerialConsoleLogOptions) (v1.SerialConsoleLog, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(virtualmachineinstancesResource, c.ns, "consolelog", name), &v1.SerialConsoleLog{})
+
+	if obj == nil {
+		return v1.SerialConsoleLog{}, err
+	}
+	return *obj.(*v1.SerialConsoleLog), err
+}
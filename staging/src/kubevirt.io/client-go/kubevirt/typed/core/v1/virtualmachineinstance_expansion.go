@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,12 +36,18 @@ const vmiSubresourceURL = "/apis/subresources.kubevirt.io/%s"
 
 type SerialConsoleOptions struct {
 	ConnectionTimeout time.Duration
+	// ReadOnly attaches to the serial console without sending input to the guest,
+	// alongside the client owning the console
+	ReadOnly bool
+	// TakeOver disconnects the client currently owning the serial console
+	TakeOver bool
 }
 
 type VirtualMachineInstanceExpansion interface {
 	SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error)
 	USBRedir(vmiName string) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	Spice(name string) (StreamInterface, error)
 	Screenshot(ctx context.Context, name string, options *v1.ScreenshotOptions) ([]byte, error)
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
 	Pause(ctx context.Context, name string, pauseOptions *v1.PauseOptions) error
@@ -58,6 +65,8 @@ type VirtualMachineInstanceExpansion interface {
 	SEVQueryLaunchMeasurement(ctx context.Context, name string) (v1.SEVMeasurementInfo, error)
 	SEVSetupSession(ctx context.Context, name string, sevSessionOptions *v1.SEVSessionOptions) error
 	SEVInjectLaunchSecret(ctx context.Context, name string, sevSecretOptions *v1.SEVSecretOptions) error
+	LaunchSecurityReport(ctx context.Context, name string) (v1.LaunchSecurityReport, error)
+	SerialConsoleLog(ctx context.Context, name string, options *v1.SerialConsoleLogOptions) (v1.SerialConsoleLog, error)
 }
 
 func (c *virtualMachineInstances) SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error) {
@@ -78,6 +87,12 @@ func (c *virtualMachineInstances) VNC(name string) (StreamInterface, error) {
 	return nil, fmt.Errorf("VNC is not implemented yet in generated client")
 }
 
+func (c *virtualMachineInstances) Spice(name string) (StreamInterface, error) {
+	// TODO not implemented yet
+	//  requires clientConfig
+	return nil, fmt.Errorf("Spice is not implemented yet in generated client")
+}
+
 func (c *virtualMachineInstances) Screenshot(ctx context.Context, name string, options *v1.ScreenshotOptions) ([]byte, error) {
 	moveCursor := "false"
 	if options.MoveCursor == true {
@@ -362,3 +377,36 @@ func (c *virtualMachineInstances) SEVInjectLaunchSecret(ctx context.Context, nam
 		Do(context.Background()).
 		Error()
 }
+
+func (c *virtualMachineInstances) LaunchSecurityReport(ctx context.Context, name string) (v1.LaunchSecurityReport, error) {
+	launchSecurityReport := v1.LaunchSecurityReport{}
+	err := c.GetClient().Get().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("launchsecurity", "report").
+		Do(ctx).
+		Into(&launchSecurityReport)
+
+	return launchSecurityReport, err
+}
+
+func (c *virtualMachineInstances) SerialConsoleLog(ctx context.Context, name string, options *v1.SerialConsoleLogOptions) (v1.SerialConsoleLog, error) {
+	serialConsoleLog := v1.SerialConsoleLog{}
+	request := c.GetClient().Get().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("consolelog")
+	if options != nil && options.SinceTime != nil {
+		request = request.Param("sinceTime", options.SinceTime.UTC().Format(time.RFC3339))
+	}
+	if options != nil && options.TailLines != nil {
+		request = request.Param("tailLines", strconv.FormatInt(*options.TailLines, 10))
+	}
+	err := request.Do(ctx).Into(&serialConsoleLog)
+
+	return serialConsoleLog, err
+}